@@ -0,0 +1,8 @@
+package api
+
+import _ "embed"
+
+// MenuGenerationEventSchema схема событий menu-generation-requests, регистрируемая в schema registry
+//
+//go:embed events/menu_generation_event.proto
+var MenuGenerationEventSchema string
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/bootstrap"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/request_metadata"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+// errUsage неверные аргументы; вместе с ошибкой печатается справка
+var errUsage = errors.New("неверные аргументы")
+
+// command подкоманда; name из одного или двух слов, например serve или migrate up
+type command struct {
+	name        string
+	args        string
+	description string
+	run         func(ctx context.Context, env *commandEnv, args []string) error
+}
+
+var commands = []command{
+	{name: "serve", description: "запустить gRPC-сервер и gateway (команда по умолчанию)", run: runServe},
+	{name: "migrate up", description: "применить недостающие миграции на всех шардах", run: runMigrateUp},
+	{name: "migrate down", args: "-yes", description: "откатить последнюю миграцию на всех шардах", run: runMigrateDown},
+	{name: "migrate status", description: "показать применённые и ожидающие миграции по шардам", run: runMigrateStatus},
+	{name: "shards status", description: "показать карту бакетов и число строк в таблицах каждого шарда", run: runShardsStatus},
+	{name: "user get", args: "<id|username>", description: "показать пользователя по id или username", run: runUserGet},
+	{name: "user delete", args: "<id|username>", description: "удалить пользователя так же, как DeleteUser API", run: runUserDelete},
+	{name: "rebalance", args: "[-apply]", description: "перенести строки на шарды по текущей карте бакетов (без -apply только план)", run: runRebalance},
+	{name: "outbox replay", args: "[-topic t] [-limit n] [-id id]", description: "повторно отправить сообщения из dead-letter в Kafka", run: runOutboxReplay},
+}
+
+// commandEnv общие для команд путь к конфигу и вывод
+type commandEnv struct {
+	configPath string
+	out        io.Writer
+}
+
+func (e *commandEnv) loadConfig() (*config.Config, error) {
+	cfg, err := config.LoadConfig(e.configPath)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка парсинга конфига, %w", err)
+	}
+	return cfg, nil
+}
+
+// connectStorage подключается к шардам без миграций, чтобы команды не меняли схему неявно
+func (e *commandEnv) connectStorage() (*config.Config, *profile_management_storage.ProfileManagementStorage, error) {
+	cfg, err := e.loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	storage, err := bootstrap.ConnectPGStorage(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ошибка подключения к БД, %w", err)
+	}
+	return cfg, storage, nil
+}
+
+func (e *commandEnv) table() *tabwriter.Writer {
+	return tabwriter.NewWriter(e.out, 0, 0, 2, ' ', 0)
+}
+
+// run разбирает общие флаги и выполняет подкоманду; без подкоманды запускается сервер
+func run(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("app", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	configPath := flags.String("config", os.Getenv("configPath"), "путь к config.yaml (по умолчанию из переменной configPath)")
+	if err := flags.Parse(args); err != nil {
+		printUsage(out)
+		return err
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(out)
+		return nil
+	}
+
+	cmd, rest, ok := findCommand(args)
+	if !ok {
+		printUsage(out)
+		return fmt.Errorf("%w: неизвестная команда %q", errUsage, strings.Join(args, " "))
+	}
+
+	env := &commandEnv{configPath: *configPath, out: out}
+	err := cmd.run(ctx, env, rest)
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(out, "использование: app [-config path] %s %s\n", cmd.name, cmd.args)
+	}
+	return err
+}
+
+// findCommand ищет подкоманду по первым одному или двум словам args и возвращает её аргументы
+func findCommand(args []string) (command, []string, bool) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) >= len(words) && slices.Equal(args[:len(words)], words) {
+			return cmd, args[len(words):], true
+		}
+	}
+	return command{}, nil, false
+}
+
+func printUsage(out io.Writer) {
+	fmt.Fprintln(out, "использование: app [-config path] <команда> [аргументы]")
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %s %s\t%s\n", cmd.name, cmd.args, cmd.description)
+	}
+	w.Flush()
+}
+
+// parseFlags разбирает флаги подкоманды и проверяет число позиционных аргументов
+func parseFlags(flags *flag.FlagSet, args []string, positional int) ([]string, error) {
+	flags.SetOutput(io.Discard)
+	if err := flags.Parse(args); err != nil {
+		return nil, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if flags.NArg() != positional {
+		return nil, fmt.Errorf("%w: ожидается аргументов: %d, передано: %d", errUsage, positional, flags.NArg())
+	}
+	return flags.Args(), nil
+}
+
+func runServe(ctx context.Context, env *commandEnv, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("serve", flag.ContinueOnError), args, 0); err != nil {
+		return err
+	}
+
+	cfg, err := env.loadConfig()
+	if err != nil {
+		return err
+	}
+	serve(env.configPath, cfg)
+	return nil
+}
+
+// adminActor автор изменений из командной строки в журнале аудита
+const adminActor = "admin-cli"
+
+func adminContext(ctx context.Context) context.Context {
+	return request_metadata.WithVerifiedActor(ctx, adminActor)
+}
+
+// adminService сервис для команд, меняющих данные: он публикует события и пишет журнал аудита так же,
+// как при запросах к API. Генерация меню и лента WatchUser из командной строки не нужны.
+func adminService(cfg *config.Config, storage *profile_management_storage.ProfileManagementStorage) (*profile_service.ProfileService, func()) {
+	profileEventsProducer := bootstrap.InitProfileEventsProducer(storage, cfg)
+	deadLetterProducer := bootstrap.InitDeadLetterProducer(cfg)
+
+	service := profile_service.NewProfileService(
+		context.Background(),
+		profile_service.Dependencies{
+			Storage:               storage,
+			ProfileEventsProducer: profileEventsProducer,
+			DeadLetterReplayer:    deadLetterProducer,
+			AuditLog:              storage,
+		},
+		profile_service.Options{
+			Settings: profile_service.Settings{
+				MinUsernameLen: cfg.ProfileServiceSettings.MinUsernameLen,
+				MaxUsernameLen: cfg.ProfileServiceSettings.MaxUsernameLen,
+				MinPasswordLen: cfg.ProfileServiceSettings.MinPasswordLen,
+			},
+			UserDeletionGracePeriod: cfg.ProfileServiceSettings.UserDeletionGracePeriod,
+		},
+	)
+	return service, func() {
+		_ = profileEventsProducer.Close()
+		_ = deadLetterProducer.Close()
+	}
+}
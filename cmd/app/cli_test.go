@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParseUserRef(t *testing.T) {
+	assert.Equal(t, parseUserRef("42"), userRef{id: 42})
+	assert.Equal(t, parseUserRef("alice"), userRef{username: "alice"})
+	// Не помещающееся в int32 или неположительное число считается username
+	assert.Equal(t, parseUserRef("0"), userRef{username: "0"})
+	assert.Equal(t, parseUserRef("9999999999"), userRef{username: "9999999999"})
+}
+
+func TestFindCommandMatchesTwoWordCommands(t *testing.T) {
+	cmd, rest, ok := findCommand([]string{"user", "get", "alice"})
+	assert.Assert(t, ok)
+	assert.Equal(t, cmd.name, "user get")
+	assert.DeepEqual(t, rest, []string{"alice"})
+
+	cmd, rest, ok = findCommand([]string{"rebalance", "-apply"})
+	assert.Assert(t, ok)
+	assert.Equal(t, cmd.name, "rebalance")
+	assert.DeepEqual(t, rest, []string{"-apply"})
+
+	_, _, ok = findCommand([]string{"migrate"})
+	assert.Assert(t, !ok)
+}
+
+func TestRunRejectsBadArgumentsBeforeLoadingConfig(t *testing.T) {
+	var out bytes.Buffer
+	err := run(context.Background(), []string{"-config", "missing.yaml", "user", "get"}, &out)
+	assert.ErrorIs(t, err, errUsage)
+	assert.Assert(t, bytes.Contains(out.Bytes(), []byte("user get <id|username>")))
+
+	err = run(context.Background(), []string{"-config", "missing.yaml", "migrate", "down"}, &out)
+	assert.ErrorContains(t, err, "-yes")
+
+	err = run(context.Background(), []string{"shards", "rebuild"}, &out)
+	assert.ErrorContains(t, err, `неизвестная команда "shards rebuild"`)
+}
+
+func TestRunHelpListsCommands(t *testing.T) {
+	var out bytes.Buffer
+	assert.NilError(t, run(context.Background(), []string{"help"}, &out))
+	for _, cmd := range commands {
+		assert.Assert(t, bytes.Contains(out.Bytes(), []byte(cmd.name)), cmd.name)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+func runMigrateUp(ctx context.Context, env *commandEnv, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("migrate up", flag.ContinueOnError), args, 0); err != nil {
+		return err
+	}
+
+	_, storage, err := env.connectStorage()
+	if err != nil {
+		return err
+	}
+	if err := storage.MigrateUp(ctx); err != nil {
+		return fmt.Errorf("ошибка применения миграций, %w", err)
+	}
+	return printMigrationStatus(ctx, env, storage)
+}
+
+func runMigrateDown(ctx context.Context, env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+	yes := flags.Bool("yes", false, "подтвердить откат")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+	// Откат может удалить колонки вместе с данными; первую миграцию откатить нельзя
+	if !*yes {
+		return fmt.Errorf("%w: откат может удалить данные, подтвердите флагом -yes", errUsage)
+	}
+
+	_, storage, err := env.connectStorage()
+	if err != nil {
+		return err
+	}
+	rolledBack, err := storage.MigrateDown(ctx)
+	for _, m := range rolledBack {
+		fmt.Fprintf(env.out, "шард %d: откачена миграция %d %s\n", m.Shard, m.Version, m.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка отката миграций, %w", err)
+	}
+	if len(rolledBack) == 0 {
+		fmt.Fprintln(env.out, "нет применённых миграций")
+	}
+	return nil
+}
+
+func runMigrateStatus(ctx context.Context, env *commandEnv, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("migrate status", flag.ContinueOnError), args, 0); err != nil {
+		return err
+	}
+
+	_, storage, err := env.connectStorage()
+	if err != nil {
+		return err
+	}
+	return printMigrationStatus(ctx, env, storage)
+}
+
+func printMigrationStatus(ctx context.Context, env *commandEnv, storage *profile_management_storage.ProfileManagementStorage) error {
+	statuses, err := storage.MigrationStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения миграций, %w", err)
+	}
+
+	w := env.table()
+	fmt.Fprintln(w, "SHARD\tVERSION\tNAME\tAPPLIED_AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\n", status.Shard, status.Version, status.Name, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+)
+
+// runOutboxReplay повторно отправляет сообщения Kafka, сохранённые в dead-letter после неудачных публикаций
+func runOutboxReplay(ctx context.Context, env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("outbox replay", flag.ContinueOnError)
+	topic := flags.String("topic", "", "только сообщения этого топика")
+	limit := flags.Int("limit", 100, "сколько сообщений отправить, не больше 500")
+	id := flags.String("id", "", "отправить одно сообщение по id")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	cfg, storage, err := env.connectStorage()
+	if err != nil {
+		return err
+	}
+	service, closeService := adminService(cfg, storage)
+	defer closeService()
+	ctx = adminContext(ctx)
+
+	var deadLetters []*models.DeadLetter
+	if *id != "" {
+		deadLetters = []*models.DeadLetter{{ID: *id}}
+	} else {
+		deadLetters, err = service.ListDeadLetters(ctx, *topic, int32(*limit))
+		if err != nil {
+			return fmt.Errorf("ошибка чтения dead-letter, %w", err)
+		}
+	}
+	if len(deadLetters) == 0 {
+		fmt.Fprintln(env.out, "нет сообщений для отправки")
+		return nil
+	}
+
+	failed := 0
+	for _, deadLetter := range deadLetters {
+		if err := service.ReplayDeadLetter(ctx, deadLetter.ID); err != nil {
+			failed++
+			fmt.Fprintf(env.out, "%s: ошибка: %v\n", deadLetter.ID, err)
+			continue
+		}
+		fmt.Fprintf(env.out, "%s: отправлено\n", deadLetter.ID)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("не удалось отправить сообщений: %d из %d", failed, len(deadLetters))
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/bootstrap"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+	"github.com/samber/lo"
+)
+
+func runRebalance(ctx context.Context, env *commandEnv, args []string) error {
+	flags := flag.NewFlagSet("rebalance", flag.ContinueOnError)
+	apply := flags.Bool("apply", false, "перенести строки; без флага выводится только план")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return err
+	}
+
+	cfg, storage, err := env.connectStorage()
+	if err != nil {
+		return err
+	}
+	moves, err := storage.Rebalance(ctx, !*apply)
+	if err != nil {
+		return fmt.Errorf("ошибка перебалансировки, %w", err)
+	}
+	if *apply {
+		// Перенос идёт в обход кеша сервиса; общий кеш (backend: redis) переживает его остановку
+		bootstrap.InitCachedStorage(storage, cfg).InvalidateUsers(ctx, lo.Uniq(lo.Map(moves, func(move profile_management_storage.RebalanceMove, _ int) int32 {
+			return move.UserID
+		}))...)
+	}
+	if len(moves) == 0 {
+		fmt.Fprintln(env.out, "все строки лежат на своих шардах")
+		return nil
+	}
+
+	w := env.table()
+	fmt.Fprintln(w, "USER_ID\tKIND\tFROM\tTO\tRESULT")
+	failed := 0
+	for _, move := range moves {
+		kind := "data"
+		if move.Profile {
+			kind = "profile"
+		}
+
+		result := "planned"
+		switch {
+		case move.Err != nil:
+			failed++
+			result = "ошибка: " + move.Err.Error()
+		case *apply:
+			result = fmt.Sprintf("moved %d", move.Rows)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%s\n", move.UserID, kind, move.From, move.To, result)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !*apply {
+		fmt.Fprintf(env.out, "\nпереносов: %d; остановите сервис и запустите с -apply, чтобы выполнить\n", len(moves))
+		return nil
+	}
+	if failed > 0 {
+		return fmt.Errorf("не удалось выполнить переносов: %d из %d", failed, len(moves))
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/bootstrap"
+)
+
+// serve запускает сервис; путь к конфигу нужен, чтобы перечитывать его при изменении
+func serve(configPath string, cfg *config.Config) {
+	bootstrap.InitLogger(cfg)
+
+	profileStorage := bootstrap.InitPGStorage(cfg)
+	menuGenerationProducer := bootstrap.InitMenuGenerationProducer(profileStorage, cfg)
+	profileEventsProducer := bootstrap.InitProfileEventsProducer(profileStorage, cfg)
+	deadLetterProducer := bootstrap.InitDeadLetterProducer(cfg)
+	changeEventBus := bootstrap.InitChangeEventBus(cfg)
+	cachedStorage := bootstrap.InitCachedStorage(profileStorage, cfg)
+	profileService := bootstrap.InitProfileService(profileStorage, cachedStorage, menuGenerationProducer, changeEventBus, profileEventsProducer, deadLetterProducer, cfg)
+	profileApi := bootstrap.InitProfileManagementAPI(profileService)
+	adminApi := bootstrap.InitProfileAdminAPI(profileService, profileStorage, cachedStorage)
+	userPurgeJob := bootstrap.InitUserPurgeJob(profileStorage, cachedStorage, cfg)
+	idempotencyKeyCleanupJob := bootstrap.InitIdempotencyKeyCleanupJob(profileStorage, cfg)
+	replicaHealthCheckJob := bootstrap.InitReplicaHealthCheckJob(profileStorage, cfg)
+	menuGenerationResultsConsumer := bootstrap.InitMenuGenerationResultsConsumer(profileService, cfg)
+	rateLimiter := bootstrap.InitRateLimiter(cfg)
+	configReloader := bootstrap.InitConfigReloader(configPath, profileService, rateLimiter, cfg)
+
+	go userPurgeJob.Run(context.Background())
+	go idempotencyKeyCleanupJob.Run(context.Background())
+	go replicaHealthCheckJob.Run(context.Background())
+	go menuGenerationResultsConsumer.Run(context.Background())
+	go configReloader.Run(context.Background())
+
+	bootstrap.AppRun(*profileApi, adminApi, rateLimiter, profileStorage, configReloader, cfg)
+}
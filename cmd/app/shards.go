@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+func runShardsStatus(ctx context.Context, env *commandEnv, args []string) error {
+	if _, err := parseFlags(flag.NewFlagSet("shards status", flag.ContinueOnError), args, 0); err != nil {
+		return err
+	}
+
+	cfg, storage, err := env.connectStorage()
+	if err != nil {
+		return err
+	}
+
+	stats := storage.ShardStats(ctx)
+	fmt.Fprintf(env.out, "бакетов: %d, шардов: %d\n\n", len(storage.BucketMap()), len(stats))
+
+	w := env.table()
+	header := []string{"SHARD", "HOST", "BUCKETS"}
+	for _, table := range firstTables(stats) {
+		header = append(header, strings.ToUpper(table))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, shard := range stats {
+		row := []string{
+			strconv.Itoa(shard.Shard),
+			fmt.Sprintf("%s:%d", cfg.Database.Shards[shard.Shard].Host, cfg.Database.Shards[shard.Shard].Port),
+			joinInts(shard.Buckets),
+		}
+		if shard.Err != nil {
+			row = append(row, "ошибка: "+shard.Err.Error())
+		}
+		for _, table := range shard.Tables {
+			row = append(row, strconv.FormatInt(table.Rows, 10))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// firstTables имена таблиц из статистики первого ответившего шарда
+func firstTables(stats []profile_management_storage.ShardStats) []string {
+	for _, shard := range stats {
+		if shard.Err != nil {
+			continue
+		}
+		names := make([]string, 0, len(shard.Tables))
+		for _, table := range shard.Tables {
+			names = append(names, table.Table)
+		}
+		return names
+	}
+	return nil
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, 0, len(values))
+	for _, value := range values {
+		parts = append(parts, strconv.Itoa(value))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+// userRef пользователь из аргумента команды: число считается id, остальное - username
+type userRef struct {
+	id       int32
+	username string
+}
+
+func parseUserRef(arg string) userRef {
+	if id, err := strconv.ParseInt(arg, 10, 32); err == nil && id > 0 {
+		return userRef{id: int32(id)}
+	}
+	return userRef{username: arg}
+}
+
+func (r userRef) find(ctx context.Context, storage *profile_management_storage.ProfileManagementStorage) (*models.User, error) {
+	var user *models.User
+	var err error
+	if r.id > 0 {
+		user, err = storage.GetUserByID(ctx, r.id)
+	} else {
+		user, err = storage.GetUserByUsername(ctx, r.username)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("пользователь %s: %w", r, err)
+	}
+	return user, nil
+}
+
+func (r userRef) String() string {
+	if r.id > 0 {
+		return strconv.Itoa(int(r.id))
+	}
+	return strconv.Quote(r.username)
+}
+
+func runUserGet(ctx context.Context, env *commandEnv, args []string) error {
+	args, err := parseFlags(flag.NewFlagSet("user get", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	_, storage, err := env.connectStorage()
+	if err != nil {
+		return err
+	}
+	user, err := parseUserRef(args[0]).find(ctx, storage)
+	if err != nil {
+		return err
+	}
+
+	// Хеш пароля не выводится
+	data, err := json.MarshalIndent(struct {
+		*models.User
+		PasswordHash string `json:"password_hash,omitempty"`
+	}{User: user}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(env.out, string(data))
+	return err
+}
+
+func runUserDelete(ctx context.Context, env *commandEnv, args []string) error {
+	args, err := parseFlags(flag.NewFlagSet("user delete", flag.ContinueOnError), args, 1)
+	if err != nil {
+		return err
+	}
+
+	cfg, storage, err := env.connectStorage()
+	if err != nil {
+		return err
+	}
+	user, err := parseUserRef(args[0]).find(ctx, storage)
+	if err != nil {
+		return err
+	}
+
+	// Удаление идёт через сервис: мягкое удаление, событие в Kafka и запись в журнале аудита как у DeleteUser
+	service, closeService := adminService(cfg, storage)
+	defer closeService()
+	if err := service.DeleteUser(adminContext(ctx), user.ID); err != nil {
+		return fmt.Errorf("ошибка удаления пользователя %d, %w", user.ID, err)
+	}
+
+	if cfg.ProfileServiceSettings.UserDeletionGracePeriod > 0 {
+		fmt.Fprintf(env.out, "пользователь %d (%s) удалён, восстановление возможно в течение %s\n",
+			user.ID, user.Username, cfg.ProfileServiceSettings.UserDeletionGracePeriod)
+	} else {
+		fmt.Fprintf(env.out, "пользователь %d (%s) удалён\n", user.ID, user.Username)
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"time"
 
 	"go.yaml.in/yaml/v4"
 )
@@ -12,11 +14,28 @@ type Config struct {
 	Kafka                  KafkaConfig            `yaml:"kafka"`
 	Server                 ServerConfig           `yaml:"server"`
 	ProfileServiceSettings ProfileServiceSettings `yaml:"profileServiceSettings"`
+	RateLimit              RateLimitConfig        `yaml:"rateLimit"`
+	Cache                  CacheConfig            `yaml:"cache"`
+	Log                    LogConfig              `yaml:"log"`
+	Admin                  AdminConfig            `yaml:"admin"`
 }
 
 type DatabaseConfig struct {
 	Shards      []DatabaseShardConfig `yaml:"shards"`
 	BucketCount int                   `yaml:"bucket_count"`
+	// ReadYourWritesWindow сколько после записи чтения пользователя идут на primary, а не на реплики
+	ReadYourWritesWindow time.Duration `yaml:"read_your_writes_window"`
+	// ReplicaHealthCheckInterval как часто проверяется доступность реплик
+	ReplicaHealthCheckInterval time.Duration `yaml:"replica_health_check_interval"`
+	// CircuitBreaker автоматы отключения primary и реплик шардов
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"`
+}
+
+type CircuitBreakerConfig struct {
+	// FailureThreshold сколько ошибок подключения подряд исключают пул из запросов (по умолчанию 5)
+	FailureThreshold int `yaml:"failure_threshold"`
+	// OpenTimeout через сколько исключённый пул получает пробный запрос (по умолчанию 30s)
+	OpenTimeout time.Duration `yaml:"open_timeout"`
 }
 
 type DatabaseShardConfig struct {
@@ -26,25 +45,140 @@ type DatabaseShardConfig struct {
 	Password string `yaml:"password"`
 	DBName   string `yaml:"name"`
 	SSLMode  string `yaml:"ssl_mode"`
+	// SSLRootCert путь к корневым сертификатам для проверки сервера при ssl_mode verify-ca и verify-full
+	SSLRootCert string `yaml:"ssl_root_cert"`
+	// ApplicationName имя приложения в pg_stat_activity
+	ApplicationName string `yaml:"application_name"`
+	// StatementTimeout прерывает запросы дольше заданного времени на стороне PostgreSQL
+	StatementTimeout time.Duration `yaml:"statement_timeout"`
+	// Настройки пула подключений; нулевые значения оставляют значения pgxpool по умолчанию
+	MaxConns          int32         `yaml:"max_conns"`
+	MinConns          int32         `yaml:"min_conns"`
+	MaxConnLifetime   time.Duration `yaml:"max_conn_lifetime"`
+	MaxConnIdleTime   time.Duration `yaml:"max_conn_idle_time"`
+	HealthCheckPeriod time.Duration `yaml:"health_check_period"`
+	// Replicas реплики шарда для чтения; учётные данные, база и остальные настройки берутся у primary
+	Replicas []DatabaseReplicaConfig `yaml:"replicas"`
+}
+
+type DatabaseReplicaConfig struct {
+	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
 }
 
 type KafkaConfig struct {
 	Host                    string `yaml:"host"`
 	Port                    int    `yaml:"port"`
 	MenuGenerationTopicName string `yaml:"menu_generation_topic_name"`
+	ProfileEventsTopicName  string `yaml:"profile_events_topic_name"`
+	// MenuGenerationResultsTopicName топик с результатами генерации меню
+	MenuGenerationResultsTopicName string `yaml:"menu_generation_results_topic_name"`
+	ConsumerGroupID                string `yaml:"consumer_group_id"`
+	// Encoding формат событий menu-generation-requests: json (по умолчанию) или protobuf в Confluent wire format
+	Encoding string `yaml:"encoding"`
+	// SchemaRegistryPath файл локального schema registry, используется при encoding: protobuf
+	SchemaRegistryPath string `yaml:"schema_registry_path"`
+	// PublishMaxAttempts сколько раз публикуется сообщение, прежде чем оно будет сохранено в dead-letter
+	PublishMaxAttempts int `yaml:"publish_max_attempts"`
+	// PublishInitialBackoff и PublishMaxBackoff границы экспоненциальной задержки между попытками
+	PublishInitialBackoff time.Duration `yaml:"publish_initial_backoff"`
+	PublishMaxBackoff     time.Duration `yaml:"publish_max_backoff"`
+	// PublishQueueSize сколько доменных событий ждёт публикации в фоне (по умолчанию 1000);
+	// события, не поместившиеся в очередь, сохраняются в dead-letter
+	PublishQueueSize int `yaml:"publish_queue_size"`
 }
 
 type ServerConfig struct {
 	GRPCPort int `yaml:"grpc_port"`
 	HTTPPort int `yaml:"http_port"`
+	// ConfigWatchInterval как часто проверяется, не изменился ли файл конфига (по умолчанию 5s)
+	ConfigWatchInterval time.Duration `yaml:"config_watch_interval"`
+}
+
+type LogConfig struct {
+	// Level минимальный уровень логов: debug, info (по умолчанию), warn или error
+	Level string `yaml:"level"`
+}
+
+// SlogLevel уровень логов для slog; неизвестный уровень отклоняется при проверке конфига
+func (c LogConfig) SlogLevel() slog.Level {
+	var level slog.Level
+	_ = level.UnmarshalText([]byte(c.Level))
+	return level
+}
+
+type AdminConfig struct {
+	// Token токен ProfileAdminService, передаётся в metadata authorization: Bearer <token>.
+	// Без токена административные методы отклоняются.
+	Token string `yaml:"token"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Backend хранилище корзин: memory (по умолчанию, лимиты на каждый экземпляр) или redis (общие лимиты)
+	Backend string      `yaml:"backend"`
+	Redis   RedisConfig `yaml:"redis"`
+	// Default ограничения методов, для которых нет своих в Methods
+	Default RateLimitRule `yaml:"default"`
+	// Methods ограничения по коротким именам методов gRPC, например CreateUser
+	Methods map[string]RateLimitRule `yaml:"methods"`
+	// HTTP ограничение всех запросов к gateway по IP клиента
+	HTTP RateLimitRule `yaml:"http"`
+}
+
+type CacheConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Backend memory (по умолчанию, LRU в памяти каждого экземпляра) или redis (общий кеш)
+	Backend string      `yaml:"backend"`
+	Redis   RedisConfig `yaml:"redis"`
+	// Size сколько записей хранит LRU в памяти
+	Size int `yaml:"size"`
+	// TTL срок жизни записи; ограничивает устаревание, если изменение прошло в обход кеша
+	TTL time.Duration `yaml:"ttl"`
+}
+
+type RedisConfig struct {
+	Address  string `yaml:"address"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+}
+
+// RateLimitRule ограничения по IP клиента и по автору запроса, подтверждённому аутентификацией;
+// нулевой лимит не применяется
+type RateLimitRule struct {
+	PerIP   RateLimit `yaml:"perIP"`
+	PerUser RateLimit `yaml:"perUser"`
+}
+
+// RateLimit token bucket: burst запросов подряд, затем rate запросов в секунду
+type RateLimit struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
 }
 
 type ProfileServiceSettings struct {
 	MinUsernameLen int `yaml:"minUsernameLen"`
 	MaxUsernameLen int `yaml:"maxUsernameLen"`
 	MinPasswordLen int `yaml:"minPasswordLen"`
+	// UserDeletionGracePeriod срок, в течение которого удалённого пользователя можно восстановить.
+	// При нулевом значении пользователь удаляется сразу вместе с продуктами и блюдами.
+	UserDeletionGracePeriod time.Duration `yaml:"userDeletionGracePeriod"`
+	UserPurgeInterval       time.Duration `yaml:"userPurgeInterval"`
+	// ChangeFeedHistorySize сколько последних изменений хранится для возобновления WatchUser по resume_token
+	ChangeFeedHistorySize int `yaml:"changeFeedHistorySize"`
+	// ChangeFeedSubscriberBuffer сколько событий может накопиться у подписчика, прежде чем он будет отключён
+	ChangeFeedSubscriberBuffer int `yaml:"changeFeedSubscriberBuffer"`
+	// MenuGenerationDebounceWindow окно, в котором автоматические запросы генерации меню после правок профиля
+	// схлопываются в один. При нулевом значении запрос отправляется сразу.
+	MenuGenerationDebounceWindow time.Duration `yaml:"menuGenerationDebounceWindow"`
+	// IdempotencyKeyTTL сколько хранится первый ответ на CreateUser, CreateProduct и CreateMeal с Idempotency-Key
+	IdempotencyKeyTTL time.Duration `yaml:"idempotencyKeyTTL"`
+	// IdempotencyKeyCleanupInterval как часто удаляются истёкшие ключи идемпотентности
+	IdempotencyKeyCleanupInterval time.Duration `yaml:"idempotencyKeyCleanupInterval"`
 }
 
+// LoadConfig читает YAML-файл, переопределяет поля переменными окружения PMS_* (и файлами из PMS_*_FILE),
+// заполняет значения по умолчанию и проверяет результат
 func LoadConfig(filename string) (*Config, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
@@ -57,5 +191,14 @@ func LoadConfig(filename string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
 
+	if err := applyEnv(&config, osEnvironment()); err != nil {
+		return nil, fmt.Errorf("failed to apply environment overrides:\n%w", err)
+	}
+	config.applyDefaults()
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config:\n%w", err)
+	}
+
 	return &config, nil
 }
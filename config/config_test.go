@@ -0,0 +1,197 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func validConfig() *Config {
+	cfg := &Config{
+		Database: DatabaseConfig{
+			Shards: []DatabaseShardConfig{
+				{Host: "localhost", Port: 5432, Username: "postgres", Password: "postgres", DBName: "postgres", SSLMode: "disable"},
+			},
+		},
+		Kafka: KafkaConfig{
+			Host:                    "localhost",
+			Port:                    9092,
+			MenuGenerationTopicName: "menu-generation-requests",
+			ProfileEventsTopicName:  "profile-events.v1",
+		},
+		RateLimit: RateLimitConfig{
+			Methods: map[string]RateLimitRule{"CreateUser": {PerIP: RateLimit{Rate: 1, Burst: 5}}},
+		},
+	}
+	cfg.applyDefaults()
+	return cfg
+}
+
+func TestEnvName(t *testing.T) {
+	for key, want := range map[string]string{
+		"grpc_port":              "GRPC_PORT",
+		"profileServiceSettings": "PROFILE_SERVICE_SETTINGS",
+		"perIP":                  "PER_IP",
+		"idempotencyKeyTTL":      "IDEMPOTENCY_KEY_TTL",
+		"CreateUser":             "CREATE_USER",
+		"HTTPPort":               "HTTP_PORT",
+	} {
+		assert.Equal(t, envName(key), want, key)
+	}
+}
+
+func TestApplyEnvOverridesFields(t *testing.T) {
+	cfg := validConfig()
+	err := applyEnv(cfg, environment{
+		"PMS_DATABASE_SHARDS_0_PASSWORD":                        "s3cr3t",
+		"PMS_DATABASE_SHARDS_0_STATEMENT_TIMEOUT":               "5s",
+		"PMS_SERVER_GRPC_PORT":                                  "6000",
+		"PMS_PROFILE_SERVICE_SETTINGS_IDEMPOTENCY_KEY_TTL":      "2h",
+		"PMS_RATE_LIMIT_ENABLED":                                "true",
+		"PMS_RATE_LIMIT_METHODS_CREATE_USER_PER_IP_RATE":        "0.5",
+		"PMS_PROFILE_SERVICE_SETTINGS_MIN_USERNAME_LEN_UNKNOWN": "ignored",
+	})
+	assert.NilError(t, err)
+
+	assert.Equal(t, cfg.Database.Shards[0].Password, "s3cr3t")
+	assert.Equal(t, cfg.Database.Shards[0].StatementTimeout, 5*time.Second)
+	assert.Equal(t, cfg.Server.GRPCPort, 6000)
+	assert.Equal(t, cfg.ProfileServiceSettings.IdempotencyKeyTTL, 2*time.Hour)
+	assert.Equal(t, cfg.RateLimit.Enabled, true)
+	assert.Equal(t, cfg.RateLimit.Methods["CreateUser"].PerIP.Rate, 0.5)
+	assert.Equal(t, cfg.RateLimit.Methods["CreateUser"].PerIP.Burst, 5)
+}
+
+func TestApplyEnvReadsSecretFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db_password")
+	assert.NilError(t, os.WriteFile(path, []byte("from-file\n"), 0o600))
+
+	cfg := validConfig()
+	err := applyEnv(cfg, environment{
+		"PMS_DATABASE_SHARDS_0_PASSWORD":      "from-env",
+		"PMS_DATABASE_SHARDS_0_PASSWORD_FILE": path,
+		"PMS_CACHE_REDIS_PASSWORD_FILE":       filepath.Join(t.TempDir(), "missing"),
+	})
+	assert.ErrorContains(t, err, "PMS_CACHE_REDIS_PASSWORD_FILE: read secret file")
+	assert.Equal(t, cfg.Database.Shards[0].Password, "from-file")
+}
+
+func TestApplyEnvAppendsListItems(t *testing.T) {
+	cfg := validConfig()
+	err := applyEnv(cfg, environment{
+		"PMS_DATABASE_SHARDS_1_HOST":             "shard-1",
+		"PMS_DATABASE_SHARDS_1_PORT":             "5433",
+		"PMS_DATABASE_SHARDS_1_REPLICAS_0_HOST":  "shard-1-replica",
+		"PMS_DATABASE_SHARDS_1_REPLICAS_0_PORT":  "5434",
+		"PMS_DATABASE_SHARDS_0_REPLICAS_0_PORT":  "not-a-number",
+		"PMS_DATABASE_SHARDS_0_HEALTH_CHECK_PER": "ignored",
+	})
+	assert.ErrorContains(t, err, "PMS_DATABASE_SHARDS_0_REPLICAS_0_PORT")
+
+	assert.Equal(t, len(cfg.Database.Shards), 2)
+	assert.Equal(t, cfg.Database.Shards[1].Host, "shard-1")
+	assert.Equal(t, cfg.Database.Shards[1].Port, 5433)
+	assert.DeepEqual(t, cfg.Database.Shards[1].Replicas, []DatabaseReplicaConfig{{Host: "shard-1-replica", Port: 5434}})
+}
+
+func TestApplyDefaultsKeepsMeaningfulZeros(t *testing.T) {
+	var cfg Config
+	cfg.applyDefaults()
+
+	assert.Equal(t, cfg.Server.GRPCPort, defaultGRPCPort)
+	assert.Equal(t, cfg.Server.HTTPPort, defaultHTTPPort)
+	assert.Equal(t, cfg.ProfileServiceSettings.MaxUsernameLen, defaultMaxUsernameLen)
+	assert.Equal(t, cfg.ProfileServiceSettings.UserPurgeInterval, defaultUserPurgeInterval)
+	// Ноль выключает отложенное удаление и ключи идемпотентности
+	assert.Equal(t, cfg.ProfileServiceSettings.UserDeletionGracePeriod, time.Duration(0))
+	assert.Equal(t, cfg.ProfileServiceSettings.IdempotencyKeyTTL, time.Duration(0))
+}
+
+func TestValidateReportsAllInvalidFields(t *testing.T) {
+	assert.NilError(t, validConfig().Validate())
+
+	cfg := validConfig()
+	cfg.Database.Shards[0].Port = 0
+	cfg.Database.Shards[0].SSLMode = "sometimes"
+	cfg.Database.Shards[0].MaxConns = 2
+	cfg.Database.Shards[0].MinConns = 4
+	cfg.Kafka.Host = ""
+	cfg.Server.HTTPPort = cfg.Server.GRPCPort
+	cfg.ProfileServiceSettings.MaxUsernameLen = 1
+	cfg.Log.Level = "verbose"
+	cfg.RateLimit.Methods["CreateUser"] = RateLimitRule{PerUser: RateLimit{Rate: -1}}
+
+	err := cfg.Validate()
+	assert.Assert(t, err != nil)
+	for _, field := range []string{
+		"database.shards[0].port",
+		"database.shards[0].ssl_mode",
+		"database.shards[0].min_conns",
+		"kafka.host",
+		"server.http_port",
+		"profileServiceSettings.maxUsernameLen",
+		"log.level",
+		"rateLimit.methods.CreateUser.perUser.rate",
+	} {
+		assert.Assert(t, strings.Contains(err.Error(), field+":"), "missing %s in %v", field, err)
+	}
+}
+
+func TestLoadConfigExample(t *testing.T) {
+	cfg, err := LoadConfig("../config.yaml")
+	assert.NilError(t, err)
+	assert.Assert(t, len(cfg.Database.Shards) > 0)
+}
+
+func TestReloadAppliesOnlySafeFields(t *testing.T) {
+	current := validConfig()
+	next := validConfig()
+	next.ProfileServiceSettings.MinUsernameLen = 5
+	next.RateLimit.Methods = map[string]RateLimitRule{"CreateMeal": {PerUser: RateLimit{Rate: 2, Burst: 2}}}
+	next.Log.Level = "debug"
+	next.Server.GRPCPort = 6000
+	next.Database.Shards = append(next.Database.Shards, DatabaseShardConfig{Host: "shard-1", Port: 5433})
+	next.Cache.TTL = time.Minute
+
+	effective, rejected := current.Reload(next)
+
+	assert.Equal(t, effective.ProfileServiceSettings.MinUsernameLen, 5)
+	assert.DeepEqual(t, effective.RateLimit.Methods, next.RateLimit.Methods)
+	assert.Equal(t, effective.Log.Level, "debug")
+	assert.Equal(t, effective.Server.GRPCPort, current.Server.GRPCPort)
+	assert.Equal(t, len(effective.Database.Shards), 1)
+	assert.DeepEqual(t, rejected, []string{"database.shards", "server.grpc_port", "cache.ttl"})
+	// Исходный конфиг не меняется
+	assert.Equal(t, current.ProfileServiceSettings.MinUsernameLen, defaultMinUsernameLen)
+}
+
+func TestReloadReportsChangedNestedFields(t *testing.T) {
+	current := validConfig()
+	next := validConfig()
+	next.Database.Shards[0].Password = "rotated"
+
+	_, rejected := current.Reload(next)
+	assert.DeepEqual(t, rejected, []string{"database.shards[0].password"})
+
+	_, rejected = current.Reload(validConfig())
+	assert.Equal(t, len(rejected), 0)
+}
+
+func TestRedactedHidesPasswords(t *testing.T) {
+	cfg := validConfig()
+	cfg.Cache.Redis.Password = "cache-secret"
+	cfg.Admin.Token = "admin-secret"
+
+	redacted := cfg.Redacted()
+	assert.Equal(t, redacted.Database.Shards[0].Password, redactedSecret)
+	assert.Equal(t, redacted.Cache.Redis.Password, redactedSecret)
+	assert.Equal(t, redacted.Admin.Token, redactedSecret)
+	// Незаданный пароль остаётся пустым, чтобы было видно, что его нет
+	assert.Equal(t, redacted.RateLimit.Redis.Password, "")
+	assert.Equal(t, cfg.Database.Shards[0].Password, "postgres")
+	assert.Equal(t, cfg.Cache.Redis.Password, "cache-secret")
+}
@@ -0,0 +1,45 @@
+package config
+
+import "time"
+
+const (
+	defaultGRPCPort = 50051
+	defaultHTTPPort = 8080
+
+	defaultConfigWatchInterval = 5 * time.Second
+	defaultLogLevel            = "info"
+
+	defaultMinUsernameLen                = 3
+	defaultMaxUsernameLen                = 50
+	defaultMinPasswordLen                = 6
+	defaultUserPurgeInterval             = time.Hour
+	defaultChangeFeedHistorySize         = 10000
+	defaultChangeFeedSubscriberBuffer    = 256
+	defaultIdempotencyKeyCleanupInterval = time.Hour
+)
+
+// applyDefaults заполняет незаданные порты, уровень логов и настройки сервиса. Нулевые UserDeletionGracePeriod,
+// MenuGenerationDebounceWindow и IdempotencyKeyTTL осмысленны (выключают функцию) и не заменяются.
+func (c *Config) applyDefaults() {
+	setDefault(&c.Server.GRPCPort, defaultGRPCPort)
+	setDefault(&c.Server.HTTPPort, defaultHTTPPort)
+	setDefault(&c.Server.ConfigWatchInterval, defaultConfigWatchInterval)
+	if c.Log.Level == "" {
+		c.Log.Level = defaultLogLevel
+	}
+
+	settings := &c.ProfileServiceSettings
+	setDefault(&settings.MinUsernameLen, defaultMinUsernameLen)
+	setDefault(&settings.MaxUsernameLen, defaultMaxUsernameLen)
+	setDefault(&settings.MinPasswordLen, defaultMinPasswordLen)
+	setDefault(&settings.UserPurgeInterval, defaultUserPurgeInterval)
+	setDefault(&settings.ChangeFeedHistorySize, defaultChangeFeedHistorySize)
+	setDefault(&settings.ChangeFeedSubscriberBuffer, defaultChangeFeedSubscriberBuffer)
+	setDefault(&settings.IdempotencyKeyCleanupInterval, defaultIdempotencyKeyCleanupInterval)
+}
+
+func setDefault[T int | time.Duration](field *T, value T) {
+	if *field == 0 {
+		*field = value
+	}
+}
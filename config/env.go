@@ -0,0 +1,171 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// EnvPrefix префикс переменных окружения, переопределяющих поля конфига
+const EnvPrefix = "PMS"
+
+// fileSuffix суффикс переменной с путём к файлу, содержимое которого становится значением поля
+const fileSuffix = "_FILE"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// environment переменные окружения процесса
+type environment map[string]string
+
+func osEnvironment() environment {
+	env := make(environment)
+	for _, item := range os.Environ() {
+		if name, value, ok := strings.Cut(item, "="); ok {
+			env[name] = value
+		}
+	}
+	return env
+}
+
+// value значение поля name: содержимое файла из name_FILE, иначе сама переменная name
+func (e environment) value(name string) (string, bool, error) {
+	if path, ok := e[name+fileSuffix]; ok {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("%s: read secret file: %w", name+fileSuffix, err)
+		}
+		// Файлы секретов обычно заканчиваются переводом строки, он не часть значения
+		return strings.TrimRight(string(data), "\r\n"), true, nil
+	}
+
+	value, ok := e[name]
+	return value, ok, nil
+}
+
+func (e environment) hasPrefix(prefix string) bool {
+	for name := range e {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyEnv переопределяет поля cfg переменными окружения. Имя переменной строится из yaml-ключей пути к полю:
+// PMS_DATABASE_SHARDS_0_PASSWORD, PMS_PROFILE_SERVICE_SETTINGS_MIN_USERNAME_LEN. Элементы списков можно
+// добавить, задав переменные со следующим индексом; ключи карт переопределяются только существующие.
+func applyEnv(cfg *Config, env environment) error {
+	var errs []error
+	applyEnvValue(reflect.ValueOf(cfg).Elem(), EnvPrefix, env, &errs)
+	return errors.Join(errs...)
+}
+
+func applyEnvValue(v reflect.Value, name string, env environment, errs *[]error) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			key, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+			if key == "" || key == "-" {
+				continue
+			}
+			applyEnvValue(v.Field(i), name+"_"+envName(key), env, errs)
+		}
+	case reflect.Slice:
+		for i := 0; ; i++ {
+			prefix := name + "_" + strconv.Itoa(i)
+			if i >= v.Len() {
+				if !env.hasPrefix(prefix + "_") {
+					return
+				}
+				v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
+			}
+			applyEnvValue(v.Index(i), prefix, env, errs)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			// Элемент карты неадресуем, поэтому меняем копию и кладём её обратно
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(key))
+			applyEnvValue(elem, name+"_"+envName(key.String()), env, errs)
+			v.SetMapIndex(key, elem)
+		}
+	default:
+		value, ok, err := env.value(name)
+		if err != nil {
+			*errs = append(*errs, err)
+			return
+		}
+		if !ok {
+			return
+		}
+		if err := setValue(v, value); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+}
+
+func setValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(duration))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// envName переводит yaml-ключ в имя переменной: grpc_port -> GRPC_PORT, perIP -> PER_IP,
+// idempotencyKeyTTL -> IDEMPOTENCY_KEY_TTL
+func envName(key string) string {
+	runes := []rune(key)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// redactedSecret значение пароля в выводе конфига
+const redactedSecret = "REDACTED"
+
+// Reload переносит из next настройки, которые можно менять без перезапуска: длины username и пароля,
+// правила ограничения запросов и уровень логов. Остальные поля остаются как в c, а пути изменённых
+// в next полей возвращаются в rejected (например, server.grpc_port или database.shards).
+func (c *Config) Reload(next *Config) (effective *Config, rejected []string) {
+	reloaded := *c
+
+	reloaded.ProfileServiceSettings.MinUsernameLen = next.ProfileServiceSettings.MinUsernameLen
+	reloaded.ProfileServiceSettings.MaxUsernameLen = next.ProfileServiceSettings.MaxUsernameLen
+	reloaded.ProfileServiceSettings.MinPasswordLen = next.ProfileServiceSettings.MinPasswordLen
+	reloaded.RateLimit.Default = next.RateLimit.Default
+	reloaded.RateLimit.Methods = next.RateLimit.Methods
+	reloaded.RateLimit.HTTP = next.RateLimit.HTTP
+	reloaded.Log.Level = next.Log.Level
+
+	diff(reflect.ValueOf(reloaded), reflect.ValueOf(*next), "", &rejected)
+	return &reloaded, rejected
+}
+
+// diff собирает пути полей, которые различаются в a и b; путь строится из yaml-ключей, как в ошибках Validate
+func diff(a, b reflect.Value, path string, changed *[]string) {
+	switch a.Kind() {
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			key, _, _ := strings.Cut(a.Type().Field(i).Tag.Get("yaml"), ",")
+			if key == "" || key == "-" {
+				continue
+			}
+			diff(a.Field(i), b.Field(i), joinPath(path, key), changed)
+		}
+	case reflect.Slice:
+		if a.Len() != b.Len() {
+			*changed = append(*changed, path)
+			return
+		}
+		for i := 0; i < a.Len(); i++ {
+			diff(a.Index(i), b.Index(i), fmt.Sprintf("%s[%d]", path, i), changed)
+		}
+	case reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, key := range append(a.MapKeys(), b.MapKeys()...) {
+			keys[key.String()] = key
+		}
+		for _, name := range slices.Sorted(maps.Keys(keys)) {
+			av, bv := a.MapIndex(keys[name]), b.MapIndex(keys[name])
+			if !av.IsValid() || !bv.IsValid() {
+				*changed = append(*changed, joinPath(path, name))
+				continue
+			}
+			diff(av, bv, joinPath(path, name), changed)
+		}
+	default:
+		if !a.Equal(b) {
+			*changed = append(*changed, path)
+		}
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// Redacted копия конфига, в которой заданные пароли и токен администратора заменены на REDACTED; c не меняется
+func (c *Config) Redacted() *Config {
+	redacted := *c
+
+	redacted.Database.Shards = slices.Clone(c.Database.Shards)
+	for i := range redacted.Database.Shards {
+		redact(&redacted.Database.Shards[i].Password)
+	}
+	redact(&redacted.RateLimit.Redis.Password)
+	redact(&redacted.Cache.Redis.Password)
+	redact(&redacted.Admin.Token)
+
+	return &redacted
+}
+
+func redact(secret *string) {
+	if *secret != "" {
+		*secret = redactedSecret
+	}
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+var sslModes = []string{"", "disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// validator собирает ошибки всех полей, чтобы сообщить о них разом
+type validator struct {
+	errs []error
+}
+
+func (v *validator) check(ok bool, field, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+}
+
+func (v *validator) port(field string, port int) {
+	v.check(port > 0 && port <= 65535, field, "port must be in 1..65535, got %d", port)
+}
+
+func (v *validator) required(field, value string) {
+	v.check(value != "", field, "is required")
+}
+
+func (v *validator) nonNegative(field string, value time.Duration) {
+	v.check(value >= 0, field, "must not be negative, got %s", value)
+}
+
+func (v *validator) oneOf(field, value string, allowed ...string) {
+	v.check(slices.Contains(allowed, value), field, "must be one of %q, got %q", allowed, value)
+}
+
+// Validate проверяет конфиг и возвращает ошибки всех неверных полей сразу
+func (c *Config) Validate() error {
+	var v validator
+
+	c.validateDatabase(&v)
+	c.validateKafka(&v)
+
+	v.port("server.grpc_port", c.Server.GRPCPort)
+	v.port("server.http_port", c.Server.HTTPPort)
+	v.check(c.Server.GRPCPort != c.Server.HTTPPort, "server.http_port", "must differ from grpc_port")
+	v.check(c.Server.ConfigWatchInterval > 0, "server.config_watch_interval", "must be positive")
+
+	settings := c.ProfileServiceSettings
+	v.check(settings.MinUsernameLen > 0, "profileServiceSettings.minUsernameLen", "must be positive")
+	v.check(settings.MaxUsernameLen >= settings.MinUsernameLen, "profileServiceSettings.maxUsernameLen",
+		"must be >= minUsernameLen (%d), got %d", settings.MinUsernameLen, settings.MaxUsernameLen)
+	v.check(settings.MinPasswordLen > 0, "profileServiceSettings.minPasswordLen", "must be positive")
+	v.nonNegative("profileServiceSettings.userDeletionGracePeriod", settings.UserDeletionGracePeriod)
+	v.check(settings.UserPurgeInterval > 0, "profileServiceSettings.userPurgeInterval", "must be positive")
+	v.check(settings.ChangeFeedHistorySize > 0, "profileServiceSettings.changeFeedHistorySize", "must be positive")
+	v.check(settings.ChangeFeedSubscriberBuffer > 0, "profileServiceSettings.changeFeedSubscriberBuffer", "must be positive")
+	v.nonNegative("profileServiceSettings.menuGenerationDebounceWindow", settings.MenuGenerationDebounceWindow)
+	v.nonNegative("profileServiceSettings.idempotencyKeyTTL", settings.IdempotencyKeyTTL)
+	v.check(settings.IdempotencyKeyCleanupInterval > 0, "profileServiceSettings.idempotencyKeyCleanupInterval", "must be positive")
+
+	c.validateRateLimit(&v)
+
+	v.oneOf("cache.backend", c.Cache.Backend, "", "memory", "redis")
+	if c.Cache.Enabled && c.Cache.Backend == "redis" {
+		v.required("cache.redis.address", c.Cache.Redis.Address)
+	}
+	v.check(c.Cache.Size >= 0, "cache.size", "must not be negative, got %d", c.Cache.Size)
+	v.nonNegative("cache.ttl", c.Cache.TTL)
+
+	var level slog.Level
+	v.check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "log.level", "must be debug, info, warn or error, got %q", c.Log.Level)
+
+	return errors.Join(v.errs...)
+}
+
+func (c *Config) validateDatabase(v *validator) {
+	db := c.Database
+	v.check(len(db.Shards) > 0, "database.shards", "at least one shard is required")
+	v.check(db.BucketCount == 0 || db.BucketCount >= len(db.Shards), "database.bucket_count",
+		"must be >= number of shards (%d), got %d", len(db.Shards), db.BucketCount)
+	v.nonNegative("database.read_your_writes_window", db.ReadYourWritesWindow)
+	v.nonNegative("database.replica_health_check_interval", db.ReplicaHealthCheckInterval)
+	v.check(db.CircuitBreaker.FailureThreshold >= 0, "database.circuit_breaker.failure_threshold",
+		"must not be negative, got %d", db.CircuitBreaker.FailureThreshold)
+	v.nonNegative("database.circuit_breaker.open_timeout", db.CircuitBreaker.OpenTimeout)
+
+	for i, shard := range db.Shards {
+		field := fmt.Sprintf("database.shards[%d]", i)
+		v.required(field+".host", shard.Host)
+		v.port(field+".port", shard.Port)
+		v.required(field+".username", shard.Username)
+		v.required(field+".name", shard.DBName)
+		v.oneOf(field+".ssl_mode", shard.SSLMode, sslModes...)
+		v.nonNegative(field+".statement_timeout", shard.StatementTimeout)
+		v.check(shard.MaxConns >= 0, field+".max_conns", "must not be negative, got %d", shard.MaxConns)
+		v.check(shard.MinConns >= 0, field+".min_conns", "must not be negative, got %d", shard.MinConns)
+		v.check(shard.MaxConns == 0 || shard.MinConns <= shard.MaxConns, field+".min_conns",
+			"must be <= max_conns (%d), got %d", shard.MaxConns, shard.MinConns)
+		v.nonNegative(field+".max_conn_lifetime", shard.MaxConnLifetime)
+		v.nonNegative(field+".max_conn_idle_time", shard.MaxConnIdleTime)
+		v.nonNegative(field+".health_check_period", shard.HealthCheckPeriod)
+
+		for j, replica := range shard.Replicas {
+			replicaField := fmt.Sprintf("%s.replicas[%d]", field, j)
+			v.required(replicaField+".host", replica.Host)
+			v.port(replicaField+".port", replica.Port)
+		}
+	}
+}
+
+func (c *Config) validateKafka(v *validator) {
+	kafka := c.Kafka
+	v.required("kafka.host", kafka.Host)
+	v.port("kafka.port", kafka.Port)
+	v.required("kafka.menu_generation_topic_name", kafka.MenuGenerationTopicName)
+	v.required("kafka.profile_events_topic_name", kafka.ProfileEventsTopicName)
+	v.oneOf("kafka.encoding", kafka.Encoding, "", "json", "protobuf")
+	if kafka.Encoding == "protobuf" {
+		v.required("kafka.schema_registry_path", kafka.SchemaRegistryPath)
+	}
+	v.check(kafka.PublishMaxAttempts >= 0, "kafka.publish_max_attempts", "must not be negative, got %d", kafka.PublishMaxAttempts)
+	v.check(kafka.PublishQueueSize >= 0, "kafka.publish_queue_size", "must not be negative, got %d", kafka.PublishQueueSize)
+	v.nonNegative("kafka.publish_initial_backoff", kafka.PublishInitialBackoff)
+	v.nonNegative("kafka.publish_max_backoff", kafka.PublishMaxBackoff)
+	v.check(kafka.PublishMaxBackoff == 0 || kafka.PublishInitialBackoff <= kafka.PublishMaxBackoff, "kafka.publish_max_backoff",
+		"must be >= publish_initial_backoff (%s), got %s", kafka.PublishInitialBackoff, kafka.PublishMaxBackoff)
+}
+
+func (c *Config) validateRateLimit(v *validator) {
+	rateLimit := c.RateLimit
+	v.oneOf("rateLimit.backend", rateLimit.Backend, "", "memory", "redis")
+	if rateLimit.Enabled && rateLimit.Backend == "redis" {
+		v.required("rateLimit.redis.address", rateLimit.Redis.Address)
+	}
+
+	rules := map[string]RateLimitRule{"rateLimit.default": rateLimit.Default, "rateLimit.http": rateLimit.HTTP}
+	for method, rule := range rateLimit.Methods {
+		rules["rateLimit.methods."+method] = rule
+	}
+	for _, field := range slices.Sorted(maps.Keys(rules)) {
+		v.rateLimit(field+".perIP", rules[field].PerIP)
+		v.rateLimit(field+".perUser", rules[field].PerUser)
+	}
+}
+
+func (v *validator) rateLimit(field string, limit RateLimit) {
+	v.check(limit.Rate >= 0, field+".rate", "must not be negative, got %g", limit.Rate)
+	v.check(limit.Burst >= 0, field+".burst", "must not be negative, got %d", limit.Burst)
+}
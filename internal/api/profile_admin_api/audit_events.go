@@ -0,0 +1,53 @@
+package profile_admin_api
+
+import (
+	"context"
+	"log"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_admin_api"
+	"github.com/samber/lo"
+)
+
+func (s *ProfileAdminAPI) ListAuditEvents(ctx context.Context, req *profile_admin_api.ListAuditEventsRequest) (*profile_admin_api.ListAuditEventsResponse, error) {
+	log.Printf("Received ListAuditEvents request for user_id: %d, entity: %s/%s", req.UserId, req.EntityType, req.EntityId)
+
+	events, err := s.profileService.ListAuditEvents(ctx, &models.AuditEventFilter{
+		UserID:     req.UserId,
+		EntityType: models.AuditEntityType(req.EntityType),
+		EntityID:   req.EntityId,
+		From:       req.From,
+		To:         req.To,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		return &profile_admin_api.ListAuditEventsResponse{}, err
+	}
+
+	return &profile_admin_api.ListAuditEventsResponse{
+		Events: lo.Map(events, func(event *models.AuditEvent, _ int) *profile_admin_api.AuditEvent {
+			return mapAuditEventToProto(event)
+		}),
+	}, nil
+}
+
+func mapAuditEventToProto(event *models.AuditEvent) *profile_admin_api.AuditEvent {
+	return &profile_admin_api.AuditEvent{
+		Id:            event.ID,
+		UserId:        event.UserID,
+		Actor:         event.Actor,
+		ActorVerified: event.ActorVerified,
+		Action:        string(event.Action),
+		EntityType:    string(event.EntityType),
+		EntityId:      event.EntityID,
+		Changes: lo.Map(event.Changes, func(change models.AuditChange, _ int) *profile_admin_api.AuditChange {
+			return &profile_admin_api.AuditChange{
+				Field:  change.Field,
+				Before: string(change.Before),
+				After:  string(change.After),
+			}
+		}),
+		RequestId: event.RequestID,
+		CreatedAt: event.CreatedAt,
+	}
+}
@@ -0,0 +1,109 @@
+package profile_admin_api
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_admin_api"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/request_metadata"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AuthorizationMetadata ключ метаданных gRPC с токеном администратора в виде Bearer <token>
+	AuthorizationMetadata = "authorization"
+
+	// AdminActor автор вызовов ProfileAdminService с верным токеном в журнале аудита
+	AdminActor = "admin"
+
+	bearerPrefix = "Bearer "
+)
+
+// adminMethodPrefix префикс полных имён методов ProfileAdminService
+var adminMethodPrefix = "/" + profile_admin_api.ProfileAdminService_ServiceDesc.ServiceName + "/"
+
+// AdminAuth пропускает вызовы ProfileAdminService только с токеном администратора; остальные методы
+// сервера не проверяются. Пустой токен выключает административные методы. Вызов с верным токеном
+// получает подтверждённого автора AdminActor, заголовок x-actor его не заменяет.
+type AdminAuth struct {
+	token string
+}
+
+func NewAdminAuth(token string) *AdminAuth {
+	return &AdminAuth{token: token}
+}
+
+func (a *AdminAuth) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *AdminAuth) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authorizedServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorizedServerStream поток с контекстом, в который положен автор вызова
+type authorizedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *AdminAuth) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	if !strings.HasPrefix(fullMethod, adminMethodPrefix) {
+		return ctx, nil
+	}
+
+	var authorization string
+	if values := metadata.ValueFromIncomingContext(ctx, AuthorizationMetadata); len(values) > 0 {
+		authorization = values[0]
+	}
+	if err := a.checkToken(authorization); err != nil {
+		return nil, err
+	}
+	return request_metadata.WithVerifiedActor(ctx, AdminActor), nil
+}
+
+// HTTPMiddleware пропускает HTTP-запрос только с заголовком Authorization: Bearer <admin.token>.
+// Им закрыты служебные маршруты gateway, например /debug/config и /debug/vars.
+func (a *AdminAuth) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := a.checkToken(r.Header.Get("Authorization")); err != nil {
+			st := status.Convert(err)
+			http.Error(w, st.Message(), runtime.HTTPStatusFromCode(st.Code()))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// checkToken сверяет значение authorization с токеном администратора
+func (a *AdminAuth) checkToken(authorization string) error {
+	if a.token == "" {
+		return status.Error(codes.PermissionDenied, "административные методы выключены: не задан admin.token")
+	}
+	if !strings.HasPrefix(authorization, bearerPrefix) {
+		return status.Error(codes.Unauthenticated, "требуется токен администратора в authorization: Bearer <token>")
+	}
+	token := strings.TrimPrefix(authorization, bearerPrefix)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+		return status.Error(codes.Unauthenticated, "неверный токен администратора")
+	}
+	return nil
+}
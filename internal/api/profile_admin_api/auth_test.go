@@ -0,0 +1,86 @@
+package profile_admin_api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/request_metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+)
+
+var moveUserInfo = &grpc.UnaryServerInfo{FullMethod: "/profile_management.admin.v1.ProfileAdminService/MoveUser"}
+
+func okHandler(ctx context.Context, req any) (any, error) {
+	return "ok", nil
+}
+
+func authContext(value string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationMetadata, value))
+}
+
+func TestAdminAuthAcceptsToken(t *testing.T) {
+	resp, err := NewAdminAuth("secret").UnaryServerInterceptor(authContext("Bearer secret"), nil, moveUserInfo, okHandler)
+	assert.NilError(t, err)
+	assert.Equal(t, resp, "ok")
+}
+
+func TestAdminAuthSetsVerifiedActor(t *testing.T) {
+	_, err := NewAdminAuth("secret").UnaryServerInterceptor(authContext("Bearer secret"), nil, moveUserInfo, func(ctx context.Context, req any) (any, error) {
+		ctx = request_metadata.WithActor(ctx, "mallory")
+		assert.Equal(t, request_metadata.Actor(ctx), AdminActor)
+		assert.Assert(t, request_metadata.ActorVerified(ctx))
+		return nil, nil
+	})
+	assert.NilError(t, err)
+}
+
+func TestAdminAuthRejectsWrongOrMissingToken(t *testing.T) {
+	auth := NewAdminAuth("secret")
+	for _, ctx := range []context.Context{context.Background(), authContext("Bearer other"), authContext("secret")} {
+		_, err := auth.UnaryServerInterceptor(ctx, nil, moveUserInfo, okHandler)
+		assert.Equal(t, status.Code(err), codes.Unauthenticated)
+	}
+}
+
+func TestAdminAuthDisabledWithoutToken(t *testing.T) {
+	_, err := NewAdminAuth("").UnaryServerInterceptor(authContext("Bearer "), nil, moveUserInfo, okHandler)
+	assert.Equal(t, status.Code(err), codes.PermissionDenied)
+}
+
+func TestAdminAuthSkipsOtherServices(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/profile_management.service.v1.ProfileManagementService/GetUser"}
+	resp, err := NewAdminAuth("secret").UnaryServerInterceptor(context.Background(), nil, info, okHandler)
+	assert.NilError(t, err)
+	assert.Equal(t, resp, "ok")
+}
+
+func TestAdminAuthHTTPMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	for _, tc := range []struct {
+		token         string
+		authorization string
+		want          int
+	}{
+		{token: "secret", authorization: "Bearer secret", want: http.StatusNoContent},
+		{token: "secret", authorization: "Bearer other", want: http.StatusUnauthorized},
+		{token: "secret", authorization: "", want: http.StatusUnauthorized},
+		{token: "", authorization: "Bearer ", want: http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/debug/config", nil)
+		if tc.authorization != "" {
+			req.Header.Set("Authorization", tc.authorization)
+		}
+		rec := httptest.NewRecorder()
+		NewAdminAuth(tc.token).HTTPMiddleware(next).ServeHTTP(rec, req)
+		assert.Equal(t, rec.Code, tc.want, "token %q, authorization %q", tc.token, tc.authorization)
+	}
+}
@@ -0,0 +1,63 @@
+package profile_admin_api
+
+import (
+	"context"
+	"log"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_admin_api"
+	"github.com/samber/lo"
+)
+
+func (s *ProfileAdminAPI) ListDeadLetters(ctx context.Context, req *profile_admin_api.ListDeadLettersRequest) (*profile_admin_api.ListDeadLettersResponse, error) {
+	log.Printf("Received ListDeadLetters request for topic: %q, limit: %d", req.Topic, req.Limit)
+
+	deadLetters, err := s.profileService.ListDeadLetters(ctx, req.Topic, req.Limit)
+	if err != nil {
+		return &profile_admin_api.ListDeadLettersResponse{}, err
+	}
+
+	return &profile_admin_api.ListDeadLettersResponse{
+		DeadLetters: lo.Map(deadLetters, func(deadLetter *models.DeadLetter, _ int) *profile_admin_api.DeadLetter {
+			return mapDeadLetterToProto(deadLetter)
+		}),
+	}, nil
+}
+
+func (s *ProfileAdminAPI) ReplayDeadLetter(ctx context.Context, req *profile_admin_api.ReplayDeadLetterRequest) (*profile_admin_api.ReplayDeadLetterResponse, error) {
+	log.Printf("Received ReplayDeadLetter request for ID: %s", req.Id)
+
+	err := s.profileService.ReplayDeadLetter(ctx, req.Id)
+	if err != nil {
+		return &profile_admin_api.ReplayDeadLetterResponse{}, err
+	}
+
+	return &profile_admin_api.ReplayDeadLetterResponse{}, nil
+}
+
+func (s *ProfileAdminAPI) DiscardDeadLetter(ctx context.Context, req *profile_admin_api.DiscardDeadLetterRequest) (*profile_admin_api.DiscardDeadLetterResponse, error) {
+	log.Printf("Received DiscardDeadLetter request for ID: %s", req.Id)
+
+	err := s.profileService.DiscardDeadLetter(ctx, req.Id)
+	if err != nil {
+		return &profile_admin_api.DiscardDeadLetterResponse{}, err
+	}
+
+	return &profile_admin_api.DiscardDeadLetterResponse{}, nil
+}
+
+func mapDeadLetterToProto(deadLetter *models.DeadLetter) *profile_admin_api.DeadLetter {
+	return &profile_admin_api.DeadLetter{
+		Id:     deadLetter.ID,
+		UserId: deadLetter.UserID,
+		Topic:  deadLetter.Topic,
+		Key:    deadLetter.Key,
+		Value:  deadLetter.Value,
+		Headers: lo.Map(deadLetter.Headers, func(header models.DeadLetterHeader, _ int) *profile_admin_api.DeadLetterHeader {
+			return &profile_admin_api.DeadLetterHeader{Key: header.Key, Value: header.Value}
+		}),
+		Error:     deadLetter.Error,
+		Attempts:  deadLetter.Attempts,
+		CreatedAt: deadLetter.CreatedAt,
+	}
+}
@@ -0,0 +1,50 @@
+package profile_admin_api
+
+import (
+	"context"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_admin_api"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/cached_storage"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+type adminService interface {
+	ListDeadLetters(ctx context.Context, topic string, limit int32) ([]*models.DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, id string) error
+	DiscardDeadLetter(ctx context.Context, id string) error
+	ListAuditEvents(ctx context.Context, filter *models.AuditEventFilter) ([]*models.AuditEvent, error)
+}
+
+type adminStorage interface {
+	SearchUsers(ctx context.Context, prefix string, limit uint64) ([]*profile_management_storage.UserPlacement, error)
+	LocateUserByID(ctx context.Context, id int32) (*profile_management_storage.UserPlacement, error)
+	LocateUserByUsername(ctx context.Context, username string) (*profile_management_storage.UserPlacement, error)
+	MoveUser(ctx context.Context, userID int32, profile bool, from, to int, force bool) (int64, error)
+	BucketMap() []int
+	ShardStats(ctx context.Context) []profile_management_storage.ShardStats
+	Health() []profile_management_storage.PoolHealth
+}
+
+type userCache interface {
+	InvalidateUsers(ctx context.Context, ids ...int32)
+}
+
+// ProfileAdminAPI реализует grpc ProfileAdminServiceServer. Операции с шардами идут в хранилище напрямую,
+// минуя кеш, поэтому после переноса пользователя его записи сбрасываются в cache; dead-letter обрабатываются
+// сервисом, чтобы повторная отправка попала в журнал аудита.
+type ProfileAdminAPI struct {
+	profile_admin_api.UnimplementedProfileAdminServiceServer
+	profileService adminService
+	storage        adminStorage
+	cache          userCache
+}
+
+func NewProfileAdminAPI(profileService *profile_service.ProfileService, storage *profile_management_storage.ProfileManagementStorage, cache *cached_storage.CachedStorage) *ProfileAdminAPI {
+	return &ProfileAdminAPI{
+		profileService: profileService,
+		storage:        storage,
+		cache:          cache,
+	}
+}
@@ -0,0 +1,47 @@
+package profile_admin_api
+
+import (
+	"context"
+	"log"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_admin_api"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+	"github.com/samber/lo"
+)
+
+func (s *ProfileAdminAPI) ListShardStats(ctx context.Context, req *profile_admin_api.ListShardStatsRequest) (*profile_admin_api.ListShardStatsResponse, error) {
+	log.Printf("Received ListShardStats request")
+
+	stats := s.storage.ShardStats(ctx)
+	shards := lo.Map(stats, func(shard profile_management_storage.ShardStats, _ int) *profile_admin_api.ShardStats {
+		return mapShardStatsToProto(shard)
+	})
+	for _, pool := range s.storage.Health() {
+		if pool.Replica < 0 {
+			shards[pool.Shard].PrimaryState = pool.State.String()
+		} else {
+			shards[pool.Shard].ReplicaStates = append(shards[pool.Shard].ReplicaStates, pool.State.String())
+		}
+	}
+
+	return &profile_admin_api.ListShardStatsResponse{
+		BucketCount: int32(len(s.storage.BucketMap())),
+		Shards:      shards,
+	}, nil
+}
+
+func mapShardStatsToProto(stats profile_management_storage.ShardStats) *profile_admin_api.ShardStats {
+	shard := &profile_admin_api.ShardStats{
+		Shard: int32(stats.Shard),
+		Buckets: lo.Map(stats.Buckets, func(bucket int, _ int) int32 {
+			return int32(bucket)
+		}),
+		Tables: lo.Map(stats.Tables, func(table profile_management_storage.TableRows, _ int) *profile_admin_api.TableRows {
+			return &profile_admin_api.TableRows{Table: table.Table, Rows: table.Rows}
+		}),
+	}
+	if stats.Err != nil {
+		shard.Error = stats.Err.Error()
+	}
+	return shard
+}
@@ -0,0 +1,110 @@
+package profile_admin_api
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_admin_api"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 1000
+)
+
+func (s *ProfileAdminAPI) SearchUsers(ctx context.Context, req *profile_admin_api.SearchUsersRequest) (*profile_admin_api.SearchUsersResponse, error) {
+	log.Printf("Received SearchUsers request for prefix: %q, limit: %d", req.UsernamePrefix, req.Limit)
+
+	if req.UsernamePrefix == "" {
+		return &profile_admin_api.SearchUsersResponse{}, status.Error(codes.InvalidArgument, "username_prefix не может быть пустым")
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	placements, err := s.storage.SearchUsers(ctx, req.UsernamePrefix, uint64(limit))
+	if err != nil {
+		return &profile_admin_api.SearchUsersResponse{}, err
+	}
+
+	return &profile_admin_api.SearchUsersResponse{
+		Users: lo.Map(placements, func(placement *profile_management_storage.UserPlacement, _ int) *profile_admin_api.UserPlacement {
+			return mapUserPlacementToProto(placement)
+		}),
+	}, nil
+}
+
+func (s *ProfileAdminAPI) GetUserPlacement(ctx context.Context, req *profile_admin_api.GetUserPlacementRequest) (*profile_admin_api.GetUserPlacementResponse, error) {
+	log.Printf("Received GetUserPlacement request for user: %v", req.User)
+
+	var placement *profile_management_storage.UserPlacement
+	var err error
+	switch user := req.User.(type) {
+	case *profile_admin_api.GetUserPlacementRequest_UserId:
+		placement, err = s.storage.LocateUserByID(ctx, user.UserId)
+	case *profile_admin_api.GetUserPlacementRequest_Username:
+		placement, err = s.storage.LocateUserByUsername(ctx, user.Username)
+	default:
+		return &profile_admin_api.GetUserPlacementResponse{}, status.Error(codes.InvalidArgument, "укажите user_id или username")
+	}
+	if err != nil {
+		return &profile_admin_api.GetUserPlacementResponse{}, err
+	}
+
+	return &profile_admin_api.GetUserPlacementResponse{
+		Placement: mapUserPlacementToProto(placement),
+	}, nil
+}
+
+func (s *ProfileAdminAPI) MoveUser(ctx context.Context, req *profile_admin_api.MoveUserRequest) (*profile_admin_api.MoveUserResponse, error) {
+	log.Printf("Received MoveUser request for user ID: %d, scope: %s, from shard %d to shard %d, force: %t",
+		req.UserId, req.Scope, req.FromShard, req.ToShard, req.Force)
+
+	var profile bool
+	switch req.Scope {
+	case profile_admin_api.MoveUserScope_MOVE_USER_SCOPE_PROFILE:
+		profile = true
+	case profile_admin_api.MoveUserScope_MOVE_USER_SCOPE_DATA:
+	default:
+		return &profile_admin_api.MoveUserResponse{}, status.Error(codes.InvalidArgument, "укажите scope: PROFILE или DATA")
+	}
+
+	rows, err := s.storage.MoveUser(ctx, req.UserId, profile, int(req.FromShard), int(req.ToShard), req.Force)
+	// Сбрасываем и при ошибке: перенос не атомарен и мог скопировать часть строк
+	s.cache.InvalidateUsers(ctx, req.UserId)
+	switch {
+	case errors.Is(err, profile_management_storage.ErrShardOutOfRange),
+		errors.Is(err, profile_management_storage.ErrForceDataMove):
+		return &profile_admin_api.MoveUserResponse{}, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, profile_management_storage.ErrMoveOffRoute):
+		return &profile_admin_api.MoveUserResponse{}, status.Errorf(codes.FailedPrecondition, "%v; для переноса на другой шард укажите force", err)
+	case errors.Is(err, profile_management_storage.ErrMoveIDConflict):
+		return &profile_admin_api.MoveUserResponse{}, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return &profile_admin_api.MoveUserResponse{}, err
+	}
+
+	return &profile_admin_api.MoveUserResponse{
+		RowsMoved: rows,
+	}, nil
+}
+
+func mapUserPlacementToProto(placement *profile_management_storage.UserPlacement) *profile_admin_api.UserPlacement {
+	return &profile_admin_api.UserPlacement{
+		UserId:         placement.User.ID,
+		Username:       placement.User.Username,
+		CreatedAt:      placement.User.CreatedAt,
+		Shard:          int32(placement.Shard),
+		UsernameBucket: int32(placement.UsernameBucket),
+		UsernameShard:  int32(placement.UsernameShard),
+		IdBucket:       int32(placement.IDBucket),
+		DataShard:      int32(placement.DataShard),
+	}
+}
@@ -0,0 +1,63 @@
+package profile_management_api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// IfMatchMetadata ключ метаданных gRPC; gateway передаёт в него HTTP-заголовок If-Match
+	IfMatchMetadata = "if-match"
+	// ETagMetadata ключ заголовка ответа gRPC; gateway отдаёт его как HTTP-заголовок ETag
+	ETagMetadata = "etag"
+)
+
+// expectedVersion возвращает ожидаемую версию записи: из expected_version, иначе из If-Match.
+// Ноль означает обновление без проверки версии.
+func expectedVersion(ctx context.Context, requested int32) (int32, error) {
+	if requested != 0 {
+		return requested, nil
+	}
+
+	values := metadata.ValueFromIncomingContext(ctx, IfMatchMetadata)
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	tag := strings.TrimSpace(values[0])
+	if tag == "*" {
+		return 0, nil
+	}
+	tag = strings.Trim(strings.TrimPrefix(tag, "W/"), `"`)
+
+	version, err := strconv.ParseInt(tag, 10, 32)
+	if err != nil || version <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "некорректный заголовок If-Match")
+	}
+	return int32(version), nil
+}
+
+// setETag отдаёт версию записи в заголовке ответа
+func setETag(ctx context.Context, version int32) {
+	if version <= 0 {
+		return
+	}
+	// Вне gRPC-вызова заголовок передать некуда, ответ при этом не меняется
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ETagMetadata, strconv.Quote(strconv.Itoa(int(version)))))
+}
+
+// mapVersionError переводит конфликт версий в FAILED_PRECONDITION
+func mapVersionError(err error) error {
+	if errors.Is(err, models.ErrVersionMismatch) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package profile_management_api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// IdempotencyKeyMetadata ключ метаданных gRPC; gateway передаёт в него HTTP-заголовок Idempotency-Key
+const IdempotencyKeyMetadata = "idempotency-key"
+
+// idempotent выполняет создающий запрос с учётом Idempotency-Key из метаданных.
+// Ответ хранится в сериализованном виде и при повторе разбирается в новый resp. Повтор получает сохранённый
+// ответ, только если совпадает хеш тела запроса; иначе возвращается ALREADY_EXISTS.
+func idempotent[Resp proto.Message](ctx context.Context, s *ProfileManagementAPI, userID int32, method string, req proto.Message, resp Resp, run func(ctx context.Context) (Resp, error)) (Resp, error) {
+	key := idempotencyKeyFromContext(ctx)
+	if key == "" {
+		return run(ctx)
+	}
+
+	requestData, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+	requestHash := sha256.Sum256(requestData)
+
+	responseData, err := s.profileService.ExecuteIdempotent(ctx, userID, method, key, requestHash[:], func(ctx context.Context) ([]byte, error) {
+		result, err := run(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(result)
+	})
+	switch {
+	case errors.Is(err, profile_service.ErrIdempotencyKeyReused):
+		return resp, status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, profile_service.ErrIdempotencyKeyInProgress):
+		return resp, status.Error(codes.Aborted, err.Error())
+	case err != nil:
+		return resp, err
+	}
+
+	if err := proto.Unmarshal(responseData, resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// scopedMethod сужает область ключей метода до scope: одинаковые ключи разных областей не пересекаются.
+// scope хешируется, чтобы имя метода уместилось в колонку и не хранило данные запроса.
+func scopedMethod(method, scope string) string {
+	sum := sha256.Sum256([]byte(scope))
+	return method + ":" + hex.EncodeToString(sum[:16])
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, IdempotencyKeyMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,17 @@
+package profile_management_api
+
+import (
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestScopedMethodSeparatesScopes(t *testing.T) {
+	alice := scopedMethod("CreateUser", "alice")
+	assert.Equal(t, alice, scopedMethod("CreateUser", "alice"))
+	assert.Assert(t, alice != scopedMethod("CreateUser", "bob"))
+	assert.Assert(t, strings.HasPrefix(alice, "CreateUser:"))
+	// Колонка method - VARCHAR(64)
+	assert.Assert(t, len(scopedMethod("CreateUser", strings.Repeat("я", 1000))) <= 64)
+}
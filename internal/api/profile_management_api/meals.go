@@ -13,6 +13,12 @@ import (
 func (s *ProfileManagementAPI) CreateMeal(ctx context.Context, req *profile_management_api.CreateMealRequest) (*profile_management_api.CreateMealResponse, error) {
 	log.Printf("Received CreateMeal request for user_id: %d", req.Meal.UserId)
 
+	return idempotent(ctx, s, req.Meal.UserId, "CreateMeal", req, &profile_management_api.CreateMealResponse{}, func(ctx context.Context) (*profile_management_api.CreateMealResponse, error) {
+		return s.createMeal(ctx, req)
+	})
+}
+
+func (s *ProfileManagementAPI) createMeal(ctx context.Context, req *profile_management_api.CreateMealRequest) (*profile_management_api.CreateMealResponse, error) {
 	meal := mapMealCreateModelToModel(req.Meal)
 
 	err := s.profileService.CreateMeal(ctx, meal)
@@ -48,17 +54,24 @@ func (s *ProfileManagementAPI) UpdateMeal(ctx context.Context, req *profile_mana
 		return &profile_management_api.UpdateMealResponse{}, err
 	}
 
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return &profile_management_api.UpdateMealResponse{}, err
+	}
+
 	meal := mapMealUpdateModelToModel(req.Meal, req.Id, existingMeal.UserID)
+	meal.Version = version
 
 	err = s.profileService.UpdateMeal(ctx, meal)
 	if err != nil {
-		return &profile_management_api.UpdateMealResponse{}, err
+		return &profile_management_api.UpdateMealResponse{}, mapVersionError(err)
 	}
 
 	updatedMeal, err := s.profileService.GetMealByID(ctx, req.Id)
 	if err != nil {
 		return &profile_management_api.UpdateMealResponse{}, err
 	}
+	setETag(ctx, updatedMeal.Version)
 
 	return &profile_management_api.UpdateMealResponse{
 		Meal: mapMealModelToProto(updatedMeal),
@@ -81,6 +94,7 @@ func mapMealCreateModelToModel(protoMeal *proto_models.MealCreateModel) *models.
 		UserID:     protoMeal.UserId,
 		Name:       protoMeal.Name,
 		ProductIDs: protoMeal.ProductIds,
+		Products:   mapMealProductsToModel(protoMeal.Products),
 	}
 }
 
@@ -90,6 +104,7 @@ func mapMealUpdateModelToModel(protoMeal *proto_models.MealUpdateModel, id int32
 		UserID:     userID,
 		Name:       protoMeal.Name,
 		ProductIDs: protoMeal.ProductIds,
+		Products:   mapMealProductsToModel(protoMeal.Products),
 	}
 }
 
@@ -100,5 +115,23 @@ func mapMealModelToProto(meal *models.Meal) *proto_models.MealModel {
 		Name:       meal.Name,
 		ProductIds: meal.ProductIDs,
 		CreatedAt:  meal.CreatedAt,
+		Version:    meal.Version,
+		Products: lo.Map(meal.Products, func(product models.MealProduct, _ int) *proto_models.MealProductModel {
+			return &proto_models.MealProductModel{
+				ProductId: product.ProductID,
+				Quantity:  lo.FromPtr(product.Quantity),
+			}
+		}),
 	}
 }
+
+func mapMealProductsToModel(protoProducts []*proto_models.MealProductModel) []models.MealProduct {
+	return lo.Map(protoProducts, func(product *proto_models.MealProductModel, _ int) models.MealProduct {
+		mealProduct := models.MealProduct{ProductID: product.ProductId}
+		if product.Quantity != 0 {
+			quantity := product.Quantity
+			mealProduct.Quantity = &quantity
+		}
+		return mealProduct
+	})
+}
@@ -0,0 +1,85 @@
+package profile_management_api
+
+import (
+	"context"
+	"log"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_management_api"
+	"github.com/samber/lo"
+)
+
+func (s *ProfileManagementAPI) GetGeneratedMenus(ctx context.Context, req *profile_management_api.GetGeneratedMenusRequest) (*profile_management_api.GetGeneratedMenusResponse, error) {
+	log.Printf("Received GetGeneratedMenus request for user_id: %d, limit: %d", req.UserId, req.Limit)
+
+	generations, err := s.profileService.GetGeneratedMenus(ctx, req.UserId, req.Limit)
+	if err != nil {
+		return &profile_management_api.GetGeneratedMenusResponse{}, err
+	}
+
+	return &profile_management_api.GetGeneratedMenusResponse{
+		Menus: lo.Map(generations, func(generation *models.MenuGeneration, _ int) *profile_management_api.MenuGeneration {
+			return mapMenuGenerationToProto(generation)
+		}),
+	}, nil
+}
+
+func (s *ProfileManagementAPI) GetMenuGenerationStatus(ctx context.Context, req *profile_management_api.GetMenuGenerationStatusRequest) (*profile_management_api.GetMenuGenerationStatusResponse, error) {
+	log.Printf("Received GetMenuGenerationStatus request for request_id: %s", req.RequestId)
+
+	generation, err := s.profileService.GetMenuGenerationStatus(ctx, req.RequestId)
+	if err != nil {
+		return &profile_management_api.GetMenuGenerationStatusResponse{}, err
+	}
+
+	return &profile_management_api.GetMenuGenerationStatusResponse{
+		Generation: mapMenuGenerationToProto(generation),
+	}, nil
+}
+
+func (s *ProfileManagementAPI) RequestMenuGeneration(ctx context.Context, req *profile_management_api.RequestMenuGenerationRequest) (*profile_management_api.RequestMenuGenerationResponse, error) {
+	log.Printf("Received RequestMenuGeneration request for user_id: %d", req.UserId)
+
+	options := &models.MenuGenerationOptions{
+		DateFrom:    req.DateFrom,
+		DateTo:      req.DateTo,
+		MealsPerDay: req.MealsPerDay,
+	}
+	if req.Budget != 0 {
+		options.Budget = &req.Budget
+	}
+
+	requestID, err := s.profileService.RequestMenuGeneration(ctx, req.UserId, options)
+	if err != nil {
+		return &profile_management_api.RequestMenuGenerationResponse{}, err
+	}
+
+	return &profile_management_api.RequestMenuGenerationResponse{
+		RequestId: requestID,
+	}, nil
+}
+
+func mapMenuGenerationToProto(generation *models.MenuGeneration) *profile_management_api.MenuGeneration {
+	return &profile_management_api.MenuGeneration{
+		RequestId:   generation.RequestID,
+		UserId:      generation.UserID,
+		Status:      mapMenuGenerationStatusToProto(generation.Status),
+		Error:       generation.Error,
+		Menu:        generation.Menu,
+		CreatedAt:   generation.CreatedAt,
+		CompletedAt: generation.CompletedAt,
+	}
+}
+
+func mapMenuGenerationStatusToProto(status models.MenuGenerationStatus) profile_management_api.MenuGenerationStatus {
+	switch status {
+	case models.MenuGenerationStatusPending:
+		return profile_management_api.MenuGenerationStatus_MENU_GENERATION_STATUS_PENDING
+	case models.MenuGenerationStatusCompleted:
+		return profile_management_api.MenuGenerationStatus_MENU_GENERATION_STATUS_COMPLETED
+	case models.MenuGenerationStatusFailed:
+		return profile_management_api.MenuGenerationStatus_MENU_GENERATION_STATUS_FAILED
+	default:
+		return profile_management_api.MenuGenerationStatus_MENU_GENERATION_STATUS_UNSPECIFIED
+	}
+}
@@ -13,6 +13,12 @@ import (
 func (s *ProfileManagementAPI) CreateProduct(ctx context.Context, req *profile_management_api.CreateProductRequest) (*profile_management_api.CreateProductResponse, error) {
 	log.Printf("Received CreateProduct request for user_id: %d", req.Product.UserId)
 
+	return idempotent(ctx, s, req.Product.UserId, "CreateProduct", req, &profile_management_api.CreateProductResponse{}, func(ctx context.Context) (*profile_management_api.CreateProductResponse, error) {
+		return s.createProduct(ctx, req)
+	})
+}
+
+func (s *ProfileManagementAPI) createProduct(ctx context.Context, req *profile_management_api.CreateProductRequest) (*profile_management_api.CreateProductResponse, error) {
 	product := mapProductCreateModelToModel(req.Product)
 
 	err := s.profileService.CreateProduct(ctx, product)
@@ -48,17 +54,24 @@ func (s *ProfileManagementAPI) UpdateProduct(ctx context.Context, req *profile_m
 		return &profile_management_api.UpdateProductResponse{}, err
 	}
 
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return &profile_management_api.UpdateProductResponse{}, err
+	}
+
 	product := mapProductUpdateModelToModel(req.Product, req.Id, existingProduct.UserID)
+	product.Version = version
 
 	err = s.profileService.UpdateProduct(ctx, product)
 	if err != nil {
-		return &profile_management_api.UpdateProductResponse{}, err
+		return &profile_management_api.UpdateProductResponse{}, mapVersionError(err)
 	}
 
 	updatedProduct, err := s.profileService.GetProductByID(ctx, req.Id)
 	if err != nil {
 		return &profile_management_api.UpdateProductResponse{}, err
 	}
+	setETag(ctx, updatedProduct.Version)
 
 	return &profile_management_api.UpdateProductResponse{
 		Product: mapProductModelToProto(updatedProduct),
@@ -66,9 +79,9 @@ func (s *ProfileManagementAPI) UpdateProduct(ctx context.Context, req *profile_m
 }
 
 func (s *ProfileManagementAPI) DeleteProduct(ctx context.Context, req *profile_management_api.DeleteProductRequest) (*profile_management_api.DeleteProductResponse, error) {
-	log.Printf("Received DeleteProduct request for ID: %d", req.Id)
+	log.Printf("Received DeleteProduct request for ID: %d, detach_from_meals: %t", req.Id, req.DetachFromMeals)
 
-	err := s.profileService.DeleteProduct(ctx, req.Id)
+	err := s.profileService.DeleteProduct(ctx, req.Id, req.DetachFromMeals)
 	if err != nil {
 		return &profile_management_api.DeleteProductResponse{}, err
 	}
@@ -76,6 +89,48 @@ func (s *ProfileManagementAPI) DeleteProduct(ctx context.Context, req *profile_m
 	return &profile_management_api.DeleteProductResponse{}, nil
 }
 
+func (s *ProfileManagementAPI) ImportProducts(ctx context.Context, req *profile_management_api.ImportProductsRequest) (*profile_management_api.ImportProductsResponse, error) {
+	log.Printf("Received ImportProducts request for user_id: %d, dry_run: %t", req.UserId, req.DryRun)
+
+	result, err := s.profileService.ImportProducts(ctx, req.UserId, mapProductImportFormatToModel(req.Format), req.Data, req.DryRun)
+	if err != nil {
+		return &profile_management_api.ImportProductsResponse{}, err
+	}
+
+	return &profile_management_api.ImportProductsResponse{
+		ImportedCount: int32(result.ImportedCount),
+		RejectedCount: int32(result.RejectedCount),
+		DryRun:        result.DryRun,
+		Rows: lo.Map(result.Rows, func(row *models.ProductImportRow, _ int) *profile_management_api.ProductImportRowResult {
+			return mapProductImportRowToProto(row)
+		}),
+	}, nil
+}
+
+func mapProductImportFormatToModel(format profile_management_api.ProductImportFormat) models.ProductImportFormat {
+	switch format {
+	case profile_management_api.ProductImportFormat_PRODUCT_IMPORT_FORMAT_CSV:
+		return models.ProductImportFormatCSV
+	case profile_management_api.ProductImportFormat_PRODUCT_IMPORT_FORMAT_JSON:
+		return models.ProductImportFormatJSON
+	default:
+		return models.ProductImportFormatUnknown
+	}
+}
+
+func mapProductImportRowToProto(row *models.ProductImportRow) *profile_management_api.ProductImportRowResult {
+	protoRow := &profile_management_api.ProductImportRowResult{
+		Line:  int32(row.Line),
+		Error: row.Error,
+	}
+
+	if row.Product != nil {
+		protoRow.Product = mapProductModelToProto(row.Product)
+	}
+
+	return protoRow
+}
+
 func mapProductCreateModelToModel(protoProduct *proto_models.ProductCreateModel) *models.Product {
 	product := &models.Product{
 		UserID: protoProduct.UserId,
@@ -135,6 +190,7 @@ func mapProductModelToProto(product *models.Product) *proto_models.ProductModel
 		UserId:    product.UserID,
 		Name:      product.Name,
 		CreatedAt: product.CreatedAt,
+		Version:   product.Version,
 	}
 
 	if product.Calories != nil {
@@ -0,0 +1,121 @@
+package profile_management_api
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_management_api"
+	"github.com/go-chi/chi/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxImportFileSize ограничивает размер загружаемого файла импорта
+const maxImportFileSize = 10 << 20
+
+// NewImportProductsHTTPHandler принимает multipart-загрузку файла для POST /users/{id}/products:import.
+// Запросы с другим Content-Type передаются в gateway без изменений. Заголовки передаются в gRPC так же,
+// как gateway: X-Forwarded-For, X-Actor, X-Request-Id и Idempotency-Key по правилам mux.
+func NewImportProductsHTTPHandler(client profile_management_api.ProfileManagementServiceClient, mux *runtime.ServeMux) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType != "multipart/form-data" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, r)
+
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, r, profile_management_api.ProfileManagementService_ImportProducts_FullMethodName,
+			runtime.WithHTTPPathPattern("/users/{user_id}/products:import"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		req, err := parseImportProductsForm(w, r)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		var md runtime.ServerMetadata
+		resp, err := client.ImportProducts(annotatedContext, req, grpc.Header(&md.HeaderMD), grpc.Trailer(&md.TrailerMD))
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, r, err)
+			return
+		}
+
+		runtime.ForwardResponseMessage(annotatedContext, mux, outboundMarshaler, w, r, resp)
+	}
+}
+
+func parseImportProductsForm(w http.ResponseWriter, r *http.Request) (*profile_management_api.ImportProductsRequest, error) {
+	userID, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "некорректный id пользователя")
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "некорректная multipart-форма: %v", err)
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "в форме отсутствует файл file")
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "ошибка чтения файла: %v", err)
+	}
+
+	format := detectProductImportFormat(r.FormValue("format"), header.Filename, header.Header.Get("Content-Type"))
+	if format == profile_management_api.ProductImportFormat_PRODUCT_IMPORT_FORMAT_UNSPECIFIED {
+		return nil, status.Error(codes.InvalidArgument, "не удалось определить формат файла, укажите format=csv или format=json")
+	}
+
+	var dryRun bool
+	if value := r.FormValue("dry_run"); value != "" {
+		dryRun, err = strconv.ParseBool(value)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "некорректное значение dry_run")
+		}
+	}
+
+	return &profile_management_api.ImportProductsRequest{
+		UserId: int32(userID),
+		Format: format,
+		Data:   data,
+		DryRun: dryRun,
+	}, nil
+}
+
+func detectProductImportFormat(formValue, filename, contentType string) profile_management_api.ProductImportFormat {
+	candidates := []string{
+		strings.ToLower(formValue),
+		strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), "."),
+		strings.ToLower(contentType),
+	}
+
+	for _, candidate := range candidates {
+		switch {
+		case candidate == "csv" || strings.HasSuffix(candidate, "/csv"):
+			return profile_management_api.ProductImportFormat_PRODUCT_IMPORT_FORMAT_CSV
+		case candidate == "json" || strings.HasSuffix(candidate, "/json"):
+			return profile_management_api.ProductImportFormat_PRODUCT_IMPORT_FORMAT_JSON
+		}
+	}
+
+	return profile_management_api.ProductImportFormat_PRODUCT_IMPORT_FORMAT_UNSPECIFIED
+}
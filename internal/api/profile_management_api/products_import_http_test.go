@@ -0,0 +1,59 @@
+package profile_management_api
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_management_api"
+	"github.com/go-chi/chi/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gotest.tools/v3/assert"
+)
+
+// importClient запоминает метаданные, с которыми пришёл ImportProducts
+type importClient struct {
+	profile_management_api.ProfileManagementServiceClient
+	md metadata.MD
+}
+
+func (c *importClient) ImportProducts(ctx context.Context, req *profile_management_api.ImportProductsRequest, opts ...grpc.CallOption) (*profile_management_api.ImportProductsResponse, error) {
+	c.md, _ = metadata.FromOutgoingContext(ctx)
+	return &profile_management_api.ImportProductsResponse{}, nil
+}
+
+func TestImportProductsHTTPHandlerForwardsHeaders(t *testing.T) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "products.csv")
+	assert.NilError(t, err)
+	_, err = file.Write([]byte("name,calories\nРис,130\n"))
+	assert.NilError(t, err)
+	assert.NilError(t, form.Close())
+
+	client := &importClient{}
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+		if key == "X-Actor" {
+			return ActorMetadata, true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}))
+	r := chi.NewRouter()
+	r.Post("/users/{id}/products:import", NewImportProductsHTTPHandler(client, mux))
+
+	req := httptest.NewRequest(http.MethodPost, "/users/7/products:import", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("X-Actor", "alice")
+	req.RemoteAddr = "203.0.113.7:5000"
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Equal(t, rec.Code, http.StatusOK)
+	assert.DeepEqual(t, client.md.Get(ActorMetadata), []string{"alice"})
+	assert.DeepEqual(t, client.md.Get("x-forwarded-for"), []string{"203.0.113.7"})
+}
@@ -13,16 +13,25 @@ type profileService interface {
 	GetUserByID(ctx context.Context, id int32) (*models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) error
 	DeleteUser(ctx context.Context, id int32) error
+	RestoreUser(ctx context.Context, id int32) (*models.User, error)
+	ExportUserData(ctx context.Context, userID int32, format models.UserDataArchiveFormat) (*models.UserDataExport, error)
+	ImportUserData(ctx context.Context, format models.UserDataArchiveFormat, data []byte, username, passwordHash string) (*models.UserDataImportResult, error)
+	WatchUser(ctx context.Context, userID int32, resumeToken string) (<-chan *models.ChangeEvent, func(), error)
 	CreateProduct(ctx context.Context, product *models.Product) error
 	GetProductsByUserID(ctx context.Context, userID int32) ([]*models.Product, error)
 	GetProductByID(ctx context.Context, id int32) (*models.Product, error)
 	UpdateProduct(ctx context.Context, product *models.Product) error
-	DeleteProduct(ctx context.Context, id int32) error
+	DeleteProduct(ctx context.Context, id int32, detachFromMeals bool) error
+	ImportProducts(ctx context.Context, userID int32, format models.ProductImportFormat, data []byte, dryRun bool) (*models.ProductImportResult, error)
 	CreateMeal(ctx context.Context, meal *models.Meal) error
 	GetMealsByUserID(ctx context.Context, userID int32) ([]*models.Meal, error)
 	GetMealByID(ctx context.Context, id int32) (*models.Meal, error)
 	UpdateMeal(ctx context.Context, meal *models.Meal) error
 	DeleteMeal(ctx context.Context, id int32) error
+	GetGeneratedMenus(ctx context.Context, userID int32, limit int32) ([]*models.MenuGeneration, error)
+	GetMenuGenerationStatus(ctx context.Context, requestID string) (*models.MenuGeneration, error)
+	RequestMenuGeneration(ctx context.Context, userID int32, options *models.MenuGenerationOptions) (string, error)
+	ExecuteIdempotent(ctx context.Context, userID int32, method, key string, requestHash []byte, run func(ctx context.Context) ([]byte, error)) ([]byte, error)
 }
 
 // ProfileManagementAPI реализует grpc ProfileManagementServiceServer
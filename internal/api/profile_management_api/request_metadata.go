@@ -0,0 +1,82 @@
+package profile_management_api
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/request_metadata"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// ActorMetadata ключ метаданных gRPC с автором запроса для журнала аудита; gateway передаёт в него заголовок X-Actor
+	ActorMetadata = "x-actor"
+	// RequestIDMetadata ключ метаданных gRPC с идентификатором запроса; gateway передаёт в него заголовок X-Request-Id
+	RequestIDMetadata = "x-request-id"
+
+	maxActorLen     = 255
+	maxRequestIDLen = 64
+)
+
+// UnaryRequestMetadataInterceptor кладёт в контекст автора и идентификатор запроса.
+// Идентификатор генерируется, если клиент его не передал, и возвращается в заголовке ответа.
+// Автор из x-actor не проверяется: автора, уже подтверждённого токеном администратора, он не заменяет.
+func UnaryRequestMetadataInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, requestID := withRequestMetadata(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadata, requestID))
+	return handler(ctx, req)
+}
+
+// StreamRequestMetadataInterceptor то же для потоковых методов
+func StreamRequestMetadataInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, requestID := withRequestMetadata(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(RequestIDMetadata, requestID))
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+}
+
+func withRequestMetadata(ctx context.Context) (context.Context, string) {
+	actor := firstMetadataValue(ctx, ActorMetadata, maxActorLen)
+	if actor == "" {
+		actor = request_metadata.AnonymousActor
+	}
+
+	requestID := firstMetadataValue(ctx, RequestIDMetadata, maxRequestIDLen)
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+
+	ctx = request_metadata.WithActor(ctx, actor)
+	ctx = request_metadata.WithRequestID(ctx, requestID)
+	return ctx, requestID
+}
+
+// contextServerStream поток с контекстом, дополненным перехватчиком
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// firstMetadataValue первое значение key, обрезанное до maxLen байт. Значение пишется в журнал аудита,
+// а Postgres не принимает некорректный UTF-8, поэтому такие байты выбрасываются, а обрезка не делит символ.
+func firstMetadataValue(ctx context.Context, key string, maxLen int) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+	value := strings.ToValidUTF8(values[0], "")
+	if len(value) > maxLen {
+		cut := maxLen
+		for cut > 0 && !utf8.RuneStart(value[cut]) {
+			cut--
+		}
+		value = value[:cut]
+	}
+	return value
+}
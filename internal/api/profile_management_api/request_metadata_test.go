@@ -0,0 +1,26 @@
+package profile_management_api
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/metadata"
+	"gotest.tools/v3/assert"
+)
+
+func TestFirstMetadataValueKeepsRunes(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ActorMetadata, "a"+strings.Repeat("я", 10)))
+
+	// Лимит приходится на середину двухбайтового символа: он отбрасывается целиком
+	value := firstMetadataValue(ctx, ActorMetadata, 4)
+	assert.Equal(t, value, "aя")
+	assert.Assert(t, utf8.ValidString(value))
+}
+
+func TestFirstMetadataValueDropsInvalidUTF8(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(ActorMetadata, "al\xffice"))
+
+	assert.Equal(t, firstMetadataValue(ctx, ActorMetadata, maxActorLen), "alice")
+}
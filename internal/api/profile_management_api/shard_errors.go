@@ -0,0 +1,33 @@
+package profile_management_api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryShardErrorInterceptor отвечает UNAVAILABLE, если запрос не выполнен из-за недоступного шарда.
+// Текст ошибки называет шард, поэтому клиент может повторить запрос позже.
+func UnaryShardErrorInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+	return resp, mapShardError(err)
+}
+
+// StreamShardErrorInterceptor то же для потоковых методов
+func StreamShardErrorInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return mapShardError(handler(srv, ss))
+}
+
+func mapShardError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, models.ErrShardUnavailable) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return err
+}
@@ -8,11 +8,23 @@ import (
 	proto_models "github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/models"
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_management_api"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
 )
 
+// exportChunkSize размер одного сообщения в потоке выгрузки
+const exportChunkSize = 64 << 10
+
 func (s *ProfileManagementAPI) CreateUser(ctx context.Context, req *profile_management_api.CreateUserRequest) (*profile_management_api.CreateUserResponse, error) {
 	log.Printf("Received CreateUser request for username: %s", req.User.Username)
 
+	// Пользователь ещё не создан, поэтому ключ идемпотентности не привязан к id. Чтобы одинаковые ключи
+	// разных клиентов не пересекались и не отдавали чужой ответ, область ключа ограничена username.
+	return idempotent(ctx, s, 0, scopedMethod("CreateUser", req.User.Username), req, &profile_management_api.CreateUserResponse{}, func(ctx context.Context) (*profile_management_api.CreateUserResponse, error) {
+		return s.createUser(ctx, req)
+	})
+}
+
+func (s *ProfileManagementAPI) createUser(ctx context.Context, req *profile_management_api.CreateUserRequest) (*profile_management_api.CreateUserResponse, error) {
 	user := mapUserCreateModelToModel(req.User)
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.User.Password), bcrypt.DefaultCost)
@@ -38,6 +50,7 @@ func (s *ProfileManagementAPI) GetUser(ctx context.Context, req *profile_managem
 	if err != nil {
 		return &profile_management_api.GetUserResponse{}, err
 	}
+	setETag(ctx, user.Version)
 
 	return &profile_management_api.GetUserResponse{
 		User: mapUserModelToProto(user),
@@ -47,17 +60,24 @@ func (s *ProfileManagementAPI) GetUser(ctx context.Context, req *profile_managem
 func (s *ProfileManagementAPI) UpdateUser(ctx context.Context, req *profile_management_api.UpdateUserRequest) (*profile_management_api.UpdateUserResponse, error) {
 	log.Printf("Received UpdateUser request for ID: %d", req.Id)
 
+	version, err := expectedVersion(ctx, req.ExpectedVersion)
+	if err != nil {
+		return &profile_management_api.UpdateUserResponse{}, err
+	}
+
 	user := mapUserUpdateModelToModel(req.User, req.Id)
+	user.Version = version
 
-	err := s.profileService.UpdateUser(ctx, user)
+	err = s.profileService.UpdateUser(ctx, user)
 	if err != nil {
-		return &profile_management_api.UpdateUserResponse{}, err
+		return &profile_management_api.UpdateUserResponse{}, mapVersionError(err)
 	}
 
 	updatedUser, err := s.profileService.GetUserByID(ctx, req.Id)
 	if err != nil {
 		return &profile_management_api.UpdateUserResponse{}, err
 	}
+	setETag(ctx, updatedUser.Version)
 
 	return &profile_management_api.UpdateUserResponse{
 		User: mapUserModelToProto(updatedUser),
@@ -75,6 +95,76 @@ func (s *ProfileManagementAPI) DeleteUser(ctx context.Context, req *profile_mana
 	return &profile_management_api.DeleteUserResponse{}, nil
 }
 
+func (s *ProfileManagementAPI) RestoreUser(ctx context.Context, req *profile_management_api.RestoreUserRequest) (*profile_management_api.RestoreUserResponse, error) {
+	log.Printf("Received RestoreUser request for ID: %d", req.Id)
+
+	user, err := s.profileService.RestoreUser(ctx, req.Id)
+	if err != nil {
+		return &profile_management_api.RestoreUserResponse{}, err
+	}
+
+	return &profile_management_api.RestoreUserResponse{
+		User: mapUserModelToProto(user),
+	}, nil
+}
+
+func (s *ProfileManagementAPI) ExportUserData(req *profile_management_api.ExportUserDataRequest, stream grpc.ServerStreamingServer[profile_management_api.ExportUserDataChunk]) error {
+	log.Printf("Received ExportUserData request for user_id: %d", req.UserId)
+
+	export, err := s.profileService.ExportUserData(stream.Context(), req.UserId, mapUserDataArchiveFormatToModel(req.Format))
+	if err != nil {
+		return err
+	}
+
+	for offset := 0; offset == 0 || offset < len(export.Data); offset += exportChunkSize {
+		end := min(offset+exportChunkSize, len(export.Data))
+		chunk := &profile_management_api.ExportUserDataChunk{
+			Data: export.Data[offset:end],
+		}
+		if offset == 0 {
+			chunk.Filename = export.Filename
+			chunk.ContentType = export.ContentType
+		}
+
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ProfileManagementAPI) ImportUserData(ctx context.Context, req *profile_management_api.ImportUserDataRequest) (*profile_management_api.ImportUserDataResponse, error) {
+	log.Printf("Received ImportUserData request, archive size: %d", len(req.Archive))
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return &profile_management_api.ImportUserDataResponse{}, err
+	}
+
+	result, err := s.profileService.ImportUserData(ctx, mapUserDataArchiveFormatToModel(req.Format), req.Archive, req.Username, string(hashedPassword))
+	if err != nil {
+		return &profile_management_api.ImportUserDataResponse{}, err
+	}
+
+	return &profile_management_api.ImportUserDataResponse{
+		User:             mapUserModelToProto(result.User),
+		ImportedProducts: int32(result.ImportedProducts),
+		ImportedMeals:    int32(result.ImportedMeals),
+	}, nil
+}
+
+func mapUserDataArchiveFormatToModel(format profile_management_api.UserDataArchiveFormat) models.UserDataArchiveFormat {
+	switch format {
+	case profile_management_api.UserDataArchiveFormat_USER_DATA_ARCHIVE_FORMAT_JSON:
+		return models.UserDataArchiveFormatJSON
+	case profile_management_api.UserDataArchiveFormat_USER_DATA_ARCHIVE_FORMAT_ZIP:
+		return models.UserDataArchiveFormatZIP
+	default:
+		return models.UserDataArchiveFormatUnknown
+	}
+}
+
 func mapUserCreateModelToModel(protoUser *proto_models.UserCreateModel) *models.User {
 	user := &models.User{
 		Username:    protoUser.Username,
@@ -141,6 +231,7 @@ func mapUserModelToProto(user *models.User) *proto_models.UserModel {
 		PasswordHash: user.PasswordHash,
 		Preferences:  user.Preferences,
 		CreatedAt:    user.CreatedAt,
+		Version:      user.Version,
 	}
 
 	if user.Height != nil {
@@ -0,0 +1,89 @@
+package profile_management_api
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/events/change_event_bus"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_management_api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ProfileManagementAPI) WatchUser(req *profile_management_api.WatchUserRequest, stream grpc.ServerStreamingServer[profile_management_api.ChangeEvent]) error {
+	log.Printf("Received WatchUser request for user_id: %d, resume_token: %q", req.UserId, req.ResumeToken)
+
+	events, cancel, err := s.profileService.WatchUser(stream.Context(), req.UserId, req.ResumeToken)
+	switch {
+	case errors.Is(err, change_event_bus.ErrResumeTokenExpired):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, change_event_bus.ErrInvalidResumeToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return err
+	}
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				// Шина отключила подписчика, который не успевал читать события
+				return status.Error(codes.Unavailable, "подписка отстала от ленты изменений, переподключитесь с последним resume_token")
+			}
+			if err := stream.Send(mapChangeEventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func mapChangeEventToProto(event *models.ChangeEvent) *profile_management_api.ChangeEvent {
+	protoEvent := &profile_management_api.ChangeEvent{
+		ResumeToken: event.ResumeToken,
+		Type:        mapChangeEventTypeToProto(event.Type),
+		UserId:      event.UserID,
+		OccurredAt:  event.OccurredAt.Format(time.RFC3339),
+	}
+
+	switch {
+	case event.User != nil:
+		protoEvent.Entity = &profile_management_api.ChangeEvent_User{User: mapUserModelToProto(event.User)}
+	case event.Product != nil:
+		protoEvent.Entity = &profile_management_api.ChangeEvent_Product{Product: mapProductModelToProto(event.Product)}
+	case event.Meal != nil:
+		protoEvent.Entity = &profile_management_api.ChangeEvent_Meal{Meal: mapMealModelToProto(event.Meal)}
+	}
+
+	return protoEvent
+}
+
+func mapChangeEventTypeToProto(eventType models.ChangeEventType) profile_management_api.ChangeEventType {
+	switch eventType {
+	case models.ChangeEventTypeUserCreated:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_USER_CREATED
+	case models.ChangeEventTypeUserDeleted:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_USER_DELETED
+	case models.ChangeEventTypeUserUpdated:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_USER_UPDATED
+	case models.ChangeEventTypeProductCreated:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_PRODUCT_CREATED
+	case models.ChangeEventTypeProductUpdated:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_PRODUCT_UPDATED
+	case models.ChangeEventTypeProductDeleted:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_PRODUCT_DELETED
+	case models.ChangeEventTypeMealCreated:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_MEAL_CREATED
+	case models.ChangeEventTypeMealUpdated:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_MEAL_UPDATED
+	case models.ChangeEventTypeMealDeleted:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_MEAL_DELETED
+	default:
+		return profile_management_api.ChangeEventType_CHANGE_EVENT_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,45 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/cache/lru_cache"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/cache/redis_cache"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/cached_storage"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+const (
+	defaultCacheSize = 10000
+	defaultCacheTTL  = time.Minute
+)
+
+// InitCachedStorage оборачивает хранилище кешем чтения; при выключенном кеше возвращает nil
+func InitCachedStorage(storage *profile_management_storage.ProfileManagementStorage, cfg *config.Config) *cached_storage.CachedStorage {
+	if !cfg.Cache.Enabled {
+		return nil
+	}
+
+	ttl := cfg.Cache.TTL
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+
+	var cache cached_storage.Cache
+	switch cfg.Cache.Backend {
+	case "", "memory":
+		size := cfg.Cache.Size
+		if size <= 0 {
+			size = defaultCacheSize
+		}
+		cache = lru_cache.NewLRUCache(size)
+	case "redis":
+		cache = redis_cache.NewRedisCache(initRedisClient(cfg.Cache.Redis))
+	default:
+		panic(fmt.Sprintf("неизвестное хранилище кеша %q", cfg.Cache.Backend))
+	}
+
+	return cached_storage.NewCachedStorage(storage, cache, ttl)
+}
@@ -0,0 +1,10 @@
+package bootstrap
+
+import (
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/events/change_event_bus"
+)
+
+func InitChangeEventBus(cfg *config.Config) *change_event_bus.ChangeEventBus {
+	return change_event_bus.NewChangeEventBus(cfg.ProfileServiceSettings.ChangeFeedHistorySize, cfg.ProfileServiceSettings.ChangeFeedSubscriberBuffer)
+}
@@ -0,0 +1,48 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/config_reloader"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/rate_limit/rate_limiter"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service"
+	"go.yaml.in/yaml/v4"
+)
+
+// InitLogger выставляет уровень логов из конфига; при перезагрузке конфига уровень меняется так же
+func InitLogger(cfg *config.Config) {
+	slog.SetLogLoggerLevel(cfg.Log.SlogLevel())
+}
+
+// InitConfigReloader перезагружает в profileService и rateLimiter (может быть nil) настройки,
+// которые можно менять без перезапуска
+func InitConfigReloader(path string, profileService *profile_service.ProfileService, rateLimiter *rate_limiter.RateLimiter, cfg *config.Config) *config_reloader.ConfigReloader {
+	return config_reloader.NewConfigReloader(path, cfg, cfg.Server.ConfigWatchInterval, func(cfg *config.Config) {
+		InitLogger(cfg)
+		profileService.UpdateSettings(profileServiceSettings(cfg))
+		if rateLimiter != nil {
+			defaultRule, methods, httpRule := rateLimitRules(cfg)
+			rateLimiter.UpdateRules(defaultRule, methods, httpRule)
+		}
+	})
+}
+
+type effectiveConfig interface {
+	Config() *config.Config
+}
+
+// configHandler отдаёт действующий конфиг в YAML с замазанными паролями
+func configHandler(reloader effectiveConfig) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		data, err := yaml.Marshal(reloader.Config().Redacted())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write(data)
+	}
+}
@@ -0,0 +1,55 @@
+package bootstrap
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+type storageHealthReporter interface {
+	Health() []profile_management_storage.PoolHealth
+}
+
+type poolHealthResponse struct {
+	Shard   int    `json:"shard"`
+	Replica *int   `json:"replica,omitempty"`
+	State   string `json:"state"`
+}
+
+type healthResponse struct {
+	// Status ok - все пулы доступны, degraded - часть пулов исключена из запросов,
+	// unavailable - не отвечает primary хотя бы одного шарда
+	Status string               `json:"status"`
+	Pools  []poolHealthResponse `json:"pools"`
+}
+
+// healthHandler отдаёт состояние автоматов отключения пулов. Код ответа 503, только если недоступен
+// primary шарда: без него не работают записи этого шарда.
+func healthHandler(storage storageHealthReporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response := healthResponse{Status: "ok"}
+		code := http.StatusOK
+		for _, pool := range storage.Health() {
+			item := poolHealthResponse{Shard: pool.Shard, State: pool.State.String()}
+			if pool.Replica >= 0 {
+				item.Replica = &pool.Replica
+			}
+			response.Pools = append(response.Pools, item)
+
+			if pool.State == profile_management_storage.CircuitClosed {
+				continue
+			}
+			if pool.Replica < 0 {
+				response.Status = "unavailable"
+				code = http.StatusServiceUnavailable
+			} else if response.Status == "ok" {
+				response.Status = "degraded"
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,11 @@
+package bootstrap
+
+import (
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/jobs/idempotency_key_cleanup_job"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+func InitIdempotencyKeyCleanupJob(storage *profile_management_storage.ProfileManagementStorage, cfg *config.Config) *idempotency_key_cleanup_job.IdempotencyKeyCleanupJob {
+	return idempotency_key_cleanup_job.NewIdempotencyKeyCleanupJob(storage, cfg.ProfileServiceSettings.IdempotencyKeyTTL, cfg.ProfileServiceSettings.IdempotencyKeyCleanupInterval)
+}
@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/consumer/menu_generation_results_consumer"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	menuGenerationResultMaxAttempts   = 5
+	menuGenerationResultRetryInterval = time.Second
+)
+
+func InitMenuGenerationResultsConsumer(profileService *profile_service.ProfileService, cfg *config.Config) *menu_generation_results_consumer.MenuGenerationResultsConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: []string{fmt.Sprintf("%s:%d", cfg.Kafka.Host, cfg.Kafka.Port)},
+		GroupID: cfg.Kafka.ConsumerGroupID,
+		Topic:   cfg.Kafka.MenuGenerationResultsTopicName,
+	})
+
+	return menu_generation_results_consumer.NewMenuGenerationResultsConsumer(
+		reader,
+		profileService,
+		menuGenerationResultMaxAttempts,
+		menuGenerationResultRetryInterval,
+	)
+}
@@ -2,13 +2,69 @@ package bootstrap
 
 import (
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/dead_letter_producer"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/kafka_retry_writer"
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/menu_generation_producer"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/profile_events_producer"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/schema_registry/file_schema_registry"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
 )
 
-func InitMenuGenerationProducer(cfg *config.Config) *menu_generation_producer.MenuGenerationProducer {
+const (
+	defaultPublishMaxAttempts    = 5
+	defaultPublishInitialBackoff = 200 * time.Millisecond
+	defaultPublishMaxBackoff     = 5 * time.Second
+	defaultPublishQueueSize      = 1000
+)
+
+func InitMenuGenerationProducer(storage *profile_management_storage.ProfileManagementStorage, cfg *config.Config) *menu_generation_producer.MenuGenerationProducer {
 	brokers := []string{fmt.Sprintf("%s:%d", cfg.Kafka.Host, cfg.Kafka.Port)}
-	return menu_generation_producer.NewMenuGenerationProducer(brokers, cfg.Kafka.MenuGenerationTopicName)
+
+	var schemaRegistry menu_generation_producer.SchemaRegistry
+	if cfg.Kafka.Encoding == menu_generation_producer.EncodingProtobuf {
+		schemaRegistry = file_schema_registry.NewFileSchemaRegistry(cfg.Kafka.SchemaRegistryPath)
+	}
+
+	producer, err := menu_generation_producer.NewMenuGenerationProducer(brokers, cfg.Kafka.MenuGenerationTopicName,
+		cfg.Kafka.Encoding, schemaRegistry, publishRetryPolicy(cfg), storage)
+	if err != nil {
+		log.Panicf("ошибка инициализации продюсера генерации меню, %v", err)
+	}
+	return producer
 }
 
+func InitProfileEventsProducer(storage *profile_management_storage.ProfileManagementStorage, cfg *config.Config) *profile_events_producer.ProfileEventsProducer {
+	brokers := []string{fmt.Sprintf("%s:%d", cfg.Kafka.Host, cfg.Kafka.Port)}
+	queueSize := cfg.Kafka.PublishQueueSize
+	if queueSize == 0 {
+		queueSize = defaultPublishQueueSize
+	}
+	return profile_events_producer.NewProfileEventsProducer(brokers, cfg.Kafka.ProfileEventsTopicName, publishRetryPolicy(cfg), storage, queueSize)
+}
+
+func InitDeadLetterProducer(cfg *config.Config) *dead_letter_producer.DeadLetterProducer {
+	brokers := []string{fmt.Sprintf("%s:%d", cfg.Kafka.Host, cfg.Kafka.Port)}
+	return dead_letter_producer.NewDeadLetterProducer(brokers)
+}
+
+func publishRetryPolicy(cfg *config.Config) kafka_retry_writer.RetryPolicy {
+	policy := kafka_retry_writer.RetryPolicy{
+		MaxAttempts:    cfg.Kafka.PublishMaxAttempts,
+		InitialBackoff: cfg.Kafka.PublishInitialBackoff,
+		MaxBackoff:     cfg.Kafka.PublishMaxBackoff,
+	}
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = defaultPublishMaxAttempts
+	}
+	if policy.InitialBackoff == 0 {
+		policy.InitialBackoff = defaultPublishInitialBackoff
+	}
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = defaultPublishMaxBackoff
+	}
+	return policy
+}
@@ -1,19 +1,48 @@
 package bootstrap
 
 import (
-	"fmt"
 	"log"
+	"net"
+	"net/url"
+	"strconv"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/config"
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
 )
 
 func InitPGStorage(cfg *config.Config) *profile_management_storage.ProfileManagementStorage {
-	connectionStrings := make([]string, 0, len(cfg.Database.Shards))
+	connections, bucketCount, breakerSettings := pgStorageSettings(cfg)
+	storage, err := profile_management_storage.NewProfileManagementStorage(connections, bucketCount, cfg.Database.ReadYourWritesWindow, breakerSettings)
+	if err != nil {
+		log.Panicf("ошибка инициализации БД, %v", err)
+		panic(err)
+	}
+	return storage
+}
+
+// ConnectPGStorage подключается к шардам без применения миграций, для административных команд
+func ConnectPGStorage(cfg *config.Config) (*profile_management_storage.ProfileManagementStorage, error) {
+	connections, bucketCount, breakerSettings := pgStorageSettings(cfg)
+	return profile_management_storage.ConnectProfileManagementStorage(connections, bucketCount, cfg.Database.ReadYourWritesWindow, breakerSettings)
+}
+
+func pgStorageSettings(cfg *config.Config) ([]profile_management_storage.ShardConnection, int, profile_management_storage.CircuitBreakerSettings) {
+	connections := make([]profile_management_storage.ShardConnection, 0, len(cfg.Database.Shards))
 	for _, shard := range cfg.Database.Shards {
-		connectionString := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-			shard.Username, shard.Password, shard.Host, shard.Port, shard.DBName, shard.SSLMode)
-		connectionStrings = append(connectionStrings, connectionString)
+		connection := profile_management_storage.ShardConnection{
+			Primary: connectionString(shard, shard.Host, shard.Port),
+			Pool: profile_management_storage.PoolSettings{
+				MaxConns:          shard.MaxConns,
+				MinConns:          shard.MinConns,
+				MaxConnLifetime:   shard.MaxConnLifetime,
+				MaxConnIdleTime:   shard.MaxConnIdleTime,
+				HealthCheckPeriod: shard.HealthCheckPeriod,
+			},
+		}
+		for _, replica := range shard.Replicas {
+			connection.Replicas = append(connection.Replicas, connectionString(shard, replica.Host, replica.Port))
+		}
+		connections = append(connections, connection)
 	}
 
 	bucketCount := cfg.Database.BucketCount
@@ -22,10 +51,38 @@ func InitPGStorage(cfg *config.Config) *profile_management_storage.ProfileManage
 		log.Printf("bucket_count не указан, используем значение по умолчанию: %d", bucketCount)
 	}
 
-	storage, err := profile_management_storage.NewProfileManagementStorage(connectionStrings, bucketCount)
-	if err != nil {
-		log.Panicf("ошибка инициализации БД, %v", err)
-		panic(err)
+	breakerSettings := profile_management_storage.CircuitBreakerSettings{
+		FailureThreshold: cfg.Database.CircuitBreaker.FailureThreshold,
+		OpenTimeout:      cfg.Database.CircuitBreaker.OpenTimeout,
 	}
-	return storage
+
+	return connections, bucketCount, breakerSettings
+}
+
+// connectionString строка подключения к host:port с учётными данными и параметрами шарда.
+// Пользователь, пароль, имя базы и параметры экранируются, поэтому могут содержать любые символы.
+func connectionString(shard config.DatabaseShardConfig, host string, port int) string {
+	query := url.Values{}
+	if shard.SSLMode != "" {
+		query.Set("sslmode", shard.SSLMode)
+	}
+	if shard.SSLRootCert != "" {
+		query.Set("sslrootcert", shard.SSLRootCert)
+	}
+	if shard.ApplicationName != "" {
+		query.Set("application_name", shard.ApplicationName)
+	}
+	if shard.StatementTimeout > 0 {
+		// Неизвестные pgx параметры передаются серверу как параметры сессии, statement_timeout - в миллисекундах
+		query.Set("statement_timeout", strconv.FormatInt(shard.StatementTimeout.Milliseconds(), 10))
+	}
+
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(shard.Username, shard.Password),
+		Host:     net.JoinHostPort(host, strconv.Itoa(port)),
+		Path:     "/" + shard.DBName,
+		RawQuery: query.Encode(),
+	}
+	return dsn.String()
 }
@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gotest.tools/v3/assert"
+)
+
+func TestConnectionStringEscapesCredentials(t *testing.T) {
+	shard := config.DatabaseShardConfig{
+		Username:         "app user",
+		Password:         "p@ss:w/rd?#%&=",
+		DBName:           "profiles db",
+		SSLMode:          "disable",
+		ApplicationName:  "profile service",
+		StatementTimeout: 5 * time.Second,
+	}
+
+	parsed, err := pgconn.ParseConfig(connectionString(shard, "db.internal", 5433))
+	assert.NilError(t, err)
+	assert.Equal(t, parsed.Host, "db.internal")
+	assert.Equal(t, parsed.Port, uint16(5433))
+	assert.Equal(t, parsed.User, "app user")
+	assert.Equal(t, parsed.Password, "p@ss:w/rd?#%&=")
+	assert.Equal(t, parsed.Database, "profiles db")
+	assert.Equal(t, parsed.RuntimeParams["application_name"], "profile service")
+	assert.Equal(t, parsed.RuntimeParams["statement_timeout"], "5000")
+}
+
+func TestConnectionStringSSLRootCert(t *testing.T) {
+	shard := config.DatabaseShardConfig{
+		Username:    "postgres",
+		SSLMode:     "verify-full",
+		SSLRootCert: "/etc/ssl/certs/root ca.pem",
+	}
+
+	dsn, err := url.Parse(connectionString(shard, "::1", 5432))
+	assert.NilError(t, err)
+	assert.Equal(t, dsn.Host, "[::1]:5432")
+	assert.Equal(t, dsn.Query().Get("sslmode"), "verify-full")
+	assert.Equal(t, dsn.Query().Get("sslrootcert"), "/etc/ssl/certs/root ca.pem")
+	assert.Equal(t, dsn.Query().Has("statement_timeout"), false)
+}
@@ -0,0 +1,12 @@
+package bootstrap
+
+import (
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/api/profile_admin_api"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/cached_storage"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+func InitProfileAdminAPI(profileService *profile_service.ProfileService, storage *profile_management_storage.ProfileManagementStorage, cachedStorage *cached_storage.CachedStorage) *profile_admin_api.ProfileAdminAPI {
+	return profile_admin_api.NewProfileAdminAPI(profileService, storage, cachedStorage)
+}
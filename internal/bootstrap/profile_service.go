@@ -4,18 +4,45 @@ import (
 	"context"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/events/change_event_bus"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/dead_letter_producer"
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/menu_generation_producer"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/profile_events_producer"
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/cached_storage"
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
 )
 
-func InitProfileService(storage *profile_management_storage.ProfileManagementStorage, producer *menu_generation_producer.MenuGenerationProducer, cfg *config.Config) *profile_service.ProfileService {
+// InitProfileService читает через cachedStorage, если кеш включён; журнал аудита пишется напрямую в storage
+func InitProfileService(storage *profile_management_storage.ProfileManagementStorage, cachedStorage *cached_storage.CachedStorage, producer *menu_generation_producer.MenuGenerationProducer, changeEventBus *change_event_bus.ChangeEventBus, profileEventsProducer *profile_events_producer.ProfileEventsProducer, deadLetterProducer *dead_letter_producer.DeadLetterProducer, cfg *config.Config) *profile_service.ProfileService {
+	var serviceStorage profile_service.ProfileStorage = storage
+	if cachedStorage != nil {
+		serviceStorage = cachedStorage
+	}
+
 	return profile_service.NewProfileService(
 		context.Background(),
-		storage,
-		producer,
-		cfg.ProfileServiceSettings.MinUsernameLen,
-		cfg.ProfileServiceSettings.MaxUsernameLen,
-		cfg.ProfileServiceSettings.MinPasswordLen,
+		profile_service.Dependencies{
+			Storage:                serviceStorage,
+			MenuGenerationProducer: producer,
+			ChangeEventBus:         changeEventBus,
+			ProfileEventsProducer:  profileEventsProducer,
+			DeadLetterReplayer:     deadLetterProducer,
+			AuditLog:               storage,
+		},
+		profile_service.Options{
+			Settings:                     profileServiceSettings(cfg),
+			UserDeletionGracePeriod:      cfg.ProfileServiceSettings.UserDeletionGracePeriod,
+			MenuGenerationDebounceWindow: cfg.ProfileServiceSettings.MenuGenerationDebounceWindow,
+			IdempotencyKeyTTL:            cfg.ProfileServiceSettings.IdempotencyKeyTTL,
+		},
 	)
 }
+
+func profileServiceSettings(cfg *config.Config) profile_service.Settings {
+	return profile_service.Settings{
+		MinUsernameLen: cfg.ProfileServiceSettings.MinUsernameLen,
+		MaxUsernameLen: cfg.ProfileServiceSettings.MaxUsernameLen,
+		MinPasswordLen: cfg.ProfileServiceSettings.MinPasswordLen,
+	}
+}
@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"fmt"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/rate_limit/memory_store"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/rate_limit/rate_limiter"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/rate_limit/redis_store"
+)
+
+// InitRateLimiter возвращает nil, если ограничение запросов выключено
+func InitRateLimiter(cfg *config.Config) *rate_limiter.RateLimiter {
+	if !cfg.RateLimit.Enabled {
+		return nil
+	}
+
+	var store rate_limiter.Store
+	switch cfg.RateLimit.Backend {
+	case "", "memory":
+		store = memory_store.NewMemoryStore()
+	case "redis":
+		store = redis_store.NewRedisStore(initRedisClient(cfg.RateLimit.Redis))
+	default:
+		panic(fmt.Sprintf("неизвестное хранилище rate limit %q", cfg.RateLimit.Backend))
+	}
+
+	defaultRule, methods, httpRule := rateLimitRules(cfg)
+	return rate_limiter.NewRateLimiter(store, defaultRule, methods, httpRule)
+}
+
+// rateLimitRules правила по умолчанию, по методам и для gateway
+func rateLimitRules(cfg *config.Config) (rate_limiter.Rule, map[string]rate_limiter.Rule, rate_limiter.Rule) {
+	methods := make(map[string]rate_limiter.Rule, len(cfg.RateLimit.Methods))
+	for method, rule := range cfg.RateLimit.Methods {
+		methods[method] = rateLimitRule(rule)
+	}
+
+	return rateLimitRule(cfg.RateLimit.Default), methods, rateLimitRule(cfg.RateLimit.HTTP)
+}
+
+func rateLimitRule(rule config.RateLimitRule) rate_limiter.Rule {
+	return rate_limiter.Rule{
+		PerIP:   rate_limiter.Limit{Rate: rule.PerIP.Rate, Burst: rule.PerIP.Burst},
+		PerUser: rate_limiter.Limit{Rate: rule.PerUser.Rate, Burst: rule.PerUser.Burst},
+	}
+}
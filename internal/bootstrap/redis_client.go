@@ -0,0 +1,10 @@
+package bootstrap
+
+import (
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/redis_client"
+)
+
+func initRedisClient(cfg config.RedisConfig) *redis_client.Client {
+	return redis_client.NewClient(cfg.Address, cfg.Password, cfg.DB)
+}
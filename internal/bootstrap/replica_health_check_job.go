@@ -0,0 +1,20 @@
+package bootstrap
+
+import (
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/jobs/replica_health_check_job"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+const defaultReplicaHealthCheckInterval = 10 * time.Second
+
+func InitReplicaHealthCheckJob(storage *profile_management_storage.ProfileManagementStorage, cfg *config.Config) *replica_health_check_job.ReplicaHealthCheckJob {
+	interval := cfg.Database.ReplicaHealthCheckInterval
+	if interval <= 0 {
+		interval = defaultReplicaHealthCheckInterval
+	}
+
+	return replica_health_check_job.NewReplicaHealthCheckJob(storage, interval)
+}
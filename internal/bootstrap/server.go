@@ -2,15 +2,20 @@ package bootstrap
 
 import (
 	"context"
+	"expvar"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	admin "github.com/Android12349/food_recomendation/profile_managment_service/internal/api/profile_admin_api"
 	server "github.com/Android12349/food_recomendation/profile_managment_service/internal/api/profile_management_api"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_admin_api"
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_management_api"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/rate_limit/rate_limiter"
 	"github.com/go-chi/chi/v5"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -18,33 +23,50 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func AppRun(api server.ProfileManagementAPI, cfg *config.Config) {
+// AppRun запускает gRPC-сервер и gateway; rateLimiter может быть nil, если ограничение запросов выключено.
+// adminApi доступен только по gRPC с токеном admin.token. storage отдаёт состояние шардов для /health,
+// reloader - действующий конфиг для /debug/config. Маршруты /debug/* закрыты тем же токеном admin.token.
+func AppRun(api server.ProfileManagementAPI, adminApi *admin.ProfileAdminAPI, rateLimiter *rate_limiter.RateLimiter, storage storageHealthReporter, reloader effectiveConfig, cfg *config.Config) {
+	adminAuth := admin.NewAdminAuth(cfg.Admin.Token)
 	go func() {
-		if err := runGRPCServer(api, cfg); err != nil {
+		if err := runGRPCServer(api, adminApi, adminAuth, rateLimiter, cfg); err != nil {
 			panic(fmt.Errorf("failed to run gRPC server: %v", err))
 		}
 	}()
 
-	if err := runGatewayServer(cfg); err != nil {
+	if err := runGatewayServer(adminAuth, rateLimiter, storage, reloader, cfg); err != nil {
 		panic(fmt.Errorf("failed to run gateway server: %v", err))
 	}
 }
 
-func runGRPCServer(api server.ProfileManagementAPI, cfg *config.Config) error {
+func runGRPCServer(api server.ProfileManagementAPI, adminApi *admin.ProfileAdminAPI, adminAuth *admin.AdminAuth, rateLimiter *rate_limiter.RateLimiter, cfg *config.Config) error {
 	grpcAddr := fmt.Sprintf(":%d", cfg.Server.GRPCPort)
 	lis, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
 		return err
 	}
 
-	s := grpc.NewServer()
+	// Токен администратора проверяется до остальных перехватчиков и задаёт подтверждённого автора, который
+	// x-actor не заменяет. Ограничение по автору запроса стоит после перехватчика, который кладёт автора в контекст.
+	unaryInterceptors := []grpc.UnaryServerInterceptor{adminAuth.UnaryServerInterceptor, server.UnaryShardErrorInterceptor, server.UnaryRequestMetadataInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{adminAuth.StreamServerInterceptor, server.StreamShardErrorInterceptor, server.StreamRequestMetadataInterceptor}
+	if rateLimiter != nil {
+		unaryInterceptors = append(unaryInterceptors, rateLimiter.UnaryServerInterceptor)
+		streamInterceptors = append(streamInterceptors, rateLimiter.StreamServerInterceptor)
+	}
+
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
 	profile_management_api.RegisterProfileManagementServiceServer(s, &api)
+	profile_admin_api.RegisterProfileAdminServiceServer(s, adminApi)
 
 	slog.Info("gRPC-server server listening on " + grpcAddr)
 	return s.Serve(lis)
 }
 
-func runGatewayServer(cfg *config.Config) error {
+func runGatewayServer(adminAuth *admin.AdminAuth, rateLimiter *rate_limiter.RateLimiter, storage storageHealthReporter, reloader effectiveConfig, cfg *config.Config) error {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -55,6 +77,9 @@ func runGatewayServer(cfg *config.Config) error {
 	}
 
 	r := chi.NewRouter()
+	if rateLimiter != nil {
+		r.Use(rateLimiter.HTTPMiddleware)
+	}
 	r.Get("/swagger.json", func(w http.ResponseWriter, r *http.Request) {
 		http.ServeFile(w, r, swaggerPath)
 	})
@@ -63,7 +88,21 @@ func runGatewayServer(cfg *config.Config) error {
 		httpSwagger.URL("/swagger.json"),
 	))
 
-	mux := runtime.NewServeMux()
+	// Служебные маршруты раскрывают устройство сервиса, поэтому требуют токен администратора
+	r.Group(func(r chi.Router) {
+		r.Use(adminAuth.HTTPMiddleware)
+		// Счётчики сервиса, в том числе неудачных публикаций в Kafka
+		r.Get("/debug/vars", expvar.Handler().ServeHTTP)
+		// Действующий конфиг с учётом перезагрузок, без паролей
+		r.Get("/debug/config", configHandler(reloader))
+	})
+	// Состояние шардов и их реплик
+	r.Get("/health", healthHandler(storage))
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(gatewayOutgoingHeaderMatcher),
+	)
 	grpcAddr := fmt.Sprintf(":%d", cfg.Server.GRPCPort)
 	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
 
@@ -72,9 +111,48 @@ func runGatewayServer(cfg *config.Config) error {
 		panic(err)
 	}
 
+	conn, err := grpc.NewClient(grpcAddr, opts...)
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+	client := profile_management_api.NewProfileManagementServiceClient(conn)
+
+	r.Post("/users/{id}/products:import", server.NewImportProductsHTTPHandler(client, mux))
 	r.Mount("/", mux)
 
 	httpAddr := fmt.Sprintf(":%d", cfg.Server.HTTPPort)
 	slog.Info("gRPC-Gateway server listening on " + httpAddr)
 	return http.ListenAndServe(httpAddr, r)
 }
+
+// gatewayHeaderMatchedKeys заголовки HTTP, которые передаются в gRPC без префикса grpcgateway-
+var gatewayHeaderMatchedKeys = []string{
+	server.IdempotencyKeyMetadata,
+	server.IfMatchMetadata,
+	server.ActorMetadata,
+	server.RequestIDMetadata,
+}
+
+func gatewayHeaderMatcher(key string) (string, bool) {
+	for _, matched := range gatewayHeaderMatchedKeys {
+		if strings.EqualFold(key, matched) {
+			return matched, true
+		}
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// gatewayOutgoingHeaderMatcher отдаёт версию записи заголовком ETag, идентификатор запроса заголовком X-Request-Id
+// и время до повтора при превышении лимита заголовком Retry-After, остальные заголовки gRPC с префиксом Grpc-Metadata-
+func gatewayOutgoingHeaderMatcher(key string) (string, bool) {
+	switch key {
+	case server.ETagMetadata:
+		return "ETag", true
+	case server.RequestIDMetadata:
+		return "X-Request-Id", true
+	case rate_limiter.RetryAfterMetadata:
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,12 @@
+package bootstrap
+
+import (
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/jobs/user_purge_job"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/cached_storage"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/storage/profile_management_storage"
+)
+
+func InitUserPurgeJob(storage *profile_management_storage.ProfileManagementStorage, cachedStorage *cached_storage.CachedStorage, cfg *config.Config) *user_purge_job.UserPurgeJob {
+	return user_purge_job.NewUserPurgeJob(storage, cachedStorage, cfg.ProfileServiceSettings.UserDeletionGracePeriod, cfg.ProfileServiceSettings.UserPurgeInterval)
+}
@@ -0,0 +1,43 @@
+package memory_broker
+
+import (
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// MemoryBroker заменяет Kafka в тестах и локальном запуске: хранит сообщения топиков в памяти
+// и закоммиченные смещения consumer group. Reader и Writer повторяют методы kafka.Reader и kafka.Writer,
+// которыми пользуются продюсеры и консьюмеры сервиса.
+type MemoryBroker struct {
+	mu      sync.Mutex
+	changed *sync.Cond
+	topics  map[string][]kafka.Message
+	// offsets закоммиченные смещения по ключу группа/топик
+	offsets map[string]int
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	broker := &MemoryBroker{
+		topics:  make(map[string][]kafka.Message),
+		offsets: make(map[string]int),
+	}
+	broker.changed = sync.NewCond(&broker.mu)
+	return broker
+}
+
+// Messages возвращает копию сообщений топика
+func (b *MemoryBroker) Messages(topic string) []kafka.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]kafka.Message(nil), b.topics[topic]...)
+}
+
+// CommittedOffset возвращает смещение, с которого группа продолжит чтение топика
+func (b *MemoryBroker) CommittedOffset(groupID, topic string) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.offsets[groupID+"/"+topic]
+}
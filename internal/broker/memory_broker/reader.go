@@ -0,0 +1,83 @@
+package memory_broker
+
+import (
+	"context"
+	"io"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Reader читает топик брокера в памяти от имени consumer group, начиная с закоммиченного смещения
+type Reader struct {
+	broker  *MemoryBroker
+	topic   string
+	groupID string
+	next    int
+	closed  bool
+}
+
+func (b *MemoryBroker) Reader(topic, groupID string) *Reader {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return &Reader{
+		broker:  b,
+		topic:   topic,
+		groupID: groupID,
+		next:    b.offsets[groupID+"/"+topic],
+	}
+}
+
+// FetchMessage блокируется до появления нового сообщения, отмены ctx или закрытия читателя
+func (r *Reader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	stop := context.AfterFunc(ctx, func() {
+		r.broker.mu.Lock()
+		defer r.broker.mu.Unlock()
+		r.broker.changed.Broadcast()
+	})
+	defer stop()
+
+	r.broker.mu.Lock()
+	defer r.broker.mu.Unlock()
+
+	for {
+		if r.closed {
+			return kafka.Message{}, io.EOF
+		}
+		if err := ctx.Err(); err != nil {
+			return kafka.Message{}, err
+		}
+
+		messages := r.broker.topics[r.topic]
+		if r.next < len(messages) {
+			msg := messages[r.next]
+			r.next++
+			return msg, nil
+		}
+
+		r.broker.changed.Wait()
+	}
+}
+
+func (r *Reader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.broker.mu.Lock()
+	defer r.broker.mu.Unlock()
+
+	key := r.groupID + "/" + r.topic
+	for _, msg := range msgs {
+		if int(msg.Offset)+1 > r.broker.offsets[key] {
+			r.broker.offsets[key] = int(msg.Offset) + 1
+		}
+	}
+
+	return nil
+}
+
+func (r *Reader) Close() error {
+	r.broker.mu.Lock()
+	defer r.broker.mu.Unlock()
+
+	r.closed = true
+	r.broker.changed.Broadcast()
+	return nil
+}
@@ -0,0 +1,43 @@
+package memory_broker
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Writer пишет сообщения в топик брокера в памяти
+type Writer struct {
+	broker *MemoryBroker
+	topic  string
+}
+
+func (b *MemoryBroker) Writer(topic string) *Writer {
+	return &Writer{broker: b, topic: topic}
+}
+
+func (w *Writer) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	w.broker.mu.Lock()
+	defer w.broker.mu.Unlock()
+
+	for _, msg := range msgs {
+		msg.Topic = w.topic
+		msg.Offset = int64(len(w.broker.topics[w.topic]))
+		if msg.Time.IsZero() {
+			msg.Time = time.Now()
+		}
+		w.broker.topics[w.topic] = append(w.broker.topics[w.topic], msg)
+	}
+	w.broker.changed.Broadcast()
+
+	return nil
+}
+
+func (w *Writer) Close() error {
+	return nil
+}
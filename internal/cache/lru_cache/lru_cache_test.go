@@ -0,0 +1,58 @@
+package lru_cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestGetExpiredEntry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := NewLRUCache(10)
+	cache.now = func() time.Time { return now }
+
+	assert.NilError(t, cache.Set(context.Background(), "user:1", []byte(`{"id":1}`), time.Minute))
+	value, ok, err := cache.Get(context.Background(), "user:1")
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, string(value), `{"id":1}`)
+
+	now = now.Add(time.Minute)
+	_, ok, _ = cache.Get(context.Background(), "user:1")
+	assert.Assert(t, !ok)
+	assert.Equal(t, len(cache.items), 0)
+}
+
+func TestSetEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewLRUCache(2)
+	ctx := context.Background()
+
+	_ = cache.Set(ctx, "user:1", []byte("1"), time.Minute)
+	_ = cache.Set(ctx, "user:2", []byte("2"), time.Minute)
+	// Чтение делает user:1 недавно использованным, вытесняется user:2
+	_, _, _ = cache.Get(ctx, "user:1")
+	_ = cache.Set(ctx, "user:3", []byte("3"), time.Minute)
+
+	_, ok, _ := cache.Get(ctx, "user:2")
+	assert.Assert(t, !ok)
+	_, ok, _ = cache.Get(ctx, "user:1")
+	assert.Assert(t, ok)
+	_, ok, _ = cache.Get(ctx, "user:3")
+	assert.Assert(t, ok)
+}
+
+func TestDelete(t *testing.T) {
+	cache := NewLRUCache(10)
+	ctx := context.Background()
+
+	_ = cache.Set(ctx, "user:1", []byte("1"), time.Minute)
+	_ = cache.Set(ctx, "user:2", []byte("2"), time.Minute)
+	assert.NilError(t, cache.Delete(ctx, "user:1", "user:3"))
+
+	_, ok, _ := cache.Get(ctx, "user:1")
+	assert.Assert(t, !ok)
+	_, ok, _ = cache.Get(ctx, "user:2")
+	assert.Assert(t, ok)
+}
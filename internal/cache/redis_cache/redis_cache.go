@@ -0,0 +1,56 @@
+package redis_cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/redis_client"
+	"github.com/pkg/errors"
+)
+
+// RedisCache кеш в Redis или совместимом сервере, общий для всех экземпляров сервиса
+type RedisCache struct {
+	client *redis_client.Client
+}
+
+func NewRedisCache(client *redis_client.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.client.Do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "redis get error")
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+
+	value, ok := reply.(string)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected redis get reply %v", reply)
+	}
+	return []byte(value), true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := c.client.Do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(max(1, ttl.Milliseconds()), 10))
+	if err != nil {
+		return errors.Wrap(err, "redis set error")
+	}
+	return nil
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := c.client.Do(ctx, append([]string{"DEL"}, keys...)...)
+	if err != nil {
+		return errors.Wrap(err, "redis del error")
+	}
+	return nil
+}
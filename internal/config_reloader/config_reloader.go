@@ -0,0 +1,92 @@
+package config_reloader
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+)
+
+// ConfigReloader перечитывает файл конфига при его изменении или по SIGHUP и передаёт слушателям настройки,
+// которые можно менять без перезапуска. Изменения остальных полей не применяются: о них пишется предупреждение.
+type ConfigReloader struct {
+	path      string
+	interval  time.Duration
+	load      func(path string) (*config.Config, error)
+	listeners []func(cfg *config.Config)
+
+	current atomic.Pointer[config.Config]
+
+	// mu не даёт двум перезагрузкам (по таймеру и по сигналу) идти одновременно
+	mu      sync.Mutex
+	modTime time.Time
+}
+
+// NewConfigReloader cfg - конфиг, с которым запущен сервис; listeners вызываются после каждой
+// успешной перезагрузки с действующим конфигом
+func NewConfigReloader(path string, cfg *config.Config, interval time.Duration, listeners ...func(cfg *config.Config)) *ConfigReloader {
+	r := &ConfigReloader{
+		path:      path,
+		interval:  interval,
+		load:      config.LoadConfig,
+		listeners: listeners,
+	}
+	r.current.Store(cfg)
+	if info, err := os.Stat(path); err == nil {
+		r.modTime = info.ModTime()
+	}
+	return r
+}
+
+// Config действующий конфиг: исходный с применёнными перезагрузками
+func (r *ConfigReloader) Config() *config.Config {
+	return r.current.Load()
+}
+
+// Reload перечитывает файл. Неверный конфиг не применяется целиком, действующий остаётся прежним.
+func (r *ConfigReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reload()
+}
+
+// reloadIfModified перечитывает файл, если с прошлой проверки изменилось время его изменения
+func (r *ConfigReloader) reloadIfModified() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat config file: %w", err)
+	}
+	if info.ModTime().Equal(r.modTime) {
+		return nil
+	}
+	r.modTime = info.ModTime()
+
+	return r.reload()
+}
+
+func (r *ConfigReloader) reload() error {
+	next, err := r.load(r.path)
+	if err != nil {
+		return err
+	}
+
+	effective, rejected := r.current.Load().Reload(next)
+	if len(rejected) > 0 {
+		slog.Warn("config changes require restart and were not applied", "fields", rejected)
+	}
+
+	r.current.Store(effective)
+	for _, listener := range r.listeners {
+		listener(effective)
+	}
+	slog.Info("config reloaded", "path", r.path)
+	return nil
+}
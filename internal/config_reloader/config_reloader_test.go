@@ -0,0 +1,64 @@
+package config_reloader
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/config"
+	"gotest.tools/v3/assert"
+)
+
+func testConfig(minUsernameLen, grpcPort int) *config.Config {
+	return &config.Config{
+		Server:                 config.ServerConfig{GRPCPort: grpcPort, HTTPPort: 8080},
+		ProfileServiceSettings: config.ProfileServiceSettings{MinUsernameLen: minUsernameLen, MaxUsernameLen: 50},
+	}
+}
+
+// testReloader перезагрузчик, который вместо чтения файла отдаёт next
+func testReloader(t *testing.T, next *config.Config, err error) (*ConfigReloader, *[]*config.Config) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NilError(t, os.WriteFile(path, []byte("{}"), 0o600))
+
+	var applied []*config.Config
+	r := NewConfigReloader(path, testConfig(3, 50051), time.Second, func(cfg *config.Config) {
+		applied = append(applied, cfg)
+	})
+	r.load = func(string) (*config.Config, error) {
+		return next, err
+	}
+	return r, &applied
+}
+
+func TestReloadAppliesSafeChangesAndKeepsUnsafe(t *testing.T) {
+	r, applied := testReloader(t, testConfig(5, 6000), nil)
+
+	assert.NilError(t, r.Reload())
+	assert.Equal(t, r.Config().ProfileServiceSettings.MinUsernameLen, 5)
+	assert.Equal(t, r.Config().Server.GRPCPort, 50051)
+	assert.Equal(t, len(*applied), 1)
+	assert.Equal(t, (*applied)[0], r.Config())
+}
+
+func TestReloadKeepsConfigWhenInvalid(t *testing.T) {
+	r, applied := testReloader(t, nil, errors.New("invalid config"))
+
+	assert.ErrorContains(t, r.Reload(), "invalid config")
+	assert.Equal(t, r.Config().ProfileServiceSettings.MinUsernameLen, 3)
+	assert.Equal(t, len(*applied), 0)
+}
+
+func TestReloadIfModifiedSkipsUnchangedFile(t *testing.T) {
+	r, applied := testReloader(t, testConfig(5, 50051), nil)
+
+	assert.NilError(t, r.reloadIfModified())
+	assert.Equal(t, len(*applied), 0)
+
+	future := time.Now().Add(time.Minute)
+	assert.NilError(t, os.Chtimes(r.path, future, future))
+	assert.NilError(t, r.reloadIfModified())
+	assert.Equal(t, len(*applied), 1)
+}
@@ -0,0 +1,36 @@
+package config_reloader
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// Run перечитывает конфиг по SIGHUP и при изменении файла, который проверяется раз в interval,
+// до отмены контекста
+func (r *ConfigReloader) Run(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			if err := r.Reload(); err != nil {
+				slog.Error("failed to reload config", "error", err)
+			}
+		case <-ticker.C:
+			if err := r.reloadIfModified(); err != nil {
+				slog.Error("failed to reload config", "error", err)
+			}
+		}
+	}
+}
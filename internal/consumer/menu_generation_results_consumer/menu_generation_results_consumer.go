@@ -0,0 +1,38 @@
+package menu_generation_results_consumer
+
+import (
+	"context"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// messageReader часть kafka.Reader, нужная консьюмеру; в тестах подменяется memory_broker.Reader
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+type menuGenerationResultHandler interface {
+	HandleMenuGenerationResult(ctx context.Context, result *models.MenuGenerationResultEvent) error
+}
+
+// MenuGenerationResultsConsumer читает топик menu-generation-results в составе consumer group
+// и сохраняет результаты генерации меню. Смещение коммитится после обработки сообщения.
+type MenuGenerationResultsConsumer struct {
+	reader        messageReader
+	handler       menuGenerationResultHandler
+	maxAttempts   int
+	retryInterval time.Duration
+}
+
+func NewMenuGenerationResultsConsumer(reader messageReader, handler menuGenerationResultHandler, maxAttempts int, retryInterval time.Duration) *MenuGenerationResultsConsumer {
+	return &MenuGenerationResultsConsumer{
+		reader:        reader,
+		handler:       handler,
+		maxAttempts:   maxAttempts,
+		retryInterval: retryInterval,
+	}
+}
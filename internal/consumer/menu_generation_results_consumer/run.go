@@ -0,0 +1,78 @@
+package menu_generation_results_consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// Run читает сообщения до отмены контекста или закрытия читателя
+func (c *MenuGenerationResultsConsumer) Run(ctx context.Context) {
+	defer c.reader.Close()
+
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
+				return
+			}
+			slog.Error("failed to fetch menu generation result", "error", err)
+			if !sleep(ctx, c.retryInterval) {
+				return
+			}
+			continue
+		}
+
+		if !c.handle(ctx, msg) {
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			slog.Error("failed to commit menu generation result", "offset", msg.Offset, "error", err)
+		}
+	}
+}
+
+// handle обрабатывает сообщение с повторами. Некорректные сообщения и исчерпавшие попытки
+// пропускаются, чтобы не блокировать партицию. Возвращает false, если контекст отменён.
+func (c *MenuGenerationResultsConsumer) handle(ctx context.Context, msg kafka.Message) bool {
+	var result models.MenuGenerationResultEvent
+	if err := json.Unmarshal(msg.Value, &result); err != nil {
+		slog.Error("skipping malformed menu generation result", "offset", msg.Offset, "error", err)
+		return true
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.handler.HandleMenuGenerationResult(ctx, &result)
+		if err == nil {
+			return true
+		}
+
+		slog.Error("failed to handle menu generation result",
+			"request_id", result.RequestID, "attempt", attempt, "error", err)
+		if attempt >= c.maxAttempts {
+			return true
+		}
+		if !sleep(ctx, c.retryInterval) {
+			return false
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
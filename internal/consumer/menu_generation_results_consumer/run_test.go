@@ -0,0 +1,118 @@
+package menu_generation_results_consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/broker/memory_broker"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+const (
+	testTopic   = "menu-generation-results"
+	testGroupID = "profile-service"
+)
+
+type recordingHandler struct {
+	mu       sync.Mutex
+	results  []*models.MenuGenerationResultEvent
+	failures int
+}
+
+func (h *recordingHandler) HandleMenuGenerationResult(ctx context.Context, result *models.MenuGenerationResultEvent) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.failures > 0 {
+		h.failures--
+		return errors.New("storage error")
+	}
+	h.results = append(h.results, result)
+	return nil
+}
+
+func (h *recordingHandler) handled() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.results)
+}
+
+type MenuGenerationResultsConsumerSuite struct {
+	suite.Suite
+	broker  *memory_broker.MemoryBroker
+	handler *recordingHandler
+}
+
+func (s *MenuGenerationResultsConsumerSuite) SetupTest() {
+	s.broker = memory_broker.NewMemoryBroker()
+	s.handler = &recordingHandler{}
+}
+
+func (s *MenuGenerationResultsConsumerSuite) run(messages ...string) {
+	writer := s.broker.Writer(testTopic)
+	for _, message := range messages {
+		err := writer.WriteMessages(context.Background(), kafka.Message{Value: []byte(message)})
+		assert.NilError(s.T(), err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumer := NewMenuGenerationResultsConsumer(s.broker.Reader(testTopic, testGroupID), s.handler, 3, time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		consumer.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	total := len(s.broker.Messages(testTopic))
+	for s.broker.CommittedOffset(testGroupID, testTopic) < total && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func (s *MenuGenerationResultsConsumerSuite) TestHandlesAndCommitsResults() {
+	s.run(
+		`{"request_id": "a", "user_id": 1, "status": "completed", "menu": {"days": []}}`,
+		`{"request_id": "b", "user_id": 1, "status": "failed", "error": "нет продуктов"}`,
+	)
+
+	assert.Equal(s.T(), s.handler.handled(), 2)
+	assert.Equal(s.T(), s.handler.results[0].RequestID, "a")
+	assert.Equal(s.T(), string(s.handler.results[0].Menu), `{"days": []}`)
+	assert.Equal(s.T(), s.handler.results[1].Status, models.MenuGenerationStatusFailed)
+	assert.Equal(s.T(), s.broker.CommittedOffset(testGroupID, testTopic), 2)
+}
+
+func (s *MenuGenerationResultsConsumerSuite) TestSkipsMalformedMessage() {
+	s.run(`not json`, `{"request_id": "a", "status": "completed", "menu": {}}`)
+
+	assert.Equal(s.T(), s.handler.handled(), 1)
+	assert.Equal(s.T(), s.broker.CommittedOffset(testGroupID, testTopic), 2)
+}
+
+func (s *MenuGenerationResultsConsumerSuite) TestRetriesHandlerErrors() {
+	s.handler.failures = 2
+	s.run(`{"request_id": "a", "status": "completed", "menu": {}}`)
+
+	assert.Equal(s.T(), s.handler.handled(), 1)
+}
+
+func (s *MenuGenerationResultsConsumerSuite) TestResumesFromCommittedOffset() {
+	s.run(`{"request_id": "a", "status": "completed", "menu": {}}`)
+	s.run(`{"request_id": "b", "status": "completed", "menu": {}}`)
+
+	assert.Equal(s.T(), s.handler.handled(), 2)
+	assert.Equal(s.T(), s.handler.results[1].RequestID, "b")
+}
+
+func TestMenuGenerationResultsConsumerSuite(t *testing.T) {
+	suite.Run(t, new(MenuGenerationResultsConsumerSuite))
+}
@@ -0,0 +1,52 @@
+package change_event_bus
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+)
+
+var (
+	// ErrInvalidResumeToken токен не выдан этой шиной
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+	// ErrResumeTokenExpired события после токена уже вытеснены из истории или выданы до перезапуска
+	ErrResumeTokenExpired = errors.New("resume token expired")
+)
+
+// ChangeEventBus шина изменений внутри процесса. Хранит последние historySize событий,
+// чтобы переподключившийся клиент получил пропущенное по resume token.
+type ChangeEventBus struct {
+	mu sync.Mutex
+	// epoch отличает токены текущего процесса от выданных до перезапуска
+	epoch            string
+	seq              uint64
+	history          []historyEntry
+	historyStart     int
+	subscriberBuffer int
+	subscribers      map[*subscriber]struct{}
+}
+
+type historyEntry struct {
+	seq   uint64
+	event *models.ChangeEvent
+}
+
+type subscriber struct {
+	userID int32
+	events chan *models.ChangeEvent
+}
+
+func NewChangeEventBus(historySize, subscriberBuffer int) *ChangeEventBus {
+	epoch := make([]byte, 6)
+	_, _ = rand.Read(epoch)
+
+	return &ChangeEventBus{
+		epoch:            hex.EncodeToString(epoch),
+		history:          make([]historyEntry, 0, historySize),
+		subscriberBuffer: subscriberBuffer,
+		subscribers:      make(map[*subscriber]struct{}),
+	}
+}
@@ -0,0 +1,39 @@
+package change_event_bus
+
+import (
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+)
+
+// Publish присваивает событию resume token, сохраняет его в истории и рассылает подписчикам пользователя.
+// Подписчик, не успевающий читать, отключается: закрытый канал означает переподключение с последним токеном.
+func (b *ChangeEventBus) Publish(event *models.ChangeEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	event.ResumeToken = b.token(b.seq)
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	entry := historyEntry{seq: b.seq, event: event}
+	if len(b.history) < cap(b.history) {
+		b.history = append(b.history, entry)
+	} else if cap(b.history) > 0 {
+		b.history[b.historyStart] = entry
+		b.historyStart = (b.historyStart + 1) % len(b.history)
+	}
+
+	for sub := range b.subscribers {
+		if sub.userID != event.UserID {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			b.unsubscribe(sub)
+		}
+	}
+}
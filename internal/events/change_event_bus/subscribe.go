@@ -0,0 +1,100 @@
+package change_event_bus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+)
+
+// Subscribe подписывает на изменения пользователя. С непустым resumeToken сначала отдаются
+// события из истории, опубликованные после него. cancel отписывает и закрывает канал.
+func (b *ChangeEventBus) Subscribe(userID int32, resumeToken string) (<-chan *models.ChangeEvent, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var backlog []*models.ChangeEvent
+	if resumeToken != "" {
+		after, err := b.parseToken(resumeToken)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		backlog, err = b.eventsAfter(userID, after)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sub := &subscriber{
+		userID: userID,
+		events: make(chan *models.ChangeEvent, len(backlog)+b.subscriberBuffer),
+	}
+	for _, event := range backlog {
+		sub.events <- event
+	}
+	b.subscribers[sub] = struct{}{}
+
+	cancel := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.unsubscribe(sub)
+	}
+
+	return sub.events, cancel, nil
+}
+
+// eventsAfter возвращает события пользователя с номером больше after
+func (b *ChangeEventBus) eventsAfter(userID int32, after uint64) ([]*models.ChangeEvent, error) {
+	if after > b.seq {
+		return nil, ErrInvalidResumeToken
+	}
+	if after == b.seq {
+		return nil, nil
+	}
+
+	// Событие after+1 должно ещё быть в истории, иначе часть изменений потеряна
+	oldest := b.seq - uint64(len(b.history)) + 1
+	if len(b.history) == 0 || after+1 < oldest {
+		return nil, ErrResumeTokenExpired
+	}
+
+	var events []*models.ChangeEvent
+	for i := range b.history {
+		entry := b.history[(b.historyStart+i)%len(b.history)]
+		if entry.seq > after && entry.event.UserID == userID {
+			events = append(events, entry.event)
+		}
+	}
+	return events, nil
+}
+
+func (b *ChangeEventBus) unsubscribe(sub *subscriber) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	close(sub.events)
+}
+
+func (b *ChangeEventBus) token(seq uint64) string {
+	return fmt.Sprintf("%s.%d", b.epoch, seq)
+}
+
+func (b *ChangeEventBus) parseToken(token string) (uint64, error) {
+	epoch, seq, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, ErrInvalidResumeToken
+	}
+
+	value, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidResumeToken
+	}
+
+	if epoch != b.epoch {
+		return 0, ErrResumeTokenExpired
+	}
+	return value, nil
+}
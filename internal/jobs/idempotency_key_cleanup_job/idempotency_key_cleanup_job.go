@@ -0,0 +1,25 @@
+package idempotency_key_cleanup_job
+
+import (
+	"context"
+	"time"
+)
+
+type idempotencyKeyCleaner interface {
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+// IdempotencyKeyCleanupJob периодически удаляет истёкшие ключи идемпотентности
+type IdempotencyKeyCleanupJob struct {
+	storage  idempotencyKeyCleaner
+	ttl      time.Duration
+	interval time.Duration
+}
+
+func NewIdempotencyKeyCleanupJob(storage idempotencyKeyCleaner, ttl, interval time.Duration) *IdempotencyKeyCleanupJob {
+	return &IdempotencyKeyCleanupJob{
+		storage:  storage,
+		ttl:      ttl,
+		interval: interval,
+	}
+}
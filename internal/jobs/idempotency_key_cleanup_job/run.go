@@ -0,0 +1,40 @@
+package idempotency_key_cleanup_job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Run запускает очистку сразу и затем раз в interval до отмены контекста
+func (j *IdempotencyKeyCleanupJob) Run(ctx context.Context) {
+	if j.ttl <= 0 {
+		slog.Info("idempotency key cleanup job disabled: idempotencyKeyTTL is not set")
+		return
+	}
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.cleanup(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *IdempotencyKeyCleanupJob) cleanup(ctx context.Context) {
+	deleted, err := j.storage.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		slog.Error("idempotency key cleanup failed", "error", err, "deleted", deleted)
+		return
+	}
+
+	if deleted > 0 {
+		slog.Info("deleted expired idempotency keys", "count", deleted)
+	}
+}
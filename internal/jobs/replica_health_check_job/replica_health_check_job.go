@@ -0,0 +1,23 @@
+package replica_health_check_job
+
+import (
+	"context"
+	"time"
+)
+
+type replicaChecker interface {
+	CheckReplicas(ctx context.Context)
+}
+
+// ReplicaHealthCheckJob периодически проверяет реплики шардов, чтобы чтения не уходили на недоступные
+type ReplicaHealthCheckJob struct {
+	storage  replicaChecker
+	interval time.Duration
+}
+
+func NewReplicaHealthCheckJob(storage replicaChecker, interval time.Duration) *ReplicaHealthCheckJob {
+	return &ReplicaHealthCheckJob{
+		storage:  storage,
+		interval: interval,
+	}
+}
@@ -0,0 +1,22 @@
+package replica_health_check_job
+
+import (
+	"context"
+	"time"
+)
+
+// Run проверяет реплики сразу и затем раз в interval до отмены контекста
+func (j *ReplicaHealthCheckJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.storage.CheckReplicas(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package user_purge_job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Run запускает очистку сразу и затем раз в interval до отмены контекста
+func (j *UserPurgeJob) Run(ctx context.Context) {
+	if j.gracePeriod <= 0 {
+		slog.Info("user purge job disabled: userDeletionGracePeriod is not set")
+		return
+	}
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		j.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j *UserPurgeJob) purge(ctx context.Context) {
+	// deleted_at хранится в UTC без часового пояса, поэтому и границу считаем в UTC
+	deletedBefore := time.Now().UTC().Add(-j.gracePeriod)
+
+	purged, err := j.storage.PurgeDeletedUsers(ctx, deletedBefore)
+	j.cache.InvalidateUsers(ctx, purged...)
+	if err != nil {
+		slog.Error("user purge failed", "error", err, "purged", len(purged))
+		return
+	}
+
+	if len(purged) > 0 {
+		slog.Info("purged deleted users", "count", len(purged), "deleted_before", deletedBefore.Format(time.RFC3339))
+	}
+}
@@ -0,0 +1,32 @@
+package user_purge_job
+
+import (
+	"context"
+	"time"
+)
+
+type userPurger interface {
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time) ([]int32, error)
+}
+
+type userCache interface {
+	InvalidateUsers(ctx context.Context, ids ...int32)
+}
+
+// UserPurgeJob периодически окончательно удаляет пользователей, у которых истёк срок восстановления,
+// и сбрасывает их записи в кеше: очистка идёт в обход кеширующего хранилища
+type UserPurgeJob struct {
+	storage     userPurger
+	cache       userCache
+	gracePeriod time.Duration
+	interval    time.Duration
+}
+
+func NewUserPurgeJob(storage userPurger, cache userCache, gracePeriod, interval time.Duration) *UserPurgeJob {
+	return &UserPurgeJob{
+		storage:     storage,
+		cache:       cache,
+		gracePeriod: gracePeriod,
+		interval:    interval,
+	}
+}
@@ -0,0 +1,69 @@
+package models
+
+import "encoding/json"
+
+// AuditAction действие над сущностью в журнале аудита
+type AuditAction string
+
+const (
+	AuditActionCreate   AuditAction = "create"
+	AuditActionUpdate   AuditAction = "update"
+	AuditActionDelete   AuditAction = "delete"
+	AuditActionRestore  AuditAction = "restore"
+	AuditActionRequest  AuditAction = "request"
+	AuditActionComplete AuditAction = "complete"
+	AuditActionReplay   AuditAction = "replay"
+	AuditActionDiscard  AuditAction = "discard"
+)
+
+// AuditEntityType тип изменённой сущности
+type AuditEntityType string
+
+const (
+	AuditEntityUser           AuditEntityType = "user"
+	AuditEntityProduct        AuditEntityType = "product"
+	AuditEntityMeal           AuditEntityType = "meal"
+	AuditEntityMenuGeneration AuditEntityType = "menu_generation"
+	AuditEntityDeadLetter     AuditEntityType = "dead_letter"
+)
+
+// AuditActorSystem автор изменений, сделанных не по запросу клиента: фоновые задачи и консьюмеры
+const AuditActorSystem = "system"
+
+// AuditEvent запись журнала аудита. Журнал только дополняется и хранится на шарде пользователя UserID.
+type AuditEvent struct {
+	ID     string
+	UserID int32
+	Actor  string
+	// ActorVerified автор подтверждён аутентификацией; иначе Actor взят из заголовка x-actor как есть
+	ActorVerified bool
+	Action        AuditAction
+	EntityType    AuditEntityType
+	// EntityID идентификатор сущности строкой: у генераций меню и dead-letter это UUID
+	EntityID  string
+	Changes   []AuditChange
+	RequestID string
+	CreatedAt string
+}
+
+// AuditChange изменение одного поля. Before пуст при создании, After пуст при удалении.
+// Значения секретных полей заменены на AuditRedactedValue.
+type AuditChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// AuditRedactedValue значение секретного поля в журнале аудита
+const AuditRedactedValue = `"[REDACTED]"`
+
+// AuditEventFilter фильтр журнала аудита. Пустые поля не ограничивают выборку.
+type AuditEventFilter struct {
+	UserID     int32
+	EntityType AuditEntityType
+	EntityID   string
+	// From и To границы created_at в RFC3339, From включительно, To не включительно
+	From  string
+	To    string
+	Limit int32
+}
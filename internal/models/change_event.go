@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ChangeEventType тип изменения в ленте пользователя
+type ChangeEventType int
+
+const (
+	ChangeEventTypeUnknown ChangeEventType = iota
+	ChangeEventTypeUserUpdated
+	ChangeEventTypeProductCreated
+	ChangeEventTypeProductUpdated
+	ChangeEventTypeProductDeleted
+	ChangeEventTypeMealCreated
+	ChangeEventTypeMealUpdated
+	ChangeEventTypeMealDeleted
+	ChangeEventTypeUserCreated
+	ChangeEventTypeUserDeleted
+)
+
+// ChangeEvent изменение профиля, продукта или блюда пользователя.
+// Заполнено одно из полей User, Product, Meal; для удалений это последнее состояние сущности,
+// у ChangeEventTypeUserDeleted сущность не заполняется.
+type ChangeEvent struct {
+	// ResumeToken позиция события в ленте, с неё клиент продолжает подписку после переподключения
+	ResumeToken string
+	Type        ChangeEventType
+	UserID      int32
+	OccurredAt  time.Time
+	User        *User
+	Product     *Product
+	Meal        *Meal
+}
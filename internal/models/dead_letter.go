@@ -0,0 +1,22 @@
+package models
+
+// DeadLetter сообщение Kafka, которое не удалось опубликовать после всех попыток.
+// Хранит сообщение целиком, чтобы повторная отправка не зависела от текущего состояния профиля.
+type DeadLetter struct {
+	ID      string
+	UserID  int32
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers []DeadLetterHeader
+	// Error ошибка последней попытки публикации
+	Error     string
+	Attempts  int32
+	CreatedAt string
+}
+
+// DeadLetterHeader заголовок сообщения Kafka
+type DeadLetterHeader struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
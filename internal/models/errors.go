@@ -0,0 +1,12 @@
+package models
+
+import "errors"
+
+// ErrVersionMismatch запись изменилась после того, как клиент прочитал ожидаемую версию
+var ErrVersionMismatch = errors.New("версия записи изменилась, перечитайте её и повторите изменение")
+
+// ErrShardUnavailable шард базы данных не отвечает или временно исключён из запросов после серии ошибок
+var ErrShardUnavailable = errors.New("шард базы данных недоступен, повторите запрос позже")
+
+// ErrNotFound запись не найдена ни на одном из ответивших шардов
+var ErrNotFound = errors.New("запись не найдена")
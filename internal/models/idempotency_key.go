@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// IdempotencyKey первый ответ на создающий запрос с заголовком Idempotency-Key.
+// Пока Response пуст, запрос с этим ключом ещё выполняется.
+type IdempotencyKey struct {
+	// UserID владелец ключа; для CreateUser пользователь ещё не существует и UserID равен нулю
+	UserID int32
+	Method string
+	Key    string
+	// RequestHash хеш тела запроса, по которому повтор отличается от другого запроса с тем же ключом
+	RequestHash []byte
+	Response    []byte
+	// TTL сколько ключ хранится с момента первого запроса
+	TTL time.Duration
+}
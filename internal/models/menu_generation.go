@@ -0,0 +1,34 @@
+package models
+
+import "encoding/json"
+
+// MenuGenerationStatus состояние запроса генерации меню
+type MenuGenerationStatus string
+
+const (
+	MenuGenerationStatusPending   MenuGenerationStatus = "pending"
+	MenuGenerationStatusCompleted MenuGenerationStatus = "completed"
+	MenuGenerationStatusFailed    MenuGenerationStatus = "failed"
+)
+
+// MenuGeneration запрос генерации меню и его результат
+type MenuGeneration struct {
+	RequestID string
+	UserID    int32
+	Status    MenuGenerationStatus
+	Error     string
+	// Menu сгенерированное меню в JSON, формат определяет генератор
+	Menu        string
+	CreatedAt   string
+	CompletedAt string
+}
+
+// MenuGenerationResultEvent сообщение топика menu-generation-results
+type MenuGenerationResultEvent struct {
+	RequestID   string               `json:"request_id"`
+	UserID      int32                `json:"user_id"`
+	Status      MenuGenerationStatus `json:"status"`
+	Error       string               `json:"error,omitempty"`
+	Menu        json.RawMessage      `json:"menu,omitempty"`
+	GeneratedAt string               `json:"generated_at,omitempty"`
+}
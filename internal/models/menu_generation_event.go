@@ -1,14 +1,78 @@
 package models
 
+import "encoding/json"
+
+// MenuGenerationRequestSchemaVersion версия схемы MenuGenerationRequestEvent.
+// Версия 1 содержала только имена продуктов в preferences.products, версия 2 добавила
+// продукты с КБЖУ, блюда с составом, параметры тела и разобранные предпочтения.
+const MenuGenerationRequestSchemaVersion = 2
+
 type MenuGenerationRequestEvent struct {
-	RequestID   string              `json:"request_id"`
-	UserID      int32               `json:"user_id"`
-	Preferences MenuGenerationPrefs `json:"preferences"`
-	Timestamp   string              `json:"timestamp"`
+	SchemaVersion int                 `json:"schema_version"`
+	RequestID     string              `json:"request_id"`
+	UserID        int32               `json:"user_id"`
+	Preferences   MenuGenerationPrefs `json:"preferences"`
+	// Body параметры тела пользователя, nil если не заполнены
+	Body     *MenuGenerationBody     `json:"body,omitempty"`
+	Products []MenuGenerationProduct `json:"products"`
+	Meals    []MenuGenerationMeal    `json:"meals"`
+	// Options задаётся только явным запросом RequestMenuGeneration
+	Options   *MenuGenerationOptions `json:"options,omitempty"`
+	Timestamp string                 `json:"timestamp"`
 }
 
 type MenuGenerationPrefs struct {
-	BJU      *BJU     `json:"bju,omitempty"`
-	Budget   *int32   `json:"budget,omitempty"`
+	BJU    *BJU   `json:"bju,omitempty"`
+	Budget *int32 `json:"budget,omitempty"`
+	// Products имена продуктов пользователя.
+	// Deprecated: оставлено для генераторов на схеме версии 1, используйте MenuGenerationRequestEvent.Products.
 	Products []string `json:"products"`
+	// Details предпочтения пользователя, если они сохранены JSON-объектом
+	Details json.RawMessage `json:"details,omitempty"`
+	// Raw предпочтения пользователя, если они сохранены произвольным текстом
+	Raw string `json:"raw,omitempty"`
+}
+
+// MenuGenerationBody параметры тела пользователя
+type MenuGenerationBody struct {
+	Height *int32 `json:"height,omitempty"`
+	Weight *int32 `json:"weight,omitempty"`
+}
+
+// MenuGenerationProduct продукт пользователя с пищевой ценностью
+type MenuGenerationProduct struct {
+	ID       int32  `json:"id"`
+	Name     string `json:"name"`
+	Calories *int32 `json:"calories,omitempty"`
+	Protein  *int32 `json:"protein,omitempty"`
+	Fat      *int32 `json:"fat,omitempty"`
+	Carbs    *int32 `json:"carbs,omitempty"`
+}
+
+// MenuGenerationMeal сохранённое блюдо пользователя с составом
+type MenuGenerationMeal struct {
+	ID       int32         `json:"id"`
+	Name     string        `json:"name"`
+	Products []MealProduct `json:"products"`
+}
+
+// MenuGenerationOptions переопределения для явного запроса генерации меню
+type MenuGenerationOptions struct {
+	// DateFrom и DateTo период меню в формате YYYY-MM-DD
+	DateFrom    string `json:"date_from,omitempty"`
+	DateTo      string `json:"date_to,omitempty"`
+	MealsPerDay int32  `json:"meals_per_day,omitempty"`
+	// Budget заменяет бюджет из профиля пользователя
+	Budget *int32 `json:"budget,omitempty"`
+}
+
+// MenuGenerationRequest данные для публикации запроса генерации меню
+type MenuGenerationRequest struct {
+	RequestID string
+	User      *User
+	// Budget бюджет с учётом переопределения из Options
+	Budget   *int32
+	Products []*Product
+	Meals    []*Meal
+	Options  *MenuGenerationOptions
 }
@@ -16,6 +16,8 @@ type User struct {
 	Budget       *int32  `json:"budget,omitempty"`
 	Preferences  string `json:"preferences,omitempty"`
 	CreatedAt    string `json:"created_at,omitempty"`
+	// Version растёт при каждом изменении. В UpdateUser ожидаемая версия, 0 отключает проверку.
+	Version int32 `json:"version,omitempty"`
 }
 
 type Product struct {
@@ -27,13 +29,25 @@ type Product struct {
 	Fat       *int32 `json:"fat,omitempty"`
 	Carbs     *int32 `json:"carbs,omitempty"`
 	CreatedAt string `json:"created_at,omitempty"`
+	// Version растёт при каждом изменении. В UpdateProduct ожидаемая версия, 0 отключает проверку.
+	Version int32 `json:"version,omitempty"`
 }
 
 type Meal struct {
-	ID         int32   `json:"id"`
-	UserID     int32   `json:"user_id"`
-	Name       string  `json:"name"`
-	ProductIDs []int32 `json:"product_ids"`
-	CreatedAt  string  `json:"created_at,omitempty"`
+	ID         int32         `json:"id"`
+	UserID     int32         `json:"user_id"`
+	Name       string        `json:"name"`
+	ProductIDs []int32       `json:"product_ids"`
+	Products   []MealProduct `json:"products,omitempty"`
+	CreatedAt  string        `json:"created_at,omitempty"`
+	// Version растёт при каждом изменении. В UpdateMeal ожидаемая версия, 0 отключает проверку.
+	Version int32 `json:"version,omitempty"`
+}
+
+// MealProduct продукт в составе блюда
+type MealProduct struct {
+	ProductID int32 `json:"product_id"`
+	// Quantity количество продукта в граммах, nil если не указано
+	Quantity *int32 `json:"quantity,omitempty"`
 }
 
@@ -0,0 +1,22 @@
+package models
+
+type ProductImportFormat int
+
+const (
+	ProductImportFormatUnknown ProductImportFormat = iota
+	ProductImportFormatCSV
+	ProductImportFormatJSON
+)
+
+type ProductImportRow struct {
+	Line    int      `json:"line"`
+	Product *Product `json:"product,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type ProductImportResult struct {
+	Rows          []*ProductImportRow `json:"rows"`
+	ImportedCount int                 `json:"imported_count"`
+	RejectedCount int                 `json:"rejected_count"`
+	DryRun        bool                `json:"dry_run"`
+}
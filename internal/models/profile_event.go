@@ -0,0 +1,45 @@
+package models
+
+// ProfileEventSchemaVersion версия схемы событий топика profile-events.
+// Увеличивается при несовместимых изменениях вместе с суффиксом имени топика.
+const ProfileEventSchemaVersion = 1
+
+// ProfileEventType тип доменного события
+type ProfileEventType string
+
+const (
+	ProfileEventTypeUserCreated    ProfileEventType = "UserCreated"
+	ProfileEventTypeUserUpdated    ProfileEventType = "UserUpdated"
+	ProfileEventTypeUserDeleted    ProfileEventType = "UserDeleted"
+	ProfileEventTypeProductCreated ProfileEventType = "ProductCreated"
+	ProfileEventTypeProductUpdated ProfileEventType = "ProductUpdated"
+	ProfileEventTypeProductDeleted ProfileEventType = "ProductDeleted"
+	ProfileEventTypeMealCreated    ProfileEventType = "MealCreated"
+	ProfileEventTypeMealUpdated    ProfileEventType = "MealUpdated"
+	ProfileEventTypeMealDeleted    ProfileEventType = "MealDeleted"
+)
+
+// ProfileEvent доменное событие об изменении пользователя, продукта или блюда.
+// UserDeleted не содержит сущности и означает удаление пользователя вместе с его продуктами и блюдами.
+type ProfileEvent struct {
+	SchemaVersion int               `json:"schema_version"`
+	EventID       string            `json:"event_id"`
+	EventType     ProfileEventType  `json:"event_type"`
+	UserID        int32             `json:"user_id"`
+	OccurredAt    string            `json:"occurred_at"`
+	User          *ProfileEventUser `json:"user,omitempty"`
+	Product       *Product          `json:"product,omitempty"`
+	Meal          *Meal             `json:"meal,omitempty"`
+}
+
+// ProfileEventUser профиль пользователя в событии, без хэша пароля
+type ProfileEventUser struct {
+	ID          int32  `json:"id"`
+	Username    string `json:"username"`
+	Height      *int32 `json:"height,omitempty"`
+	Weight      *int32 `json:"weight,omitempty"`
+	BJU         *BJU   `json:"bju,omitempty"`
+	Budget      *int32 `json:"budget,omitempty"`
+	Preferences string `json:"preferences,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
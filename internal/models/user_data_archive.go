@@ -0,0 +1,44 @@
+package models
+
+// UserDataArchiveVersion версия формата архива выгрузки данных пользователя
+const UserDataArchiveVersion = 1
+
+type UserDataArchiveFormat int
+
+const (
+	UserDataArchiveFormatUnknown UserDataArchiveFormat = iota
+	UserDataArchiveFormatJSON
+	UserDataArchiveFormatZIP
+)
+
+type UserDataArchive struct {
+	Version    int                  `json:"version"`
+	ExportedAt string               `json:"exported_at"`
+	User       *UserDataArchiveUser `json:"user"`
+	Products   []*Product           `json:"products"`
+	Meals      []*Meal              `json:"meals"`
+}
+
+// UserDataArchiveUser профиль пользователя в архиве, хеш пароля не выгружается
+type UserDataArchiveUser struct {
+	ID          int32  `json:"id"`
+	Username    string `json:"username"`
+	Height      *int32 `json:"height,omitempty"`
+	Weight      *int32 `json:"weight,omitempty"`
+	BJU         *BJU   `json:"bju,omitempty"`
+	Budget      *int32 `json:"budget,omitempty"`
+	Preferences string `json:"preferences,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
+
+type UserDataExport struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+type UserDataImportResult struct {
+	User             *User
+	ImportedProducts int
+	ImportedMeals    int
+}
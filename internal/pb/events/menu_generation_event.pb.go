@@ -0,0 +1,721 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: events/menu_generation_event.proto
+
+package events
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// MenuGenerationRequestEvent запрос генерации меню в топике menu-generation-requests.
+// Должен оставаться первым сообщением файла: в Confluent wire format он кодируется индексом [0].
+type MenuGenerationRequestEvent struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	SchemaVersion int32                      `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	RequestId     string                     `protobuf:"bytes,2,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserId        int32                      `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Preferences   *MenuGenerationPreferences `protobuf:"bytes,4,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	// body не задаётся, если параметры тела не заполнены
+	Body     *MenuGenerationBody      `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
+	Products []*MenuGenerationProduct `protobuf:"bytes,6,rep,name=products,proto3" json:"products,omitempty"`
+	Meals    []*MenuGenerationMeal    `protobuf:"bytes,7,rep,name=meals,proto3" json:"meals,omitempty"`
+	// options задаётся только явным запросом RequestMenuGeneration
+	Options       *MenuGenerationOptions `protobuf:"bytes,8,opt,name=options,proto3" json:"options,omitempty"`
+	Timestamp     string                 `protobuf:"bytes,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuGenerationRequestEvent) Reset() {
+	*x = MenuGenerationRequestEvent{}
+	mi := &file_events_menu_generation_event_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuGenerationRequestEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuGenerationRequestEvent) ProtoMessage() {}
+
+func (x *MenuGenerationRequestEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_menu_generation_event_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuGenerationRequestEvent.ProtoReflect.Descriptor instead.
+func (*MenuGenerationRequestEvent) Descriptor() ([]byte, []int) {
+	return file_events_menu_generation_event_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MenuGenerationRequestEvent) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *MenuGenerationRequestEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *MenuGenerationRequestEvent) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MenuGenerationRequestEvent) GetPreferences() *MenuGenerationPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *MenuGenerationRequestEvent) GetBody() *MenuGenerationBody {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *MenuGenerationRequestEvent) GetProducts() []*MenuGenerationProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *MenuGenerationRequestEvent) GetMeals() []*MenuGenerationMeal {
+	if x != nil {
+		return x.Meals
+	}
+	return nil
+}
+
+func (x *MenuGenerationRequestEvent) GetOptions() *MenuGenerationOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *MenuGenerationRequestEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+type MenuGenerationBJU struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Protein       int32                  `protobuf:"varint,1,opt,name=protein,proto3" json:"protein,omitempty"`
+	Fat           int32                  `protobuf:"varint,2,opt,name=fat,proto3" json:"fat,omitempty"`
+	Carbs         int32                  `protobuf:"varint,3,opt,name=carbs,proto3" json:"carbs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuGenerationBJU) Reset() {
+	*x = MenuGenerationBJU{}
+	mi := &file_events_menu_generation_event_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuGenerationBJU) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuGenerationBJU) ProtoMessage() {}
+
+func (x *MenuGenerationBJU) ProtoReflect() protoreflect.Message {
+	mi := &file_events_menu_generation_event_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuGenerationBJU.ProtoReflect.Descriptor instead.
+func (*MenuGenerationBJU) Descriptor() ([]byte, []int) {
+	return file_events_menu_generation_event_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MenuGenerationBJU) GetProtein() int32 {
+	if x != nil {
+		return x.Protein
+	}
+	return 0
+}
+
+func (x *MenuGenerationBJU) GetFat() int32 {
+	if x != nil {
+		return x.Fat
+	}
+	return 0
+}
+
+func (x *MenuGenerationBJU) GetCarbs() int32 {
+	if x != nil {
+		return x.Carbs
+	}
+	return 0
+}
+
+type MenuGenerationPreferences struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Bju    *MenuGenerationBJU     `protobuf:"bytes,1,opt,name=bju,proto3" json:"bju,omitempty"`
+	Budget *int32                 `protobuf:"varint,2,opt,name=budget,proto3,oneof" json:"budget,omitempty"`
+	// products имена продуктов для генераторов на схеме версии 1
+	//
+	// Deprecated: Marked as deprecated in events/menu_generation_event.proto.
+	Products []string `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
+	// details предпочтения, сохранённые JSON-объектом
+	Details string `protobuf:"bytes,4,opt,name=details,proto3" json:"details,omitempty"`
+	// raw предпочтения, сохранённые произвольным текстом
+	Raw           string `protobuf:"bytes,5,opt,name=raw,proto3" json:"raw,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuGenerationPreferences) Reset() {
+	*x = MenuGenerationPreferences{}
+	mi := &file_events_menu_generation_event_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuGenerationPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuGenerationPreferences) ProtoMessage() {}
+
+func (x *MenuGenerationPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_events_menu_generation_event_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuGenerationPreferences.ProtoReflect.Descriptor instead.
+func (*MenuGenerationPreferences) Descriptor() ([]byte, []int) {
+	return file_events_menu_generation_event_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MenuGenerationPreferences) GetBju() *MenuGenerationBJU {
+	if x != nil {
+		return x.Bju
+	}
+	return nil
+}
+
+func (x *MenuGenerationPreferences) GetBudget() int32 {
+	if x != nil && x.Budget != nil {
+		return *x.Budget
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in events/menu_generation_event.proto.
+func (x *MenuGenerationPreferences) GetProducts() []string {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *MenuGenerationPreferences) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *MenuGenerationPreferences) GetRaw() string {
+	if x != nil {
+		return x.Raw
+	}
+	return ""
+}
+
+type MenuGenerationBody struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        *int32                 `protobuf:"varint,1,opt,name=height,proto3,oneof" json:"height,omitempty"`
+	Weight        *int32                 `protobuf:"varint,2,opt,name=weight,proto3,oneof" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuGenerationBody) Reset() {
+	*x = MenuGenerationBody{}
+	mi := &file_events_menu_generation_event_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuGenerationBody) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuGenerationBody) ProtoMessage() {}
+
+func (x *MenuGenerationBody) ProtoReflect() protoreflect.Message {
+	mi := &file_events_menu_generation_event_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuGenerationBody.ProtoReflect.Descriptor instead.
+func (*MenuGenerationBody) Descriptor() ([]byte, []int) {
+	return file_events_menu_generation_event_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MenuGenerationBody) GetHeight() int32 {
+	if x != nil && x.Height != nil {
+		return *x.Height
+	}
+	return 0
+}
+
+func (x *MenuGenerationBody) GetWeight() int32 {
+	if x != nil && x.Weight != nil {
+		return *x.Weight
+	}
+	return 0
+}
+
+type MenuGenerationProduct struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Calories      *int32                 `protobuf:"varint,3,opt,name=calories,proto3,oneof" json:"calories,omitempty"`
+	Protein       *int32                 `protobuf:"varint,4,opt,name=protein,proto3,oneof" json:"protein,omitempty"`
+	Fat           *int32                 `protobuf:"varint,5,opt,name=fat,proto3,oneof" json:"fat,omitempty"`
+	Carbs         *int32                 `protobuf:"varint,6,opt,name=carbs,proto3,oneof" json:"carbs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuGenerationProduct) Reset() {
+	*x = MenuGenerationProduct{}
+	mi := &file_events_menu_generation_event_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuGenerationProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuGenerationProduct) ProtoMessage() {}
+
+func (x *MenuGenerationProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_events_menu_generation_event_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuGenerationProduct.ProtoReflect.Descriptor instead.
+func (*MenuGenerationProduct) Descriptor() ([]byte, []int) {
+	return file_events_menu_generation_event_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MenuGenerationProduct) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MenuGenerationProduct) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MenuGenerationProduct) GetCalories() int32 {
+	if x != nil && x.Calories != nil {
+		return *x.Calories
+	}
+	return 0
+}
+
+func (x *MenuGenerationProduct) GetProtein() int32 {
+	if x != nil && x.Protein != nil {
+		return *x.Protein
+	}
+	return 0
+}
+
+func (x *MenuGenerationProduct) GetFat() int32 {
+	if x != nil && x.Fat != nil {
+		return *x.Fat
+	}
+	return 0
+}
+
+func (x *MenuGenerationProduct) GetCarbs() int32 {
+	if x != nil && x.Carbs != nil {
+		return *x.Carbs
+	}
+	return 0
+}
+
+type MenuGenerationMeal struct {
+	state         protoimpl.MessageState       `protogen:"open.v1"`
+	Id            int32                        `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Products      []*MenuGenerationMealProduct `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuGenerationMeal) Reset() {
+	*x = MenuGenerationMeal{}
+	mi := &file_events_menu_generation_event_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuGenerationMeal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuGenerationMeal) ProtoMessage() {}
+
+func (x *MenuGenerationMeal) ProtoReflect() protoreflect.Message {
+	mi := &file_events_menu_generation_event_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuGenerationMeal.ProtoReflect.Descriptor instead.
+func (*MenuGenerationMeal) Descriptor() ([]byte, []int) {
+	return file_events_menu_generation_event_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MenuGenerationMeal) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MenuGenerationMeal) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MenuGenerationMeal) GetProducts() []*MenuGenerationMealProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type MenuGenerationMealProduct struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// quantity количество в граммах
+	Quantity      *int32 `protobuf:"varint,2,opt,name=quantity,proto3,oneof" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuGenerationMealProduct) Reset() {
+	*x = MenuGenerationMealProduct{}
+	mi := &file_events_menu_generation_event_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuGenerationMealProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuGenerationMealProduct) ProtoMessage() {}
+
+func (x *MenuGenerationMealProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_events_menu_generation_event_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuGenerationMealProduct.ProtoReflect.Descriptor instead.
+func (*MenuGenerationMealProduct) Descriptor() ([]byte, []int) {
+	return file_events_menu_generation_event_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MenuGenerationMealProduct) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *MenuGenerationMealProduct) GetQuantity() int32 {
+	if x != nil && x.Quantity != nil {
+		return *x.Quantity
+	}
+	return 0
+}
+
+type MenuGenerationOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// date_from и date_to период меню в формате YYYY-MM-DD
+	DateFrom      string `protobuf:"bytes,1,opt,name=date_from,json=dateFrom,proto3" json:"date_from,omitempty"`
+	DateTo        string `protobuf:"bytes,2,opt,name=date_to,json=dateTo,proto3" json:"date_to,omitempty"`
+	MealsPerDay   int32  `protobuf:"varint,3,opt,name=meals_per_day,json=mealsPerDay,proto3" json:"meals_per_day,omitempty"`
+	Budget        *int32 `protobuf:"varint,4,opt,name=budget,proto3,oneof" json:"budget,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuGenerationOptions) Reset() {
+	*x = MenuGenerationOptions{}
+	mi := &file_events_menu_generation_event_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuGenerationOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuGenerationOptions) ProtoMessage() {}
+
+func (x *MenuGenerationOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_events_menu_generation_event_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuGenerationOptions.ProtoReflect.Descriptor instead.
+func (*MenuGenerationOptions) Descriptor() ([]byte, []int) {
+	return file_events_menu_generation_event_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MenuGenerationOptions) GetDateFrom() string {
+	if x != nil {
+		return x.DateFrom
+	}
+	return ""
+}
+
+func (x *MenuGenerationOptions) GetDateTo() string {
+	if x != nil {
+		return x.DateTo
+	}
+	return ""
+}
+
+func (x *MenuGenerationOptions) GetMealsPerDay() int32 {
+	if x != nil {
+		return x.MealsPerDay
+	}
+	return 0
+}
+
+func (x *MenuGenerationOptions) GetBudget() int32 {
+	if x != nil && x.Budget != nil {
+		return *x.Budget
+	}
+	return 0
+}
+
+var File_events_menu_generation_event_proto protoreflect.FileDescriptor
+
+const file_events_menu_generation_event_proto_rawDesc = "" +
+	"\n" +
+	"\"events/menu_generation_event.proto\x12\x1cprofile_management.events.v1\"\xa2\x04\n" +
+	"\x1aMenuGenerationRequestEvent\x12%\n" +
+	"\x0eschema_version\x18\x01 \x01(\x05R\rschemaVersion\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x02 \x01(\tR\trequestId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12Y\n" +
+	"\vpreferences\x18\x04 \x01(\v27.profile_management.events.v1.MenuGenerationPreferencesR\vpreferences\x12D\n" +
+	"\x04body\x18\x05 \x01(\v20.profile_management.events.v1.MenuGenerationBodyR\x04body\x12O\n" +
+	"\bproducts\x18\x06 \x03(\v23.profile_management.events.v1.MenuGenerationProductR\bproducts\x12F\n" +
+	"\x05meals\x18\a \x03(\v20.profile_management.events.v1.MenuGenerationMealR\x05meals\x12M\n" +
+	"\aoptions\x18\b \x01(\v23.profile_management.events.v1.MenuGenerationOptionsR\aoptions\x12\x1c\n" +
+	"\ttimestamp\x18\t \x01(\tR\ttimestamp\"U\n" +
+	"\x11MenuGenerationBJU\x12\x18\n" +
+	"\aprotein\x18\x01 \x01(\x05R\aprotein\x12\x10\n" +
+	"\x03fat\x18\x02 \x01(\x05R\x03fat\x12\x14\n" +
+	"\x05carbs\x18\x03 \x01(\x05R\x05carbs\"\xd2\x01\n" +
+	"\x19MenuGenerationPreferences\x12A\n" +
+	"\x03bju\x18\x01 \x01(\v2/.profile_management.events.v1.MenuGenerationBJUR\x03bju\x12\x1b\n" +
+	"\x06budget\x18\x02 \x01(\x05H\x00R\x06budget\x88\x01\x01\x12\x1e\n" +
+	"\bproducts\x18\x03 \x03(\tB\x02\x18\x01R\bproducts\x12\x18\n" +
+	"\adetails\x18\x04 \x01(\tR\adetails\x12\x10\n" +
+	"\x03raw\x18\x05 \x01(\tR\x03rawB\t\n" +
+	"\a_budget\"d\n" +
+	"\x12MenuGenerationBody\x12\x1b\n" +
+	"\x06height\x18\x01 \x01(\x05H\x00R\x06height\x88\x01\x01\x12\x1b\n" +
+	"\x06weight\x18\x02 \x01(\x05H\x01R\x06weight\x88\x01\x01B\t\n" +
+	"\a_heightB\t\n" +
+	"\a_weight\"\xd8\x01\n" +
+	"\x15MenuGenerationProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\bcalories\x18\x03 \x01(\x05H\x00R\bcalories\x88\x01\x01\x12\x1d\n" +
+	"\aprotein\x18\x04 \x01(\x05H\x01R\aprotein\x88\x01\x01\x12\x15\n" +
+	"\x03fat\x18\x05 \x01(\x05H\x02R\x03fat\x88\x01\x01\x12\x19\n" +
+	"\x05carbs\x18\x06 \x01(\x05H\x03R\x05carbs\x88\x01\x01B\v\n" +
+	"\t_caloriesB\n" +
+	"\n" +
+	"\b_proteinB\x06\n" +
+	"\x04_fatB\b\n" +
+	"\x06_carbs\"\x8d\x01\n" +
+	"\x12MenuGenerationMeal\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12S\n" +
+	"\bproducts\x18\x03 \x03(\v27.profile_management.events.v1.MenuGenerationMealProductR\bproducts\"h\n" +
+	"\x19MenuGenerationMealProduct\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1f\n" +
+	"\bquantity\x18\x02 \x01(\x05H\x00R\bquantity\x88\x01\x01B\v\n" +
+	"\t_quantity\"\x99\x01\n" +
+	"\x15MenuGenerationOptions\x12\x1b\n" +
+	"\tdate_from\x18\x01 \x01(\tR\bdateFrom\x12\x17\n" +
+	"\adate_to\x18\x02 \x01(\tR\x06dateTo\x12\"\n" +
+	"\rmeals_per_day\x18\x03 \x01(\x05R\vmealsPerDay\x12\x1b\n" +
+	"\x06budget\x18\x04 \x01(\x05H\x00R\x06budget\x88\x01\x01B\t\n" +
+	"\a_budgetBYZWgithub.com/Android12349/food_recomendation/profile_managment_service/internal/pb/eventsb\x06proto3"
+
+var (
+	file_events_menu_generation_event_proto_rawDescOnce sync.Once
+	file_events_menu_generation_event_proto_rawDescData []byte
+)
+
+func file_events_menu_generation_event_proto_rawDescGZIP() []byte {
+	file_events_menu_generation_event_proto_rawDescOnce.Do(func() {
+		file_events_menu_generation_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_events_menu_generation_event_proto_rawDesc), len(file_events_menu_generation_event_proto_rawDesc)))
+	})
+	return file_events_menu_generation_event_proto_rawDescData
+}
+
+var file_events_menu_generation_event_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_events_menu_generation_event_proto_goTypes = []any{
+	(*MenuGenerationRequestEvent)(nil), // 0: profile_management.events.v1.MenuGenerationRequestEvent
+	(*MenuGenerationBJU)(nil),          // 1: profile_management.events.v1.MenuGenerationBJU
+	(*MenuGenerationPreferences)(nil),  // 2: profile_management.events.v1.MenuGenerationPreferences
+	(*MenuGenerationBody)(nil),         // 3: profile_management.events.v1.MenuGenerationBody
+	(*MenuGenerationProduct)(nil),      // 4: profile_management.events.v1.MenuGenerationProduct
+	(*MenuGenerationMeal)(nil),         // 5: profile_management.events.v1.MenuGenerationMeal
+	(*MenuGenerationMealProduct)(nil),  // 6: profile_management.events.v1.MenuGenerationMealProduct
+	(*MenuGenerationOptions)(nil),      // 7: profile_management.events.v1.MenuGenerationOptions
+}
+var file_events_menu_generation_event_proto_depIdxs = []int32{
+	2, // 0: profile_management.events.v1.MenuGenerationRequestEvent.preferences:type_name -> profile_management.events.v1.MenuGenerationPreferences
+	3, // 1: profile_management.events.v1.MenuGenerationRequestEvent.body:type_name -> profile_management.events.v1.MenuGenerationBody
+	4, // 2: profile_management.events.v1.MenuGenerationRequestEvent.products:type_name -> profile_management.events.v1.MenuGenerationProduct
+	5, // 3: profile_management.events.v1.MenuGenerationRequestEvent.meals:type_name -> profile_management.events.v1.MenuGenerationMeal
+	7, // 4: profile_management.events.v1.MenuGenerationRequestEvent.options:type_name -> profile_management.events.v1.MenuGenerationOptions
+	1, // 5: profile_management.events.v1.MenuGenerationPreferences.bju:type_name -> profile_management.events.v1.MenuGenerationBJU
+	6, // 6: profile_management.events.v1.MenuGenerationMeal.products:type_name -> profile_management.events.v1.MenuGenerationMealProduct
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_events_menu_generation_event_proto_init() }
+func file_events_menu_generation_event_proto_init() {
+	if File_events_menu_generation_event_proto != nil {
+		return
+	}
+	file_events_menu_generation_event_proto_msgTypes[2].OneofWrappers = []any{}
+	file_events_menu_generation_event_proto_msgTypes[3].OneofWrappers = []any{}
+	file_events_menu_generation_event_proto_msgTypes[4].OneofWrappers = []any{}
+	file_events_menu_generation_event_proto_msgTypes[6].OneofWrappers = []any{}
+	file_events_menu_generation_event_proto_msgTypes[7].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_menu_generation_event_proto_rawDesc), len(file_events_menu_generation_event_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_events_menu_generation_event_proto_goTypes,
+		DependencyIndexes: file_events_menu_generation_event_proto_depIdxs,
+		MessageInfos:      file_events_menu_generation_event_proto_msgTypes,
+	}.Build()
+	File_events_menu_generation_event_proto = out.File
+	file_events_menu_generation_event_proto_goTypes = nil
+	file_events_menu_generation_event_proto_depIdxs = nil
+}
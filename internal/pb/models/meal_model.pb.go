@@ -22,12 +22,15 @@ const (
 )
 
 type MealModel struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	ProductIds    []int32                `protobuf:"varint,4,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId     int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name       string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ProductIds []int32                `protobuf:"varint,4,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	CreatedAt  string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Products   []*MealProductModel    `protobuf:"bytes,6,rep,name=products,proto3" json:"products,omitempty"`
+	// version растёт при каждом изменении, передаётся в UpdateMeal как expected_version
+	Version       int32 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -97,11 +100,27 @@ func (x *MealModel) GetCreatedAt() string {
 	return ""
 }
 
+func (x *MealModel) GetProducts() []*MealProductModel {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *MealModel) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type MealCreateModel struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ProductIds    []int32                `protobuf:"varint,3,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ProductIds []int32                `protobuf:"varint,3,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	// products состав с количеством; если задан, product_ids игнорируется
+	Products      []*MealProductModel `protobuf:"bytes,4,rep,name=products,proto3" json:"products,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -157,10 +176,19 @@ func (x *MealCreateModel) GetProductIds() []int32 {
 	return nil
 }
 
+func (x *MealCreateModel) GetProducts() []*MealProductModel {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
 type MealUpdateModel struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	ProductIds    []int32                `protobuf:"varint,2,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Name       string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ProductIds []int32                `protobuf:"varint,2,rep,packed,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	// products состав с количеством; если задан, product_ids игнорируется
+	Products      []*MealProductModel `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -209,11 +237,71 @@ func (x *MealUpdateModel) GetProductIds() []int32 {
 	return nil
 }
 
+func (x *MealUpdateModel) GetProducts() []*MealProductModel {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type MealProductModel struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int32                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// quantity количество в граммах, 0 если не указано
+	Quantity      int32 `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MealProductModel) Reset() {
+	*x = MealProductModel{}
+	mi := &file_models_meal_model_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MealProductModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MealProductModel) ProtoMessage() {}
+
+func (x *MealProductModel) ProtoReflect() protoreflect.Message {
+	mi := &file_models_meal_model_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MealProductModel.ProtoReflect.Descriptor instead.
+func (*MealProductModel) Descriptor() ([]byte, []int) {
+	return file_models_meal_model_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MealProductModel) GetProductId() int32 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *MealProductModel) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
 var File_models_meal_model_proto protoreflect.FileDescriptor
 
 const file_models_meal_model_proto_rawDesc = "" +
 	"\n" +
-	"\x17models/meal_model.proto\x12\x1cprofile_management.models.v1\"\x88\x01\n" +
+	"\x17models/meal_model.proto\x12\x1cprofile_management.models.v1\"\xee\x01\n" +
 	"\tMealModel\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
@@ -221,16 +309,24 @@ const file_models_meal_model_proto_rawDesc = "" +
 	"\vproduct_ids\x18\x04 \x03(\x05R\n" +
 	"productIds\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\tR\tcreatedAt\"_\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12J\n" +
+	"\bproducts\x18\x06 \x03(\v2..profile_management.models.v1.MealProductModelR\bproducts\x12\x18\n" +
+	"\aversion\x18\a \x01(\x05R\aversion\"\xab\x01\n" +
 	"\x0fMealCreateModel\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
 	"\vproduct_ids\x18\x03 \x03(\x05R\n" +
-	"productIds\"F\n" +
+	"productIds\x12J\n" +
+	"\bproducts\x18\x04 \x03(\v2..profile_management.models.v1.MealProductModelR\bproducts\"\x92\x01\n" +
 	"\x0fMealUpdateModel\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
 	"\vproduct_ids\x18\x02 \x03(\x05R\n" +
-	"productIdsBYZWgithub.com/Android12349/food_recomendation/profile_managment_service/internal/pb/modelsb\x06proto3"
+	"productIds\x12J\n" +
+	"\bproducts\x18\x03 \x03(\v2..profile_management.models.v1.MealProductModelR\bproducts\"M\n" +
+	"\x10MealProductModel\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x05R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantityBYZWgithub.com/Android12349/food_recomendation/profile_managment_service/internal/pb/modelsb\x06proto3"
 
 var (
 	file_models_meal_model_proto_rawDescOnce sync.Once
@@ -244,18 +340,22 @@ func file_models_meal_model_proto_rawDescGZIP() []byte {
 	return file_models_meal_model_proto_rawDescData
 }
 
-var file_models_meal_model_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_models_meal_model_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_models_meal_model_proto_goTypes = []any{
-	(*MealModel)(nil),       // 0: profile_management.models.v1.MealModel
-	(*MealCreateModel)(nil), // 1: profile_management.models.v1.MealCreateModel
-	(*MealUpdateModel)(nil), // 2: profile_management.models.v1.MealUpdateModel
+	(*MealModel)(nil),        // 0: profile_management.models.v1.MealModel
+	(*MealCreateModel)(nil),  // 1: profile_management.models.v1.MealCreateModel
+	(*MealUpdateModel)(nil),  // 2: profile_management.models.v1.MealUpdateModel
+	(*MealProductModel)(nil), // 3: profile_management.models.v1.MealProductModel
 }
 var file_models_meal_model_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3, // 0: profile_management.models.v1.MealModel.products:type_name -> profile_management.models.v1.MealProductModel
+	3, // 1: profile_management.models.v1.MealCreateModel.products:type_name -> profile_management.models.v1.MealProductModel
+	3, // 2: profile_management.models.v1.MealUpdateModel.products:type_name -> profile_management.models.v1.MealProductModel
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_models_meal_model_proto_init() }
@@ -269,7 +369,7 @@ func file_models_meal_model_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_models_meal_model_proto_rawDesc), len(file_models_meal_model_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -22,15 +22,17 @@ const (
 )
 
 type ProductModel struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Calories      int32                  `protobuf:"varint,4,opt,name=calories,proto3" json:"calories,omitempty"`
-	Protein       int32                  `protobuf:"varint,5,opt,name=protein,proto3" json:"protein,omitempty"`
-	Fat           int32                  `protobuf:"varint,6,opt,name=fat,proto3" json:"fat,omitempty"`
-	Carbs         int32                  `protobuf:"varint,7,opt,name=carbs,proto3" json:"carbs,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId    int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Calories  int32                  `protobuf:"varint,4,opt,name=calories,proto3" json:"calories,omitempty"`
+	Protein   int32                  `protobuf:"varint,5,opt,name=protein,proto3" json:"protein,omitempty"`
+	Fat       int32                  `protobuf:"varint,6,opt,name=fat,proto3" json:"fat,omitempty"`
+	Carbs     int32                  `protobuf:"varint,7,opt,name=carbs,proto3" json:"carbs,omitempty"`
+	CreatedAt string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// version растёт при каждом изменении, передаётся в UpdateProduct как expected_version
+	Version       int32 `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -121,6 +123,13 @@ func (x *ProductModel) GetCreatedAt() string {
 	return ""
 }
 
+func (x *ProductModel) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type ProductCreateModel struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -285,7 +294,7 @@ var File_models_product_model_proto protoreflect.FileDescriptor
 
 const file_models_product_model_proto_rawDesc = "" +
 	"\n" +
-	"\x1amodels/product_model.proto\x12\x1cprofile_management.models.v1\"\xc8\x01\n" +
+	"\x1amodels/product_model.proto\x12\x1cprofile_management.models.v1\"\xe2\x01\n" +
 	"\fProductModel\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x12\n" +
@@ -295,7 +304,8 @@ const file_models_product_model_proto_rawDesc = "" +
 	"\x03fat\x18\x06 \x01(\x05R\x03fat\x12\x14\n" +
 	"\x05carbs\x18\a \x01(\x05R\x05carbs\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\b \x01(\tR\tcreatedAt\"\x9f\x01\n" +
+	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\aversion\x18\t \x01(\x05R\aversion\"\x9f\x01\n" +
 	"\x12ProductCreateModel\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
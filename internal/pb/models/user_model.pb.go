@@ -22,16 +22,18 @@ const (
 )
 
 type UserModel struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	PasswordHash  string                 `protobuf:"bytes,3,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
-	Height        int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
-	Weight        int32                  `protobuf:"varint,5,opt,name=weight,proto3" json:"weight,omitempty"`
-	Bju           *BJUModel              `protobuf:"bytes,6,opt,name=bju,proto3" json:"bju,omitempty"`
-	Budget        int32                  `protobuf:"varint,7,opt,name=budget,proto3" json:"budget,omitempty"`
-	Preferences   string                 `protobuf:"bytes,8,opt,name=preferences,proto3" json:"preferences,omitempty"` // JSON string
-	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username     string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	PasswordHash string                 `protobuf:"bytes,3,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"`
+	Height       int32                  `protobuf:"varint,4,opt,name=height,proto3" json:"height,omitempty"`
+	Weight       int32                  `protobuf:"varint,5,opt,name=weight,proto3" json:"weight,omitempty"`
+	Bju          *BJUModel              `protobuf:"bytes,6,opt,name=bju,proto3" json:"bju,omitempty"`
+	Budget       int32                  `protobuf:"varint,7,opt,name=budget,proto3" json:"budget,omitempty"`
+	Preferences  string                 `protobuf:"bytes,8,opt,name=preferences,proto3" json:"preferences,omitempty"` // JSON string
+	CreatedAt    string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// version растёт при каждом изменении, передаётся в UpdateUser как expected_version
+	Version       int32 `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -129,6 +131,13 @@ func (x *UserModel) GetCreatedAt() string {
 	return ""
 }
 
+func (x *UserModel) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type BJUModel struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Protein       int32                  `protobuf:"varint,1,opt,name=protein,proto3" json:"protein,omitempty"`
@@ -369,7 +378,7 @@ var File_models_user_model_proto protoreflect.FileDescriptor
 
 const file_models_user_model_proto_rawDesc = "" +
 	"\n" +
-	"\x17models/user_model.proto\x12\x1cprofile_management.models.v1\"\x9f\x02\n" +
+	"\x17models/user_model.proto\x12\x1cprofile_management.models.v1\"\xb9\x02\n" +
 	"\tUserModel\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12#\n" +
@@ -380,7 +389,9 @@ const file_models_user_model_proto_rawDesc = "" +
 	"\x06budget\x18\a \x01(\x05R\x06budget\x12 \n" +
 	"\vpreferences\x18\b \x01(\tR\vpreferences\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\t \x01(\tR\tcreatedAt\"L\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x05R\aversion\"L\n" +
 	"\bBJUModel\x12\x18\n" +
 	"\aprotein\x18\x01 \x01(\x05R\aprotein\x12\x10\n" +
 	"\x03fat\x18\x02 \x01(\x05R\x03fat\x12\x14\n" +
@@ -0,0 +1,1705 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: profile_admin_api/profile_admin.proto
+
+package profile_admin_api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type MoveUserScope int32
+
+const (
+	MoveUserScope_MOVE_USER_SCOPE_UNSPECIFIED MoveUserScope = 0
+	// строка пользователя в users
+	MoveUserScope_MOVE_USER_SCOPE_PROFILE MoveUserScope = 1
+	// продукты, блюда, генерации меню, dead-letter, ключи идемпотентности и журнал аудита
+	MoveUserScope_MOVE_USER_SCOPE_DATA MoveUserScope = 2
+)
+
+// Enum value maps for MoveUserScope.
+var (
+	MoveUserScope_name = map[int32]string{
+		0: "MOVE_USER_SCOPE_UNSPECIFIED",
+		1: "MOVE_USER_SCOPE_PROFILE",
+		2: "MOVE_USER_SCOPE_DATA",
+	}
+	MoveUserScope_value = map[string]int32{
+		"MOVE_USER_SCOPE_UNSPECIFIED": 0,
+		"MOVE_USER_SCOPE_PROFILE":     1,
+		"MOVE_USER_SCOPE_DATA":        2,
+	}
+)
+
+func (x MoveUserScope) Enum() *MoveUserScope {
+	p := new(MoveUserScope)
+	*p = x
+	return p
+}
+
+func (x MoveUserScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MoveUserScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_profile_admin_api_profile_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (MoveUserScope) Type() protoreflect.EnumType {
+	return &file_profile_admin_api_profile_admin_proto_enumTypes[0]
+}
+
+func (x MoveUserScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MoveUserScope.Descriptor instead.
+func (MoveUserScope) EnumDescriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type UserPlacement struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username  string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	CreatedAt string                 `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// shard шард, на котором лежит строка пользователя
+	Shard int32 `protobuf:"varint,4,opt,name=shard,proto3" json:"shard,omitempty"`
+	// username_bucket и username_shard - куда карта бакетов направляет строку пользователя
+	UsernameBucket int32 `protobuf:"varint,5,opt,name=username_bucket,json=usernameBucket,proto3" json:"username_bucket,omitempty"`
+	UsernameShard  int32 `protobuf:"varint,6,opt,name=username_shard,json=usernameShard,proto3" json:"username_shard,omitempty"`
+	// id_bucket и data_shard - куда карта бакетов направляет продукты, блюда и остальные данные пользователя
+	IdBucket      int32 `protobuf:"varint,7,opt,name=id_bucket,json=idBucket,proto3" json:"id_bucket,omitempty"`
+	DataShard     int32 `protobuf:"varint,8,opt,name=data_shard,json=dataShard,proto3" json:"data_shard,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserPlacement) Reset() {
+	*x = UserPlacement{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPlacement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPlacement) ProtoMessage() {}
+
+func (x *UserPlacement) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPlacement.ProtoReflect.Descriptor instead.
+func (*UserPlacement) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserPlacement) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserPlacement) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserPlacement) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *UserPlacement) GetShard() int32 {
+	if x != nil {
+		return x.Shard
+	}
+	return 0
+}
+
+func (x *UserPlacement) GetUsernameBucket() int32 {
+	if x != nil {
+		return x.UsernameBucket
+	}
+	return 0
+}
+
+func (x *UserPlacement) GetUsernameShard() int32 {
+	if x != nil {
+		return x.UsernameShard
+	}
+	return 0
+}
+
+func (x *UserPlacement) GetIdBucket() int32 {
+	if x != nil {
+		return x.IdBucket
+	}
+	return 0
+}
+
+func (x *UserPlacement) GetDataShard() int32 {
+	if x != nil {
+		return x.DataShard
+	}
+	return 0
+}
+
+type SearchUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UsernamePrefix string                 `protobuf:"bytes,1,opt,name=username_prefix,json=usernamePrefix,proto3" json:"username_prefix,omitempty"`
+	// limit по умолчанию 50, не больше 1000
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersRequest) Reset() {
+	*x = SearchUsersRequest{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersRequest) ProtoMessage() {}
+
+func (x *SearchUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersRequest.ProtoReflect.Descriptor instead.
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchUsersRequest) GetUsernamePrefix() string {
+	if x != nil {
+		return x.UsernamePrefix
+	}
+	return ""
+}
+
+func (x *SearchUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserPlacement       `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchUsersResponse) Reset() {
+	*x = SearchUsersResponse{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchUsersResponse) ProtoMessage() {}
+
+func (x *SearchUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchUsersResponse.ProtoReflect.Descriptor instead.
+func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchUsersResponse) GetUsers() []*UserPlacement {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GetUserPlacementRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to User:
+	//
+	//	*GetUserPlacementRequest_UserId
+	//	*GetUserPlacementRequest_Username
+	User          isGetUserPlacementRequest_User `protobuf_oneof:"user"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserPlacementRequest) Reset() {
+	*x = GetUserPlacementRequest{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserPlacementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserPlacementRequest) ProtoMessage() {}
+
+func (x *GetUserPlacementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserPlacementRequest.ProtoReflect.Descriptor instead.
+func (*GetUserPlacementRequest) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetUserPlacementRequest) GetUser() isGetUserPlacementRequest_User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetUserPlacementRequest) GetUserId() int32 {
+	if x != nil {
+		if x, ok := x.User.(*GetUserPlacementRequest_UserId); ok {
+			return x.UserId
+		}
+	}
+	return 0
+}
+
+func (x *GetUserPlacementRequest) GetUsername() string {
+	if x != nil {
+		if x, ok := x.User.(*GetUserPlacementRequest_Username); ok {
+			return x.Username
+		}
+	}
+	return ""
+}
+
+type isGetUserPlacementRequest_User interface {
+	isGetUserPlacementRequest_User()
+}
+
+type GetUserPlacementRequest_UserId struct {
+	UserId int32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3,oneof"`
+}
+
+type GetUserPlacementRequest_Username struct {
+	Username string `protobuf:"bytes,2,opt,name=username,proto3,oneof"`
+}
+
+func (*GetUserPlacementRequest_UserId) isGetUserPlacementRequest_User() {}
+
+func (*GetUserPlacementRequest_Username) isGetUserPlacementRequest_User() {}
+
+type GetUserPlacementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Placement     *UserPlacement         `protobuf:"bytes,1,opt,name=placement,proto3" json:"placement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserPlacementResponse) Reset() {
+	*x = GetUserPlacementResponse{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserPlacementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserPlacementResponse) ProtoMessage() {}
+
+func (x *GetUserPlacementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserPlacementResponse.ProtoReflect.Descriptor instead.
+func (*GetUserPlacementResponse) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetUserPlacementResponse) GetPlacement() *UserPlacement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+type MoveUserRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Scope     MoveUserScope          `protobuf:"varint,2,opt,name=scope,proto3,enum=profile_management.admin.v1.MoveUserScope" json:"scope,omitempty"`
+	FromShard int32                  `protobuf:"varint,3,opt,name=from_shard,json=fromShard,proto3" json:"from_shard,omitempty"`
+	ToShard   int32                  `protobuf:"varint,4,opt,name=to_shard,json=toShard,proto3" json:"to_shard,omitempty"`
+	// force разрешает перенос строки пользователя на шард, который не совпадает с шардом по карте бакетов.
+	// Для MOVE_USER_SCOPE_DATA force не допускается: данные читаются только с шарда по карте бакетов
+	Force         bool `protobuf:"varint,5,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveUserRequest) Reset() {
+	*x = MoveUserRequest{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveUserRequest) ProtoMessage() {}
+
+func (x *MoveUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveUserRequest.ProtoReflect.Descriptor instead.
+func (*MoveUserRequest) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MoveUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *MoveUserRequest) GetScope() MoveUserScope {
+	if x != nil {
+		return x.Scope
+	}
+	return MoveUserScope_MOVE_USER_SCOPE_UNSPECIFIED
+}
+
+func (x *MoveUserRequest) GetFromShard() int32 {
+	if x != nil {
+		return x.FromShard
+	}
+	return 0
+}
+
+func (x *MoveUserRequest) GetToShard() int32 {
+	if x != nil {
+		return x.ToShard
+	}
+	return 0
+}
+
+func (x *MoveUserRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type MoveUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RowsMoved     int64                  `protobuf:"varint,1,opt,name=rows_moved,json=rowsMoved,proto3" json:"rows_moved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveUserResponse) Reset() {
+	*x = MoveUserResponse{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveUserResponse) ProtoMessage() {}
+
+func (x *MoveUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveUserResponse.ProtoReflect.Descriptor instead.
+func (*MoveUserResponse) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MoveUserResponse) GetRowsMoved() int64 {
+	if x != nil {
+		return x.RowsMoved
+	}
+	return 0
+}
+
+type ListShardStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShardStatsRequest) Reset() {
+	*x = ListShardStatsRequest{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShardStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShardStatsRequest) ProtoMessage() {}
+
+func (x *ListShardStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShardStatsRequest.ProtoReflect.Descriptor instead.
+func (*ListShardStatsRequest) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{7}
+}
+
+type TableRows struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         string                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	Rows          int64                  `protobuf:"varint,2,opt,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableRows) Reset() {
+	*x = TableRows{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableRows) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableRows) ProtoMessage() {}
+
+func (x *TableRows) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TableRows.ProtoReflect.Descriptor instead.
+func (*TableRows) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TableRows) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *TableRows) GetRows() int64 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+type ShardStats struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Shard   int32                  `protobuf:"varint,1,opt,name=shard,proto3" json:"shard,omitempty"`
+	Buckets []int32                `protobuf:"varint,2,rep,packed,name=buckets,proto3" json:"buckets,omitempty"`
+	Tables  []*TableRows           `protobuf:"bytes,3,rep,name=tables,proto3" json:"tables,omitempty"`
+	// error ошибка подсчёта строк, если шард не ответил
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// primary_state и replica_states - состояние автоматов отключения: closed, open или half_open
+	PrimaryState  string   `protobuf:"bytes,5,opt,name=primary_state,json=primaryState,proto3" json:"primary_state,omitempty"`
+	ReplicaStates []string `protobuf:"bytes,6,rep,name=replica_states,json=replicaStates,proto3" json:"replica_states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShardStats) Reset() {
+	*x = ShardStats{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShardStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardStats) ProtoMessage() {}
+
+func (x *ShardStats) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardStats.ProtoReflect.Descriptor instead.
+func (*ShardStats) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ShardStats) GetShard() int32 {
+	if x != nil {
+		return x.Shard
+	}
+	return 0
+}
+
+func (x *ShardStats) GetBuckets() []int32 {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *ShardStats) GetTables() []*TableRows {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *ShardStats) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ShardStats) GetPrimaryState() string {
+	if x != nil {
+		return x.PrimaryState
+	}
+	return ""
+}
+
+func (x *ShardStats) GetReplicaStates() []string {
+	if x != nil {
+		return x.ReplicaStates
+	}
+	return nil
+}
+
+type ListShardStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BucketCount   int32                  `protobuf:"varint,1,opt,name=bucket_count,json=bucketCount,proto3" json:"bucket_count,omitempty"`
+	Shards        []*ShardStats          `protobuf:"bytes,2,rep,name=shards,proto3" json:"shards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShardStatsResponse) Reset() {
+	*x = ListShardStatsResponse{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShardStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShardStatsResponse) ProtoMessage() {}
+
+func (x *ListShardStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShardStatsResponse.ProtoReflect.Descriptor instead.
+func (*ListShardStatsResponse) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListShardStatsResponse) GetBucketCount() int32 {
+	if x != nil {
+		return x.BucketCount
+	}
+	return 0
+}
+
+func (x *ListShardStatsResponse) GetShards() []*ShardStats {
+	if x != nil {
+		return x.Shards
+	}
+	return nil
+}
+
+// Dead-letter messages
+type DeadLetterHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetterHeader) Reset() {
+	*x = DeadLetterHeader{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetterHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterHeader) ProtoMessage() {}
+
+func (x *DeadLetterHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterHeader.ProtoReflect.Descriptor instead.
+func (*DeadLetterHeader) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeadLetterHeader) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DeadLetterHeader) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type DeadLetter struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId  int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Topic   string                 `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	Key     []byte                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Value   []byte                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
+	Headers []*DeadLetterHeader    `protobuf:"bytes,6,rep,name=headers,proto3" json:"headers,omitempty"`
+	// error ошибка последней попытки публикации
+	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts      int32  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	CreatedAt     string `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeadLetter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetHeaders() []*DeadLetterHeader {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListDeadLettersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// topic пустой - все топики
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// limit по умолчанию 50, не больше 500
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListDeadLettersRequest) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+type ReplayDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayDeadLetterRequest) Reset() {
+	*x = ReplayDeadLetterRequest{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLetterRequest) ProtoMessage() {}
+
+func (x *ReplayDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReplayDeadLetterRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ReplayDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayDeadLetterResponse) Reset() {
+	*x = ReplayDeadLetterResponse{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLetterResponse) ProtoMessage() {}
+
+func (x *ReplayDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{16}
+}
+
+type DiscardDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardDeadLetterRequest) Reset() {
+	*x = DiscardDeadLetterRequest{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardDeadLetterRequest) ProtoMessage() {}
+
+func (x *DiscardDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*DiscardDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DiscardDeadLetterRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DiscardDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardDeadLetterResponse) Reset() {
+	*x = DiscardDeadLetterResponse{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardDeadLetterResponse) ProtoMessage() {}
+
+func (x *DiscardDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*DiscardDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{18}
+}
+
+// Audit messages
+type AuditChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Field string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// before и after значения поля в JSON; пустые, если поля не было или оно удалено
+	Before        string `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         string `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditChange) Reset() {
+	*x = AuditChange{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditChange) ProtoMessage() {}
+
+func (x *AuditChange) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditChange.ProtoReflect.Descriptor instead.
+func (*AuditChange) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AuditChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AuditChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AuditChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+type AuditEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// actor автор изменения: admin для вызовов ProfileAdminService, admin-cli для команд, system для фоновых
+	// изменений, иначе значение x-actor от клиента
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// action create, update, delete, restore, request, complete, replay или discard
+	Action string `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	// entity_type user, product, meal, menu_generation или dead_letter
+	EntityType string         `protobuf:"bytes,5,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId   string         `protobuf:"bytes,6,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	Changes    []*AuditChange `protobuf:"bytes,7,rep,name=changes,proto3" json:"changes,omitempty"`
+	RequestId  string         `protobuf:"bytes,8,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	CreatedAt  string         `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// actor_verified false, если actor взят из x-actor и не подтверждён аутентификацией
+	ActorVerified bool `protobuf:"varint,10,opt,name=actor_verified,json=actorVerified,proto3" json:"actor_verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetChanges() []*AuditChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetActorVerified() bool {
+	if x != nil {
+		return x.ActorVerified
+	}
+	return false
+}
+
+type ListAuditEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Пустые поля не ограничивают выборку
+	UserId     int32  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	EntityType string `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId   string `protobuf:"bytes,3,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// from и to границы периода в RFC3339, from включительно
+	From string `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	// limit по умолчанию 50, не больше 500
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListAuditEventsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetEntityId() string {
+	if x != nil {
+		return x.EntityId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_admin_api_profile_admin_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_profile_admin_api_profile_admin_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+var File_profile_admin_api_profile_admin_proto protoreflect.FileDescriptor
+
+const file_profile_admin_api_profile_admin_proto_rawDesc = "" +
+	"\n" +
+	"%profile_admin_api/profile_admin.proto\x12\x1bprofile_management.admin.v1\"\x85\x02\n" +
+	"\rUserPlacement\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\tR\tcreatedAt\x12\x14\n" +
+	"\x05shard\x18\x04 \x01(\x05R\x05shard\x12'\n" +
+	"\x0fusername_bucket\x18\x05 \x01(\x05R\x0eusernameBucket\x12%\n" +
+	"\x0eusername_shard\x18\x06 \x01(\x05R\rusernameShard\x12\x1b\n" +
+	"\tid_bucket\x18\a \x01(\x05R\bidBucket\x12\x1d\n" +
+	"\n" +
+	"data_shard\x18\b \x01(\x05R\tdataShard\"S\n" +
+	"\x12SearchUsersRequest\x12'\n" +
+	"\x0fusername_prefix\x18\x01 \x01(\tR\x0eusernamePrefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"W\n" +
+	"\x13SearchUsersResponse\x12@\n" +
+	"\x05users\x18\x01 \x03(\v2*.profile_management.admin.v1.UserPlacementR\x05users\"Z\n" +
+	"\x17GetUserPlacementRequest\x12\x19\n" +
+	"\auser_id\x18\x01 \x01(\x05H\x00R\x06userId\x12\x1c\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busernameB\x06\n" +
+	"\x04user\"d\n" +
+	"\x18GetUserPlacementResponse\x12H\n" +
+	"\tplacement\x18\x01 \x01(\v2*.profile_management.admin.v1.UserPlacementR\tplacement\"\xbc\x01\n" +
+	"\x0fMoveUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12@\n" +
+	"\x05scope\x18\x02 \x01(\x0e2*.profile_management.admin.v1.MoveUserScopeR\x05scope\x12\x1d\n" +
+	"\n" +
+	"from_shard\x18\x03 \x01(\x05R\tfromShard\x12\x19\n" +
+	"\bto_shard\x18\x04 \x01(\x05R\atoShard\x12\x14\n" +
+	"\x05force\x18\x05 \x01(\bR\x05force\"1\n" +
+	"\x10MoveUserResponse\x12\x1d\n" +
+	"\n" +
+	"rows_moved\x18\x01 \x01(\x03R\trowsMoved\"\x17\n" +
+	"\x15ListShardStatsRequest\"5\n" +
+	"\tTableRows\x12\x14\n" +
+	"\x05table\x18\x01 \x01(\tR\x05table\x12\x12\n" +
+	"\x04rows\x18\x02 \x01(\x03R\x04rows\"\xde\x01\n" +
+	"\n" +
+	"ShardStats\x12\x14\n" +
+	"\x05shard\x18\x01 \x01(\x05R\x05shard\x12\x18\n" +
+	"\abuckets\x18\x02 \x03(\x05R\abuckets\x12>\n" +
+	"\x06tables\x18\x03 \x03(\v2&.profile_management.admin.v1.TableRowsR\x06tables\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12#\n" +
+	"\rprimary_state\x18\x05 \x01(\tR\fprimaryState\x12%\n" +
+	"\x0ereplica_states\x18\x06 \x03(\tR\rreplicaStates\"|\n" +
+	"\x16ListShardStatsResponse\x12!\n" +
+	"\fbucket_count\x18\x01 \x01(\x05R\vbucketCount\x12?\n" +
+	"\x06shards\x18\x02 \x03(\v2'.profile_management.admin.v1.ShardStatsR\x06shards\":\n" +
+	"\x10DeadLetterHeader\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\"\x8d\x02\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05topic\x18\x03 \x01(\tR\x05topic\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\fR\x03key\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\fR\x05value\x12G\n" +
+	"\aheaders\x18\x06 \x03(\v2-.profile_management.admin.v1.DeadLetterHeaderR\aheaders\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1a\n" +
+	"\battempts\x18\b \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\"D\n" +
+	"\x16ListDeadLettersRequest\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"e\n" +
+	"\x17ListDeadLettersResponse\x12J\n" +
+	"\fdead_letters\x18\x01 \x03(\v2'.profile_management.admin.v1.DeadLetterR\vdeadLetters\")\n" +
+	"\x17ReplayDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1a\n" +
+	"\x18ReplayDeadLetterResponse\"*\n" +
+	"\x18DiscardDeadLetterRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1b\n" +
+	"\x19DiscardDeadLetterResponse\"Q\n" +
+	"\vAuditChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\"\xca\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1f\n" +
+	"\ventity_type\x18\x05 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x06 \x01(\tR\bentityId\x12B\n" +
+	"\achanges\x18\a \x03(\v2(.profile_management.admin.v1.AuditChangeR\achanges\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\b \x01(\tR\trequestId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12%\n" +
+	"\x0eactor_verified\x18\n" +
+	" \x01(\bR\ractorVerified\"\xa9\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1f\n" +
+	"\ventity_type\x18\x02 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x03 \x01(\tR\bentityId\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"Z\n" +
+	"\x17ListAuditEventsResponse\x12?\n" +
+	"\x06events\x18\x01 \x03(\v2'.profile_management.admin.v1.AuditEventR\x06events*g\n" +
+	"\rMoveUserScope\x12\x1f\n" +
+	"\x1bMOVE_USER_SCOPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17MOVE_USER_SCOPE_PROFILE\x10\x01\x12\x18\n" +
+	"\x14MOVE_USER_SCOPE_DATA\x10\x022\xee\a\n" +
+	"\x13ProfileAdminService\x12p\n" +
+	"\vSearchUsers\x12/.profile_management.admin.v1.SearchUsersRequest\x1a0.profile_management.admin.v1.SearchUsersResponse\x12\x7f\n" +
+	"\x10GetUserPlacement\x124.profile_management.admin.v1.GetUserPlacementRequest\x1a5.profile_management.admin.v1.GetUserPlacementResponse\x12g\n" +
+	"\bMoveUser\x12,.profile_management.admin.v1.MoveUserRequest\x1a-.profile_management.admin.v1.MoveUserResponse\x12y\n" +
+	"\x0eListShardStats\x122.profile_management.admin.v1.ListShardStatsRequest\x1a3.profile_management.admin.v1.ListShardStatsResponse\x12|\n" +
+	"\x0fListDeadLetters\x123.profile_management.admin.v1.ListDeadLettersRequest\x1a4.profile_management.admin.v1.ListDeadLettersResponse\x12\x7f\n" +
+	"\x10ReplayDeadLetter\x124.profile_management.admin.v1.ReplayDeadLetterRequest\x1a5.profile_management.admin.v1.ReplayDeadLetterResponse\x12\x82\x01\n" +
+	"\x11DiscardDeadLetter\x125.profile_management.admin.v1.DiscardDeadLetterRequest\x1a6.profile_management.admin.v1.DiscardDeadLetterResponse\x12|\n" +
+	"\x0fListAuditEvents\x123.profile_management.admin.v1.ListAuditEventsRequest\x1a4.profile_management.admin.v1.ListAuditEventsResponseBdZbgithub.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_admin_apib\x06proto3"
+
+var (
+	file_profile_admin_api_profile_admin_proto_rawDescOnce sync.Once
+	file_profile_admin_api_profile_admin_proto_rawDescData []byte
+)
+
+func file_profile_admin_api_profile_admin_proto_rawDescGZIP() []byte {
+	file_profile_admin_api_profile_admin_proto_rawDescOnce.Do(func() {
+		file_profile_admin_api_profile_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_profile_admin_api_profile_admin_proto_rawDesc), len(file_profile_admin_api_profile_admin_proto_rawDesc)))
+	})
+	return file_profile_admin_api_profile_admin_proto_rawDescData
+}
+
+var file_profile_admin_api_profile_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_profile_admin_api_profile_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_profile_admin_api_profile_admin_proto_goTypes = []any{
+	(MoveUserScope)(0),                // 0: profile_management.admin.v1.MoveUserScope
+	(*UserPlacement)(nil),             // 1: profile_management.admin.v1.UserPlacement
+	(*SearchUsersRequest)(nil),        // 2: profile_management.admin.v1.SearchUsersRequest
+	(*SearchUsersResponse)(nil),       // 3: profile_management.admin.v1.SearchUsersResponse
+	(*GetUserPlacementRequest)(nil),   // 4: profile_management.admin.v1.GetUserPlacementRequest
+	(*GetUserPlacementResponse)(nil),  // 5: profile_management.admin.v1.GetUserPlacementResponse
+	(*MoveUserRequest)(nil),           // 6: profile_management.admin.v1.MoveUserRequest
+	(*MoveUserResponse)(nil),          // 7: profile_management.admin.v1.MoveUserResponse
+	(*ListShardStatsRequest)(nil),     // 8: profile_management.admin.v1.ListShardStatsRequest
+	(*TableRows)(nil),                 // 9: profile_management.admin.v1.TableRows
+	(*ShardStats)(nil),                // 10: profile_management.admin.v1.ShardStats
+	(*ListShardStatsResponse)(nil),    // 11: profile_management.admin.v1.ListShardStatsResponse
+	(*DeadLetterHeader)(nil),          // 12: profile_management.admin.v1.DeadLetterHeader
+	(*DeadLetter)(nil),                // 13: profile_management.admin.v1.DeadLetter
+	(*ListDeadLettersRequest)(nil),    // 14: profile_management.admin.v1.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),   // 15: profile_management.admin.v1.ListDeadLettersResponse
+	(*ReplayDeadLetterRequest)(nil),   // 16: profile_management.admin.v1.ReplayDeadLetterRequest
+	(*ReplayDeadLetterResponse)(nil),  // 17: profile_management.admin.v1.ReplayDeadLetterResponse
+	(*DiscardDeadLetterRequest)(nil),  // 18: profile_management.admin.v1.DiscardDeadLetterRequest
+	(*DiscardDeadLetterResponse)(nil), // 19: profile_management.admin.v1.DiscardDeadLetterResponse
+	(*AuditChange)(nil),               // 20: profile_management.admin.v1.AuditChange
+	(*AuditEvent)(nil),                // 21: profile_management.admin.v1.AuditEvent
+	(*ListAuditEventsRequest)(nil),    // 22: profile_management.admin.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),   // 23: profile_management.admin.v1.ListAuditEventsResponse
+}
+var file_profile_admin_api_profile_admin_proto_depIdxs = []int32{
+	1,  // 0: profile_management.admin.v1.SearchUsersResponse.users:type_name -> profile_management.admin.v1.UserPlacement
+	1,  // 1: profile_management.admin.v1.GetUserPlacementResponse.placement:type_name -> profile_management.admin.v1.UserPlacement
+	0,  // 2: profile_management.admin.v1.MoveUserRequest.scope:type_name -> profile_management.admin.v1.MoveUserScope
+	9,  // 3: profile_management.admin.v1.ShardStats.tables:type_name -> profile_management.admin.v1.TableRows
+	10, // 4: profile_management.admin.v1.ListShardStatsResponse.shards:type_name -> profile_management.admin.v1.ShardStats
+	12, // 5: profile_management.admin.v1.DeadLetter.headers:type_name -> profile_management.admin.v1.DeadLetterHeader
+	13, // 6: profile_management.admin.v1.ListDeadLettersResponse.dead_letters:type_name -> profile_management.admin.v1.DeadLetter
+	20, // 7: profile_management.admin.v1.AuditEvent.changes:type_name -> profile_management.admin.v1.AuditChange
+	21, // 8: profile_management.admin.v1.ListAuditEventsResponse.events:type_name -> profile_management.admin.v1.AuditEvent
+	2,  // 9: profile_management.admin.v1.ProfileAdminService.SearchUsers:input_type -> profile_management.admin.v1.SearchUsersRequest
+	4,  // 10: profile_management.admin.v1.ProfileAdminService.GetUserPlacement:input_type -> profile_management.admin.v1.GetUserPlacementRequest
+	6,  // 11: profile_management.admin.v1.ProfileAdminService.MoveUser:input_type -> profile_management.admin.v1.MoveUserRequest
+	8,  // 12: profile_management.admin.v1.ProfileAdminService.ListShardStats:input_type -> profile_management.admin.v1.ListShardStatsRequest
+	14, // 13: profile_management.admin.v1.ProfileAdminService.ListDeadLetters:input_type -> profile_management.admin.v1.ListDeadLettersRequest
+	16, // 14: profile_management.admin.v1.ProfileAdminService.ReplayDeadLetter:input_type -> profile_management.admin.v1.ReplayDeadLetterRequest
+	18, // 15: profile_management.admin.v1.ProfileAdminService.DiscardDeadLetter:input_type -> profile_management.admin.v1.DiscardDeadLetterRequest
+	22, // 16: profile_management.admin.v1.ProfileAdminService.ListAuditEvents:input_type -> profile_management.admin.v1.ListAuditEventsRequest
+	3,  // 17: profile_management.admin.v1.ProfileAdminService.SearchUsers:output_type -> profile_management.admin.v1.SearchUsersResponse
+	5,  // 18: profile_management.admin.v1.ProfileAdminService.GetUserPlacement:output_type -> profile_management.admin.v1.GetUserPlacementResponse
+	7,  // 19: profile_management.admin.v1.ProfileAdminService.MoveUser:output_type -> profile_management.admin.v1.MoveUserResponse
+	11, // 20: profile_management.admin.v1.ProfileAdminService.ListShardStats:output_type -> profile_management.admin.v1.ListShardStatsResponse
+	15, // 21: profile_management.admin.v1.ProfileAdminService.ListDeadLetters:output_type -> profile_management.admin.v1.ListDeadLettersResponse
+	17, // 22: profile_management.admin.v1.ProfileAdminService.ReplayDeadLetter:output_type -> profile_management.admin.v1.ReplayDeadLetterResponse
+	19, // 23: profile_management.admin.v1.ProfileAdminService.DiscardDeadLetter:output_type -> profile_management.admin.v1.DiscardDeadLetterResponse
+	23, // 24: profile_management.admin.v1.ProfileAdminService.ListAuditEvents:output_type -> profile_management.admin.v1.ListAuditEventsResponse
+	17, // [17:25] is the sub-list for method output_type
+	9,  // [9:17] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_profile_admin_api_profile_admin_proto_init() }
+func file_profile_admin_api_profile_admin_proto_init() {
+	if File_profile_admin_api_profile_admin_proto != nil {
+		return
+	}
+	file_profile_admin_api_profile_admin_proto_msgTypes[3].OneofWrappers = []any{
+		(*GetUserPlacementRequest_UserId)(nil),
+		(*GetUserPlacementRequest_Username)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_profile_admin_api_profile_admin_proto_rawDesc), len(file_profile_admin_api_profile_admin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_profile_admin_api_profile_admin_proto_goTypes,
+		DependencyIndexes: file_profile_admin_api_profile_admin_proto_depIdxs,
+		EnumInfos:         file_profile_admin_api_profile_admin_proto_enumTypes,
+		MessageInfos:      file_profile_admin_api_profile_admin_proto_msgTypes,
+	}.Build()
+	File_profile_admin_api_profile_admin_proto = out.File
+	file_profile_admin_api_profile_admin_proto_goTypes = nil
+	file_profile_admin_api_profile_admin_proto_depIdxs = nil
+}
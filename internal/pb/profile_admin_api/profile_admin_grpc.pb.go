@@ -0,0 +1,403 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.2
+// source: profile_admin_api/profile_admin.proto
+
+package profile_admin_api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ProfileAdminService_SearchUsers_FullMethodName       = "/profile_management.admin.v1.ProfileAdminService/SearchUsers"
+	ProfileAdminService_GetUserPlacement_FullMethodName  = "/profile_management.admin.v1.ProfileAdminService/GetUserPlacement"
+	ProfileAdminService_MoveUser_FullMethodName          = "/profile_management.admin.v1.ProfileAdminService/MoveUser"
+	ProfileAdminService_ListShardStats_FullMethodName    = "/profile_management.admin.v1.ProfileAdminService/ListShardStats"
+	ProfileAdminService_ListDeadLetters_FullMethodName   = "/profile_management.admin.v1.ProfileAdminService/ListDeadLetters"
+	ProfileAdminService_ReplayDeadLetter_FullMethodName  = "/profile_management.admin.v1.ProfileAdminService/ReplayDeadLetter"
+	ProfileAdminService_DiscardDeadLetter_FullMethodName = "/profile_management.admin.v1.ProfileAdminService/DiscardDeadLetter"
+	ProfileAdminService_ListAuditEvents_FullMethodName   = "/profile_management.admin.v1.ProfileAdminService/ListAuditEvents"
+)
+
+// ProfileAdminServiceClient is the client API for ProfileAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Операции администратора над шардами; доступны только по токену admin.token
+type ProfileAdminServiceClient interface {
+	// SearchUsers ищет пользователей по началу username на всех шардах
+	SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error)
+	// GetUserPlacement показывает шард и бакеты пользователя
+	GetUserPlacement(ctx context.Context, in *GetUserPlacementRequest, opts ...grpc.CallOption) (*GetUserPlacementResponse, error)
+	// MoveUser переносит строку пользователя или его данные на другой шард
+	MoveUser(ctx context.Context, in *MoveUserRequest, opts ...grpc.CallOption) (*MoveUserResponse, error)
+	// ListShardStats бакеты, состояние пулов и число строк в таблицах каждого шарда
+	ListShardStats(ctx context.Context, in *ListShardStatsRequest, opts ...grpc.CallOption) (*ListShardStatsResponse, error)
+	// Dead-letter: сообщения Kafka, не опубликованные после всех попыток
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	ReplayDeadLetter(ctx context.Context, in *ReplayDeadLetterRequest, opts ...grpc.CallOption) (*ReplayDeadLetterResponse, error)
+	DiscardDeadLetter(ctx context.Context, in *DiscardDeadLetterRequest, opts ...grpc.CallOption) (*DiscardDeadLetterResponse, error)
+	// Журнал аудита изменений профиля
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
+}
+
+type profileAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProfileAdminServiceClient(cc grpc.ClientConnInterface) ProfileAdminServiceClient {
+	return &profileAdminServiceClient{cc}
+}
+
+func (c *profileAdminServiceClient) SearchUsers(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchUsersResponse)
+	err := c.cc.Invoke(ctx, ProfileAdminService_SearchUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileAdminServiceClient) GetUserPlacement(ctx context.Context, in *GetUserPlacementRequest, opts ...grpc.CallOption) (*GetUserPlacementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserPlacementResponse)
+	err := c.cc.Invoke(ctx, ProfileAdminService_GetUserPlacement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileAdminServiceClient) MoveUser(ctx context.Context, in *MoveUserRequest, opts ...grpc.CallOption) (*MoveUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveUserResponse)
+	err := c.cc.Invoke(ctx, ProfileAdminService_MoveUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileAdminServiceClient) ListShardStats(ctx context.Context, in *ListShardStatsRequest, opts ...grpc.CallOption) (*ListShardStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShardStatsResponse)
+	err := c.cc.Invoke(ctx, ProfileAdminService_ListShardStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileAdminServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, ProfileAdminService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileAdminServiceClient) ReplayDeadLetter(ctx context.Context, in *ReplayDeadLetterRequest, opts ...grpc.CallOption) (*ReplayDeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayDeadLetterResponse)
+	err := c.cc.Invoke(ctx, ProfileAdminService_ReplayDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileAdminServiceClient) DiscardDeadLetter(ctx context.Context, in *DiscardDeadLetterRequest, opts ...grpc.CallOption) (*DiscardDeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscardDeadLetterResponse)
+	err := c.cc.Invoke(ctx, ProfileAdminService_DiscardDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileAdminServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, ProfileAdminService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProfileAdminServiceServer is the server API for ProfileAdminService service.
+// All implementations must embed UnimplementedProfileAdminServiceServer
+// for forward compatibility.
+//
+// Операции администратора над шардами; доступны только по токену admin.token
+type ProfileAdminServiceServer interface {
+	// SearchUsers ищет пользователей по началу username на всех шардах
+	SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error)
+	// GetUserPlacement показывает шард и бакеты пользователя
+	GetUserPlacement(context.Context, *GetUserPlacementRequest) (*GetUserPlacementResponse, error)
+	// MoveUser переносит строку пользователя или его данные на другой шард
+	MoveUser(context.Context, *MoveUserRequest) (*MoveUserResponse, error)
+	// ListShardStats бакеты, состояние пулов и число строк в таблицах каждого шарда
+	ListShardStats(context.Context, *ListShardStatsRequest) (*ListShardStatsResponse, error)
+	// Dead-letter: сообщения Kafka, не опубликованные после всех попыток
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	ReplayDeadLetter(context.Context, *ReplayDeadLetterRequest) (*ReplayDeadLetterResponse, error)
+	DiscardDeadLetter(context.Context, *DiscardDeadLetterRequest) (*DiscardDeadLetterResponse, error)
+	// Журнал аудита изменений профиля
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	mustEmbedUnimplementedProfileAdminServiceServer()
+}
+
+// UnimplementedProfileAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedProfileAdminServiceServer struct{}
+
+func (UnimplementedProfileAdminServiceServer) SearchUsers(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchUsers not implemented")
+}
+func (UnimplementedProfileAdminServiceServer) GetUserPlacement(context.Context, *GetUserPlacementRequest) (*GetUserPlacementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUserPlacement not implemented")
+}
+func (UnimplementedProfileAdminServiceServer) MoveUser(context.Context, *MoveUserRequest) (*MoveUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method MoveUser not implemented")
+}
+func (UnimplementedProfileAdminServiceServer) ListShardStats(context.Context, *ListShardStatsRequest) (*ListShardStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListShardStats not implemented")
+}
+func (UnimplementedProfileAdminServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedProfileAdminServiceServer) ReplayDeadLetter(context.Context, *ReplayDeadLetterRequest) (*ReplayDeadLetterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplayDeadLetter not implemented")
+}
+func (UnimplementedProfileAdminServiceServer) DiscardDeadLetter(context.Context, *DiscardDeadLetterRequest) (*DiscardDeadLetterResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiscardDeadLetter not implemented")
+}
+func (UnimplementedProfileAdminServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedProfileAdminServiceServer) mustEmbedUnimplementedProfileAdminServiceServer() {}
+func (UnimplementedProfileAdminServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeProfileAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProfileAdminServiceServer will
+// result in compilation errors.
+type UnsafeProfileAdminServiceServer interface {
+	mustEmbedUnimplementedProfileAdminServiceServer()
+}
+
+func RegisterProfileAdminServiceServer(s grpc.ServiceRegistrar, srv ProfileAdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedProfileAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ProfileAdminService_ServiceDesc, srv)
+}
+
+func _ProfileAdminService_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileAdminServiceServer).SearchUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileAdminService_SearchUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileAdminServiceServer).SearchUsers(ctx, req.(*SearchUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileAdminService_GetUserPlacement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserPlacementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileAdminServiceServer).GetUserPlacement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileAdminService_GetUserPlacement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileAdminServiceServer).GetUserPlacement(ctx, req.(*GetUserPlacementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileAdminService_MoveUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileAdminServiceServer).MoveUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileAdminService_MoveUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileAdminServiceServer).MoveUser(ctx, req.(*MoveUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileAdminService_ListShardStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShardStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileAdminServiceServer).ListShardStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileAdminService_ListShardStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileAdminServiceServer).ListShardStats(ctx, req.(*ListShardStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileAdminService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileAdminServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileAdminService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileAdminServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileAdminService_ReplayDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileAdminServiceServer).ReplayDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileAdminService_ReplayDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileAdminServiceServer).ReplayDeadLetter(ctx, req.(*ReplayDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileAdminService_DiscardDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileAdminServiceServer).DiscardDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileAdminService_DiscardDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileAdminServiceServer).DiscardDeadLetter(ctx, req.(*DiscardDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileAdminService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileAdminServiceServer).ListAuditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileAdminService_ListAuditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileAdminServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ProfileAdminService_ServiceDesc is the grpc.ServiceDesc for ProfileAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ProfileAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "profile_management.admin.v1.ProfileAdminService",
+	HandlerType: (*ProfileAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchUsers",
+			Handler:    _ProfileAdminService_SearchUsers_Handler,
+		},
+		{
+			MethodName: "GetUserPlacement",
+			Handler:    _ProfileAdminService_GetUserPlacement_Handler,
+		},
+		{
+			MethodName: "MoveUser",
+			Handler:    _ProfileAdminService_MoveUser_Handler,
+		},
+		{
+			MethodName: "ListShardStats",
+			Handler:    _ProfileAdminService_ListShardStats_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _ProfileAdminService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetter",
+			Handler:    _ProfileAdminService_ReplayDeadLetter_Handler,
+		},
+		{
+			MethodName: "DiscardDeadLetter",
+			Handler:    _ProfileAdminService_DiscardDeadLetter_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _ProfileAdminService_ListAuditEvents_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "profile_admin_api/profile_admin.proto",
+}
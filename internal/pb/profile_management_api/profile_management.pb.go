@@ -23,6 +23,227 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type UserDataArchiveFormat int32
+
+const (
+	UserDataArchiveFormat_USER_DATA_ARCHIVE_FORMAT_UNSPECIFIED UserDataArchiveFormat = 0
+	UserDataArchiveFormat_USER_DATA_ARCHIVE_FORMAT_JSON        UserDataArchiveFormat = 1
+	UserDataArchiveFormat_USER_DATA_ARCHIVE_FORMAT_ZIP         UserDataArchiveFormat = 2
+)
+
+// Enum value maps for UserDataArchiveFormat.
+var (
+	UserDataArchiveFormat_name = map[int32]string{
+		0: "USER_DATA_ARCHIVE_FORMAT_UNSPECIFIED",
+		1: "USER_DATA_ARCHIVE_FORMAT_JSON",
+		2: "USER_DATA_ARCHIVE_FORMAT_ZIP",
+	}
+	UserDataArchiveFormat_value = map[string]int32{
+		"USER_DATA_ARCHIVE_FORMAT_UNSPECIFIED": 0,
+		"USER_DATA_ARCHIVE_FORMAT_JSON":        1,
+		"USER_DATA_ARCHIVE_FORMAT_ZIP":         2,
+	}
+)
+
+func (x UserDataArchiveFormat) Enum() *UserDataArchiveFormat {
+	p := new(UserDataArchiveFormat)
+	*p = x
+	return p
+}
+
+func (x UserDataArchiveFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserDataArchiveFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_profile_management_api_profile_management_proto_enumTypes[0].Descriptor()
+}
+
+func (UserDataArchiveFormat) Type() protoreflect.EnumType {
+	return &file_profile_management_api_profile_management_proto_enumTypes[0]
+}
+
+func (x UserDataArchiveFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserDataArchiveFormat.Descriptor instead.
+func (UserDataArchiveFormat) EnumDescriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{0}
+}
+
+type ChangeEventType int32
+
+const (
+	ChangeEventType_CHANGE_EVENT_TYPE_UNSPECIFIED     ChangeEventType = 0
+	ChangeEventType_CHANGE_EVENT_TYPE_USER_UPDATED    ChangeEventType = 1
+	ChangeEventType_CHANGE_EVENT_TYPE_PRODUCT_CREATED ChangeEventType = 2
+	ChangeEventType_CHANGE_EVENT_TYPE_PRODUCT_UPDATED ChangeEventType = 3
+	ChangeEventType_CHANGE_EVENT_TYPE_PRODUCT_DELETED ChangeEventType = 4
+	ChangeEventType_CHANGE_EVENT_TYPE_MEAL_CREATED    ChangeEventType = 5
+	ChangeEventType_CHANGE_EVENT_TYPE_MEAL_UPDATED    ChangeEventType = 6
+	ChangeEventType_CHANGE_EVENT_TYPE_MEAL_DELETED    ChangeEventType = 7
+	ChangeEventType_CHANGE_EVENT_TYPE_USER_CREATED    ChangeEventType = 8
+	ChangeEventType_CHANGE_EVENT_TYPE_USER_DELETED    ChangeEventType = 9
+)
+
+// Enum value maps for ChangeEventType.
+var (
+	ChangeEventType_name = map[int32]string{
+		0: "CHANGE_EVENT_TYPE_UNSPECIFIED",
+		1: "CHANGE_EVENT_TYPE_USER_UPDATED",
+		2: "CHANGE_EVENT_TYPE_PRODUCT_CREATED",
+		3: "CHANGE_EVENT_TYPE_PRODUCT_UPDATED",
+		4: "CHANGE_EVENT_TYPE_PRODUCT_DELETED",
+		5: "CHANGE_EVENT_TYPE_MEAL_CREATED",
+		6: "CHANGE_EVENT_TYPE_MEAL_UPDATED",
+		7: "CHANGE_EVENT_TYPE_MEAL_DELETED",
+		8: "CHANGE_EVENT_TYPE_USER_CREATED",
+		9: "CHANGE_EVENT_TYPE_USER_DELETED",
+	}
+	ChangeEventType_value = map[string]int32{
+		"CHANGE_EVENT_TYPE_UNSPECIFIED":     0,
+		"CHANGE_EVENT_TYPE_USER_UPDATED":    1,
+		"CHANGE_EVENT_TYPE_PRODUCT_CREATED": 2,
+		"CHANGE_EVENT_TYPE_PRODUCT_UPDATED": 3,
+		"CHANGE_EVENT_TYPE_PRODUCT_DELETED": 4,
+		"CHANGE_EVENT_TYPE_MEAL_CREATED":    5,
+		"CHANGE_EVENT_TYPE_MEAL_UPDATED":    6,
+		"CHANGE_EVENT_TYPE_MEAL_DELETED":    7,
+		"CHANGE_EVENT_TYPE_USER_CREATED":    8,
+		"CHANGE_EVENT_TYPE_USER_DELETED":    9,
+	}
+)
+
+func (x ChangeEventType) Enum() *ChangeEventType {
+	p := new(ChangeEventType)
+	*p = x
+	return p
+}
+
+func (x ChangeEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_profile_management_api_profile_management_proto_enumTypes[1].Descriptor()
+}
+
+func (ChangeEventType) Type() protoreflect.EnumType {
+	return &file_profile_management_api_profile_management_proto_enumTypes[1]
+}
+
+func (x ChangeEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeEventType.Descriptor instead.
+func (ChangeEventType) EnumDescriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{1}
+}
+
+type ProductImportFormat int32
+
+const (
+	ProductImportFormat_PRODUCT_IMPORT_FORMAT_UNSPECIFIED ProductImportFormat = 0
+	ProductImportFormat_PRODUCT_IMPORT_FORMAT_CSV         ProductImportFormat = 1
+	ProductImportFormat_PRODUCT_IMPORT_FORMAT_JSON        ProductImportFormat = 2
+)
+
+// Enum value maps for ProductImportFormat.
+var (
+	ProductImportFormat_name = map[int32]string{
+		0: "PRODUCT_IMPORT_FORMAT_UNSPECIFIED",
+		1: "PRODUCT_IMPORT_FORMAT_CSV",
+		2: "PRODUCT_IMPORT_FORMAT_JSON",
+	}
+	ProductImportFormat_value = map[string]int32{
+		"PRODUCT_IMPORT_FORMAT_UNSPECIFIED": 0,
+		"PRODUCT_IMPORT_FORMAT_CSV":         1,
+		"PRODUCT_IMPORT_FORMAT_JSON":        2,
+	}
+)
+
+func (x ProductImportFormat) Enum() *ProductImportFormat {
+	p := new(ProductImportFormat)
+	*p = x
+	return p
+}
+
+func (x ProductImportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProductImportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_profile_management_api_profile_management_proto_enumTypes[2].Descriptor()
+}
+
+func (ProductImportFormat) Type() protoreflect.EnumType {
+	return &file_profile_management_api_profile_management_proto_enumTypes[2]
+}
+
+func (x ProductImportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProductImportFormat.Descriptor instead.
+func (ProductImportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{2}
+}
+
+// Menu generation messages
+type MenuGenerationStatus int32
+
+const (
+	MenuGenerationStatus_MENU_GENERATION_STATUS_UNSPECIFIED MenuGenerationStatus = 0
+	MenuGenerationStatus_MENU_GENERATION_STATUS_PENDING     MenuGenerationStatus = 1
+	MenuGenerationStatus_MENU_GENERATION_STATUS_COMPLETED   MenuGenerationStatus = 2
+	MenuGenerationStatus_MENU_GENERATION_STATUS_FAILED      MenuGenerationStatus = 3
+)
+
+// Enum value maps for MenuGenerationStatus.
+var (
+	MenuGenerationStatus_name = map[int32]string{
+		0: "MENU_GENERATION_STATUS_UNSPECIFIED",
+		1: "MENU_GENERATION_STATUS_PENDING",
+		2: "MENU_GENERATION_STATUS_COMPLETED",
+		3: "MENU_GENERATION_STATUS_FAILED",
+	}
+	MenuGenerationStatus_value = map[string]int32{
+		"MENU_GENERATION_STATUS_UNSPECIFIED": 0,
+		"MENU_GENERATION_STATUS_PENDING":     1,
+		"MENU_GENERATION_STATUS_COMPLETED":   2,
+		"MENU_GENERATION_STATUS_FAILED":      3,
+	}
+)
+
+func (x MenuGenerationStatus) Enum() *MenuGenerationStatus {
+	p := new(MenuGenerationStatus)
+	*p = x
+	return p
+}
+
+func (x MenuGenerationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MenuGenerationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_profile_management_api_profile_management_proto_enumTypes[3].Descriptor()
+}
+
+func (MenuGenerationStatus) Type() protoreflect.EnumType {
+	return &file_profile_management_api_profile_management_proto_enumTypes[3]
+}
+
+func (x MenuGenerationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MenuGenerationStatus.Descriptor instead.
+func (MenuGenerationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{3}
+}
+
 // User messages
 type CreateUserRequest struct {
 	state         protoimpl.MessageState  `protogen:"open.v1"`
@@ -201,11 +422,13 @@ func (x *GetUserResponse) GetUser() *models.UserModel {
 }
 
 type UpdateUserRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Id            int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	User          *models.UserUpdateModel `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState  `protogen:"open.v1"`
+	Id    int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	User  *models.UserUpdateModel `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	// expected_version версия, которую видел клиент; 0 берёт версию из заголовка If-Match или отключает проверку
+	ExpectedVersion int32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateUserRequest) Reset() {
@@ -252,6 +475,13 @@ func (x *UpdateUserRequest) GetUser() *models.UserUpdateModel {
 	return nil
 }
 
+func (x *UpdateUserRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
 type UpdateUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	User          *models.UserModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
@@ -376,28 +606,27 @@ func (*DeleteUserResponse) Descriptor() ([]byte, []int) {
 	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{7}
 }
 
-// Product messages
-type CreateProductRequest struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	Product       *models.ProductCreateModel `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+type RestoreUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProductRequest) Reset() {
-	*x = CreateProductRequest{}
+func (x *RestoreUserRequest) Reset() {
+	*x = RestoreUserRequest{}
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProductRequest) String() string {
+func (x *RestoreUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProductRequest) ProtoMessage() {}
+func (*RestoreUserRequest) ProtoMessage() {}
 
-func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+func (x *RestoreUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -409,39 +638,39 @@ func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
-func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreUserRequest.ProtoReflect.Descriptor instead.
+func (*RestoreUserRequest) Descriptor() ([]byte, []int) {
 	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CreateProductRequest) GetProduct() *models.ProductCreateModel {
+func (x *RestoreUserRequest) GetId() int32 {
 	if x != nil {
-		return x.Product
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type CreateProductResponse struct {
+type RestoreUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *models.ProductModel   `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	User          *models.UserModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateProductResponse) Reset() {
-	*x = CreateProductResponse{}
+func (x *RestoreUserResponse) Reset() {
+	*x = RestoreUserResponse{}
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateProductResponse) String() string {
+func (x *RestoreUserResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateProductResponse) ProtoMessage() {}
+func (*RestoreUserResponse) ProtoMessage() {}
 
-func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
+func (x *RestoreUserResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -453,39 +682,40 @@ func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
-func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreUserResponse.ProtoReflect.Descriptor instead.
+func (*RestoreUserResponse) Descriptor() ([]byte, []int) {
 	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateProductResponse) GetProduct() *models.ProductModel {
+func (x *RestoreUserResponse) GetUser() *models.UserModel {
 	if x != nil {
-		return x.Product
+		return x.User
 	}
 	return nil
 }
 
-type GetProductsRequest struct {
+type ExportUserDataRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format        UserDataArchiveFormat  `protobuf:"varint,2,opt,name=format,proto3,enum=profile_management.service.v1.UserDataArchiveFormat" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductsRequest) Reset() {
-	*x = GetProductsRequest{}
+func (x *ExportUserDataRequest) Reset() {
+	*x = ExportUserDataRequest{}
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductsRequest) String() string {
+func (x *ExportUserDataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductsRequest) ProtoMessage() {}
+func (*ExportUserDataRequest) ProtoMessage() {}
 
-func (x *GetProductsRequest) ProtoReflect() protoreflect.Message {
+func (x *ExportUserDataRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -497,39 +727,48 @@ func (x *GetProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductsRequest.ProtoReflect.Descriptor instead.
-func (*GetProductsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ExportUserDataRequest) Descriptor() ([]byte, []int) {
 	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetProductsRequest) GetUserId() int32 {
+func (x *ExportUserDataRequest) GetUserId() int32 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-type GetProductsResponse struct {
+func (x *ExportUserDataRequest) GetFormat() UserDataArchiveFormat {
+	if x != nil {
+		return x.Format
+	}
+	return UserDataArchiveFormat_USER_DATA_ARCHIVE_FORMAT_UNSPECIFIED
+}
+
+type ExportUserDataChunk struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*models.ProductModel `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductsResponse) Reset() {
-	*x = GetProductsResponse{}
+func (x *ExportUserDataChunk) Reset() {
+	*x = ExportUserDataChunk{}
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductsResponse) String() string {
+func (x *ExportUserDataChunk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductsResponse) ProtoMessage() {}
+func (*ExportUserDataChunk) ProtoMessage() {}
 
-func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
+func (x *ExportUserDataChunk) ProtoReflect() protoreflect.Message {
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -541,40 +780,56 @@ func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductsResponse.ProtoReflect.Descriptor instead.
-func (*GetProductsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExportUserDataChunk.ProtoReflect.Descriptor instead.
+func (*ExportUserDataChunk) Descriptor() ([]byte, []int) {
 	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetProductsResponse) GetProducts() []*models.ProductModel {
+func (x *ExportUserDataChunk) GetFilename() string {
 	if x != nil {
-		return x.Products
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ExportUserDataChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportUserDataChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
-type UpdateProductRequest struct {
-	state         protoimpl.MessageState     `protogen:"open.v1"`
-	Id            int32                      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Product       *models.ProductUpdateModel `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+type ImportUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        UserDataArchiveFormat  `protobuf:"varint,1,opt,name=format,proto3,enum=profile_management.service.v1.UserDataArchiveFormat" json:"format,omitempty"`
+	Archive       []byte                 `protobuf:"bytes,2,opt,name=archive,proto3" json:"archive,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateProductRequest) Reset() {
-	*x = UpdateProductRequest{}
+func (x *ImportUserDataRequest) Reset() {
+	*x = ImportUserDataRequest{}
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductRequest) String() string {
+func (x *ImportUserDataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductRequest) ProtoMessage() {}
+func (*ImportUserDataRequest) ProtoMessage() {}
 
-func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+func (x *ImportUserDataRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -586,46 +841,62 @@ func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
-func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImportUserDataRequest.ProtoReflect.Descriptor instead.
+func (*ImportUserDataRequest) Descriptor() ([]byte, []int) {
 	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateProductRequest) GetId() int32 {
+func (x *ImportUserDataRequest) GetFormat() UserDataArchiveFormat {
 	if x != nil {
-		return x.Id
+		return x.Format
 	}
-	return 0
+	return UserDataArchiveFormat_USER_DATA_ARCHIVE_FORMAT_UNSPECIFIED
 }
 
-func (x *UpdateProductRequest) GetProduct() *models.ProductUpdateModel {
+func (x *ImportUserDataRequest) GetArchive() []byte {
 	if x != nil {
-		return x.Product
+		return x.Archive
 	}
 	return nil
 }
 
-type UpdateProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *models.ProductModel   `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ImportUserDataRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
 }
 
-func (x *UpdateProductResponse) Reset() {
-	*x = UpdateProductResponse{}
+func (x *ImportUserDataRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ImportUserDataResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	User             *models.UserModel      `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	ImportedProducts int32                  `protobuf:"varint,2,opt,name=imported_products,json=importedProducts,proto3" json:"imported_products,omitempty"`
+	ImportedMeals    int32                  `protobuf:"varint,3,opt,name=imported_meals,json=importedMeals,proto3" json:"imported_meals,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ImportUserDataResponse) Reset() {
+	*x = ImportUserDataResponse{}
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateProductResponse) String() string {
+func (x *ImportUserDataResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateProductResponse) ProtoMessage() {}
+func (*ImportUserDataResponse) ProtoMessage() {}
 
-func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
+func (x *ImportUserDataResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -637,39 +908,55 @@ func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
-func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImportUserDataResponse.ProtoReflect.Descriptor instead.
+func (*ImportUserDataResponse) Descriptor() ([]byte, []int) {
 	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *UpdateProductResponse) GetProduct() *models.ProductModel {
+func (x *ImportUserDataResponse) GetUser() *models.UserModel {
 	if x != nil {
-		return x.Product
+		return x.User
 	}
 	return nil
 }
 
-type DeleteProductRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *ImportUserDataResponse) GetImportedProducts() int32 {
+	if x != nil {
+		return x.ImportedProducts
+	}
+	return 0
+}
+
+func (x *ImportUserDataResponse) GetImportedMeals() int32 {
+	if x != nil {
+		return x.ImportedMeals
+	}
+	return 0
+}
+
+type WatchUserRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// resume_token последнего полученного события; пустой - только новые события
+	ResumeToken   string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductRequest) Reset() {
-	*x = DeleteProductRequest{}
+func (x *WatchUserRequest) Reset() {
+	*x = WatchUserRequest{}
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductRequest) String() string {
+func (x *WatchUserRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductRequest) ProtoMessage() {}
+func (*WatchUserRequest) ProtoMessage() {}
 
-func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+func (x *WatchUserRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -681,38 +968,55 @@ func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
-func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchUserRequest.ProtoReflect.Descriptor instead.
+func (*WatchUserRequest) Descriptor() ([]byte, []int) {
 	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *DeleteProductRequest) GetId() int32 {
+func (x *WatchUserRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return 0
 }
 
-type DeleteProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+func (x *WatchUserRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type ChangeEvent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ResumeToken string                 `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	Type        ChangeEventType        `protobuf:"varint,2,opt,name=type,proto3,enum=profile_management.service.v1.ChangeEventType" json:"type,omitempty"`
+	UserId      int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OccurredAt  string                 `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Types that are valid to be assigned to Entity:
+	//
+	//	*ChangeEvent_User
+	//	*ChangeEvent_Product
+	//	*ChangeEvent_Meal
+	Entity        isChangeEvent_Entity `protobuf_oneof:"entity"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteProductResponse) Reset() {
-	*x = DeleteProductResponse{}
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteProductResponse) String() string {
+func (x *ChangeEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteProductResponse) ProtoMessage() {}
+func (*ChangeEvent) ProtoMessage() {}
 
-func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
 	mi := &file_profile_management_api_profile_management_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -724,34 +1028,922 @@ func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
-func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
 	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{15}
 }
 
-// Meal messages
-type CreateMealRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Meal          *models.MealCreateModel `protobuf:"bytes,1,opt,name=meal,proto3" json:"meal,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ChangeEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
 }
 
-func (x *CreateMealRequest) Reset() {
-	*x = CreateMealRequest{}
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[16]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ChangeEvent) GetType() ChangeEventType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeEventType_CHANGE_EVENT_TYPE_UNSPECIFIED
 }
 
-func (x *CreateMealRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ChangeEvent) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
 }
 
-func (*CreateMealRequest) ProtoMessage() {}
-
-func (x *CreateMealRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[16]
+func (x *ChangeEvent) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetEntity() isChangeEvent_Entity {
+	if x != nil {
+		return x.Entity
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetUser() *models.UserModel {
+	if x != nil {
+		if x, ok := x.Entity.(*ChangeEvent_User); ok {
+			return x.User
+		}
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetProduct() *models.ProductModel {
+	if x != nil {
+		if x, ok := x.Entity.(*ChangeEvent_Product); ok {
+			return x.Product
+		}
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetMeal() *models.MealModel {
+	if x != nil {
+		if x, ok := x.Entity.(*ChangeEvent_Meal); ok {
+			return x.Meal
+		}
+	}
+	return nil
+}
+
+type isChangeEvent_Entity interface {
+	isChangeEvent_Entity()
+}
+
+type ChangeEvent_User struct {
+	User *models.UserModel `protobuf:"bytes,5,opt,name=user,proto3,oneof"`
+}
+
+type ChangeEvent_Product struct {
+	Product *models.ProductModel `protobuf:"bytes,6,opt,name=product,proto3,oneof"`
+}
+
+type ChangeEvent_Meal struct {
+	Meal *models.MealModel `protobuf:"bytes,7,opt,name=meal,proto3,oneof"`
+}
+
+func (*ChangeEvent_User) isChangeEvent_Entity() {}
+
+func (*ChangeEvent_Product) isChangeEvent_Entity() {}
+
+func (*ChangeEvent_Meal) isChangeEvent_Entity() {}
+
+// Product messages
+type CreateProductRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Product       *models.ProductCreateModel `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductRequest) Reset() {
+	*x = CreateProductRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductRequest) ProtoMessage() {}
+
+func (x *CreateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateProductRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateProductRequest) GetProduct() *models.ProductCreateModel {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type CreateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *models.ProductModel   `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProductResponse) Reset() {
+	*x = CreateProductResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProductResponse) ProtoMessage() {}
+
+func (x *CreateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateProductResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateProductResponse) GetProduct() *models.ProductModel {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type GetProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsRequest) Reset() {
+	*x = GetProductsRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsRequest) ProtoMessage() {}
+
+func (x *GetProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductsRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetProductsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*models.ProductModel `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsResponse) Reset() {
+	*x = GetProductsResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsResponse) ProtoMessage() {}
+
+func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetProductsResponse) GetProducts() []*models.ProductModel {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type UpdateProductRequest struct {
+	state   protoimpl.MessageState     `protogen:"open.v1"`
+	Id      int32                      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Product *models.ProductUpdateModel `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	// expected_version версия, которую видел клиент; 0 берёт версию из заголовка If-Match или отключает проверку
+	ExpectedVersion int32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateProductRequest) Reset() {
+	*x = UpdateProductRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductRequest) ProtoMessage() {}
+
+func (x *UpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateProductRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateProductRequest) GetProduct() *models.ProductUpdateModel {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *UpdateProductRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *models.ProductModel   `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductResponse) Reset() {
+	*x = UpdateProductResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductResponse) ProtoMessage() {}
+
+func (x *UpdateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateProductResponse) GetProduct() *models.ProductModel {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// detach_from_meals убирает продукт из состава блюд вместо отказа в удалении
+	DetachFromMeals bool `protobuf:"varint,2,opt,name=detach_from_meals,json=detachFromMeals,proto3" json:"detach_from_meals,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteProductRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteProductRequest) GetDetachFromMeals() bool {
+	if x != nil {
+		return x.DetachFromMeals
+	}
+	return false
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{23}
+}
+
+type ImportProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Format        ProductImportFormat    `protobuf:"varint,2,opt,name=format,proto3,enum=profile_management.service.v1.ProductImportFormat" json:"format,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsRequest) Reset() {
+	*x = ImportProductsRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsRequest) ProtoMessage() {}
+
+func (x *ImportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ImportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ImportProductsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImportProductsRequest) GetFormat() ProductImportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ProductImportFormat_PRODUCT_IMPORT_FORMAT_UNSPECIFIED
+}
+
+func (x *ImportProductsRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ImportProductsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportProductsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	ImportedCount int32                     `protobuf:"varint,1,opt,name=imported_count,json=importedCount,proto3" json:"imported_count,omitempty"`
+	RejectedCount int32                     `protobuf:"varint,2,opt,name=rejected_count,json=rejectedCount,proto3" json:"rejected_count,omitempty"`
+	DryRun        bool                      `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Rows          []*ProductImportRowResult `protobuf:"bytes,4,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsResponse) Reset() {
+	*x = ImportProductsResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsResponse) ProtoMessage() {}
+
+func (x *ImportProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsResponse.ProtoReflect.Descriptor instead.
+func (*ImportProductsResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ImportProductsResponse) GetImportedCount() int32 {
+	if x != nil {
+		return x.ImportedCount
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetRejectedCount() int32 {
+	if x != nil {
+		return x.RejectedCount
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportProductsResponse) GetRows() []*ProductImportRowResult {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type ProductImportRowResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Product       *models.ProductModel   `protobuf:"bytes,2,opt,name=product,proto3" json:"product,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductImportRowResult) Reset() {
+	*x = ProductImportRowResult{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductImportRowResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductImportRowResult) ProtoMessage() {}
+
+func (x *ProductImportRowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductImportRowResult.ProtoReflect.Descriptor instead.
+func (*ProductImportRowResult) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ProductImportRowResult) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ProductImportRowResult) GetProduct() *models.ProductModel {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *ProductImportRowResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Meal messages
+type CreateMealRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Meal          *models.MealCreateModel `protobuf:"bytes,1,opt,name=meal,proto3" json:"meal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMealRequest) Reset() {
+	*x = CreateMealRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMealRequest) ProtoMessage() {}
+
+func (x *CreateMealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMealRequest.ProtoReflect.Descriptor instead.
+func (*CreateMealRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateMealRequest) GetMeal() *models.MealCreateModel {
+	if x != nil {
+		return x.Meal
+	}
+	return nil
+}
+
+type CreateMealResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meal          *models.MealModel      `protobuf:"bytes,1,opt,name=meal,proto3" json:"meal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMealResponse) Reset() {
+	*x = CreateMealResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMealResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMealResponse) ProtoMessage() {}
+
+func (x *CreateMealResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMealResponse.ProtoReflect.Descriptor instead.
+func (*CreateMealResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateMealResponse) GetMeal() *models.MealModel {
+	if x != nil {
+		return x.Meal
+	}
+	return nil
+}
+
+type GetMealsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMealsRequest) Reset() {
+	*x = GetMealsRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMealsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMealsRequest) ProtoMessage() {}
+
+func (x *GetMealsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMealsRequest.ProtoReflect.Descriptor instead.
+func (*GetMealsRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetMealsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetMealsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meals         []*models.MealModel    `protobuf:"bytes,1,rep,name=meals,proto3" json:"meals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMealsResponse) Reset() {
+	*x = GetMealsResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMealsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMealsResponse) ProtoMessage() {}
+
+func (x *GetMealsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMealsResponse.ProtoReflect.Descriptor instead.
+func (*GetMealsResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetMealsResponse) GetMeals() []*models.MealModel {
+	if x != nil {
+		return x.Meals
+	}
+	return nil
+}
+
+type UpdateMealRequest struct {
+	state protoimpl.MessageState  `protogen:"open.v1"`
+	Id    int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Meal  *models.MealUpdateModel `protobuf:"bytes,2,opt,name=meal,proto3" json:"meal,omitempty"`
+	// expected_version версия, которую видел клиент; 0 берёт версию из заголовка If-Match или отключает проверку
+	ExpectedVersion int32 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateMealRequest) Reset() {
+	*x = UpdateMealRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMealRequest) ProtoMessage() {}
+
+func (x *UpdateMealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMealRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMealRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateMealRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateMealRequest) GetMeal() *models.MealUpdateModel {
+	if x != nil {
+		return x.Meal
+	}
+	return nil
+}
+
+func (x *UpdateMealRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateMealResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meal          *models.MealModel      `protobuf:"bytes,1,opt,name=meal,proto3" json:"meal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMealResponse) Reset() {
+	*x = UpdateMealResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMealResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMealResponse) ProtoMessage() {}
+
+func (x *UpdateMealResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -762,40 +1954,40 @@ func (x *CreateMealRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateMealRequest.ProtoReflect.Descriptor instead.
-func (*CreateMealRequest) Descriptor() ([]byte, []int) {
-	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use UpdateMealResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMealResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *CreateMealRequest) GetMeal() *models.MealCreateModel {
+func (x *UpdateMealResponse) GetMeal() *models.MealModel {
 	if x != nil {
 		return x.Meal
 	}
 	return nil
 }
 
-type CreateMealResponse struct {
+type DeleteMealRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Meal          *models.MealModel      `protobuf:"bytes,1,opt,name=meal,proto3" json:"meal,omitempty"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateMealResponse) Reset() {
-	*x = CreateMealResponse{}
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[17]
+func (x *DeleteMealRequest) Reset() {
+	*x = DeleteMealRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateMealResponse) String() string {
+func (x *DeleteMealRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateMealResponse) ProtoMessage() {}
+func (*DeleteMealRequest) ProtoMessage() {}
 
-func (x *CreateMealResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[17]
+func (x *DeleteMealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -806,40 +1998,39 @@ func (x *CreateMealResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateMealResponse.ProtoReflect.Descriptor instead.
-func (*CreateMealResponse) Descriptor() ([]byte, []int) {
-	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use DeleteMealRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMealRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *CreateMealResponse) GetMeal() *models.MealModel {
+func (x *DeleteMealRequest) GetId() int32 {
 	if x != nil {
-		return x.Meal
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type GetMealsRequest struct {
+type DeleteMealResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMealsRequest) Reset() {
-	*x = GetMealsRequest{}
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[18]
+func (x *DeleteMealResponse) Reset() {
+	*x = DeleteMealResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMealsRequest) String() string {
+func (x *DeleteMealResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMealsRequest) ProtoMessage() {}
+func (*DeleteMealResponse) ProtoMessage() {}
 
-func (x *GetMealsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[18]
+func (x *DeleteMealResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -850,40 +2041,128 @@ func (x *GetMealsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMealsRequest.ProtoReflect.Descriptor instead.
-func (*GetMealsRequest) Descriptor() ([]byte, []int) {
-	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use DeleteMealResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMealResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{34}
+}
+
+type MenuGeneration struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RequestId string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	UserId    int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status    MenuGenerationStatus   `protobuf:"varint,3,opt,name=status,proto3,enum=profile_management.service.v1.MenuGenerationStatus" json:"status,omitempty"`
+	Error     string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// menu сгенерированное меню, JSON строка в формате генератора
+	Menu          string `protobuf:"bytes,5,opt,name=menu,proto3" json:"menu,omitempty"`
+	CreatedAt     string `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   string `protobuf:"bytes,7,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMealsRequest) GetUserId() int32 {
+func (x *MenuGeneration) Reset() {
+	*x = MenuGeneration{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuGeneration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuGeneration) ProtoMessage() {}
+
+func (x *MenuGeneration) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuGeneration.ProtoReflect.Descriptor instead.
+func (*MenuGeneration) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MenuGeneration) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *MenuGeneration) GetUserId() int32 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-type GetMealsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Meals         []*models.MealModel    `protobuf:"bytes,1,rep,name=meals,proto3" json:"meals,omitempty"`
+func (x *MenuGeneration) GetStatus() MenuGenerationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MenuGenerationStatus_MENU_GENERATION_STATUS_UNSPECIFIED
+}
+
+func (x *MenuGeneration) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MenuGeneration) GetMenu() string {
+	if x != nil {
+		return x.Menu
+	}
+	return ""
+}
+
+func (x *MenuGeneration) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *MenuGeneration) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+type GetGeneratedMenusRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// limit по умолчанию 10, не больше 100
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMealsResponse) Reset() {
-	*x = GetMealsResponse{}
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[19]
+func (x *GetGeneratedMenusRequest) Reset() {
+	*x = GetGeneratedMenusRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMealsResponse) String() string {
+func (x *GetGeneratedMenusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMealsResponse) ProtoMessage() {}
+func (*GetGeneratedMenusRequest) ProtoMessage() {}
 
-func (x *GetMealsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[19]
+func (x *GetGeneratedMenusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -894,41 +2173,47 @@ func (x *GetMealsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMealsResponse.ProtoReflect.Descriptor instead.
-func (*GetMealsResponse) Descriptor() ([]byte, []int) {
-	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use GetGeneratedMenusRequest.ProtoReflect.Descriptor instead.
+func (*GetGeneratedMenusRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *GetMealsResponse) GetMeals() []*models.MealModel {
+func (x *GetGeneratedMenusRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Meals
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-type UpdateMealRequest struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Id            int32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Meal          *models.MealUpdateModel `protobuf:"bytes,2,opt,name=meal,proto3" json:"meal,omitempty"`
+func (x *GetGeneratedMenusRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetGeneratedMenusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Menus         []*MenuGeneration      `protobuf:"bytes,1,rep,name=menus,proto3" json:"menus,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateMealRequest) Reset() {
-	*x = UpdateMealRequest{}
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[20]
+func (x *GetGeneratedMenusResponse) Reset() {
+	*x = GetGeneratedMenusResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateMealRequest) String() string {
+func (x *GetGeneratedMenusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateMealRequest) ProtoMessage() {}
+func (*GetGeneratedMenusResponse) ProtoMessage() {}
 
-func (x *UpdateMealRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[20]
+func (x *GetGeneratedMenusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -939,47 +2224,84 @@ func (x *UpdateMealRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateMealRequest.ProtoReflect.Descriptor instead.
-func (*UpdateMealRequest) Descriptor() ([]byte, []int) {
-	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetGeneratedMenusResponse.ProtoReflect.Descriptor instead.
+func (*GetGeneratedMenusResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *UpdateMealRequest) GetId() int32 {
+func (x *GetGeneratedMenusResponse) GetMenus() []*MenuGeneration {
 	if x != nil {
-		return x.Id
+		return x.Menus
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateMealRequest) GetMeal() *models.MealUpdateModel {
+type GetMenuGenerationStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuGenerationStatusRequest) Reset() {
+	*x = GetMenuGenerationStatusRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuGenerationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuGenerationStatusRequest) ProtoMessage() {}
+
+func (x *GetMenuGenerationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[38]
 	if x != nil {
-		return x.Meal
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type UpdateMealResponse struct {
+// Deprecated: Use GetMenuGenerationStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuGenerationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetMenuGenerationStatusRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type GetMenuGenerationStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Meal          *models.MealModel      `protobuf:"bytes,1,opt,name=meal,proto3" json:"meal,omitempty"`
+	Generation    *MenuGeneration        `protobuf:"bytes,1,opt,name=generation,proto3" json:"generation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateMealResponse) Reset() {
-	*x = UpdateMealResponse{}
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[21]
+func (x *GetMenuGenerationStatusResponse) Reset() {
+	*x = GetMenuGenerationStatusResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateMealResponse) String() string {
+func (x *GetMenuGenerationStatusResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateMealResponse) ProtoMessage() {}
+func (*GetMenuGenerationStatusResponse) ProtoMessage() {}
 
-func (x *UpdateMealResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[21]
+func (x *GetMenuGenerationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -990,40 +2312,48 @@ func (x *UpdateMealResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateMealResponse.ProtoReflect.Descriptor instead.
-func (*UpdateMealResponse) Descriptor() ([]byte, []int) {
-	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetMenuGenerationStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetMenuGenerationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *UpdateMealResponse) GetMeal() *models.MealModel {
+func (x *GetMenuGenerationStatusResponse) GetGeneration() *MenuGeneration {
 	if x != nil {
-		return x.Meal
+		return x.Generation
 	}
 	return nil
 }
 
-type DeleteMealRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+// Все переопределения необязательные; пустые значения берутся из профиля пользователя
+type RequestMenuGenerationRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// date_from и date_to в формате YYYY-MM-DD, задаются вместе, период не длиннее 31 дня
+	DateFrom string `protobuf:"bytes,2,opt,name=date_from,json=dateFrom,proto3" json:"date_from,omitempty"`
+	DateTo   string `protobuf:"bytes,3,opt,name=date_to,json=dateTo,proto3" json:"date_to,omitempty"`
+	// meals_per_day от 1 до 10
+	MealsPerDay int32 `protobuf:"varint,4,opt,name=meals_per_day,json=mealsPerDay,proto3" json:"meals_per_day,omitempty"`
+	// budget заменяет бюджет из профиля
+	Budget        int32 `protobuf:"varint,5,opt,name=budget,proto3" json:"budget,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMealRequest) Reset() {
-	*x = DeleteMealRequest{}
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[22]
+func (x *RequestMenuGenerationRequest) Reset() {
+	*x = RequestMenuGenerationRequest{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMealRequest) String() string {
+func (x *RequestMenuGenerationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMealRequest) ProtoMessage() {}
+func (*RequestMenuGenerationRequest) ProtoMessage() {}
 
-func (x *DeleteMealRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[22]
+func (x *RequestMenuGenerationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,39 +2364,68 @@ func (x *DeleteMealRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMealRequest.ProtoReflect.Descriptor instead.
-func (*DeleteMealRequest) Descriptor() ([]byte, []int) {
-	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use RequestMenuGenerationRequest.ProtoReflect.Descriptor instead.
+func (*RequestMenuGenerationRequest) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *DeleteMealRequest) GetId() int32 {
+func (x *RequestMenuGenerationRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return 0
 }
 
-type DeleteMealResponse struct {
+func (x *RequestMenuGenerationRequest) GetDateFrom() string {
+	if x != nil {
+		return x.DateFrom
+	}
+	return ""
+}
+
+func (x *RequestMenuGenerationRequest) GetDateTo() string {
+	if x != nil {
+		return x.DateTo
+	}
+	return ""
+}
+
+func (x *RequestMenuGenerationRequest) GetMealsPerDay() int32 {
+	if x != nil {
+		return x.MealsPerDay
+	}
+	return 0
+}
+
+func (x *RequestMenuGenerationRequest) GetBudget() int32 {
+	if x != nil {
+		return x.Budget
+	}
+	return 0
+}
+
+type RequestMenuGenerationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	RequestId     string                 `protobuf:"bytes,1,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMealResponse) Reset() {
-	*x = DeleteMealResponse{}
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[23]
+func (x *RequestMenuGenerationResponse) Reset() {
+	*x = RequestMenuGenerationResponse{}
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMealResponse) String() string {
+func (x *RequestMenuGenerationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMealResponse) ProtoMessage() {}
+func (*RequestMenuGenerationResponse) ProtoMessage() {}
 
-func (x *DeleteMealResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_profile_management_api_profile_management_proto_msgTypes[23]
+func (x *RequestMenuGenerationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_profile_management_api_profile_management_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1077,9 +2436,16 @@ func (x *DeleteMealResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMealResponse.ProtoReflect.Descriptor instead.
-func (*DeleteMealResponse) Descriptor() ([]byte, []int) {
-	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use RequestMenuGenerationResponse.ProtoReflect.Descriptor instead.
+func (*RequestMenuGenerationResponse) Descriptor() ([]byte, []int) {
+	return file_profile_management_api_profile_management_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RequestMenuGenerationResponse) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
 }
 
 var File_profile_management_api_profile_management_proto protoreflect.FileDescriptor
@@ -1094,15 +2460,49 @@ const file_profile_management_api_profile_management_proto_rawDesc = "" +
 	"\x0eGetUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"N\n" +
 	"\x0fGetUserResponse\x12;\n" +
-	"\x04user\x18\x01 \x01(\v2'.profile_management.models.v1.UserModelR\x04user\"f\n" +
+	"\x04user\x18\x01 \x01(\v2'.profile_management.models.v1.UserModelR\x04user\"\x91\x01\n" +
 	"\x11UpdateUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12A\n" +
-	"\x04user\x18\x02 \x01(\v2-.profile_management.models.v1.UserUpdateModelR\x04user\"Q\n" +
+	"\x04user\x18\x02 \x01(\v2-.profile_management.models.v1.UserUpdateModelR\x04user\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x05R\x0fexpectedVersion\"Q\n" +
 	"\x12UpdateUserResponse\x12;\n" +
 	"\x04user\x18\x01 \x01(\v2'.profile_management.models.v1.UserModelR\x04user\"#\n" +
 	"\x11DeleteUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"\x14\n" +
-	"\x12DeleteUserResponse\"b\n" +
+	"\x12DeleteUserResponse\"$\n" +
+	"\x12RestoreUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"R\n" +
+	"\x13RestoreUserResponse\x12;\n" +
+	"\x04user\x18\x01 \x01(\v2'.profile_management.models.v1.UserModelR\x04user\"~\n" +
+	"\x15ExportUserDataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12L\n" +
+	"\x06format\x18\x02 \x01(\x0e24.profile_management.service.v1.UserDataArchiveFormatR\x06format\"h\n" +
+	"\x13ExportUserDataChunk\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"\xb7\x01\n" +
+	"\x15ImportUserDataRequest\x12L\n" +
+	"\x06format\x18\x01 \x01(\x0e24.profile_management.service.v1.UserDataArchiveFormatR\x06format\x12\x18\n" +
+	"\aarchive\x18\x02 \x01(\fR\aarchive\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\"\xa9\x01\n" +
+	"\x16ImportUserDataResponse\x12;\n" +
+	"\x04user\x18\x01 \x01(\v2'.profile_management.models.v1.UserModelR\x04user\x12+\n" +
+	"\x11imported_products\x18\x02 \x01(\x05R\x10importedProducts\x12%\n" +
+	"\x0eimported_meals\x18\x03 \x01(\x05R\rimportedMeals\"N\n" +
+	"\x10WatchUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12!\n" +
+	"\fresume_token\x18\x02 \x01(\tR\vresumeToken\"\xfe\x02\n" +
+	"\vChangeEvent\x12!\n" +
+	"\fresume_token\x18\x01 \x01(\tR\vresumeToken\x12B\n" +
+	"\x04type\x18\x02 \x01(\x0e2..profile_management.service.v1.ChangeEventTypeR\x04type\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x1f\n" +
+	"\voccurred_at\x18\x04 \x01(\tR\n" +
+	"occurredAt\x12=\n" +
+	"\x04user\x18\x05 \x01(\v2'.profile_management.models.v1.UserModelH\x00R\x04user\x12F\n" +
+	"\aproduct\x18\x06 \x01(\v2*.profile_management.models.v1.ProductModelH\x00R\aproduct\x12=\n" +
+	"\x04meal\x18\a \x01(\v2'.profile_management.models.v1.MealModelH\x00R\x04mealB\b\n" +
+	"\x06entity\"b\n" +
 	"\x14CreateProductRequest\x12J\n" +
 	"\aproduct\x18\x01 \x01(\v20.profile_management.models.v1.ProductCreateModelR\aproduct\"]\n" +
 	"\x15CreateProductResponse\x12D\n" +
@@ -1110,15 +2510,31 @@ const file_profile_management_api_profile_management_proto_rawDesc = "" +
 	"\x12GetProductsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\"]\n" +
 	"\x13GetProductsResponse\x12F\n" +
-	"\bproducts\x18\x01 \x03(\v2*.profile_management.models.v1.ProductModelR\bproducts\"r\n" +
+	"\bproducts\x18\x01 \x03(\v2*.profile_management.models.v1.ProductModelR\bproducts\"\x9d\x01\n" +
 	"\x14UpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12J\n" +
-	"\aproduct\x18\x02 \x01(\v20.profile_management.models.v1.ProductUpdateModelR\aproduct\"]\n" +
+	"\aproduct\x18\x02 \x01(\v20.profile_management.models.v1.ProductUpdateModelR\aproduct\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x05R\x0fexpectedVersion\"]\n" +
 	"\x15UpdateProductResponse\x12D\n" +
-	"\aproduct\x18\x01 \x01(\v2*.profile_management.models.v1.ProductModelR\aproduct\"&\n" +
+	"\aproduct\x18\x01 \x01(\v2*.profile_management.models.v1.ProductModelR\aproduct\"R\n" +
 	"\x14DeleteProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"\x17\n" +
-	"\x15DeleteProductResponse\"V\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12*\n" +
+	"\x11detach_from_meals\x18\x02 \x01(\bR\x0fdetachFromMeals\"\x17\n" +
+	"\x15DeleteProductResponse\"\xa9\x01\n" +
+	"\x15ImportProductsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12J\n" +
+	"\x06format\x18\x02 \x01(\x0e22.profile_management.service.v1.ProductImportFormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"\xca\x01\n" +
+	"\x16ImportProductsResponse\x12%\n" +
+	"\x0eimported_count\x18\x01 \x01(\x05R\rimportedCount\x12%\n" +
+	"\x0erejected_count\x18\x02 \x01(\x05R\rrejectedCount\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\x12I\n" +
+	"\x04rows\x18\x04 \x03(\v25.profile_management.service.v1.ProductImportRowResultR\x04rows\"\x88\x01\n" +
+	"\x16ProductImportRowResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12D\n" +
+	"\aproduct\x18\x02 \x01(\v2*.profile_management.models.v1.ProductModelR\aproduct\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"V\n" +
 	"\x11CreateMealRequest\x12A\n" +
 	"\x04meal\x18\x01 \x01(\v2-.profile_management.models.v1.MealCreateModelR\x04meal\"Q\n" +
 	"\x12CreateMealResponse\x12;\n" +
@@ -1126,15 +2542,71 @@ const file_profile_management_api_profile_management_proto_rawDesc = "" +
 	"\x0fGetMealsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\"Q\n" +
 	"\x10GetMealsResponse\x12=\n" +
-	"\x05meals\x18\x01 \x03(\v2'.profile_management.models.v1.MealModelR\x05meals\"f\n" +
+	"\x05meals\x18\x01 \x03(\v2'.profile_management.models.v1.MealModelR\x05meals\"\x91\x01\n" +
 	"\x11UpdateMealRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12A\n" +
-	"\x04meal\x18\x02 \x01(\v2-.profile_management.models.v1.MealUpdateModelR\x04meal\"Q\n" +
+	"\x04meal\x18\x02 \x01(\v2-.profile_management.models.v1.MealUpdateModelR\x04meal\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x05R\x0fexpectedVersion\"Q\n" +
 	"\x12UpdateMealResponse\x12;\n" +
 	"\x04meal\x18\x01 \x01(\v2'.profile_management.models.v1.MealModelR\x04meal\"#\n" +
 	"\x11DeleteMealRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\"\x14\n" +
-	"\x12DeleteMealResponse2\x98\r\n" +
+	"\x12DeleteMealResponse\"\x81\x02\n" +
+	"\x0eMenuGeneration\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12K\n" +
+	"\x06status\x18\x03 \x01(\x0e23.profile_management.service.v1.MenuGenerationStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x12\n" +
+	"\x04menu\x18\x05 \x01(\tR\x04menu\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12!\n" +
+	"\fcompleted_at\x18\a \x01(\tR\vcompletedAt\"I\n" +
+	"\x18GetGeneratedMenusRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"`\n" +
+	"\x19GetGeneratedMenusResponse\x12C\n" +
+	"\x05menus\x18\x01 \x03(\v2-.profile_management.service.v1.MenuGenerationR\x05menus\"?\n" +
+	"\x1eGetMenuGenerationStatusRequest\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId\"p\n" +
+	"\x1fGetMenuGenerationStatusResponse\x12M\n" +
+	"\n" +
+	"generation\x18\x01 \x01(\v2-.profile_management.service.v1.MenuGenerationR\n" +
+	"generation\"\xa9\x01\n" +
+	"\x1cRequestMenuGenerationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1b\n" +
+	"\tdate_from\x18\x02 \x01(\tR\bdateFrom\x12\x17\n" +
+	"\adate_to\x18\x03 \x01(\tR\x06dateTo\x12\"\n" +
+	"\rmeals_per_day\x18\x04 \x01(\x05R\vmealsPerDay\x12\x16\n" +
+	"\x06budget\x18\x05 \x01(\x05R\x06budget\">\n" +
+	"\x1dRequestMenuGenerationResponse\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x01 \x01(\tR\trequestId*\x86\x01\n" +
+	"\x15UserDataArchiveFormat\x12(\n" +
+	"$USER_DATA_ARCHIVE_FORMAT_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dUSER_DATA_ARCHIVE_FORMAT_JSON\x10\x01\x12 \n" +
+	"\x1cUSER_DATA_ARCHIVE_FORMAT_ZIP\x10\x02*\x81\x03\n" +
+	"\x0fChangeEventType\x12!\n" +
+	"\x1dCHANGE_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eCHANGE_EVENT_TYPE_USER_UPDATED\x10\x01\x12%\n" +
+	"!CHANGE_EVENT_TYPE_PRODUCT_CREATED\x10\x02\x12%\n" +
+	"!CHANGE_EVENT_TYPE_PRODUCT_UPDATED\x10\x03\x12%\n" +
+	"!CHANGE_EVENT_TYPE_PRODUCT_DELETED\x10\x04\x12\"\n" +
+	"\x1eCHANGE_EVENT_TYPE_MEAL_CREATED\x10\x05\x12\"\n" +
+	"\x1eCHANGE_EVENT_TYPE_MEAL_UPDATED\x10\x06\x12\"\n" +
+	"\x1eCHANGE_EVENT_TYPE_MEAL_DELETED\x10\a\x12\"\n" +
+	"\x1eCHANGE_EVENT_TYPE_USER_CREATED\x10\b\x12\"\n" +
+	"\x1eCHANGE_EVENT_TYPE_USER_DELETED\x10\t*{\n" +
+	"\x13ProductImportFormat\x12%\n" +
+	"!PRODUCT_IMPORT_FORMAT_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19PRODUCT_IMPORT_FORMAT_CSV\x10\x01\x12\x1e\n" +
+	"\x1aPRODUCT_IMPORT_FORMAT_JSON\x10\x02*\xab\x01\n" +
+	"\x14MenuGenerationStatus\x12&\n" +
+	"\"MENU_GENERATION_STATUS_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eMENU_GENERATION_STATUS_PENDING\x10\x01\x12$\n" +
+	" MENU_GENERATION_STATUS_COMPLETED\x10\x02\x12!\n" +
+	"\x1dMENU_GENERATION_STATUS_FAILED\x10\x032\xd0\x17\n" +
 	"\x18ProfileManagementService\x12\x84\x01\n" +
 	"\n" +
 	"CreateUser\x120.profile_management.service.v1.CreateUserRequest\x1a1.profile_management.service.v1.CreateUserResponse\"\x11\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/users\x12}\n" +
@@ -1142,18 +2614,26 @@ const file_profile_management_api_profile_management_proto_rawDesc = "" +
 	"\n" +
 	"UpdateUser\x120.profile_management.service.v1.UpdateUserRequest\x1a1.profile_management.service.v1.UpdateUserResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*2\v/users/{id}\x12\x86\x01\n" +
 	"\n" +
-	"DeleteUser\x120.profile_management.service.v1.DeleteUserRequest\x1a1.profile_management.service.v1.DeleteUserResponse\"\x13\x82\xd3\xe4\x93\x02\r*\v/users/{id}\x12\x90\x01\n" +
+	"DeleteUser\x120.profile_management.service.v1.DeleteUserRequest\x1a1.profile_management.service.v1.DeleteUserResponse\"\x13\x82\xd3\xe4\x93\x02\r*\v/users/{id}\x12\x94\x01\n" +
+	"\vRestoreUser\x121.profile_management.service.v1.RestoreUserRequest\x1a2.profile_management.service.v1.RestoreUserResponse\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/users/{id}:restore\x12\x9d\x01\n" +
+	"\x0eExportUserData\x124.profile_management.service.v1.ExportUserDataRequest\x1a2.profile_management.service.v1.ExportUserDataChunk\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/users/{user_id}/export0\x01\x12\x97\x01\n" +
+	"\x0eImportUserData\x124.profile_management.service.v1.ImportUserDataRequest\x1a5.profile_management.service.v1.ImportUserDataResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/users:import\x12\x8a\x01\n" +
+	"\tWatchUser\x12/.profile_management.service.v1.WatchUserRequest\x1a*.profile_management.service.v1.ChangeEvent\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/users/{user_id}/watch0\x01\x12\x90\x01\n" +
 	"\rCreateProduct\x123.profile_management.service.v1.CreateProductRequest\x1a4.profile_management.service.v1.CreateProductResponse\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/products\x12\x87\x01\n" +
 	"\vGetProducts\x121.profile_management.service.v1.GetProductsRequest\x1a2.profile_management.service.v1.GetProductsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/products\x12\x95\x01\n" +
 	"\rUpdateProduct\x123.profile_management.service.v1.UpdateProductRequest\x1a4.profile_management.service.v1.UpdateProductResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*2\x0e/products/{id}\x12\x92\x01\n" +
-	"\rDeleteProduct\x123.profile_management.service.v1.DeleteProductRequest\x1a4.profile_management.service.v1.DeleteProductResponse\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/products/{id}\x12\x84\x01\n" +
+	"\rDeleteProduct\x123.profile_management.service.v1.DeleteProductRequest\x1a4.profile_management.service.v1.DeleteProductResponse\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/products/{id}\x12\xaa\x01\n" +
+	"\x0eImportProducts\x124.profile_management.service.v1.ImportProductsRequest\x1a5.profile_management.service.v1.ImportProductsResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /users/{user_id}/products:import\x12\x84\x01\n" +
 	"\n" +
 	"CreateMeal\x120.profile_management.service.v1.CreateMealRequest\x1a1.profile_management.service.v1.CreateMealResponse\"\x11\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/meals\x12{\n" +
 	"\bGetMeals\x12..profile_management.service.v1.GetMealsRequest\x1a/.profile_management.service.v1.GetMealsResponse\"\x0e\x82\xd3\xe4\x93\x02\b\x12\x06/meals\x12\x89\x01\n" +
 	"\n" +
 	"UpdateMeal\x120.profile_management.service.v1.UpdateMealRequest\x1a1.profile_management.service.v1.UpdateMealResponse\"\x16\x82\xd3\xe4\x93\x02\x10:\x01*2\v/meals/{id}\x12\x86\x01\n" +
 	"\n" +
-	"DeleteMeal\x120.profile_management.service.v1.DeleteMealRequest\x1a1.profile_management.service.v1.DeleteMealResponse\"\x13\x82\xd3\xe4\x93\x02\r*\v/meals/{id}BiZggithub.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_management_apib\x06proto3"
+	"DeleteMeal\x120.profile_management.service.v1.DeleteMealRequest\x1a1.profile_management.service.v1.DeleteMealResponse\"\x13\x82\xd3\xe4\x93\x02\r*\v/meals/{id}\x12\xa6\x01\n" +
+	"\x11GetGeneratedMenus\x127.profile_management.service.v1.GetGeneratedMenusRequest\x1a8.profile_management.service.v1.GetGeneratedMenusResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/users/{user_id}/menus\x12\xc0\x01\n" +
+	"\x17GetMenuGenerationStatus\x12=.profile_management.service.v1.GetMenuGenerationStatusRequest\x1a>.profile_management.service.v1.GetMenuGenerationStatusResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/menu-generations/{request_id}\x12\xbe\x01\n" +
+	"\x15RequestMenuGeneration\x12;.profile_management.service.v1.RequestMenuGenerationRequest\x1a<.profile_management.service.v1.RequestMenuGenerationResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/users/{user_id}/menus:generateBiZggithub.com/Android12349/food_recomendation/profile_managment_service/internal/pb/profile_management_apib\x06proto3"
 
 var (
 	file_profile_management_api_profile_management_proto_rawDescOnce sync.Once
@@ -1167,87 +2647,140 @@ func file_profile_management_api_profile_management_proto_rawDescGZIP() []byte {
 	return file_profile_management_api_profile_management_proto_rawDescData
 }
 
-var file_profile_management_api_profile_management_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_profile_management_api_profile_management_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_profile_management_api_profile_management_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_profile_management_api_profile_management_proto_goTypes = []any{
-	(*CreateUserRequest)(nil),         // 0: profile_management.service.v1.CreateUserRequest
-	(*CreateUserResponse)(nil),        // 1: profile_management.service.v1.CreateUserResponse
-	(*GetUserRequest)(nil),            // 2: profile_management.service.v1.GetUserRequest
-	(*GetUserResponse)(nil),           // 3: profile_management.service.v1.GetUserResponse
-	(*UpdateUserRequest)(nil),         // 4: profile_management.service.v1.UpdateUserRequest
-	(*UpdateUserResponse)(nil),        // 5: profile_management.service.v1.UpdateUserResponse
-	(*DeleteUserRequest)(nil),         // 6: profile_management.service.v1.DeleteUserRequest
-	(*DeleteUserResponse)(nil),        // 7: profile_management.service.v1.DeleteUserResponse
-	(*CreateProductRequest)(nil),      // 8: profile_management.service.v1.CreateProductRequest
-	(*CreateProductResponse)(nil),     // 9: profile_management.service.v1.CreateProductResponse
-	(*GetProductsRequest)(nil),        // 10: profile_management.service.v1.GetProductsRequest
-	(*GetProductsResponse)(nil),       // 11: profile_management.service.v1.GetProductsResponse
-	(*UpdateProductRequest)(nil),      // 12: profile_management.service.v1.UpdateProductRequest
-	(*UpdateProductResponse)(nil),     // 13: profile_management.service.v1.UpdateProductResponse
-	(*DeleteProductRequest)(nil),      // 14: profile_management.service.v1.DeleteProductRequest
-	(*DeleteProductResponse)(nil),     // 15: profile_management.service.v1.DeleteProductResponse
-	(*CreateMealRequest)(nil),         // 16: profile_management.service.v1.CreateMealRequest
-	(*CreateMealResponse)(nil),        // 17: profile_management.service.v1.CreateMealResponse
-	(*GetMealsRequest)(nil),           // 18: profile_management.service.v1.GetMealsRequest
-	(*GetMealsResponse)(nil),          // 19: profile_management.service.v1.GetMealsResponse
-	(*UpdateMealRequest)(nil),         // 20: profile_management.service.v1.UpdateMealRequest
-	(*UpdateMealResponse)(nil),        // 21: profile_management.service.v1.UpdateMealResponse
-	(*DeleteMealRequest)(nil),         // 22: profile_management.service.v1.DeleteMealRequest
-	(*DeleteMealResponse)(nil),        // 23: profile_management.service.v1.DeleteMealResponse
-	(*models.UserCreateModel)(nil),    // 24: profile_management.models.v1.UserCreateModel
-	(*models.UserModel)(nil),          // 25: profile_management.models.v1.UserModel
-	(*models.UserUpdateModel)(nil),    // 26: profile_management.models.v1.UserUpdateModel
-	(*models.ProductCreateModel)(nil), // 27: profile_management.models.v1.ProductCreateModel
-	(*models.ProductModel)(nil),       // 28: profile_management.models.v1.ProductModel
-	(*models.ProductUpdateModel)(nil), // 29: profile_management.models.v1.ProductUpdateModel
-	(*models.MealCreateModel)(nil),    // 30: profile_management.models.v1.MealCreateModel
-	(*models.MealModel)(nil),          // 31: profile_management.models.v1.MealModel
-	(*models.MealUpdateModel)(nil),    // 32: profile_management.models.v1.MealUpdateModel
+	(UserDataArchiveFormat)(0),              // 0: profile_management.service.v1.UserDataArchiveFormat
+	(ChangeEventType)(0),                    // 1: profile_management.service.v1.ChangeEventType
+	(ProductImportFormat)(0),                // 2: profile_management.service.v1.ProductImportFormat
+	(MenuGenerationStatus)(0),               // 3: profile_management.service.v1.MenuGenerationStatus
+	(*CreateUserRequest)(nil),               // 4: profile_management.service.v1.CreateUserRequest
+	(*CreateUserResponse)(nil),              // 5: profile_management.service.v1.CreateUserResponse
+	(*GetUserRequest)(nil),                  // 6: profile_management.service.v1.GetUserRequest
+	(*GetUserResponse)(nil),                 // 7: profile_management.service.v1.GetUserResponse
+	(*UpdateUserRequest)(nil),               // 8: profile_management.service.v1.UpdateUserRequest
+	(*UpdateUserResponse)(nil),              // 9: profile_management.service.v1.UpdateUserResponse
+	(*DeleteUserRequest)(nil),               // 10: profile_management.service.v1.DeleteUserRequest
+	(*DeleteUserResponse)(nil),              // 11: profile_management.service.v1.DeleteUserResponse
+	(*RestoreUserRequest)(nil),              // 12: profile_management.service.v1.RestoreUserRequest
+	(*RestoreUserResponse)(nil),             // 13: profile_management.service.v1.RestoreUserResponse
+	(*ExportUserDataRequest)(nil),           // 14: profile_management.service.v1.ExportUserDataRequest
+	(*ExportUserDataChunk)(nil),             // 15: profile_management.service.v1.ExportUserDataChunk
+	(*ImportUserDataRequest)(nil),           // 16: profile_management.service.v1.ImportUserDataRequest
+	(*ImportUserDataResponse)(nil),          // 17: profile_management.service.v1.ImportUserDataResponse
+	(*WatchUserRequest)(nil),                // 18: profile_management.service.v1.WatchUserRequest
+	(*ChangeEvent)(nil),                     // 19: profile_management.service.v1.ChangeEvent
+	(*CreateProductRequest)(nil),            // 20: profile_management.service.v1.CreateProductRequest
+	(*CreateProductResponse)(nil),           // 21: profile_management.service.v1.CreateProductResponse
+	(*GetProductsRequest)(nil),              // 22: profile_management.service.v1.GetProductsRequest
+	(*GetProductsResponse)(nil),             // 23: profile_management.service.v1.GetProductsResponse
+	(*UpdateProductRequest)(nil),            // 24: profile_management.service.v1.UpdateProductRequest
+	(*UpdateProductResponse)(nil),           // 25: profile_management.service.v1.UpdateProductResponse
+	(*DeleteProductRequest)(nil),            // 26: profile_management.service.v1.DeleteProductRequest
+	(*DeleteProductResponse)(nil),           // 27: profile_management.service.v1.DeleteProductResponse
+	(*ImportProductsRequest)(nil),           // 28: profile_management.service.v1.ImportProductsRequest
+	(*ImportProductsResponse)(nil),          // 29: profile_management.service.v1.ImportProductsResponse
+	(*ProductImportRowResult)(nil),          // 30: profile_management.service.v1.ProductImportRowResult
+	(*CreateMealRequest)(nil),               // 31: profile_management.service.v1.CreateMealRequest
+	(*CreateMealResponse)(nil),              // 32: profile_management.service.v1.CreateMealResponse
+	(*GetMealsRequest)(nil),                 // 33: profile_management.service.v1.GetMealsRequest
+	(*GetMealsResponse)(nil),                // 34: profile_management.service.v1.GetMealsResponse
+	(*UpdateMealRequest)(nil),               // 35: profile_management.service.v1.UpdateMealRequest
+	(*UpdateMealResponse)(nil),              // 36: profile_management.service.v1.UpdateMealResponse
+	(*DeleteMealRequest)(nil),               // 37: profile_management.service.v1.DeleteMealRequest
+	(*DeleteMealResponse)(nil),              // 38: profile_management.service.v1.DeleteMealResponse
+	(*MenuGeneration)(nil),                  // 39: profile_management.service.v1.MenuGeneration
+	(*GetGeneratedMenusRequest)(nil),        // 40: profile_management.service.v1.GetGeneratedMenusRequest
+	(*GetGeneratedMenusResponse)(nil),       // 41: profile_management.service.v1.GetGeneratedMenusResponse
+	(*GetMenuGenerationStatusRequest)(nil),  // 42: profile_management.service.v1.GetMenuGenerationStatusRequest
+	(*GetMenuGenerationStatusResponse)(nil), // 43: profile_management.service.v1.GetMenuGenerationStatusResponse
+	(*RequestMenuGenerationRequest)(nil),    // 44: profile_management.service.v1.RequestMenuGenerationRequest
+	(*RequestMenuGenerationResponse)(nil),   // 45: profile_management.service.v1.RequestMenuGenerationResponse
+	(*models.UserCreateModel)(nil),          // 46: profile_management.models.v1.UserCreateModel
+	(*models.UserModel)(nil),                // 47: profile_management.models.v1.UserModel
+	(*models.UserUpdateModel)(nil),          // 48: profile_management.models.v1.UserUpdateModel
+	(*models.ProductModel)(nil),             // 49: profile_management.models.v1.ProductModel
+	(*models.MealModel)(nil),                // 50: profile_management.models.v1.MealModel
+	(*models.ProductCreateModel)(nil),       // 51: profile_management.models.v1.ProductCreateModel
+	(*models.ProductUpdateModel)(nil),       // 52: profile_management.models.v1.ProductUpdateModel
+	(*models.MealCreateModel)(nil),          // 53: profile_management.models.v1.MealCreateModel
+	(*models.MealUpdateModel)(nil),          // 54: profile_management.models.v1.MealUpdateModel
 }
 var file_profile_management_api_profile_management_proto_depIdxs = []int32{
-	24, // 0: profile_management.service.v1.CreateUserRequest.user:type_name -> profile_management.models.v1.UserCreateModel
-	25, // 1: profile_management.service.v1.CreateUserResponse.user:type_name -> profile_management.models.v1.UserModel
-	25, // 2: profile_management.service.v1.GetUserResponse.user:type_name -> profile_management.models.v1.UserModel
-	26, // 3: profile_management.service.v1.UpdateUserRequest.user:type_name -> profile_management.models.v1.UserUpdateModel
-	25, // 4: profile_management.service.v1.UpdateUserResponse.user:type_name -> profile_management.models.v1.UserModel
-	27, // 5: profile_management.service.v1.CreateProductRequest.product:type_name -> profile_management.models.v1.ProductCreateModel
-	28, // 6: profile_management.service.v1.CreateProductResponse.product:type_name -> profile_management.models.v1.ProductModel
-	28, // 7: profile_management.service.v1.GetProductsResponse.products:type_name -> profile_management.models.v1.ProductModel
-	29, // 8: profile_management.service.v1.UpdateProductRequest.product:type_name -> profile_management.models.v1.ProductUpdateModel
-	28, // 9: profile_management.service.v1.UpdateProductResponse.product:type_name -> profile_management.models.v1.ProductModel
-	30, // 10: profile_management.service.v1.CreateMealRequest.meal:type_name -> profile_management.models.v1.MealCreateModel
-	31, // 11: profile_management.service.v1.CreateMealResponse.meal:type_name -> profile_management.models.v1.MealModel
-	31, // 12: profile_management.service.v1.GetMealsResponse.meals:type_name -> profile_management.models.v1.MealModel
-	32, // 13: profile_management.service.v1.UpdateMealRequest.meal:type_name -> profile_management.models.v1.MealUpdateModel
-	31, // 14: profile_management.service.v1.UpdateMealResponse.meal:type_name -> profile_management.models.v1.MealModel
-	0,  // 15: profile_management.service.v1.ProfileManagementService.CreateUser:input_type -> profile_management.service.v1.CreateUserRequest
-	2,  // 16: profile_management.service.v1.ProfileManagementService.GetUser:input_type -> profile_management.service.v1.GetUserRequest
-	4,  // 17: profile_management.service.v1.ProfileManagementService.UpdateUser:input_type -> profile_management.service.v1.UpdateUserRequest
-	6,  // 18: profile_management.service.v1.ProfileManagementService.DeleteUser:input_type -> profile_management.service.v1.DeleteUserRequest
-	8,  // 19: profile_management.service.v1.ProfileManagementService.CreateProduct:input_type -> profile_management.service.v1.CreateProductRequest
-	10, // 20: profile_management.service.v1.ProfileManagementService.GetProducts:input_type -> profile_management.service.v1.GetProductsRequest
-	12, // 21: profile_management.service.v1.ProfileManagementService.UpdateProduct:input_type -> profile_management.service.v1.UpdateProductRequest
-	14, // 22: profile_management.service.v1.ProfileManagementService.DeleteProduct:input_type -> profile_management.service.v1.DeleteProductRequest
-	16, // 23: profile_management.service.v1.ProfileManagementService.CreateMeal:input_type -> profile_management.service.v1.CreateMealRequest
-	18, // 24: profile_management.service.v1.ProfileManagementService.GetMeals:input_type -> profile_management.service.v1.GetMealsRequest
-	20, // 25: profile_management.service.v1.ProfileManagementService.UpdateMeal:input_type -> profile_management.service.v1.UpdateMealRequest
-	22, // 26: profile_management.service.v1.ProfileManagementService.DeleteMeal:input_type -> profile_management.service.v1.DeleteMealRequest
-	1,  // 27: profile_management.service.v1.ProfileManagementService.CreateUser:output_type -> profile_management.service.v1.CreateUserResponse
-	3,  // 28: profile_management.service.v1.ProfileManagementService.GetUser:output_type -> profile_management.service.v1.GetUserResponse
-	5,  // 29: profile_management.service.v1.ProfileManagementService.UpdateUser:output_type -> profile_management.service.v1.UpdateUserResponse
-	7,  // 30: profile_management.service.v1.ProfileManagementService.DeleteUser:output_type -> profile_management.service.v1.DeleteUserResponse
-	9,  // 31: profile_management.service.v1.ProfileManagementService.CreateProduct:output_type -> profile_management.service.v1.CreateProductResponse
-	11, // 32: profile_management.service.v1.ProfileManagementService.GetProducts:output_type -> profile_management.service.v1.GetProductsResponse
-	13, // 33: profile_management.service.v1.ProfileManagementService.UpdateProduct:output_type -> profile_management.service.v1.UpdateProductResponse
-	15, // 34: profile_management.service.v1.ProfileManagementService.DeleteProduct:output_type -> profile_management.service.v1.DeleteProductResponse
-	17, // 35: profile_management.service.v1.ProfileManagementService.CreateMeal:output_type -> profile_management.service.v1.CreateMealResponse
-	19, // 36: profile_management.service.v1.ProfileManagementService.GetMeals:output_type -> profile_management.service.v1.GetMealsResponse
-	21, // 37: profile_management.service.v1.ProfileManagementService.UpdateMeal:output_type -> profile_management.service.v1.UpdateMealResponse
-	23, // 38: profile_management.service.v1.ProfileManagementService.DeleteMeal:output_type -> profile_management.service.v1.DeleteMealResponse
-	27, // [27:39] is the sub-list for method output_type
-	15, // [15:27] is the sub-list for method input_type
-	15, // [15:15] is the sub-list for extension type_name
-	15, // [15:15] is the sub-list for extension extendee
-	0,  // [0:15] is the sub-list for field type_name
+	46, // 0: profile_management.service.v1.CreateUserRequest.user:type_name -> profile_management.models.v1.UserCreateModel
+	47, // 1: profile_management.service.v1.CreateUserResponse.user:type_name -> profile_management.models.v1.UserModel
+	47, // 2: profile_management.service.v1.GetUserResponse.user:type_name -> profile_management.models.v1.UserModel
+	48, // 3: profile_management.service.v1.UpdateUserRequest.user:type_name -> profile_management.models.v1.UserUpdateModel
+	47, // 4: profile_management.service.v1.UpdateUserResponse.user:type_name -> profile_management.models.v1.UserModel
+	47, // 5: profile_management.service.v1.RestoreUserResponse.user:type_name -> profile_management.models.v1.UserModel
+	0,  // 6: profile_management.service.v1.ExportUserDataRequest.format:type_name -> profile_management.service.v1.UserDataArchiveFormat
+	0,  // 7: profile_management.service.v1.ImportUserDataRequest.format:type_name -> profile_management.service.v1.UserDataArchiveFormat
+	47, // 8: profile_management.service.v1.ImportUserDataResponse.user:type_name -> profile_management.models.v1.UserModel
+	1,  // 9: profile_management.service.v1.ChangeEvent.type:type_name -> profile_management.service.v1.ChangeEventType
+	47, // 10: profile_management.service.v1.ChangeEvent.user:type_name -> profile_management.models.v1.UserModel
+	49, // 11: profile_management.service.v1.ChangeEvent.product:type_name -> profile_management.models.v1.ProductModel
+	50, // 12: profile_management.service.v1.ChangeEvent.meal:type_name -> profile_management.models.v1.MealModel
+	51, // 13: profile_management.service.v1.CreateProductRequest.product:type_name -> profile_management.models.v1.ProductCreateModel
+	49, // 14: profile_management.service.v1.CreateProductResponse.product:type_name -> profile_management.models.v1.ProductModel
+	49, // 15: profile_management.service.v1.GetProductsResponse.products:type_name -> profile_management.models.v1.ProductModel
+	52, // 16: profile_management.service.v1.UpdateProductRequest.product:type_name -> profile_management.models.v1.ProductUpdateModel
+	49, // 17: profile_management.service.v1.UpdateProductResponse.product:type_name -> profile_management.models.v1.ProductModel
+	2,  // 18: profile_management.service.v1.ImportProductsRequest.format:type_name -> profile_management.service.v1.ProductImportFormat
+	30, // 19: profile_management.service.v1.ImportProductsResponse.rows:type_name -> profile_management.service.v1.ProductImportRowResult
+	49, // 20: profile_management.service.v1.ProductImportRowResult.product:type_name -> profile_management.models.v1.ProductModel
+	53, // 21: profile_management.service.v1.CreateMealRequest.meal:type_name -> profile_management.models.v1.MealCreateModel
+	50, // 22: profile_management.service.v1.CreateMealResponse.meal:type_name -> profile_management.models.v1.MealModel
+	50, // 23: profile_management.service.v1.GetMealsResponse.meals:type_name -> profile_management.models.v1.MealModel
+	54, // 24: profile_management.service.v1.UpdateMealRequest.meal:type_name -> profile_management.models.v1.MealUpdateModel
+	50, // 25: profile_management.service.v1.UpdateMealResponse.meal:type_name -> profile_management.models.v1.MealModel
+	3,  // 26: profile_management.service.v1.MenuGeneration.status:type_name -> profile_management.service.v1.MenuGenerationStatus
+	39, // 27: profile_management.service.v1.GetGeneratedMenusResponse.menus:type_name -> profile_management.service.v1.MenuGeneration
+	39, // 28: profile_management.service.v1.GetMenuGenerationStatusResponse.generation:type_name -> profile_management.service.v1.MenuGeneration
+	4,  // 29: profile_management.service.v1.ProfileManagementService.CreateUser:input_type -> profile_management.service.v1.CreateUserRequest
+	6,  // 30: profile_management.service.v1.ProfileManagementService.GetUser:input_type -> profile_management.service.v1.GetUserRequest
+	8,  // 31: profile_management.service.v1.ProfileManagementService.UpdateUser:input_type -> profile_management.service.v1.UpdateUserRequest
+	10, // 32: profile_management.service.v1.ProfileManagementService.DeleteUser:input_type -> profile_management.service.v1.DeleteUserRequest
+	12, // 33: profile_management.service.v1.ProfileManagementService.RestoreUser:input_type -> profile_management.service.v1.RestoreUserRequest
+	14, // 34: profile_management.service.v1.ProfileManagementService.ExportUserData:input_type -> profile_management.service.v1.ExportUserDataRequest
+	16, // 35: profile_management.service.v1.ProfileManagementService.ImportUserData:input_type -> profile_management.service.v1.ImportUserDataRequest
+	18, // 36: profile_management.service.v1.ProfileManagementService.WatchUser:input_type -> profile_management.service.v1.WatchUserRequest
+	20, // 37: profile_management.service.v1.ProfileManagementService.CreateProduct:input_type -> profile_management.service.v1.CreateProductRequest
+	22, // 38: profile_management.service.v1.ProfileManagementService.GetProducts:input_type -> profile_management.service.v1.GetProductsRequest
+	24, // 39: profile_management.service.v1.ProfileManagementService.UpdateProduct:input_type -> profile_management.service.v1.UpdateProductRequest
+	26, // 40: profile_management.service.v1.ProfileManagementService.DeleteProduct:input_type -> profile_management.service.v1.DeleteProductRequest
+	28, // 41: profile_management.service.v1.ProfileManagementService.ImportProducts:input_type -> profile_management.service.v1.ImportProductsRequest
+	31, // 42: profile_management.service.v1.ProfileManagementService.CreateMeal:input_type -> profile_management.service.v1.CreateMealRequest
+	33, // 43: profile_management.service.v1.ProfileManagementService.GetMeals:input_type -> profile_management.service.v1.GetMealsRequest
+	35, // 44: profile_management.service.v1.ProfileManagementService.UpdateMeal:input_type -> profile_management.service.v1.UpdateMealRequest
+	37, // 45: profile_management.service.v1.ProfileManagementService.DeleteMeal:input_type -> profile_management.service.v1.DeleteMealRequest
+	40, // 46: profile_management.service.v1.ProfileManagementService.GetGeneratedMenus:input_type -> profile_management.service.v1.GetGeneratedMenusRequest
+	42, // 47: profile_management.service.v1.ProfileManagementService.GetMenuGenerationStatus:input_type -> profile_management.service.v1.GetMenuGenerationStatusRequest
+	44, // 48: profile_management.service.v1.ProfileManagementService.RequestMenuGeneration:input_type -> profile_management.service.v1.RequestMenuGenerationRequest
+	5,  // 49: profile_management.service.v1.ProfileManagementService.CreateUser:output_type -> profile_management.service.v1.CreateUserResponse
+	7,  // 50: profile_management.service.v1.ProfileManagementService.GetUser:output_type -> profile_management.service.v1.GetUserResponse
+	9,  // 51: profile_management.service.v1.ProfileManagementService.UpdateUser:output_type -> profile_management.service.v1.UpdateUserResponse
+	11, // 52: profile_management.service.v1.ProfileManagementService.DeleteUser:output_type -> profile_management.service.v1.DeleteUserResponse
+	13, // 53: profile_management.service.v1.ProfileManagementService.RestoreUser:output_type -> profile_management.service.v1.RestoreUserResponse
+	15, // 54: profile_management.service.v1.ProfileManagementService.ExportUserData:output_type -> profile_management.service.v1.ExportUserDataChunk
+	17, // 55: profile_management.service.v1.ProfileManagementService.ImportUserData:output_type -> profile_management.service.v1.ImportUserDataResponse
+	19, // 56: profile_management.service.v1.ProfileManagementService.WatchUser:output_type -> profile_management.service.v1.ChangeEvent
+	21, // 57: profile_management.service.v1.ProfileManagementService.CreateProduct:output_type -> profile_management.service.v1.CreateProductResponse
+	23, // 58: profile_management.service.v1.ProfileManagementService.GetProducts:output_type -> profile_management.service.v1.GetProductsResponse
+	25, // 59: profile_management.service.v1.ProfileManagementService.UpdateProduct:output_type -> profile_management.service.v1.UpdateProductResponse
+	27, // 60: profile_management.service.v1.ProfileManagementService.DeleteProduct:output_type -> profile_management.service.v1.DeleteProductResponse
+	29, // 61: profile_management.service.v1.ProfileManagementService.ImportProducts:output_type -> profile_management.service.v1.ImportProductsResponse
+	32, // 62: profile_management.service.v1.ProfileManagementService.CreateMeal:output_type -> profile_management.service.v1.CreateMealResponse
+	34, // 63: profile_management.service.v1.ProfileManagementService.GetMeals:output_type -> profile_management.service.v1.GetMealsResponse
+	36, // 64: profile_management.service.v1.ProfileManagementService.UpdateMeal:output_type -> profile_management.service.v1.UpdateMealResponse
+	38, // 65: profile_management.service.v1.ProfileManagementService.DeleteMeal:output_type -> profile_management.service.v1.DeleteMealResponse
+	41, // 66: profile_management.service.v1.ProfileManagementService.GetGeneratedMenus:output_type -> profile_management.service.v1.GetGeneratedMenusResponse
+	43, // 67: profile_management.service.v1.ProfileManagementService.GetMenuGenerationStatus:output_type -> profile_management.service.v1.GetMenuGenerationStatusResponse
+	45, // 68: profile_management.service.v1.ProfileManagementService.RequestMenuGeneration:output_type -> profile_management.service.v1.RequestMenuGenerationResponse
+	49, // [49:69] is the sub-list for method output_type
+	29, // [29:49] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_profile_management_api_profile_management_proto_init() }
@@ -1255,18 +2788,24 @@ func file_profile_management_api_profile_management_proto_init() {
 	if File_profile_management_api_profile_management_proto != nil {
 		return
 	}
+	file_profile_management_api_profile_management_proto_msgTypes[15].OneofWrappers = []any{
+		(*ChangeEvent_User)(nil),
+		(*ChangeEvent_Product)(nil),
+		(*ChangeEvent_Meal)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_profile_management_api_profile_management_proto_rawDesc), len(file_profile_management_api_profile_management_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   24,
+			NumEnums:      4,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_profile_management_api_profile_management_proto_goTypes,
 		DependencyIndexes: file_profile_management_api_profile_management_proto_depIdxs,
+		EnumInfos:         file_profile_management_api_profile_management_proto_enumTypes,
 		MessageInfos:      file_profile_management_api_profile_management_proto_msgTypes,
 	}.Build()
 	File_profile_management_api_profile_management_proto = out.File
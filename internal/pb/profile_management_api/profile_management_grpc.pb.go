@@ -19,18 +19,26 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProfileManagementService_CreateUser_FullMethodName    = "/profile_management.service.v1.ProfileManagementService/CreateUser"
-	ProfileManagementService_GetUser_FullMethodName       = "/profile_management.service.v1.ProfileManagementService/GetUser"
-	ProfileManagementService_UpdateUser_FullMethodName    = "/profile_management.service.v1.ProfileManagementService/UpdateUser"
-	ProfileManagementService_DeleteUser_FullMethodName    = "/profile_management.service.v1.ProfileManagementService/DeleteUser"
-	ProfileManagementService_CreateProduct_FullMethodName = "/profile_management.service.v1.ProfileManagementService/CreateProduct"
-	ProfileManagementService_GetProducts_FullMethodName   = "/profile_management.service.v1.ProfileManagementService/GetProducts"
-	ProfileManagementService_UpdateProduct_FullMethodName = "/profile_management.service.v1.ProfileManagementService/UpdateProduct"
-	ProfileManagementService_DeleteProduct_FullMethodName = "/profile_management.service.v1.ProfileManagementService/DeleteProduct"
-	ProfileManagementService_CreateMeal_FullMethodName    = "/profile_management.service.v1.ProfileManagementService/CreateMeal"
-	ProfileManagementService_GetMeals_FullMethodName      = "/profile_management.service.v1.ProfileManagementService/GetMeals"
-	ProfileManagementService_UpdateMeal_FullMethodName    = "/profile_management.service.v1.ProfileManagementService/UpdateMeal"
-	ProfileManagementService_DeleteMeal_FullMethodName    = "/profile_management.service.v1.ProfileManagementService/DeleteMeal"
+	ProfileManagementService_CreateUser_FullMethodName              = "/profile_management.service.v1.ProfileManagementService/CreateUser"
+	ProfileManagementService_GetUser_FullMethodName                 = "/profile_management.service.v1.ProfileManagementService/GetUser"
+	ProfileManagementService_UpdateUser_FullMethodName              = "/profile_management.service.v1.ProfileManagementService/UpdateUser"
+	ProfileManagementService_DeleteUser_FullMethodName              = "/profile_management.service.v1.ProfileManagementService/DeleteUser"
+	ProfileManagementService_RestoreUser_FullMethodName             = "/profile_management.service.v1.ProfileManagementService/RestoreUser"
+	ProfileManagementService_ExportUserData_FullMethodName          = "/profile_management.service.v1.ProfileManagementService/ExportUserData"
+	ProfileManagementService_ImportUserData_FullMethodName          = "/profile_management.service.v1.ProfileManagementService/ImportUserData"
+	ProfileManagementService_WatchUser_FullMethodName               = "/profile_management.service.v1.ProfileManagementService/WatchUser"
+	ProfileManagementService_CreateProduct_FullMethodName           = "/profile_management.service.v1.ProfileManagementService/CreateProduct"
+	ProfileManagementService_GetProducts_FullMethodName             = "/profile_management.service.v1.ProfileManagementService/GetProducts"
+	ProfileManagementService_UpdateProduct_FullMethodName           = "/profile_management.service.v1.ProfileManagementService/UpdateProduct"
+	ProfileManagementService_DeleteProduct_FullMethodName           = "/profile_management.service.v1.ProfileManagementService/DeleteProduct"
+	ProfileManagementService_ImportProducts_FullMethodName          = "/profile_management.service.v1.ProfileManagementService/ImportProducts"
+	ProfileManagementService_CreateMeal_FullMethodName              = "/profile_management.service.v1.ProfileManagementService/CreateMeal"
+	ProfileManagementService_GetMeals_FullMethodName                = "/profile_management.service.v1.ProfileManagementService/GetMeals"
+	ProfileManagementService_UpdateMeal_FullMethodName              = "/profile_management.service.v1.ProfileManagementService/UpdateMeal"
+	ProfileManagementService_DeleteMeal_FullMethodName              = "/profile_management.service.v1.ProfileManagementService/DeleteMeal"
+	ProfileManagementService_GetGeneratedMenus_FullMethodName       = "/profile_management.service.v1.ProfileManagementService/GetGeneratedMenus"
+	ProfileManagementService_GetMenuGenerationStatus_FullMethodName = "/profile_management.service.v1.ProfileManagementService/GetMenuGenerationStatus"
+	ProfileManagementService_RequestMenuGeneration_FullMethodName   = "/profile_management.service.v1.ProfileManagementService/RequestMenuGeneration"
 )
 
 // ProfileManagementServiceClient is the client API for ProfileManagementService service.
@@ -42,16 +50,30 @@ type ProfileManagementServiceClient interface {
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
 	DeleteUser(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*DeleteUserResponse, error)
+	// Восстановление мягко удалённого пользователя в течение срока восстановления
+	RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error)
+	// Выгрузка всех данных пользователя в версионированный архив
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUserDataChunk], error)
+	// Восстановление архива выгрузки в новый аккаунт
+	ImportUserData(ctx context.Context, in *ImportUserDataRequest, opts ...grpc.CallOption) (*ImportUserDataResponse, error)
+	// Лента изменений профиля, продуктов и блюд пользователя
+	WatchUser(ctx context.Context, in *WatchUserRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error)
 	// Products CRUD
 	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error)
 	GetProducts(ctx context.Context, in *GetProductsRequest, opts ...grpc.CallOption) (*GetProductsResponse, error)
 	UpdateProduct(ctx context.Context, in *UpdateProductRequest, opts ...grpc.CallOption) (*UpdateProductResponse, error)
 	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error)
+	// Массовый импорт продуктов из CSV или JSON
+	ImportProducts(ctx context.Context, in *ImportProductsRequest, opts ...grpc.CallOption) (*ImportProductsResponse, error)
 	// Meals CRUD
 	CreateMeal(ctx context.Context, in *CreateMealRequest, opts ...grpc.CallOption) (*CreateMealResponse, error)
 	GetMeals(ctx context.Context, in *GetMealsRequest, opts ...grpc.CallOption) (*GetMealsResponse, error)
 	UpdateMeal(ctx context.Context, in *UpdateMealRequest, opts ...grpc.CallOption) (*UpdateMealResponse, error)
 	DeleteMeal(ctx context.Context, in *DeleteMealRequest, opts ...grpc.CallOption) (*DeleteMealResponse, error)
+	// Menu generation
+	GetGeneratedMenus(ctx context.Context, in *GetGeneratedMenusRequest, opts ...grpc.CallOption) (*GetGeneratedMenusResponse, error)
+	GetMenuGenerationStatus(ctx context.Context, in *GetMenuGenerationStatusRequest, opts ...grpc.CallOption) (*GetMenuGenerationStatusResponse, error)
+	RequestMenuGeneration(ctx context.Context, in *RequestMenuGenerationRequest, opts ...grpc.CallOption) (*RequestMenuGenerationResponse, error)
 }
 
 type profileManagementServiceClient struct {
@@ -102,6 +124,64 @@ func (c *profileManagementServiceClient) DeleteUser(ctx context.Context, in *Del
 	return out, nil
 }
 
+func (c *profileManagementServiceClient) RestoreUser(ctx context.Context, in *RestoreUserRequest, opts ...grpc.CallOption) (*RestoreUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreUserResponse)
+	err := c.cc.Invoke(ctx, ProfileManagementService_RestoreUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileManagementServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportUserDataChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProfileManagementService_ServiceDesc.Streams[0], ProfileManagementService_ExportUserData_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportUserDataRequest, ExportUserDataChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProfileManagementService_ExportUserDataClient = grpc.ServerStreamingClient[ExportUserDataChunk]
+
+func (c *profileManagementServiceClient) ImportUserData(ctx context.Context, in *ImportUserDataRequest, opts ...grpc.CallOption) (*ImportUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportUserDataResponse)
+	err := c.cc.Invoke(ctx, ProfileManagementService_ImportUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileManagementServiceClient) WatchUser(ctx context.Context, in *WatchUserRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProfileManagementService_ServiceDesc.Streams[1], ProfileManagementService_WatchUser_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchUserRequest, ChangeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProfileManagementService_WatchUserClient = grpc.ServerStreamingClient[ChangeEvent]
+
 func (c *profileManagementServiceClient) CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateProductResponse)
@@ -142,6 +222,16 @@ func (c *profileManagementServiceClient) DeleteProduct(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *profileManagementServiceClient) ImportProducts(ctx context.Context, in *ImportProductsRequest, opts ...grpc.CallOption) (*ImportProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportProductsResponse)
+	err := c.cc.Invoke(ctx, ProfileManagementService_ImportProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *profileManagementServiceClient) CreateMeal(ctx context.Context, in *CreateMealRequest, opts ...grpc.CallOption) (*CreateMealResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateMealResponse)
@@ -182,6 +272,36 @@ func (c *profileManagementServiceClient) DeleteMeal(ctx context.Context, in *Del
 	return out, nil
 }
 
+func (c *profileManagementServiceClient) GetGeneratedMenus(ctx context.Context, in *GetGeneratedMenusRequest, opts ...grpc.CallOption) (*GetGeneratedMenusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGeneratedMenusResponse)
+	err := c.cc.Invoke(ctx, ProfileManagementService_GetGeneratedMenus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileManagementServiceClient) GetMenuGenerationStatus(ctx context.Context, in *GetMenuGenerationStatusRequest, opts ...grpc.CallOption) (*GetMenuGenerationStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMenuGenerationStatusResponse)
+	err := c.cc.Invoke(ctx, ProfileManagementService_GetMenuGenerationStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileManagementServiceClient) RequestMenuGeneration(ctx context.Context, in *RequestMenuGenerationRequest, opts ...grpc.CallOption) (*RequestMenuGenerationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestMenuGenerationResponse)
+	err := c.cc.Invoke(ctx, ProfileManagementService_RequestMenuGeneration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProfileManagementServiceServer is the server API for ProfileManagementService service.
 // All implementations must embed UnimplementedProfileManagementServiceServer
 // for forward compatibility.
@@ -191,16 +311,30 @@ type ProfileManagementServiceServer interface {
 	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
 	DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
+	// Восстановление мягко удалённого пользователя в течение срока восстановления
+	RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error)
+	// Выгрузка всех данных пользователя в версионированный архив
+	ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportUserDataChunk]) error
+	// Восстановление архива выгрузки в новый аккаунт
+	ImportUserData(context.Context, *ImportUserDataRequest) (*ImportUserDataResponse, error)
+	// Лента изменений профиля, продуктов и блюд пользователя
+	WatchUser(*WatchUserRequest, grpc.ServerStreamingServer[ChangeEvent]) error
 	// Products CRUD
 	CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error)
 	GetProducts(context.Context, *GetProductsRequest) (*GetProductsResponse, error)
 	UpdateProduct(context.Context, *UpdateProductRequest) (*UpdateProductResponse, error)
 	DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error)
+	// Массовый импорт продуктов из CSV или JSON
+	ImportProducts(context.Context, *ImportProductsRequest) (*ImportProductsResponse, error)
 	// Meals CRUD
 	CreateMeal(context.Context, *CreateMealRequest) (*CreateMealResponse, error)
 	GetMeals(context.Context, *GetMealsRequest) (*GetMealsResponse, error)
 	UpdateMeal(context.Context, *UpdateMealRequest) (*UpdateMealResponse, error)
 	DeleteMeal(context.Context, *DeleteMealRequest) (*DeleteMealResponse, error)
+	// Menu generation
+	GetGeneratedMenus(context.Context, *GetGeneratedMenusRequest) (*GetGeneratedMenusResponse, error)
+	GetMenuGenerationStatus(context.Context, *GetMenuGenerationStatusRequest) (*GetMenuGenerationStatusResponse, error)
+	RequestMenuGeneration(context.Context, *RequestMenuGenerationRequest) (*RequestMenuGenerationResponse, error)
 	mustEmbedUnimplementedProfileManagementServiceServer()
 }
 
@@ -223,6 +357,18 @@ func (UnimplementedProfileManagementServiceServer) UpdateUser(context.Context, *
 func (UnimplementedProfileManagementServiceServer) DeleteUser(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteUser not implemented")
 }
+func (UnimplementedProfileManagementServiceServer) RestoreUser(context.Context, *RestoreUserRequest) (*RestoreUserResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreUser not implemented")
+}
+func (UnimplementedProfileManagementServiceServer) ExportUserData(*ExportUserDataRequest, grpc.ServerStreamingServer[ExportUserDataChunk]) error {
+	return status.Error(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedProfileManagementServiceServer) ImportUserData(context.Context, *ImportUserDataRequest) (*ImportUserDataResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportUserData not implemented")
+}
+func (UnimplementedProfileManagementServiceServer) WatchUser(*WatchUserRequest, grpc.ServerStreamingServer[ChangeEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchUser not implemented")
+}
 func (UnimplementedProfileManagementServiceServer) CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateProduct not implemented")
 }
@@ -235,6 +381,9 @@ func (UnimplementedProfileManagementServiceServer) UpdateProduct(context.Context
 func (UnimplementedProfileManagementServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteProduct not implemented")
 }
+func (UnimplementedProfileManagementServiceServer) ImportProducts(context.Context, *ImportProductsRequest) (*ImportProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ImportProducts not implemented")
+}
 func (UnimplementedProfileManagementServiceServer) CreateMeal(context.Context, *CreateMealRequest) (*CreateMealResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateMeal not implemented")
 }
@@ -247,6 +396,15 @@ func (UnimplementedProfileManagementServiceServer) UpdateMeal(context.Context, *
 func (UnimplementedProfileManagementServiceServer) DeleteMeal(context.Context, *DeleteMealRequest) (*DeleteMealResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteMeal not implemented")
 }
+func (UnimplementedProfileManagementServiceServer) GetGeneratedMenus(context.Context, *GetGeneratedMenusRequest) (*GetGeneratedMenusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetGeneratedMenus not implemented")
+}
+func (UnimplementedProfileManagementServiceServer) GetMenuGenerationStatus(context.Context, *GetMenuGenerationStatusRequest) (*GetMenuGenerationStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMenuGenerationStatus not implemented")
+}
+func (UnimplementedProfileManagementServiceServer) RequestMenuGeneration(context.Context, *RequestMenuGenerationRequest) (*RequestMenuGenerationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestMenuGeneration not implemented")
+}
 func (UnimplementedProfileManagementServiceServer) mustEmbedUnimplementedProfileManagementServiceServer() {
 }
 func (UnimplementedProfileManagementServiceServer) testEmbeddedByValue() {}
@@ -341,6 +499,64 @@ func _ProfileManagementService_DeleteUser_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileManagementService_RestoreUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileManagementServiceServer).RestoreUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileManagementService_RestoreUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileManagementServiceServer).RestoreUser(ctx, req.(*RestoreUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileManagementService_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportUserDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProfileManagementServiceServer).ExportUserData(m, &grpc.GenericServerStream[ExportUserDataRequest, ExportUserDataChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProfileManagementService_ExportUserDataServer = grpc.ServerStreamingServer[ExportUserDataChunk]
+
+func _ProfileManagementService_ImportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileManagementServiceServer).ImportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileManagementService_ImportUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileManagementServiceServer).ImportUserData(ctx, req.(*ImportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileManagementService_WatchUser_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUserRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProfileManagementServiceServer).WatchUser(m, &grpc.GenericServerStream[WatchUserRequest, ChangeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProfileManagementService_WatchUserServer = grpc.ServerStreamingServer[ChangeEvent]
+
 func _ProfileManagementService_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateProductRequest)
 	if err := dec(in); err != nil {
@@ -413,6 +629,24 @@ func _ProfileManagementService_DeleteProduct_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileManagementService_ImportProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileManagementServiceServer).ImportProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileManagementService_ImportProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileManagementServiceServer).ImportProducts(ctx, req.(*ImportProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProfileManagementService_CreateMeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateMealRequest)
 	if err := dec(in); err != nil {
@@ -485,6 +719,60 @@ func _ProfileManagementService_DeleteMeal_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileManagementService_GetGeneratedMenus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGeneratedMenusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileManagementServiceServer).GetGeneratedMenus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileManagementService_GetGeneratedMenus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileManagementServiceServer).GetGeneratedMenus(ctx, req.(*GetGeneratedMenusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileManagementService_GetMenuGenerationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMenuGenerationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileManagementServiceServer).GetMenuGenerationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileManagementService_GetMenuGenerationStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileManagementServiceServer).GetMenuGenerationStatus(ctx, req.(*GetMenuGenerationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProfileManagementService_RequestMenuGeneration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestMenuGenerationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileManagementServiceServer).RequestMenuGeneration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileManagementService_RequestMenuGeneration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileManagementServiceServer).RequestMenuGeneration(ctx, req.(*RequestMenuGenerationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProfileManagementService_ServiceDesc is the grpc.ServiceDesc for ProfileManagementService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -508,6 +796,14 @@ var ProfileManagementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUser",
 			Handler:    _ProfileManagementService_DeleteUser_Handler,
 		},
+		{
+			MethodName: "RestoreUser",
+			Handler:    _ProfileManagementService_RestoreUser_Handler,
+		},
+		{
+			MethodName: "ImportUserData",
+			Handler:    _ProfileManagementService_ImportUserData_Handler,
+		},
 		{
 			MethodName: "CreateProduct",
 			Handler:    _ProfileManagementService_CreateProduct_Handler,
@@ -524,6 +820,10 @@ var ProfileManagementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteProduct",
 			Handler:    _ProfileManagementService_DeleteProduct_Handler,
 		},
+		{
+			MethodName: "ImportProducts",
+			Handler:    _ProfileManagementService_ImportProducts_Handler,
+		},
 		{
 			MethodName: "CreateMeal",
 			Handler:    _ProfileManagementService_CreateMeal_Handler,
@@ -540,7 +840,30 @@ var ProfileManagementService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteMeal",
 			Handler:    _ProfileManagementService_DeleteMeal_Handler,
 		},
+		{
+			MethodName: "GetGeneratedMenus",
+			Handler:    _ProfileManagementService_GetGeneratedMenus_Handler,
+		},
+		{
+			MethodName: "GetMenuGenerationStatus",
+			Handler:    _ProfileManagementService_GetMenuGenerationStatus_Handler,
+		},
+		{
+			MethodName: "RequestMenuGeneration",
+			Handler:    _ProfileManagementService_RequestMenuGeneration_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _ProfileManagementService_ExportUserData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchUser",
+			Handler:       _ProfileManagementService_WatchUser_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "profile_management_api/profile_management.proto",
 }
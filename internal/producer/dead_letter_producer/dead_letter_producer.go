@@ -0,0 +1,25 @@
+package dead_letter_producer
+
+import (
+	"github.com/segmentio/kafka-go"
+)
+
+// DeadLetterProducer повторно отправляет сохранённые в dead-letter сообщения в их исходные топики
+type DeadLetterProducer struct {
+	writer *kafka.Writer
+}
+
+func NewDeadLetterProducer(kafkaBroker []string) *DeadLetterProducer {
+	return &DeadLetterProducer{
+		// Топик не задан: он берётся из каждого сообщения
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(kafkaBroker...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+func (p *DeadLetterProducer) Close() error {
+	return p.writer.Close()
+}
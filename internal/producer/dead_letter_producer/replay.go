@@ -0,0 +1,32 @@
+package dead_letter_producer
+
+import (
+	"context"
+	"expvar"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+var deadLettersReplayed = expvar.NewMap("kafka_dead_letters_replayed")
+
+// ReplayDeadLetter отправляет сообщение в исходный топик с прежними ключом, телом и заголовками
+func (p *DeadLetterProducer) ReplayDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error {
+	msg := kafka.Message{
+		Topic:   deadLetter.Topic,
+		Key:     deadLetter.Key,
+		Value:   deadLetter.Value,
+		Headers: make([]kafka.Header, 0, len(deadLetter.Headers)),
+	}
+	for _, header := range deadLetter.Headers {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: header.Key, Value: header.Value})
+	}
+
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		return errors.Wrap(err, "failed to write message to kafka")
+	}
+
+	deadLettersReplayed.Add(deadLetter.Topic, 1)
+	return nil
+}
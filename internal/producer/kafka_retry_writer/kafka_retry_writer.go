@@ -0,0 +1,76 @@
+package kafka_retry_writer
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/segmentio/kafka-go"
+)
+
+// ErrDeadLettered сообщение не опубликовано, но сохранено в dead-letter и может быть отправлено повторно
+var ErrDeadLettered = errors.New("kafka message saved to dead letters")
+
+// Счётчики по топикам, доступны в /debug/vars
+var (
+	publishRetries     = expvar.NewMap("kafka_publish_retries")
+	publishFailures    = expvar.NewMap("kafka_publish_failures")
+	deadLettersSaved   = expvar.NewMap("kafka_dead_letters_saved")
+	deadLettersDropped = expvar.NewMap("kafka_dead_letters_dropped")
+)
+
+// MessageWriter пишет сообщения в топик, реализуется kafka.Writer
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// DeadLetterStorage сохраняет сообщения, которые не удалось опубликовать после всех попыток
+type DeadLetterStorage interface {
+	CreateDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error
+}
+
+// RetryPolicy политика повторов публикации
+type RetryPolicy struct {
+	// MaxAttempts общее число попыток, включая первую
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Backoff задержка после неудачной попытки attempt (с 1): экспоненциальный рост до MaxBackoff,
+// из которого случайна вторая половина, чтобы продюсеры не повторяли запросы одновременно
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 {
+		backoff = min(backoff, p.MaxBackoff)
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	half := backoff / 2
+	return half + rand.N(backoff-half+1)
+}
+
+// RetryWriter публикует сообщения в топик с повторами, а исчерпав попытки сохраняет сообщение в dead-letter
+type RetryWriter struct {
+	writer      MessageWriter
+	topic       string
+	policy      RetryPolicy
+	deadLetters DeadLetterStorage
+}
+
+func NewRetryWriter(writer MessageWriter, topic string, policy RetryPolicy, deadLetters DeadLetterStorage) *RetryWriter {
+	return &RetryWriter{
+		writer:      writer,
+		topic:       topic,
+		policy:      policy,
+		deadLetters: deadLetters,
+	}
+}
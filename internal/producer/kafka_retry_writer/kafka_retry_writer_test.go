@@ -0,0 +1,117 @@
+package kafka_retry_writer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/segmentio/kafka-go"
+	"gotest.tools/v3/assert"
+)
+
+type flakyWriter struct {
+	failures int
+	calls    int
+}
+
+func (w *flakyWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.calls++
+	if w.calls <= w.failures {
+		return errors.New("kafka unavailable")
+	}
+	return nil
+}
+
+type recordingDeadLetterStorage struct {
+	deadLetters []*models.DeadLetter
+	err         error
+}
+
+func (s *recordingDeadLetterStorage) CreateDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.deadLetters = append(s.deadLetters, deadLetter)
+	return nil
+}
+
+var testPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+func testMessage() kafka.Message {
+	return kafka.Message{
+		Key:     []byte("user_1"),
+		Value:   []byte(`{"event_type":"UserCreated"}`),
+		Headers: []kafka.Header{{Key: "content_type", Value: []byte("application/json")}},
+	}
+}
+
+func TestWriteMessageRetriesUntilSuccess(t *testing.T) {
+	writer := &flakyWriter{failures: 2}
+	storage := &recordingDeadLetterStorage{}
+
+	err := NewRetryWriter(writer, "profile-events.v1", testPolicy, storage).WriteMessage(context.Background(), 1, testMessage())
+	assert.NilError(t, err)
+	assert.Equal(t, writer.calls, 3)
+	assert.Equal(t, len(storage.deadLetters), 0)
+}
+
+func TestWriteMessageSavesDeadLetter(t *testing.T) {
+	writer := &flakyWriter{failures: 10}
+	storage := &recordingDeadLetterStorage{}
+
+	err := NewRetryWriter(writer, "profile-events.v1", testPolicy, storage).WriteMessage(context.Background(), 1, testMessage())
+	assert.Assert(t, errors.Is(err, ErrDeadLettered))
+	assert.Equal(t, writer.calls, 3)
+
+	assert.Equal(t, len(storage.deadLetters), 1)
+	deadLetter := storage.deadLetters[0]
+	assert.Assert(t, deadLetter.ID != "")
+	assert.Equal(t, deadLetter.UserID, int32(1))
+	assert.Equal(t, deadLetter.Topic, "profile-events.v1")
+	assert.Equal(t, string(deadLetter.Key), "user_1")
+	assert.Equal(t, deadLetter.Attempts, int32(3))
+	assert.Equal(t, deadLetter.Error, "kafka unavailable")
+	assert.DeepEqual(t, deadLetter.Headers, []models.DeadLetterHeader{{Key: "content_type", Value: []byte("application/json")}})
+}
+
+func TestWriteMessageDeadLetterStorageError(t *testing.T) {
+	writer := &flakyWriter{failures: 10}
+	storage := &recordingDeadLetterStorage{err: errors.New("shard unavailable")}
+
+	err := NewRetryWriter(writer, "profile-events.v1", testPolicy, storage).WriteMessage(context.Background(), 1, testMessage())
+	assert.ErrorContains(t, err, "kafka unavailable")
+	assert.Assert(t, !errors.Is(err, ErrDeadLettered))
+}
+
+func TestWriteMessageCancelledContextStillSavesDeadLetter(t *testing.T) {
+	writer := &flakyWriter{failures: 10}
+	storage := &recordingDeadLetterStorage{}
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour, MaxBackoff: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := NewRetryWriter(writer, "profile-events.v1", policy, storage).WriteMessage(ctx, 1, testMessage())
+	assert.Assert(t, errors.Is(err, ErrDeadLettered))
+	assert.Equal(t, writer.calls, 1)
+	assert.Equal(t, storage.deadLetters[0].Attempts, int32(1))
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, want := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		5:  time.Second,
+		20: time.Second,
+	} {
+		for range 10 {
+			backoff := policy.Backoff(attempt)
+			assert.Assert(t, backoff >= want/2 && backoff <= want, "attempt %d: %s", attempt, backoff)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package kafka_retry_writer
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// errQueueFull очередь публикации заполнена, сообщение сохраняется в dead-letter без попыток отправки
+var errQueueFull = errors.New("kafka publish queue is full")
+
+// publishQueueOverflows сколько сообщений не поместилось в очередь, по топикам; доступно в /debug/vars
+var publishQueueOverflows = expvar.NewMap("kafka_publish_queue_overflows")
+
+type queuedMessage struct {
+	ctx    context.Context
+	userID int32
+	msg    kafka.Message
+}
+
+// QueueWriter публикует сообщения через RetryWriter в фоне, чтобы повторы и задержки Kafka не задерживали
+// запросы. Сообщения отправляются одной горутиной в порядке постановки, поэтому события пользователя
+// не переставляются. Очередь ограничена: если она заполнена или закрыта, сообщение сразу сохраняется в dead-letter.
+type QueueWriter struct {
+	retryWriter *RetryWriter
+	queue       chan queuedMessage
+	done        chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func NewQueueWriter(retryWriter *RetryWriter, size int) *QueueWriter {
+	q := &QueueWriter{
+		retryWriter: retryWriter,
+		queue:       make(chan queuedMessage, size),
+		done:        make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// Enqueue ставит сообщение пользователя userID в очередь и сразу возвращается. Ошибка означает, что очередь
+// заполнена: сообщение сохранено в dead-letter (ошибка оборачивает ErrDeadLettered) или потеряно.
+func (q *QueueWriter) Enqueue(ctx context.Context, userID int32, msg kafka.Message) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	// Запрос завершится раньше публикации, поэтому его отмена не должна прерывать повторы
+	item := queuedMessage{ctx: context.WithoutCancel(ctx), userID: userID, msg: msg}
+	if !q.closed {
+		select {
+		case q.queue <- item:
+			return nil
+		default:
+		}
+	}
+
+	publishQueueOverflows.Add(q.retryWriter.topic, 1)
+	return q.retryWriter.saveDeadLetter(item.ctx, userID, msg, 0, errQueueFull)
+}
+
+// Close перестаёт принимать сообщения и ждёт, пока опубликуются уже поставленные в очередь
+func (q *QueueWriter) Close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.queue)
+	}
+	q.mu.Unlock()
+
+	<-q.done
+}
+
+func (q *QueueWriter) run() {
+	defer close(q.done)
+	for item := range q.queue {
+		// Ошибка уже залогирована, а сообщение сохранено в dead-letter
+		_ = q.retryWriter.WriteMessage(item.ctx, item.userID, item.msg)
+	}
+}
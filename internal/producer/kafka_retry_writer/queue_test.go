@@ -0,0 +1,60 @@
+package kafka_retry_writer
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"gotest.tools/v3/assert"
+)
+
+// gatedWriter сообщает о каждом вызове в started и не возвращается из WriteMessages, пока не открыт gate
+type gatedWriter struct {
+	started chan struct{}
+	gate    chan struct{}
+
+	mu   sync.Mutex
+	keys []string
+}
+
+func (w *gatedWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	w.started <- struct{}{}
+	<-w.gate
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for _, msg := range msgs {
+		w.keys = append(w.keys, string(msg.Key))
+	}
+	return nil
+}
+
+func TestQueueWriterDoesNotWaitForKafka(t *testing.T) {
+	writer := &gatedWriter{started: make(chan struct{}, 3), gate: make(chan struct{})}
+	deadLetters := &recordingDeadLetterStorage{}
+	queue := NewQueueWriter(NewRetryWriter(writer, "profile-events", testPolicy, deadLetters), 2)
+
+	// Первое сообщение забирает горутина публикации, следующие два ждут в очереди, четвёртое не помещается
+	assert.NilError(t, queue.Enqueue(context.Background(), 1, kafka.Message{Key: []byte("user_1")}))
+	<-writer.started
+	assert.NilError(t, queue.Enqueue(context.Background(), 1, kafka.Message{Key: []byte("user_2")}))
+	assert.NilError(t, queue.Enqueue(context.Background(), 1, kafka.Message{Key: []byte("user_3")}))
+	err := queue.Enqueue(context.Background(), 1, kafka.Message{Key: []byte("user_4")})
+	assert.ErrorIs(t, err, ErrDeadLettered)
+	assert.Equal(t, len(deadLetters.deadLetters), 1)
+	assert.DeepEqual(t, deadLetters.deadLetters[0].Key, []byte("user_4"))
+
+	close(writer.gate)
+	queue.Close()
+	assert.DeepEqual(t, writer.keys, []string{"user_1", "user_2", "user_3"})
+}
+
+func TestQueueWriterAfterCloseSavesDeadLetter(t *testing.T) {
+	deadLetters := &recordingDeadLetterStorage{}
+	queue := NewQueueWriter(NewRetryWriter(&flakyWriter{}, "profile-events", testPolicy, deadLetters), 1)
+	queue.Close()
+
+	err := queue.Enqueue(context.Background(), 1, testMessage())
+	assert.ErrorIs(t, err, ErrDeadLettered)
+	assert.Equal(t, len(deadLetters.deadLetters), 1)
+}
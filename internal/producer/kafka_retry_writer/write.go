@@ -0,0 +1,91 @@
+package kafka_retry_writer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+)
+
+// WriteMessage публикует сообщение пользователя userID. Если все попытки неудачны, сообщение сохраняется
+// в dead-letter на шарде пользователя и возвращается ошибка, оборачивающая ErrDeadLettered.
+// Если сохранить тоже не удалось, возвращается ошибка публикации и сообщение теряется.
+func (w *RetryWriter) WriteMessage(ctx context.Context, userID int32, msg kafka.Message) error {
+	maxAttempts := max(w.policy.MaxAttempts, 1)
+
+	var err error
+	attempts := 0
+	for attempts < maxAttempts {
+		attempts++
+		err = w.writer.WriteMessages(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		if attempts == maxAttempts {
+			break
+		}
+
+		publishRetries.Add(w.topic, 1)
+		backoff := w.policy.Backoff(attempts)
+		slog.Warn("kafka publish failed, retrying",
+			"topic", w.topic, "user_id", userID, "attempt", attempts, "backoff", backoff, "error", err)
+		if !sleep(ctx, backoff) {
+			break
+		}
+	}
+
+	publishFailures.Add(w.topic, 1)
+	slog.Error("kafka publish failed, saving to dead letters",
+		"topic", w.topic, "user_id", userID, "attempts", attempts, "error", err)
+
+	return w.saveDeadLetter(ctx, userID, msg, attempts, err)
+}
+
+func (w *RetryWriter) saveDeadLetter(ctx context.Context, userID int32, msg kafka.Message, attempts int, publishErr error) error {
+	if w.deadLetters == nil {
+		deadLettersDropped.Add(w.topic, 1)
+		return publishErr
+	}
+
+	deadLetter := &models.DeadLetter{
+		ID:       uuid.New().String(),
+		UserID:   userID,
+		Topic:    w.topic,
+		Key:      msg.Key,
+		Value:    msg.Value,
+		Headers:  make([]models.DeadLetterHeader, 0, len(msg.Headers)),
+		Error:    publishErr.Error(),
+		Attempts: int32(attempts),
+	}
+	for _, header := range msg.Headers {
+		deadLetter.Headers = append(deadLetter.Headers, models.DeadLetterHeader{Key: header.Key, Value: header.Value})
+	}
+
+	// Запрос мог быть отменён во время повторов, но сообщение всё равно нужно сохранить
+	if err := w.deadLetters.CreateDeadLetter(context.WithoutCancel(ctx), deadLetter); err != nil {
+		deadLettersDropped.Add(w.topic, 1)
+		slog.Error("failed to save kafka message to dead letters, message lost",
+			"topic", w.topic, "user_id", userID, "error", err)
+		return publishErr
+	}
+
+	deadLettersSaved.Add(w.topic, 1)
+	return fmt.Errorf("%w: id %s: %v", ErrDeadLettered, deadLetter.ID, publishErr)
+}
+
+// sleep ждёт d и возвращает false, если контекст отменён раньше
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,136 @@
+package menu_generation_producer
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/api"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/events"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	contentTypeJSON     = "application/json"
+	contentTypeProtobuf = "application/x-protobuf"
+
+	confluentMagicByte = 0
+)
+
+// encode сериализует событие в выбранном формате и возвращает тело сообщения и его content_type
+func (p *MenuGenerationProducer) encode(ctx context.Context, event *models.MenuGenerationRequestEvent) ([]byte, string, error) {
+	if p.encoding != EncodingProtobuf {
+		eventJSON, err := json.Marshal(event)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to marshal menu generation event")
+		}
+		return eventJSON, contentTypeJSON, nil
+	}
+
+	schemaID, err := p.registerSchema(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	value, err := encodeConfluentProtobuf(schemaID, menuGenerationRequestEventToProto(event))
+	if err != nil {
+		return nil, "", err
+	}
+	return value, contentTypeProtobuf, nil
+}
+
+// registerSchema регистрирует схему события в subject <topic>-value (TopicNameStrategy) один раз на продюсер.
+// Ошибка регистрации не кэшируется: следующая публикация повторит попытку.
+func (p *MenuGenerationProducer) registerSchema(ctx context.Context) (int32, error) {
+	p.schemaMu.Lock()
+	defer p.schemaMu.Unlock()
+
+	if p.schemaID != 0 {
+		return p.schemaID, nil
+	}
+
+	schemaID, err := p.schemaRegistry.RegisterSchema(ctx, p.topicName+"-value", api.MenuGenerationEventSchema)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to register menu generation event schema")
+	}
+	p.schemaID = schemaID
+	return schemaID, nil
+}
+
+// encodeConfluentProtobuf кодирует сообщение в Confluent wire format: магический байт, идентификатор схемы
+// big-endian, индексы сообщения в файле схемы и protobuf. Сообщение первое в файле, поэтому индексы
+// записываются одним нулевым байтом.
+func encodeConfluentProtobuf(schemaID int32, message proto.Message) ([]byte, error) {
+	payload, err := proto.Marshal(message)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal menu generation event")
+	}
+
+	value := make([]byte, 0, 6+len(payload))
+	value = append(value, confluentMagicByte)
+	value = binary.BigEndian.AppendUint32(value, uint32(schemaID))
+	value = append(value, 0)
+	return append(value, payload...), nil
+}
+
+func menuGenerationRequestEventToProto(event *models.MenuGenerationRequestEvent) *events.MenuGenerationRequestEvent {
+	pbEvent := &events.MenuGenerationRequestEvent{
+		SchemaVersion: int32(event.SchemaVersion),
+		RequestId:     event.RequestID,
+		UserId:        event.UserID,
+		Preferences: &events.MenuGenerationPreferences{
+			Budget:   event.Preferences.Budget,
+			Products: event.Preferences.Products,
+			Details:  string(event.Preferences.Details),
+			Raw:      event.Preferences.Raw,
+		},
+		Products:  make([]*events.MenuGenerationProduct, 0, len(event.Products)),
+		Meals:     make([]*events.MenuGenerationMeal, 0, len(event.Meals)),
+		Timestamp: event.Timestamp,
+	}
+
+	if bju := event.Preferences.BJU; bju != nil {
+		pbEvent.Preferences.Bju = &events.MenuGenerationBJU{Protein: bju.Protein, Fat: bju.Fat, Carbs: bju.Carbs}
+	}
+	if event.Body != nil {
+		pbEvent.Body = &events.MenuGenerationBody{Height: event.Body.Height, Weight: event.Body.Weight}
+	}
+	if options := event.Options; options != nil {
+		pbEvent.Options = &events.MenuGenerationOptions{
+			DateFrom:    options.DateFrom,
+			DateTo:      options.DateTo,
+			MealsPerDay: options.MealsPerDay,
+			Budget:      options.Budget,
+		}
+	}
+
+	for _, product := range event.Products {
+		pbEvent.Products = append(pbEvent.Products, &events.MenuGenerationProduct{
+			Id:       product.ID,
+			Name:     product.Name,
+			Calories: product.Calories,
+			Protein:  product.Protein,
+			Fat:      product.Fat,
+			Carbs:    product.Carbs,
+		})
+	}
+
+	for _, meal := range event.Meals {
+		pbMeal := &events.MenuGenerationMeal{
+			Id:       meal.ID,
+			Name:     meal.Name,
+			Products: make([]*events.MenuGenerationMealProduct, 0, len(meal.Products)),
+		}
+		for _, product := range meal.Products {
+			pbMeal.Products = append(pbMeal.Products, &events.MenuGenerationMealProduct{
+				ProductId: product.ProductID,
+				Quantity:  product.Quantity,
+			})
+		}
+		pbEvent.Meals = append(pbEvent.Meals, pbMeal)
+	}
+
+	return pbEvent
+}
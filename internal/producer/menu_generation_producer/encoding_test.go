@@ -0,0 +1,109 @@
+package menu_generation_producer
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/api"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/pb/events"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/kafka_retry_writer"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/v3/assert"
+)
+
+type recordingSchemaRegistry struct {
+	subjects []string
+	schemas  []string
+	err      error
+}
+
+func (r *recordingSchemaRegistry) RegisterSchema(ctx context.Context, subject, schema string) (int32, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	r.subjects = append(r.subjects, subject)
+	r.schemas = append(r.schemas, schema)
+	return 42, nil
+}
+
+func testEvent() *models.MenuGenerationRequestEvent {
+	event := buildMenuGenerationRequestEvent(&models.MenuGenerationRequest{
+		RequestID: "req-1",
+		User:      &models.User{ID: 7, Weight: int32Ptr(75), BJU: &models.BJU{Protein: 120, Fat: 60, Carbs: 250}},
+		Products:  []*models.Product{{ID: 1, Name: "Рис", Calories: int32Ptr(130)}},
+		Meals:     []*models.Meal{{ID: 3, Name: "Рис отварной", Products: []models.MealProduct{{ProductID: 1, Quantity: int32Ptr(200)}}}},
+		Options:   &models.MenuGenerationOptions{MealsPerDay: 3},
+	}, time.Date(2025, 12, 26, 15, 0, 0, 0, time.UTC))
+	return &event
+}
+
+func TestNewMenuGenerationProducerEncoding(t *testing.T) {
+	producer, err := NewMenuGenerationProducer(nil, "menu-generation-requests", "", nil, kafka_retry_writer.RetryPolicy{}, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, producer.encoding, EncodingJSON)
+
+	_, err = NewMenuGenerationProducer(nil, "menu-generation-requests", EncodingProtobuf, nil, kafka_retry_writer.RetryPolicy{}, nil)
+	assert.ErrorContains(t, err, "schema registry is required")
+
+	_, err = NewMenuGenerationProducer(nil, "menu-generation-requests", "avro", nil, kafka_retry_writer.RetryPolicy{}, nil)
+	assert.ErrorContains(t, err, "unknown menu generation event encoding")
+}
+
+func TestEncodeProtobufConfluentWireFormat(t *testing.T) {
+	registry := &recordingSchemaRegistry{}
+	producer, err := NewMenuGenerationProducer(nil, "menu-generation-requests", EncodingProtobuf, registry, kafka_retry_writer.RetryPolicy{}, nil)
+	assert.NilError(t, err)
+
+	value, contentType, err := producer.encode(context.Background(), testEvent())
+	assert.NilError(t, err)
+	assert.Equal(t, contentType, contentTypeProtobuf)
+
+	assert.Equal(t, value[0], byte(confluentMagicByte))
+	assert.Equal(t, binary.BigEndian.Uint32(value[1:5]), uint32(42))
+	assert.Equal(t, value[5], byte(0))
+
+	var decoded events.MenuGenerationRequestEvent
+	assert.NilError(t, proto.Unmarshal(value[6:], &decoded))
+	assert.Equal(t, decoded.GetSchemaVersion(), int32(models.MenuGenerationRequestSchemaVersion))
+	assert.Equal(t, decoded.GetUserId(), int32(7))
+	assert.Equal(t, decoded.GetPreferences().GetBju().GetProtein(), int32(120))
+	assert.Equal(t, decoded.GetBody().GetWeight(), int32(75))
+	assert.Assert(t, decoded.GetBody().Height == nil)
+	assert.Equal(t, decoded.GetProducts()[0].GetCalories(), int32(130))
+	assert.Assert(t, decoded.GetProducts()[0].Protein == nil)
+	assert.Equal(t, decoded.GetMeals()[0].GetProducts()[0].GetQuantity(), int32(200))
+	assert.Equal(t, decoded.GetOptions().GetMealsPerDay(), int32(3))
+
+	// Схема регистрируется один раз под subject по TopicNameStrategy
+	_, _, err = producer.encode(context.Background(), testEvent())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, registry.subjects, []string{"menu-generation-requests-value"})
+	assert.Equal(t, registry.schemas[0], api.MenuGenerationEventSchema)
+}
+
+func TestEncodeProtobufRegistryError(t *testing.T) {
+	registry := &recordingSchemaRegistry{err: errors.New("registry unavailable")}
+	producer, err := NewMenuGenerationProducer(nil, "menu-generation-requests", EncodingProtobuf, registry, kafka_retry_writer.RetryPolicy{}, nil)
+	assert.NilError(t, err)
+
+	_, _, err = producer.encode(context.Background(), testEvent())
+	assert.ErrorContains(t, err, "registry unavailable")
+
+	registry.err = nil
+	_, _, err = producer.encode(context.Background(), testEvent())
+	assert.NilError(t, err)
+}
+
+func TestEncodeJSON(t *testing.T) {
+	producer, err := NewMenuGenerationProducer(nil, "menu-generation-requests", EncodingJSON, nil, kafka_retry_writer.RetryPolicy{}, nil)
+	assert.NilError(t, err)
+
+	value, contentType, err := producer.encode(context.Background(), testEvent())
+	assert.NilError(t, err)
+	assert.Equal(t, contentType, contentTypeJSON)
+	assert.Equal(t, value[0], byte('{'))
+}
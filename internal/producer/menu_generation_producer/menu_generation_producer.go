@@ -1,13 +1,67 @@
 package menu_generation_producer
 
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/kafka_retry_writer"
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// EncodingJSON события сериализуются в JSON, формат по умолчанию
+	EncodingJSON = "json"
+	// EncodingProtobuf события сериализуются в protobuf в Confluent wire format
+	EncodingProtobuf = "protobuf"
+)
+
+// SchemaRegistry регистрирует схему в subject и возвращает её идентификатор для Confluent wire format
+type SchemaRegistry interface {
+	RegisterSchema(ctx context.Context, subject, schema string) (int32, error)
+}
+
 type MenuGenerationProducer struct {
-	kafkaBroker []string
-	topicName   string
+	writer         *kafka.Writer
+	retryWriter    *kafka_retry_writer.RetryWriter
+	topicName      string
+	encoding       string
+	schemaRegistry SchemaRegistry
+
+	// schemaID идентификатор схемы, полученный при первой публикации в protobuf
+	schemaMu sync.Mutex
+	schemaID int32
 }
 
-func NewMenuGenerationProducer(kafkaBroker []string, topicName string) *MenuGenerationProducer {
-	return &MenuGenerationProducer{
-		kafkaBroker: kafkaBroker,
-		topicName:   topicName,
+func NewMenuGenerationProducer(kafkaBroker []string, topicName string, encoding string, schemaRegistry SchemaRegistry, retryPolicy kafka_retry_writer.RetryPolicy, deadLetters kafka_retry_writer.DeadLetterStorage) (*MenuGenerationProducer, error) {
+	switch encoding {
+	case "":
+		encoding = EncodingJSON
+	case EncodingJSON:
+	case EncodingProtobuf:
+		if schemaRegistry == nil {
+			return nil, errors.New("schema registry is required for protobuf encoding")
+		}
+	default:
+		return nil, fmt.Errorf("unknown menu generation event encoding %q", encoding)
 	}
+
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(kafkaBroker...),
+		Topic:    topicName,
+		Balancer: &kafka.LeastBytes{},
+	}
+
+	return &MenuGenerationProducer{
+		writer:         writer,
+		retryWriter:    kafka_retry_writer.NewRetryWriter(writer, topicName, retryPolicy, deadLetters),
+		topicName:      topicName,
+		encoding:       encoding,
+		schemaRegistry: schemaRegistry,
+	}, nil
+}
+
+func (p *MenuGenerationProducer) Close() error {
+	return p.writer.Close()
 }
@@ -1,65 +1,124 @@
 package menu_generation_producer
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/segmentio/kafka-go"
 )
 
-func (p *MenuGenerationProducer) PublishMenuGenerationRequest(ctx context.Context, userID int32, bju *models.BJU, budget *int32, preferences string, productNames []string) error {
-	writer := &kafka.Writer{
-		Addr:     kafka.TCP(p.kafkaBroker...),
-		Topic:    p.topicName,
-		Balancer: &kafka.LeastBytes{},
+func (p *MenuGenerationProducer) PublishMenuGenerationRequest(ctx context.Context, request *models.MenuGenerationRequest) error {
+	event := buildMenuGenerationRequestEvent(request, time.Now())
+
+	value, contentType, err := p.encode(ctx, &event)
+	if err != nil {
+		return err
+	}
+
+	msg := kafka.Message{
+		Key:   []byte(fmt.Sprintf("user_%d", request.User.ID)),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: "content_type", Value: []byte(contentType)},
+		},
+	}
+
+	err = p.retryWriter.WriteMessage(ctx, request.User.ID, msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to write message to kafka")
 	}
-	defer writer.Close()
+
+	return nil
+}
+
+func buildMenuGenerationRequestEvent(request *models.MenuGenerationRequest, now time.Time) models.MenuGenerationRequestEvent {
+	user := request.User
 
 	event := models.MenuGenerationRequestEvent{
-		RequestID: uuid.New().String(),
-		UserID:    userID,
+		SchemaVersion: models.MenuGenerationRequestSchemaVersion,
+		RequestID:     request.RequestID,
+		UserID:        user.ID,
 		Preferences: models.MenuGenerationPrefs{
-			BJU:      bju,
-			Budget:   budget,
-			Products: productNames,
+			BJU:      user.BJU,
+			Budget:   request.Budget,
+			Products: make([]string, 0, len(request.Products)),
 		},
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Products:  make([]models.MenuGenerationProduct, 0, len(request.Products)),
+		Meals:     make([]models.MenuGenerationMeal, 0, len(request.Meals)),
+		Options:   request.Options,
+		Timestamp: now.UTC().Format(time.RFC3339),
+	}
+
+	if user.Height != nil || user.Weight != nil {
+		event.Body = &models.MenuGenerationBody{Height: user.Height, Weight: user.Weight}
 	}
 
-	if len(productNames) == 0 && preferences != "" {
-		var prefsMap map[string]interface{}
-		if err := json.Unmarshal([]byte(preferences), &prefsMap); err == nil {
-			if products, ok := prefsMap["products"].([]interface{}); ok {
-				productStrings := make([]string, 0, len(products))
-				for _, prod := range products {
-					if str, ok := prod.(string); ok {
-						productStrings = append(productStrings, str)
-					}
-				}
-				event.Preferences.Products = productStrings
+	for _, product := range request.Products {
+		event.Preferences.Products = append(event.Preferences.Products, product.Name)
+		event.Products = append(event.Products, models.MenuGenerationProduct{
+			ID:       product.ID,
+			Name:     product.Name,
+			Calories: product.Calories,
+			Protein:  product.Protein,
+			Fat:      product.Fat,
+			Carbs:    product.Carbs,
+		})
+	}
+
+	for _, meal := range request.Meals {
+		products := meal.Products
+		if len(products) == 0 {
+			// Блюда, сохранённые до появления количеств, содержат только product_ids
+			for _, productID := range meal.ProductIDs {
+				products = append(products, models.MealProduct{ProductID: productID})
 			}
 		}
+		if products == nil {
+			products = []models.MealProduct{}
+		}
+		event.Meals = append(event.Meals, models.MenuGenerationMeal{
+			ID:       meal.ID,
+			Name:     meal.Name,
+			Products: products,
+		})
 	}
 
-	eventJSON, err := json.Marshal(event)
-	if err != nil {
-		return errors.Wrap(err, "failed to marshal menu generation event")
-	}
+	setMenuGenerationPreferences(&event.Preferences, user.Preferences)
 
-	msg := kafka.Message{
-		Key:   []byte(fmt.Sprintf("user_%d", userID)),
-		Value: eventJSON,
+	return event
+}
+
+// setMenuGenerationPreferences передаёт JSON-объект предпочтений как есть, а произвольный текст в поле raw
+func setMenuGenerationPreferences(prefs *models.MenuGenerationPrefs, preferences string) {
+	trimmed := bytes.TrimSpace([]byte(preferences))
+	if len(trimmed) == 0 {
+		return
 	}
 
-	err = writer.WriteMessages(ctx, msg)
-	if err != nil {
-		return errors.Wrap(err, "failed to write message to kafka")
+	if trimmed[0] != '{' || !json.Valid(trimmed) {
+		prefs.Raw = preferences
+		return
 	}
+	prefs.Details = json.RawMessage(trimmed)
 
-	return nil
+	// Для схемы версии 1 имена продуктов брались из preferences.products, если у пользователя нет продуктов
+	if len(prefs.Products) > 0 {
+		return
+	}
+	var details struct {
+		Products []interface{} `json:"products"`
+	}
+	if err := json.Unmarshal(trimmed, &details); err != nil {
+		return
+	}
+	for _, product := range details.Products {
+		if name, ok := product.(string); ok {
+			prefs.Products = append(prefs.Products, name)
+		}
+	}
 }
@@ -0,0 +1,83 @@
+package menu_generation_producer
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"gotest.tools/v3/assert"
+)
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestBuildMenuGenerationRequestEvent(t *testing.T) {
+	now := time.Date(2025, 12, 26, 15, 0, 0, 0, time.UTC)
+	request := &models.MenuGenerationRequest{
+		RequestID: "req-1",
+		User: &models.User{
+			ID:          1,
+			Height:      int32Ptr(180),
+			BJU:         &models.BJU{Protein: 120, Fat: 60, Carbs: 250},
+			Preferences: `{"diet": "high_protein"}`,
+		},
+		Budget: int32Ptr(5000),
+		Products: []*models.Product{
+			{ID: 1, Name: "Курица", Calories: int32Ptr(165), Protein: int32Ptr(31)},
+		},
+		Meals: []*models.Meal{
+			{ID: 1, Name: "Курица с рисом", Products: []models.MealProduct{{ProductID: 1, Quantity: int32Ptr(150)}}},
+			{ID: 2, Name: "Старое блюдо", ProductIDs: []int32{1}},
+		},
+	}
+
+	event := buildMenuGenerationRequestEvent(request, now)
+
+	assert.Equal(t, event.SchemaVersion, models.MenuGenerationRequestSchemaVersion)
+	assert.Equal(t, event.UserID, int32(1))
+	assert.Equal(t, event.Timestamp, "2025-12-26T15:00:00Z")
+	assert.DeepEqual(t, event.Preferences.Products, []string{"Курица"})
+	assert.Equal(t, string(event.Preferences.Details), `{"diet": "high_protein"}`)
+	assert.Equal(t, event.Preferences.Raw, "")
+	assert.Equal(t, *event.Body.Height, int32(180))
+	assert.Assert(t, event.Body.Weight == nil)
+	assert.Equal(t, len(event.Products), 1)
+	assert.Equal(t, *event.Products[0].Calories, int32(165))
+	assert.Equal(t, len(event.Meals), 2)
+	assert.Equal(t, *event.Meals[0].Products[0].Quantity, int32(150))
+	assert.DeepEqual(t, event.Meals[1].Products, []models.MealProduct{{ProductID: 1}})
+}
+
+func TestBuildMenuGenerationRequestEventRawPreferences(t *testing.T) {
+	request := &models.MenuGenerationRequest{
+		RequestID: "req-1",
+		User:      &models.User{ID: 1, Preferences: "без глютена"},
+	}
+
+	event := buildMenuGenerationRequestEvent(request, time.Now())
+
+	assert.Equal(t, event.Preferences.Raw, "без глютена")
+	assert.Assert(t, event.Preferences.Details == nil)
+	assert.Assert(t, event.Body == nil)
+
+	// Пустые списки сериализуются как [], а не null
+	eventJSON, err := json.Marshal(event)
+	assert.NilError(t, err)
+	var decoded map[string]json.RawMessage
+	assert.NilError(t, json.Unmarshal(eventJSON, &decoded))
+	assert.Equal(t, string(decoded["products"]), "[]")
+	assert.Equal(t, string(decoded["meals"]), "[]")
+}
+
+func TestBuildMenuGenerationRequestEventLegacyProductNames(t *testing.T) {
+	request := &models.MenuGenerationRequest{
+		RequestID: "req-1",
+		User:      &models.User{ID: 1, Preferences: `{"products": ["Рис", 1, "Гречка"]}`},
+	}
+
+	event := buildMenuGenerationRequestEvent(request, time.Now())
+
+	assert.DeepEqual(t, event.Preferences.Products, []string{"Рис", "Гречка"})
+}
@@ -0,0 +1,34 @@
+package profile_events_producer
+
+import (
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/kafka_retry_writer"
+	"github.com/segmentio/kafka-go"
+)
+
+// ProfileEventsProducer публикует доменные события в топик profile-events через очередь размера queueSize,
+// поэтому запрос не ждёт Kafka. Сообщения партиционируются по ключу пользователя, чтобы события
+// одного пользователя шли по порядку.
+type ProfileEventsProducer struct {
+	writer *kafka.Writer
+	queue  *kafka_retry_writer.QueueWriter
+}
+
+func NewProfileEventsProducer(kafkaBroker []string, topicName string, retryPolicy kafka_retry_writer.RetryPolicy, deadLetters kafka_retry_writer.DeadLetterStorage, queueSize int) *ProfileEventsProducer {
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(kafkaBroker...),
+		Topic:        topicName,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+	}
+
+	return &ProfileEventsProducer{
+		writer: writer,
+		queue:  kafka_retry_writer.NewQueueWriter(kafka_retry_writer.NewRetryWriter(writer, topicName, retryPolicy, deadLetters), queueSize),
+	}
+}
+
+// Close дожидается публикации событий из очереди и закрывает соединение с Kafka
+func (p *ProfileEventsProducer) Close() error {
+	p.queue.Close()
+	return p.writer.Close()
+}
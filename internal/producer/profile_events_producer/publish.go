@@ -0,0 +1,38 @@
+package profile_events_producer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+)
+
+// PublishProfileEvent ставит событие в очередь публикации. Ошибка означает, что очередь заполнена
+// и событие сохранено в dead-letter, не дожидаясь Kafka.
+func (p *ProfileEventsProducer) PublishProfileEvent(ctx context.Context, event *models.ProfileEvent) error {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal profile event")
+	}
+
+	msg := kafka.Message{
+		Key:   []byte(fmt.Sprintf("user_%d", event.UserID)),
+		Value: eventJSON,
+		Headers: []kafka.Header{
+			{Key: "event_type", Value: []byte(event.EventType)},
+			{Key: "schema_version", Value: []byte(strconv.Itoa(event.SchemaVersion))},
+			{Key: "content_type", Value: []byte("application/json")},
+		},
+	}
+
+	err = p.queue.Enqueue(ctx, event.UserID, msg)
+	if err != nil {
+		return errors.Wrap(err, "failed to enqueue message to kafka")
+	}
+
+	return nil
+}
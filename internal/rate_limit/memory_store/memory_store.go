@@ -0,0 +1,75 @@
+package memory_store
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/rate_limit/rate_limiter"
+)
+
+// sweepInterval как часто удаляются полностью пополнившиеся корзины, чтобы память не росла с числом клиентов
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	full      time.Time
+}
+
+// MemoryStore хранит корзины в памяти процесса; лимиты действуют на каждый экземпляр сервиса отдельно
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Take забирает токен из корзины key, пополнив её за прошедшее время
+func (s *MemoryStore) Take(ctx context.Context, key string, limit rate_limiter.Limit) (rate_limiter.Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	elapsed := max(0, now.Sub(b.updatedAt).Seconds())
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		retryAfter := time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+		return rate_limiter.Result{RetryAfter: retryAfter}, nil
+	}
+
+	b.tokens--
+	b.full = now.Add(time.Duration((float64(limit.Burst) - b.tokens) / limit.Rate * float64(time.Second)))
+	return rate_limiter.Result{Allowed: true}, nil
+}
+
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, b := range s.buckets {
+		if !now.Before(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
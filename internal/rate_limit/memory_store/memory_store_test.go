@@ -0,0 +1,67 @@
+package memory_store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/rate_limit/rate_limiter"
+	"gotest.tools/v3/assert"
+)
+
+func testStore(now *time.Time) *MemoryStore {
+	store := NewMemoryStore()
+	store.now = func() time.Time { return *now }
+	return store
+}
+
+func TestTakeRejectsAfterBurst(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := testStore(&now)
+	limit := rate_limiter.Limit{Rate: 2, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		result, err := store.Take(context.Background(), "ip:10.0.0.1", limit)
+		assert.NilError(t, err)
+		assert.Assert(t, result.Allowed)
+	}
+
+	result, err := store.Take(context.Background(), "ip:10.0.0.1", limit)
+	assert.NilError(t, err)
+	assert.Assert(t, !result.Allowed)
+	assert.Equal(t, result.RetryAfter, 500*time.Millisecond)
+
+	// Другая корзина не затронута
+	result, err = store.Take(context.Background(), "ip:10.0.0.2", limit)
+	assert.NilError(t, err)
+	assert.Assert(t, result.Allowed)
+}
+
+func TestTakeRefillsOverTime(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := testStore(&now)
+	limit := rate_limiter.Limit{Rate: 1, Burst: 1}
+
+	result, _ := store.Take(context.Background(), "user:alice", limit)
+	assert.Assert(t, result.Allowed)
+	result, _ = store.Take(context.Background(), "user:alice", limit)
+	assert.Assert(t, !result.Allowed)
+
+	now = now.Add(time.Second)
+	result, _ = store.Take(context.Background(), "user:alice", limit)
+	assert.Assert(t, result.Allowed)
+}
+
+func TestSweepRemovesFullBuckets(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	store := testStore(&now)
+	limit := rate_limiter.Limit{Rate: 1, Burst: 10}
+
+	_, _ = store.Take(context.Background(), "ip:10.0.0.1", limit)
+	now = now.Add(sweepInterval)
+	_, _ = store.Take(context.Background(), "ip:10.0.0.2", limit)
+
+	_, ok := store.buckets["ip:10.0.0.1"]
+	assert.Assert(t, !ok)
+	assert.Equal(t, len(store.buckets), 1)
+}
@@ -0,0 +1,91 @@
+package rate_limiter
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/request_metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RetryAfterMetadata ключ метаданных gRPC с числом секунд до повтора; gateway отдаёт его заголовком Retry-After
+	RetryAfterMetadata = "retry-after"
+
+	forwardedForMetadata = "x-forwarded-for"
+)
+
+// UnaryServerInterceptor ограничивает вызовы по IP клиента и по подтверждённому автору запроса.
+// Должен стоять в цепочке после перехватчика, который кладёт автора в контекст.
+func (l *RateLimiter) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	method := path.Base(info.FullMethod)
+	if err := l.check(ctx, method, l.rule(method), clientIP(ctx), requestUser(ctx)); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor ограничивает открытие потоков по IP клиента
+func (l *RateLimiter) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	method := path.Base(info.FullMethod)
+	if err := l.check(ss.Context(), method, l.rule(method), clientIP(ss.Context()), ""); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (l *RateLimiter) check(ctx context.Context, method string, rule Rule, ip, user string) error {
+	allowed, retryAfter := l.allow(ctx, method, rule, ip, user)
+	if allowed {
+		return nil
+	}
+
+	seconds := retryAfterSeconds(retryAfter)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RetryAfterMetadata, strconv.Itoa(seconds)))
+	return status.Error(codes.ResourceExhausted, fmt.Sprintf("превышен лимит запросов, повторите через %d с", seconds))
+}
+
+// requestUser автор запроса, подтверждённый аутентификацией. Автор из x-actor клиент выбирает сам и может
+// менять на каждый запрос, поэтому такие запросы ограничиваются только по IP.
+func requestUser(ctx context.Context) string {
+	if !request_metadata.ActorVerified(ctx) {
+		return ""
+	}
+	return request_metadata.Actor(ctx)
+}
+
+// clientIP адрес клиента. Запросы через gateway приходят с локального адреса,
+// для них берётся последний адрес X-Forwarded-For: его добавляет сам gateway, остальные передал клиент.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	ip := hostIP(p.Addr.String())
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.IsLoopback() {
+		if forwarded := metadata.ValueFromIncomingContext(ctx, forwardedForMetadata); len(forwarded) > 0 {
+			entries := strings.Split(forwarded[len(forwarded)-1], ",")
+			if last := strings.TrimSpace(entries[len(entries)-1]); last != "" {
+				return last
+			}
+		}
+	}
+	return ip
+}
+
+func hostIP(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
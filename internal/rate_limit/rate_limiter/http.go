@@ -0,0 +1,24 @@
+package rate_limiter
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// httpMethod имя, под которым учитываются все запросы к gateway
+const httpMethod = "http"
+
+// HTTPMiddleware ограничивает запросы к gateway по IP клиента до маршрутизации.
+// Ограничения отдельных методов применяет UnaryServerInterceptor, а их ответ RESOURCE_EXHAUSTED
+// gateway превращает в 429 с тем же Retry-After.
+func (l *RateLimiter) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed, retryAfter := l.allow(r.Context(), httpMethod, l.rules.Load().httpRule, hostIP(r.RemoteAddr), "")
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds(retryAfter)))
+			http.Error(w, "превышен лимит запросов", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,127 @@
+package rate_limiter
+
+import (
+	"context"
+	"expvar"
+	"log/slog"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// Счётчики по методам, доступны в /debug/vars
+var (
+	rejectedRequests = expvar.NewMap("rate_limit_rejected")
+	storeErrors      = expvar.NewMap("rate_limit_store_errors")
+)
+
+// Limit ограничение token bucket: корзина на Burst запросов, пополняется на Rate запросов в секунду
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Enabled ограничение с нулевой скоростью или ёмкостью не применяется
+func (l Limit) Enabled() bool {
+	return l.Rate > 0 && l.Burst > 0
+}
+
+// Rule ограничения метода по IP клиента и по пользователю. PerUser применяется только к автору,
+// подтверждённому аутентификацией.
+type Rule struct {
+	PerIP   Limit
+	PerUser Limit
+}
+
+// Result результат попытки забрать токен из корзины
+type Result struct {
+	Allowed bool
+	// RetryAfter через сколько в корзине появится токен, если запрос отклонён
+	RetryAfter time.Duration
+}
+
+// Store хранит корзины token bucket по ключу
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// rules набор правил, который заменяется целиком при перезагрузке конфига
+type rules struct {
+	defaultRule Rule
+	methods     map[string]Rule
+	httpRule    Rule
+}
+
+// RateLimiter применяет ограничения к вызовам методов gRPC и запросам к gateway
+type RateLimiter struct {
+	store Store
+	rules atomic.Pointer[rules]
+}
+
+// NewRateLimiter создаёт ограничитель. Методы из methods задаются коротким именем (CreateUser),
+// для остальных используется defaultRule; httpRule применяется ко всем запросам к gateway до маршрутизации.
+func NewRateLimiter(store Store, defaultRule Rule, methods map[string]Rule, httpRule Rule) *RateLimiter {
+	l := &RateLimiter{store: store}
+	l.UpdateRules(defaultRule, methods, httpRule)
+	return l
+}
+
+// UpdateRules атомарно заменяет правила. Корзины в хранилище сохраняются: новый лимит применяется
+// к уже накопленным токенам.
+func (l *RateLimiter) UpdateRules(defaultRule Rule, methods map[string]Rule, httpRule Rule) {
+	l.rules.Store(&rules{
+		defaultRule: defaultRule,
+		methods:     methods,
+		httpRule:    httpRule,
+	})
+}
+
+func (l *RateLimiter) rule(method string) Rule {
+	current := l.rules.Load()
+	if rule, ok := current.methods[method]; ok {
+		return rule
+	}
+	return current.defaultRule
+}
+
+// allow забирает токены из корзин IP и пользователя. Пустые ip и user не ограничиваются.
+// При недоступности хранилища запрос пропускается: ограничение не должно останавливать сервис.
+func (l *RateLimiter) allow(ctx context.Context, method string, rule Rule, ip, user string) (bool, time.Duration) {
+	checks := []struct {
+		key   string
+		limit Limit
+	}{
+		{key: "ip:" + ip, limit: rule.PerIP},
+		{key: "user:" + user, limit: rule.PerUser},
+	}
+	if ip == "" {
+		checks[0].limit = Limit{}
+	}
+	if user == "" {
+		checks[1].limit = Limit{}
+	}
+
+	for _, check := range checks {
+		if !check.limit.Enabled() {
+			continue
+		}
+
+		result, err := l.store.Take(ctx, "rate_limit:"+method+":"+check.key, check.limit)
+		if err != nil {
+			storeErrors.Add(method, 1)
+			slog.Error("rate limit store failed", "method", method, "error", err)
+			continue
+		}
+		if !result.Allowed {
+			rejectedRequests.Add(method, 1)
+			return false, result.RetryAfter
+		}
+	}
+
+	return true, 0
+}
+
+// retryAfterSeconds значение Retry-After в целых секундах, не меньше одной
+func retryAfterSeconds(retryAfter time.Duration) int {
+	return max(1, int(math.Ceil(retryAfter.Seconds())))
+}
@@ -0,0 +1,157 @@
+package rate_limiter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/request_metadata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"gotest.tools/v3/assert"
+)
+
+// countingStore разрешает allowed запросов на каждый ключ и запоминает ключи
+type countingStore struct {
+	allowed int
+	taken   map[string]int
+	err     error
+}
+
+func (s *countingStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if s.err != nil {
+		return Result{}, s.err
+	}
+	if s.taken == nil {
+		s.taken = make(map[string]int)
+	}
+	s.taken[key]++
+	if s.taken[key] > s.allowed {
+		return Result{RetryAfter: 1500 * time.Millisecond}, nil
+	}
+	return Result{Allowed: true}, nil
+}
+
+var testLimit = Limit{Rate: 1, Burst: 1}
+
+func peerContext(address string) context.Context {
+	addr, _ := net.ResolveTCPAddr("tcp", address)
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+}
+
+func okHandler(ctx context.Context, req any) (any, error) {
+	return "ok", nil
+}
+
+var createUserInfo = &grpc.UnaryServerInfo{FullMethod: "/profile_management_api.ProfileManagementService/CreateUser"}
+
+func TestUnaryInterceptorRejectsWithRetryAfter(t *testing.T) {
+	store := &countingStore{allowed: 1}
+	limiter := NewRateLimiter(store, Rule{}, map[string]Rule{"CreateUser": {PerIP: testLimit}}, Rule{})
+	ctx := peerContext("10.0.0.1:5000")
+
+	_, err := limiter.UnaryServerInterceptor(ctx, nil, createUserInfo, okHandler)
+	assert.NilError(t, err)
+
+	_, err = limiter.UnaryServerInterceptor(ctx, nil, createUserInfo, okHandler)
+	assert.Equal(t, status.Code(err), codes.ResourceExhausted)
+	assert.ErrorContains(t, err, "повторите через 2 с")
+	assert.Equal(t, store.taken["rate_limit:CreateUser:ip:10.0.0.1"], 2)
+}
+
+func TestUnaryInterceptorUsesDefaultRuleAndVerifiedUser(t *testing.T) {
+	store := &countingStore{allowed: 10}
+	limiter := NewRateLimiter(store, Rule{PerIP: testLimit, PerUser: testLimit}, nil, Rule{})
+	ctx := request_metadata.WithVerifiedActor(peerContext("10.0.0.1:5000"), "alice")
+
+	_, err := limiter.UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/profile_management_api.ProfileManagementService/GetUser"}, okHandler)
+	assert.NilError(t, err)
+	assert.Equal(t, store.taken["rate_limit:GetUser:ip:10.0.0.1"], 1)
+	assert.Equal(t, store.taken["rate_limit:GetUser:user:alice"], 1)
+}
+
+func TestUnaryInterceptorSkipsUnverifiedUser(t *testing.T) {
+	store := &countingStore{allowed: 1}
+	limiter := NewRateLimiter(store, Rule{PerUser: testLimit}, nil, Rule{})
+
+	// Автор из x-actor не ограничивается: клиент мог бы обойти лимит, меняя его, или исчерпать чужую корзину
+	for _, actor := range []string{"alice", "alice", request_metadata.AnonymousActor} {
+		ctx := request_metadata.WithActor(peerContext("10.0.0.1:5000"), actor)
+		_, err := limiter.UnaryServerInterceptor(ctx, nil, createUserInfo, okHandler)
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, len(store.taken), 0)
+}
+
+func TestUnaryInterceptorUsesGatewayForwardedIP(t *testing.T) {
+	store := &countingStore{allowed: 10}
+	limiter := NewRateLimiter(store, Rule{PerIP: testLimit}, nil, Rule{})
+	ctx := metadata.NewIncomingContext(peerContext("127.0.0.1:5000"), metadata.Pairs(forwardedForMetadata, "1.1.1.1, 203.0.113.7"))
+
+	_, err := limiter.UnaryServerInterceptor(ctx, nil, createUserInfo, okHandler)
+	assert.NilError(t, err)
+	assert.Equal(t, store.taken["rate_limit:CreateUser:ip:203.0.113.7"], 1)
+}
+
+func TestUnaryInterceptorIgnoresForwardedIPFromRemotePeer(t *testing.T) {
+	store := &countingStore{allowed: 10}
+	limiter := NewRateLimiter(store, Rule{PerIP: testLimit}, nil, Rule{})
+	ctx := metadata.NewIncomingContext(peerContext("10.0.0.1:5000"), metadata.Pairs(forwardedForMetadata, "203.0.113.7"))
+
+	_, err := limiter.UnaryServerInterceptor(ctx, nil, createUserInfo, okHandler)
+	assert.NilError(t, err)
+	assert.Equal(t, store.taken["rate_limit:CreateUser:ip:10.0.0.1"], 1)
+}
+
+func TestUnaryInterceptorAllowsOnStoreError(t *testing.T) {
+	limiter := NewRateLimiter(&countingStore{err: errors.New("redis unavailable")}, Rule{PerIP: testLimit}, nil, Rule{})
+
+	resp, err := limiter.UnaryServerInterceptor(peerContext("10.0.0.1:5000"), nil, createUserInfo, okHandler)
+	assert.NilError(t, err)
+	assert.Equal(t, resp, "ok")
+}
+
+func TestHTTPMiddlewareReturns429(t *testing.T) {
+	store := &countingStore{allowed: 1}
+	limiter := NewRateLimiter(store, Rule{}, nil, Rule{PerIP: testLimit})
+	handler := limiter.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	request.RemoteAddr = "203.0.113.7:40000"
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, recorder.Code, http.StatusOK)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, recorder.Code, http.StatusTooManyRequests)
+	assert.Equal(t, recorder.Header().Get("Retry-After"), "2")
+	assert.Equal(t, store.taken["rate_limit:http:ip:203.0.113.7"], 2)
+}
+
+func TestUpdateRulesAppliesToNextRequests(t *testing.T) {
+	store := &countingStore{allowed: 1}
+	limiter := NewRateLimiter(store, Rule{}, nil, Rule{})
+	ctx := peerContext("10.0.0.1:5000")
+
+	for range 2 {
+		_, err := limiter.UnaryServerInterceptor(ctx, nil, createUserInfo, okHandler)
+		assert.NilError(t, err)
+	}
+
+	limiter.UpdateRules(Rule{}, map[string]Rule{"CreateUser": {PerIP: testLimit}}, Rule{})
+	_, err := limiter.UnaryServerInterceptor(ctx, nil, createUserInfo, okHandler)
+	assert.NilError(t, err)
+	_, err = limiter.UnaryServerInterceptor(ctx, nil, createUserInfo, okHandler)
+	assert.Equal(t, status.Code(err), codes.ResourceExhausted)
+}
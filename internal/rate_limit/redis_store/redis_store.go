@@ -0,0 +1,88 @@
+package redis_store
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/rate_limit/rate_limiter"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/redis_client"
+	"github.com/pkg/errors"
+)
+
+// takeScript пополняет корзину за прошедшее время и забирает токен атомарно на сервере.
+// Возвращает {1, 0}, если запрос разрешён, и {0, миллисекунды до появления токена} иначе.
+// Время передаёт клиент, т.к. не все Redis-совместимые серверы разрешают TIME в скриптах.
+const takeScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = burst
+  ts = now
+end
+tokens = math.min(burst, tokens + math.max(0, now - ts) / 1000 * rate)
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+else
+  retry = math.ceil((1 - tokens) / rate * 1000)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+return {allowed, retry}
+`
+
+var takeScriptSHA = func() string {
+	sum := sha1.Sum([]byte(takeScript))
+	return hex.EncodeToString(sum[:])
+}()
+
+// RedisStore хранит корзины в Redis или совместимом сервере, лимиты общие для всех экземпляров сервиса
+type RedisStore struct {
+	client *redis_client.Client
+}
+
+func NewRedisStore(client *redis_client.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Take забирает токен из корзины key скриптом на сервере
+func (s *RedisStore) Take(ctx context.Context, key string, limit rate_limiter.Limit) (rate_limiter.Result, error) {
+	args := []string{
+		"1", key,
+		strconv.FormatFloat(limit.Rate, 'f', -1, 64),
+		strconv.Itoa(limit.Burst),
+		strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}
+
+	reply, err := s.client.Do(ctx, append([]string{"EVALSHA", takeScriptSHA}, args...)...)
+	if err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT") {
+		// Скрипт ещё не загружен на сервер: EVAL выполнит и закеширует его
+		reply, err = s.client.Do(ctx, append([]string{"EVAL", takeScript}, args...)...)
+	}
+	if err != nil {
+		return rate_limiter.Result{}, errors.Wrap(err, "rate limit script error")
+	}
+
+	items, ok := reply.([]any)
+	if !ok || len(items) != 2 {
+		return rate_limiter.Result{}, fmt.Errorf("unexpected rate limit script reply %v", reply)
+	}
+	allowed, _ := items[0].(int64)
+	retryAfter, _ := items[1].(int64)
+
+	return rate_limiter.Result{
+		Allowed:    allowed == 1,
+		RetryAfter: time.Duration(retryAfter) * time.Millisecond,
+	}, nil
+}
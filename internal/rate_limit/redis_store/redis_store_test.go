@@ -0,0 +1,86 @@
+package redis_store
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/rate_limit/rate_limiter"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/redis_client"
+	"gotest.tools/v3/assert"
+)
+
+// fakeRedis отвечает на команды по очереди заранее заданными ответами и запоминает имена команд
+func fakeRedis(t *testing.T, replies ...string) (string, <-chan []string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	commands := make(chan []string, len(replies))
+	go func() {
+		netConn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer netConn.Close()
+
+		reader := bufio.NewReader(netConn)
+		for _, reply := range replies {
+			command, err := redis_client.ReadReply(reader)
+			if err != nil {
+				return
+			}
+			args := make([]string, 0)
+			for _, arg := range command.([]any) {
+				args = append(args, arg.(string))
+			}
+			commands <- args
+			if _, err := netConn.Write([]byte(reply)); err != nil {
+				return
+			}
+		}
+	}()
+
+	return listener.Addr().String(), commands
+}
+
+func TestTakeLoadsScriptOnNoScript(t *testing.T) {
+	address, commands := fakeRedis(t,
+		"-NOSCRIPT No matching script\r\n",
+		"*2\r\n:1\r\n:0\r\n",
+	)
+	store := NewRedisStore(redis_client.NewClient(address, "", 0))
+
+	result, err := store.Take(context.Background(), "rate_limit:CreateUser:ip:10.0.0.1", rate_limiter.Limit{Rate: 0.5, Burst: 5})
+	assert.NilError(t, err)
+	assert.Assert(t, result.Allowed)
+
+	evalSHA := <-commands
+	assert.DeepEqual(t, evalSHA[:3], []string{"EVALSHA", takeScriptSHA, "1"})
+	assert.DeepEqual(t, evalSHA[3:6], []string{"rate_limit:CreateUser:ip:10.0.0.1", "0.5", "5"})
+	eval := <-commands
+	assert.DeepEqual(t, eval[:2], []string{"EVAL", takeScript})
+}
+
+func TestTakeRejected(t *testing.T) {
+	address, _ := fakeRedis(t,
+		"+OK\r\n",
+		"*2\r\n:0\r\n:1500\r\n",
+	)
+	store := NewRedisStore(redis_client.NewClient(address, "secret", 0))
+
+	result, err := store.Take(context.Background(), "rate_limit:GetUser:user:alice", rate_limiter.Limit{Rate: 1, Burst: 1})
+	assert.NilError(t, err)
+	assert.Assert(t, !result.Allowed)
+	assert.Equal(t, result.RetryAfter, 1500*time.Millisecond)
+}
+
+func TestTakeServerError(t *testing.T) {
+	address, _ := fakeRedis(t, "-ERR wrong number of arguments\r\n")
+	store := NewRedisStore(redis_client.NewClient(address, "", 0))
+
+	_, err := store.Take(context.Background(), "rate_limit:GetUser:ip:10.0.0.1", rate_limiter.Limit{Rate: 1, Burst: 1})
+	assert.ErrorContains(t, err, "wrong number of arguments")
+}
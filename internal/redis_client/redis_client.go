@@ -0,0 +1,105 @@
+package redis_client
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultTimeout = time.Second
+	maxIdleConns   = 16
+)
+
+// Client минимальный клиент Redis-совместимых серверов с пулом соединений
+type Client struct {
+	address  string
+	password string
+	db       int
+	timeout  time.Duration
+	idle     chan *conn
+}
+
+func NewClient(address, password string, db int) *Client {
+	return &Client{
+		address:  address,
+		password: password,
+		db:       db,
+		timeout:  defaultTimeout,
+		idle:     make(chan *conn, maxIdleConns),
+	}
+}
+
+// Do выполняет команду на свободном соединении. Соединение после сетевой ошибки закрывается,
+// после ответа сервера, в том числе с ошибкой ServerError, возвращается в пул.
+func (c *Client) Do(ctx context.Context, args ...string) (any, error) {
+	cn, err := c.getConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(c.timeout)
+	}
+	if err := cn.SetDeadline(deadline); err != nil {
+		cn.Close()
+		return nil, err
+	}
+
+	reply, err := cn.do(args...)
+	var serverErr ServerError
+	if err != nil && !errors.As(err, &serverErr) {
+		cn.Close()
+		return nil, err
+	}
+	c.putConn(cn)
+
+	return reply, err
+}
+
+func (c *Client) getConn(ctx context.Context) (*conn, error) {
+	select {
+	case cn := <-c.idle:
+		return cn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: c.timeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return nil, errors.Wrap(err, "dial redis error")
+	}
+	cn := &conn{Conn: netConn, reader: bufio.NewReader(netConn)}
+	if err := cn.SetDeadline(time.Now().Add(c.timeout)); err != nil {
+		cn.Close()
+		return nil, err
+	}
+
+	if c.password != "" {
+		if _, err := cn.do("AUTH", c.password); err != nil {
+			cn.Close()
+			return nil, errors.Wrap(err, "redis auth error")
+		}
+	}
+	if c.db != 0 {
+		if _, err := cn.do("SELECT", strconv.Itoa(c.db)); err != nil {
+			cn.Close()
+			return nil, errors.Wrap(err, "redis select error")
+		}
+	}
+
+	return cn, nil
+}
+
+func (c *Client) putConn(cn *conn) {
+	select {
+	case c.idle <- cn:
+	default:
+		cn.Close()
+	}
+}
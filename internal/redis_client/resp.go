@@ -0,0 +1,88 @@
+package redis_client
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ServerError ошибка, которую вернул сервер (ответ с префиксом -)
+type ServerError string
+
+func (e ServerError) Error() string {
+	return string(e)
+}
+
+// conn соединение с сервером по протоколу RESP
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *conn) do(args ...string) (any, error) {
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.Conn, command.String()); err != nil {
+		return nil, err
+	}
+
+	return ReadReply(c.reader)
+}
+
+// ReadReply читает ответ: строки, ошибки, целые числа, bulk-строки и массивы.
+// Отсутствующее значение ($-1) возвращается как nil.
+func ReadReply(reader *bufio.Reader) (any, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("empty redis reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, ServerError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		return string(data[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]any, count)
+		for i := range items {
+			if items[i], err = ReadReply(reader); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("unexpected redis reply %q", line)
+}
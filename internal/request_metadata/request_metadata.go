@@ -0,0 +1,53 @@
+package request_metadata
+
+import "context"
+
+// AnonymousActor автор запроса, если клиент не передал X-Actor
+const AnonymousActor = "anonymous"
+
+type actorKey struct{}
+
+type requestIDKey struct{}
+
+// actorInfo автор запроса; verified, если он подтверждён аутентификацией, а не назван клиентом
+type actorInfo struct {
+	name     string
+	verified bool
+}
+
+// WithActor сохраняет в контексте автора запроса, которого назвал клиент (X-Actor). Он не проверяется,
+// поэтому в журнале аудита помечается неподтверждённым. Подтверждённого автора WithActor не заменяет.
+func WithActor(ctx context.Context, name string) context.Context {
+	if ActorVerified(ctx) {
+		return ctx
+	}
+	return context.WithValue(ctx, actorKey{}, actorInfo{name: name})
+}
+
+// WithVerifiedActor сохраняет автора, подтверждённого аутентификацией: токеном администратора или запуском команды
+func WithVerifiedActor(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actorInfo{name: name, verified: true})
+}
+
+// Actor возвращает автора запроса или пустую строку, если изменение сделано не по запросу клиента
+func Actor(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(actorInfo)
+	return actor.name
+}
+
+// ActorVerified сообщает, подтверждён ли автор запроса аутентификацией
+func ActorVerified(ctx context.Context) bool {
+	actor, _ := ctx.Value(actorKey{}).(actorInfo)
+	return actor.verified
+}
+
+// WithRequestID сохраняет в контексте идентификатор запроса
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID возвращает идентификатор запроса или пустую строку
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,119 @@
+package file_schema_registry
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// FileSchemaRegistry заменяет Confluent Schema Registry при локальном запуске: хранит схемы по subject в JSON-файле.
+// Как и в Confluent, идентификаторы схем общие для всех subject, а повторная регистрация той же схемы
+// возвращает прежний идентификатор.
+type FileSchemaRegistry struct {
+	mu   sync.Mutex
+	path string
+}
+
+type registryFile struct {
+	Subjects map[string][]registeredSchema `json:"subjects"`
+}
+
+type registeredSchema struct {
+	ID      int32  `json:"id"`
+	Version int    `json:"version"`
+	Schema  string `json:"schema"`
+}
+
+func NewFileSchemaRegistry(path string) *FileSchemaRegistry {
+	return &FileSchemaRegistry{path: path}
+}
+
+// RegisterSchema регистрирует схему в subject и возвращает её идентификатор
+func (r *FileSchemaRegistry) RegisterSchema(ctx context.Context, subject, schema string) (int32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registry, err := r.load()
+	if err != nil {
+		return 0, err
+	}
+
+	var maxID int32
+	var existingID int32
+	for _, schemas := range registry.Subjects {
+		for _, registered := range schemas {
+			maxID = max(maxID, registered.ID)
+			if registered.Schema == schema {
+				existingID = registered.ID
+			}
+		}
+	}
+
+	versions := registry.Subjects[subject]
+	for _, registered := range versions {
+		if registered.Schema == schema {
+			return registered.ID, nil
+		}
+	}
+
+	id := existingID
+	if id == 0 {
+		id = maxID + 1
+	}
+	registry.Subjects[subject] = append(versions, registeredSchema{
+		ID:      id,
+		Version: len(versions) + 1,
+		Schema:  schema,
+	})
+
+	if err := r.save(registry); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *FileSchemaRegistry) load() (*registryFile, error) {
+	registry := &registryFile{Subjects: make(map[string][]registeredSchema)}
+
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return registry, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read schema registry file")
+	}
+
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal schema registry file")
+	}
+	if registry.Subjects == nil {
+		registry.Subjects = make(map[string][]registeredSchema)
+	}
+	return registry, nil
+}
+
+// save записывает реестр через временный файл, чтобы прерванная запись не повредила уже выданные идентификаторы
+func (r *FileSchemaRegistry) save(registry *registryFile) error {
+	data, err := json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal schema registry file")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return errors.Wrap(err, "failed to create schema registry directory")
+	}
+
+	tmpPath := r.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return errors.Wrap(err, "failed to write schema registry file")
+	}
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		return errors.Wrap(err, "failed to replace schema registry file")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package file_schema_registry
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestRegisterSchemaReusesID(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "registry", "schemas.json")
+	registry := NewFileSchemaRegistry(path)
+
+	first, err := registry.RegisterSchema(ctx, "menu-generation-requests-value", "schema v1")
+	assert.NilError(t, err)
+	assert.Equal(t, first, int32(1))
+
+	again, err := registry.RegisterSchema(ctx, "menu-generation-requests-value", "schema v1")
+	assert.NilError(t, err)
+	assert.Equal(t, again, first)
+
+	second, err := registry.RegisterSchema(ctx, "menu-generation-requests-value", "schema v2")
+	assert.NilError(t, err)
+	assert.Equal(t, second, int32(2))
+
+	// Та же схема в другом subject получает прежний идентификатор
+	otherSubject, err := registry.RegisterSchema(ctx, "other-value", "schema v1")
+	assert.NilError(t, err)
+	assert.Equal(t, otherSubject, first)
+}
+
+func TestRegisterSchemaPersistsBetweenInstances(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "schemas.json")
+
+	id, err := NewFileSchemaRegistry(path).RegisterSchema(ctx, "menu-generation-requests-value", "schema v1")
+	assert.NilError(t, err)
+
+	reopened := NewFileSchemaRegistry(path)
+	sameID, err := reopened.RegisterSchema(ctx, "menu-generation-requests-value", "schema v1")
+	assert.NilError(t, err)
+	assert.Equal(t, sameID, id)
+
+	nextID, err := reopened.RegisterSchema(ctx, "menu-generation-requests-value", "schema v2")
+	assert.NilError(t, err)
+	assert.Equal(t, nextID, id+1)
+}
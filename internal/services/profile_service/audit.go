@@ -0,0 +1,191 @@
+package profile_service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/request_metadata"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultAuditEventsLimit = 50
+	maxAuditEventsLimit     = 500
+)
+
+// auditRedactedFields поля, значения которых не попадают в журнал аудита
+var auditRedactedFields = map[string]bool{
+	"password":      true,
+	"password_hash": true,
+}
+
+// ListAuditEvents возвращает события журнала аудита по фильтру, новые первыми
+func (s *ProfileService) ListAuditEvents(ctx context.Context, filter *models.AuditEventFilter) ([]*models.AuditEvent, error) {
+	if s.auditLog == nil {
+		return nil, errors.New("журнал аудита не настроен")
+	}
+
+	from, err := parseAuditTime(filter.From)
+	if err != nil {
+		return nil, errors.New("некорректное начало периода, ожидается RFC3339")
+	}
+	to, err := parseAuditTime(filter.To)
+	if err != nil {
+		return nil, errors.New("некорректный конец периода, ожидается RFC3339")
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, errors.New("начало периода должно быть раньше конца")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditEventsLimit
+	}
+	limit = min(limit, maxAuditEventsLimit)
+
+	return s.auditLog.GetAuditEvents(ctx, filter.UserID, string(filter.EntityType), filter.EntityID, from, to, uint64(limit))
+}
+
+func parseAuditTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func (s *ProfileService) auditUser(ctx context.Context, action models.AuditAction, userID int32, before, after *models.User) {
+	s.audit(ctx, userID, action, models.AuditEntityUser, strconv.Itoa(int(userID)), before, after)
+}
+
+func (s *ProfileService) auditProduct(ctx context.Context, action models.AuditAction, before, after *models.Product) {
+	product := after
+	if product == nil {
+		product = before
+	}
+	s.audit(ctx, product.UserID, action, models.AuditEntityProduct, strconv.Itoa(int(product.ID)), before, after)
+}
+
+func (s *ProfileService) auditMeal(ctx context.Context, action models.AuditAction, before, after *models.Meal) {
+	meal := after
+	if meal == nil {
+		meal = before
+	}
+	s.audit(ctx, meal.UserID, action, models.AuditEntityMeal, strconv.Itoa(int(meal.ID)), before, after)
+}
+
+// audit дописывает изменение в журнал аудита. Изменение уже сохранено, поэтому ошибка записи только логируется.
+// before и after сравниваются по JSON-представлению; nil означает, что сущности не было или она удалена.
+func (s *ProfileService) audit(ctx context.Context, userID int32, action models.AuditAction, entityType models.AuditEntityType, entityID string, before, after any) {
+	if s.auditLog == nil {
+		return
+	}
+
+	changes, err := auditChanges(before, after)
+	if err != nil {
+		slog.Error("failed to build audit changes", "entity_type", entityType, "entity_id", entityID, "error", err)
+	}
+
+	// Без автора в контексте изменение сделал сам сервис, такой автор достоверен
+	actor, verified := request_metadata.Actor(ctx), request_metadata.ActorVerified(ctx)
+	if actor == "" {
+		actor, verified = models.AuditActorSystem, true
+	}
+
+	event := &models.AuditEvent{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		Actor:         actor,
+		ActorVerified: verified,
+		Action:        action,
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Changes:       changes,
+		RequestID:     request_metadata.RequestID(ctx),
+	}
+	if err := s.auditLog.CreateAuditEvent(context.WithoutCancel(ctx), event); err != nil {
+		slog.Error("failed to write audit event",
+			"action", action, "entity_type", entityType, "entity_id", entityID, "user_id", userID, "error", err)
+	}
+}
+
+// auditChanges возвращает изменённые поля в алфавитном порядке
+func auditChanges(before, after any) ([]models.AuditChange, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(beforeFields)+len(afterFields))
+	for name := range beforeFields {
+		names = append(names, name)
+	}
+	for name := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []models.AuditChange
+	for _, name := range names {
+		beforeValue, afterValue := beforeFields[name], afterFields[name]
+		if bytes.Equal(beforeValue, afterValue) {
+			continue
+		}
+		if auditRedactedFields[name] {
+			beforeValue, afterValue = redactAuditValue(beforeValue), redactAuditValue(afterValue)
+		}
+		changes = append(changes, models.AuditChange{Field: name, Before: beforeValue, After: afterValue})
+	}
+
+	return changes, nil
+}
+
+// auditFields раскладывает сущность на поля верхнего уровня; пустые значения не считаются полями
+func auditFields(entity any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range fields {
+		if isEmptyAuditValue(value) {
+			delete(fields, name)
+		}
+	}
+	return fields, nil
+}
+
+func isEmptyAuditValue(value json.RawMessage) bool {
+	switch string(value) {
+	case "null", `""`, "[]", "{}":
+		return true
+	}
+	return false
+}
+
+func redactAuditValue(value json.RawMessage) json.RawMessage {
+	if value == nil {
+		return nil
+	}
+	return json.RawMessage(models.AuditRedactedValue)
+}
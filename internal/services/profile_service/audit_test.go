@@ -0,0 +1,144 @@
+package profile_service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/request_metadata"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+type AuditServiceSuite struct {
+	suite.Suite
+	ctx            context.Context
+	profileStorage *mocks.ProfileStorage
+	auditLog       *mockAuditLog
+	profileService *ProfileService
+}
+
+func (s *AuditServiceSuite) SetupTest() {
+	s.profileStorage = mocks.NewProfileStorage(s.T())
+	s.ctx = request_metadata.WithRequestID(request_metadata.WithActor(context.Background(), "mobile-app"), "req-1")
+	s.auditLog = &mockAuditLog{}
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}, AuditLog: s.auditLog}, Options{Settings: testSettings})
+}
+
+func (s *AuditServiceSuite) TestUpdateUserWritesDiff() {
+	existingUser := testUserWithParams(1, "olduser", int32Ptr(180), nil, nil, nil)
+	existingUser.PasswordHash = "$2a$10$hash"
+	existingUser.Version = 1
+	user := testUserWithParams(1, "olduser", int32Ptr(185), nil, nil, nil)
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, user.ID).Return(existingUser, nil)
+	s.profileStorage.EXPECT().UpdateUser(s.ctx, user).RunAndReturn(func(ctx context.Context, user *models.User) error {
+		user.Version = 2
+		return nil
+	})
+
+	err := s.profileService.UpdateUser(s.ctx, user)
+	assert.NilError(s.T(), err)
+
+	assert.Equal(s.T(), len(s.auditLog.events), 1)
+	event := s.auditLog.events[0]
+	assert.Equal(s.T(), event.Actor, "mobile-app")
+	assert.Equal(s.T(), event.ActorVerified, false)
+	assert.Equal(s.T(), event.RequestID, "req-1")
+	assert.Equal(s.T(), event.Action, models.AuditActionUpdate)
+	assert.Equal(s.T(), event.EntityType, models.AuditEntityUser)
+	assert.Equal(s.T(), event.EntityID, "1")
+	assert.DeepEqual(s.T(), event.Changes, []models.AuditChange{
+		{Field: "height", Before: json.RawMessage("180"), After: json.RawMessage("185")},
+		{Field: "version", Before: json.RawMessage("1"), After: json.RawMessage("2")},
+	})
+}
+
+func (s *AuditServiceSuite) TestCreateUserRedactsPasswordHash() {
+	user := testUser(0, "newuser")
+	user.PasswordHash = "$2a$10$hash"
+
+	s.profileStorage.EXPECT().CreateUser(s.ctx, user).RunAndReturn(func(ctx context.Context, user *models.User) error {
+		user.ID = 7
+		return nil
+	})
+
+	err := s.profileService.CreateUser(s.ctx, user)
+	assert.NilError(s.T(), err)
+
+	assert.Equal(s.T(), len(s.auditLog.events), 1)
+	event := s.auditLog.events[0]
+	assert.Equal(s.T(), event.UserID, int32(7))
+	assert.Equal(s.T(), event.Action, models.AuditActionCreate)
+	assert.DeepEqual(s.T(), event.Changes, []models.AuditChange{
+		{Field: "id", After: json.RawMessage("7")},
+		{Field: "password_hash", After: json.RawMessage(models.AuditRedactedValue)},
+		{Field: "username", After: json.RawMessage(`"newuser"`)},
+	})
+}
+
+func (s *AuditServiceSuite) TestDeleteMealWritesBeforeState() {
+	meal := testMeal(3, 1, "Курица с рисом", []int32{1})
+
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, meal.ID).Return(meal, nil)
+	s.profileStorage.EXPECT().DeleteMeal(s.ctx, meal.ID).Return(nil)
+
+	err := s.profileService.DeleteMeal(s.ctx, meal.ID)
+	assert.NilError(s.T(), err)
+
+	assert.Equal(s.T(), len(s.auditLog.events), 1)
+	event := s.auditLog.events[0]
+	assert.Equal(s.T(), event.Action, models.AuditActionDelete)
+	assert.Equal(s.T(), event.EntityType, models.AuditEntityMeal)
+	assert.Equal(s.T(), event.EntityID, "3")
+	for _, change := range event.Changes {
+		assert.Assert(s.T(), change.After == nil, "поле %s после удаления", change.Field)
+	}
+}
+
+func (s *AuditServiceSuite) TestBackgroundChangeUsesSystemActor() {
+	product := testProduct(0, 1, "Гречка")
+
+	s.profileStorage.EXPECT().GetUserByID(context.Background(), int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().CreateProduct(context.Background(), product).Return(nil)
+
+	err := s.profileService.CreateProduct(context.Background(), product)
+	assert.NilError(s.T(), err)
+
+	assert.Equal(s.T(), len(s.auditLog.events), 1)
+	assert.Equal(s.T(), s.auditLog.events[0].Actor, models.AuditActorSystem)
+	assert.Equal(s.T(), s.auditLog.events[0].ActorVerified, true)
+	assert.Equal(s.T(), s.auditLog.events[0].RequestID, "")
+}
+
+func (s *AuditServiceSuite) TestVerifiedActorIsNotReplacedByClientActor() {
+	ctx := request_metadata.WithActor(request_metadata.WithVerifiedActor(context.Background(), "admin"), "mobile-app")
+	product := testProduct(0, 1, "Гречка")
+
+	s.profileStorage.EXPECT().GetUserByID(ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().CreateProduct(ctx, product).Return(nil)
+
+	err := s.profileService.CreateProduct(ctx, product)
+	assert.NilError(s.T(), err)
+
+	assert.Equal(s.T(), len(s.auditLog.events), 1)
+	assert.Equal(s.T(), s.auditLog.events[0].Actor, "admin")
+	assert.Equal(s.T(), s.auditLog.events[0].ActorVerified, true)
+}
+
+func (s *AuditServiceSuite) TestListAuditEventsInvalidPeriod() {
+	_, err := s.profileService.ListAuditEvents(s.ctx, &models.AuditEventFilter{From: "вчера"})
+	assert.ErrorContains(s.T(), err, "некорректное начало периода")
+
+	_, err = s.profileService.ListAuditEvents(s.ctx, &models.AuditEventFilter{
+		From: "2025-12-27T00:00:00Z",
+		To:   "2025-12-26T00:00:00Z",
+	})
+	assert.ErrorContains(s.T(), err, "начало периода должно быть раньше конца")
+}
+
+func TestAuditServiceSuite(t *testing.T) {
+	suite.Run(t, new(AuditServiceSuite))
+}
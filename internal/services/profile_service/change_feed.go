@@ -0,0 +1,36 @@
+package profile_service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/events/change_event_bus"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+)
+
+// WatchUser подписывает на изменения профиля, продуктов и блюд пользователя.
+// Канал закрывается при вызове cancel или если подписчик не успевает читать события;
+// в обоих случаях клиент переподключается с ResumeToken последнего полученного события.
+func (s *ProfileService) WatchUser(ctx context.Context, userID int32, resumeToken string) (<-chan *models.ChangeEvent, func(), error) {
+	if s.changeEventBus == nil {
+		return nil, nil, errors.New("лента изменений отключена")
+	}
+
+	_, err := s.profileStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, nil, userLookupError(err, "пользователь не найден")
+	}
+
+	events, cancel, err := s.changeEventBus.Subscribe(userID, resumeToken)
+	switch {
+	case errors.Is(err, change_event_bus.ErrResumeTokenExpired):
+		return nil, nil, fmt.Errorf("resume_token устарел, загрузите актуальное состояние и подпишитесь заново: %w", err)
+	case errors.Is(err, change_event_bus.ErrInvalidResumeToken):
+		return nil, nil, fmt.Errorf("некорректный resume_token: %w", err)
+	case err != nil:
+		return nil, nil, err
+	}
+
+	return events, cancel, nil
+}
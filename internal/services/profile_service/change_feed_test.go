@@ -0,0 +1,130 @@
+package profile_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/events/change_event_bus"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+type ChangeFeedServiceSuite struct {
+	suite.Suite
+	ctx            context.Context
+	profileStorage *mocks.ProfileStorage
+	changeEventBus *change_event_bus.ChangeEventBus
+	profileService *ProfileService
+}
+
+func (s *ChangeFeedServiceSuite) SetupTest() {
+	s.profileStorage = mocks.NewProfileStorage(s.T())
+	s.ctx = context.Background()
+	s.changeEventBus = change_event_bus.NewChangeEventBus(3, 8)
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}, ChangeEventBus: s.changeEventBus}, Options{Settings: testSettings})
+}
+
+func (s *ChangeFeedServiceSuite) TestWatchUserReceivesOwnEvents() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+
+	events, cancel, err := s.profileService.WatchUser(s.ctx, 1, "")
+	assert.NilError(s.T(), err)
+	defer cancel()
+
+	s.profileService.publishProductChange(s.ctx, models.ChangeEventTypeProductCreated, testProduct(10, 2, "Чужой продукт"))
+	s.profileService.publishProductChange(s.ctx, models.ChangeEventTypeProductCreated, testProduct(11, 1, "Рис"))
+
+	event := <-events
+	assert.Equal(s.T(), event.Type, models.ChangeEventTypeProductCreated)
+	assert.Equal(s.T(), event.Product.ID, int32(11))
+	assert.Check(s.T(), event.ResumeToken != "")
+	assert.Equal(s.T(), len(events), 0)
+}
+
+func (s *ChangeFeedServiceSuite) TestWatchUserResumesAfterToken() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+
+	first := &models.ChangeEvent{Type: models.ChangeEventTypeMealCreated, UserID: 1, Meal: testMeal(1, 1, "Плов", []int32{1})}
+	s.changeEventBus.Publish(first)
+	s.profileService.publishMealChange(s.ctx, models.ChangeEventTypeMealUpdated, testMeal(1, 1, "Плов с курицей", []int32{1}))
+	s.profileService.publishMealChange(s.ctx, models.ChangeEventTypeMealDeleted, testMeal(1, 1, "Плов с курицей", []int32{1}))
+
+	events, cancel, err := s.profileService.WatchUser(s.ctx, 1, first.ResumeToken)
+	assert.NilError(s.T(), err)
+	defer cancel()
+
+	assert.Equal(s.T(), (<-events).Type, models.ChangeEventTypeMealUpdated)
+	assert.Equal(s.T(), (<-events).Type, models.ChangeEventTypeMealDeleted)
+}
+
+func (s *ChangeFeedServiceSuite) TestWatchUserResumeTokenExpired() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+
+	first := &models.ChangeEvent{Type: models.ChangeEventTypeUserUpdated, UserID: 1, User: testUser(1, "testuser")}
+	s.changeEventBus.Publish(first)
+	for i := 0; i < 4; i++ {
+		s.profileService.publishUserChange(s.ctx, models.ChangeEventTypeUserUpdated, testUser(1, "testuser"))
+	}
+
+	_, _, err := s.profileService.WatchUser(s.ctx, 1, first.ResumeToken)
+	assert.Check(s.T(), errors.Is(err, change_event_bus.ErrResumeTokenExpired))
+	assert.ErrorContains(s.T(), err, "resume_token устарел")
+}
+
+func (s *ChangeFeedServiceSuite) TestWatchUserInvalidResumeToken() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+
+	_, _, err := s.profileService.WatchUser(s.ctx, 1, "garbage")
+	assert.ErrorContains(s.T(), err, "некорректный resume_token")
+}
+
+func (s *ChangeFeedServiceSuite) TestWatchUserSlowSubscriberDisconnected() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+
+	events, cancel, err := s.profileService.WatchUser(s.ctx, 1, "")
+	assert.NilError(s.T(), err)
+	defer cancel()
+
+	for i := 0; i < 9; i++ {
+		s.profileService.publishUserChange(s.ctx, models.ChangeEventTypeUserUpdated, testUser(1, "testuser"))
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Equal(s.T(), received, 8)
+}
+
+func (s *ChangeFeedServiceSuite) TestDeleteProductDetachPublishesMealUpdates() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(2)).Return(testProduct(2, 1, "Рис"), nil)
+	s.profileStorage.EXPECT().GetMealsByProductID(s.ctx, int32(1), int32(2)).Return([]*models.Meal{testMeal(3, 1, "Плов", []int32{1, 2})}, nil)
+	s.profileStorage.EXPECT().DeleteProduct(s.ctx, int32(2), true).Return(nil)
+
+	events, cancel, err := s.profileService.WatchUser(s.ctx, 1, "")
+	assert.NilError(s.T(), err)
+	defer cancel()
+
+	err = s.profileService.DeleteProduct(s.ctx, 2, true)
+	assert.NilError(s.T(), err)
+
+	mealEvent := <-events
+	assert.Equal(s.T(), mealEvent.Type, models.ChangeEventTypeMealUpdated)
+	assert.DeepEqual(s.T(), mealEvent.Meal.ProductIDs, []int32{1})
+	assert.Equal(s.T(), (<-events).Type, models.ChangeEventTypeProductDeleted)
+}
+
+func (s *ChangeFeedServiceSuite) TestWatchUserDisabled() {
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}}, Options{Settings: testSettings})
+
+	_, _, err := s.profileService.WatchUser(s.ctx, 1, "")
+	assert.ErrorContains(s.T(), err, "лента изменений отключена")
+}
+
+func TestChangeFeedServiceSuite(t *testing.T) {
+	suite.Run(t, new(ChangeFeedServiceSuite))
+}
@@ -0,0 +1,88 @@
+package profile_service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultDeadLettersLimit = 50
+	maxDeadLettersLimit     = 500
+)
+
+// ListDeadLetters возвращает неопубликованные сообщения Kafka, новые первыми. Пустой topic означает все топики.
+func (s *ProfileService) ListDeadLetters(ctx context.Context, topic string, limit int32) ([]*models.DeadLetter, error) {
+	if limit <= 0 {
+		limit = defaultDeadLettersLimit
+	}
+	limit = min(limit, maxDeadLettersLimit)
+
+	return s.profileStorage.GetDeadLetters(ctx, topic, uint64(limit))
+}
+
+// ReplayDeadLetter повторно отправляет сообщение в исходный топик и удаляет его из dead-letter.
+// При ошибке отправки сообщение остаётся для следующей попытки.
+func (s *ProfileService) ReplayDeadLetter(ctx context.Context, id string) error {
+	deadLetter, err := s.getDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if s.deadLetterReplayer == nil {
+		return errors.New("повторная отправка сообщений не настроена")
+	}
+	if err := s.deadLetterReplayer.ReplayDeadLetter(ctx, deadLetter); err != nil {
+		return err
+	}
+
+	if err := s.profileStorage.DeleteDeadLetter(ctx, id); err != nil {
+		// Сообщение уже отправлено: повторный replay продублирует его
+		slog.Error("failed to delete replayed dead letter", "id", id, "error", err)
+		return err
+	}
+
+	slog.Info("dead letter replayed", "id", id, "topic", deadLetter.Topic, "user_id", deadLetter.UserID)
+	s.audit(ctx, deadLetter.UserID, models.AuditActionReplay, models.AuditEntityDeadLetter, id, deadLetterAuditFields(deadLetter), nil)
+	return nil
+}
+
+// DiscardDeadLetter удаляет сообщение без отправки
+func (s *ProfileService) DiscardDeadLetter(ctx context.Context, id string) error {
+	deadLetter, err := s.getDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.profileStorage.DeleteDeadLetter(ctx, id); err != nil {
+		return err
+	}
+
+	slog.Info("dead letter discarded", "id", id, "topic", deadLetter.Topic, "user_id", deadLetter.UserID)
+	s.audit(ctx, deadLetter.UserID, models.AuditActionDiscard, models.AuditEntityDeadLetter, id, deadLetterAuditFields(deadLetter), nil)
+	return nil
+}
+
+func (s *ProfileService) getDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, errors.New("некорректный id сообщения")
+	}
+
+	deadLetter, err := s.profileStorage.GetDeadLetter(ctx, id)
+	if err != nil {
+		return nil, errors.New("сообщение не найдено")
+	}
+	return deadLetter, nil
+}
+
+// deadLetterAuditFields описание сообщения для журнала аудита без тела, которое может содержать данные профиля
+func deadLetterAuditFields(deadLetter *models.DeadLetter) map[string]any {
+	return map[string]any{
+		"topic":    deadLetter.Topic,
+		"error":    deadLetter.Error,
+		"attempts": deadLetter.Attempts,
+	}
+}
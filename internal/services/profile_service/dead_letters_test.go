@@ -0,0 +1,101 @@
+package profile_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+const testDeadLetterID = "0b6f3f1e-8f7a-4c1d-9d43-6a9b1f2c3d4e"
+
+type DeadLetterServiceSuite struct {
+	suite.Suite
+	ctx            context.Context
+	profileStorage *mocks.ProfileStorage
+	replayer       *mockDeadLetterReplayer
+	profileService *ProfileService
+}
+
+func (s *DeadLetterServiceSuite) SetupTest() {
+	s.profileStorage = mocks.NewProfileStorage(s.T())
+	s.ctx = context.Background()
+	s.replayer = &mockDeadLetterReplayer{}
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}, DeadLetterReplayer: s.replayer}, Options{Settings: testSettings})
+}
+
+func testDeadLetter() *models.DeadLetter {
+	return &models.DeadLetter{
+		ID:       testDeadLetterID,
+		UserID:   1,
+		Topic:    "profile-events.v1",
+		Key:      []byte("user_1"),
+		Value:    []byte(`{"event_type":"UserCreated"}`),
+		Error:    "kafka unavailable",
+		Attempts: 5,
+	}
+}
+
+func (s *DeadLetterServiceSuite) TestListDeadLettersDefaultLimit() {
+	s.profileStorage.EXPECT().GetDeadLetters(s.ctx, "", uint64(defaultDeadLettersLimit)).Return([]*models.DeadLetter{testDeadLetter()}, nil)
+
+	got, err := s.profileService.ListDeadLetters(s.ctx, "", 0)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), len(got), 1)
+}
+
+func (s *DeadLetterServiceSuite) TestListDeadLettersLimitCapped() {
+	s.profileStorage.EXPECT().GetDeadLetters(s.ctx, "profile-events.v1", uint64(maxDeadLettersLimit)).Return(nil, nil)
+
+	_, err := s.profileService.ListDeadLetters(s.ctx, "profile-events.v1", 10000)
+	assert.NilError(s.T(), err)
+}
+
+func (s *DeadLetterServiceSuite) TestReplayDeadLetterSuccess() {
+	deadLetter := testDeadLetter()
+	s.profileStorage.EXPECT().GetDeadLetter(s.ctx, testDeadLetterID).Return(deadLetter, nil)
+	s.profileStorage.EXPECT().DeleteDeadLetter(s.ctx, testDeadLetterID).Return(nil)
+
+	err := s.profileService.ReplayDeadLetter(s.ctx, testDeadLetterID)
+	assert.NilError(s.T(), err)
+	assert.DeepEqual(s.T(), s.replayer.replayed, []*models.DeadLetter{deadLetter})
+}
+
+func (s *DeadLetterServiceSuite) TestReplayDeadLetterPublishErrorKeepsMessage() {
+	s.replayer.err = errors.New("kafka unavailable")
+	s.profileStorage.EXPECT().GetDeadLetter(s.ctx, testDeadLetterID).Return(testDeadLetter(), nil)
+
+	err := s.profileService.ReplayDeadLetter(s.ctx, testDeadLetterID)
+	assert.ErrorContains(s.T(), err, "kafka unavailable")
+	s.profileStorage.AssertNotCalled(s.T(), "DeleteDeadLetter")
+}
+
+func (s *DeadLetterServiceSuite) TestReplayDeadLetterNotFound() {
+	s.profileStorage.EXPECT().GetDeadLetter(s.ctx, testDeadLetterID).Return(nil, errors.New("dead letter not found"))
+
+	err := s.profileService.ReplayDeadLetter(s.ctx, testDeadLetterID)
+	assert.ErrorContains(s.T(), err, "сообщение не найдено")
+	assert.Equal(s.T(), len(s.replayer.replayed), 0)
+}
+
+func (s *DeadLetterServiceSuite) TestReplayDeadLetterInvalidID() {
+	err := s.profileService.ReplayDeadLetter(s.ctx, "not-a-uuid")
+	assert.ErrorContains(s.T(), err, "некорректный id сообщения")
+}
+
+func (s *DeadLetterServiceSuite) TestDiscardDeadLetter() {
+	s.profileStorage.EXPECT().GetDeadLetter(s.ctx, testDeadLetterID).Return(testDeadLetter(), nil)
+	s.profileStorage.EXPECT().DeleteDeadLetter(s.ctx, testDeadLetterID).Return(nil)
+
+	err := s.profileService.DiscardDeadLetter(s.ctx, testDeadLetterID)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), len(s.replayer.replayed), 0)
+}
+
+func TestDeadLetterServiceSuite(t *testing.T) {
+	suite.Run(t, new(DeadLetterServiceSuite))
+}
@@ -0,0 +1,95 @@
+package profile_service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/google/uuid"
+)
+
+func (s *ProfileService) publishUserChange(ctx context.Context, eventType models.ChangeEventType, user *models.User) {
+	s.publishChange(ctx, &models.ChangeEvent{Type: eventType, UserID: user.ID, User: user})
+}
+
+func (s *ProfileService) publishProductChange(ctx context.Context, eventType models.ChangeEventType, product *models.Product) {
+	s.publishChange(ctx, &models.ChangeEvent{Type: eventType, UserID: product.UserID, Product: product})
+}
+
+func (s *ProfileService) publishMealChange(ctx context.Context, eventType models.ChangeEventType, meal *models.Meal) {
+	s.publishChange(ctx, &models.ChangeEvent{Type: eventType, UserID: meal.UserID, Meal: meal})
+}
+
+// publishChange отправляет изменение в ленту WatchUser и ставит доменное событие в очередь публикации в Kafka;
+// сама публикация с повторами идёт в фоне и не задерживает запрос. Изменение уже сохранено,
+// поэтому ошибка постановки в очередь только логируется.
+func (s *ProfileService) publishChange(ctx context.Context, event *models.ChangeEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	if s.changeEventBus != nil {
+		s.changeEventBus.Publish(event)
+	}
+
+	if s.profileEventsProducer != nil {
+		profileEvent := mapChangeEventToProfileEvent(event)
+		if err := s.profileEventsProducer.PublishProfileEvent(ctx, profileEvent); err != nil {
+			slog.Error("failed to publish profile event",
+				"event_type", profileEvent.EventType, "user_id", profileEvent.UserID, "error", err)
+		}
+	}
+}
+
+func mapChangeEventToProfileEvent(event *models.ChangeEvent) *models.ProfileEvent {
+	profileEvent := &models.ProfileEvent{
+		SchemaVersion: models.ProfileEventSchemaVersion,
+		EventID:       uuid.New().String(),
+		EventType:     mapChangeEventTypeToProfileEventType(event.Type),
+		UserID:        event.UserID,
+		OccurredAt:    event.OccurredAt.UTC().Format(time.RFC3339),
+		Product:       event.Product,
+		Meal:          event.Meal,
+	}
+
+	if event.User != nil {
+		profileEvent.User = &models.ProfileEventUser{
+			ID:          event.User.ID,
+			Username:    event.User.Username,
+			Height:      event.User.Height,
+			Weight:      event.User.Weight,
+			BJU:         event.User.BJU,
+			Budget:      event.User.Budget,
+			Preferences: event.User.Preferences,
+			CreatedAt:   event.User.CreatedAt,
+		}
+	}
+
+	return profileEvent
+}
+
+func mapChangeEventTypeToProfileEventType(eventType models.ChangeEventType) models.ProfileEventType {
+	switch eventType {
+	case models.ChangeEventTypeUserCreated:
+		return models.ProfileEventTypeUserCreated
+	case models.ChangeEventTypeUserUpdated:
+		return models.ProfileEventTypeUserUpdated
+	case models.ChangeEventTypeUserDeleted:
+		return models.ProfileEventTypeUserDeleted
+	case models.ChangeEventTypeProductCreated:
+		return models.ProfileEventTypeProductCreated
+	case models.ChangeEventTypeProductUpdated:
+		return models.ProfileEventTypeProductUpdated
+	case models.ChangeEventTypeProductDeleted:
+		return models.ProfileEventTypeProductDeleted
+	case models.ChangeEventTypeMealCreated:
+		return models.ProfileEventTypeMealCreated
+	case models.ChangeEventTypeMealUpdated:
+		return models.ProfileEventTypeMealUpdated
+	case models.ChangeEventTypeMealDeleted:
+		return models.ProfileEventTypeMealDeleted
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,93 @@
+package profile_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+type ProfileEventsServiceSuite struct {
+	suite.Suite
+	ctx            context.Context
+	profileStorage *mocks.ProfileStorage
+	eventsProducer *mockProfileEventsProducer
+	profileService *ProfileService
+}
+
+func (s *ProfileEventsServiceSuite) SetupTest() {
+	s.profileStorage = mocks.NewProfileStorage(s.T())
+	s.ctx = context.Background()
+	s.eventsProducer = &mockProfileEventsProducer{}
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}, ProfileEventsProducer: s.eventsProducer}, Options{Settings: testSettings})
+}
+
+func (s *ProfileEventsServiceSuite) TestCreateUserPublishesUserCreated() {
+	user := testUser(0, "testuser")
+	user.PasswordHash = "hash"
+
+	s.profileStorage.EXPECT().CreateUser(s.ctx, user).Return(nil)
+
+	err := s.profileService.CreateUser(s.ctx, user)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), len(s.eventsProducer.events), 1)
+
+	event := s.eventsProducer.events[0]
+	assert.Equal(s.T(), event.EventType, models.ProfileEventTypeUserCreated)
+	assert.Equal(s.T(), event.SchemaVersion, models.ProfileEventSchemaVersion)
+	assert.Equal(s.T(), event.User.Username, "testuser")
+	assert.Check(s.T(), event.EventID != "")
+	assert.Check(s.T(), event.OccurredAt != "")
+}
+
+func (s *ProfileEventsServiceSuite) TestDeleteUserPublishesUserDeleted() {
+	s.profileStorage.EXPECT().DeleteUser(s.ctx, int32(1)).Return(nil)
+
+	err := s.profileService.DeleteUser(s.ctx, 1)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), len(s.eventsProducer.events), 1)
+	assert.Equal(s.T(), s.eventsProducer.events[0].EventType, models.ProfileEventTypeUserDeleted)
+	assert.Equal(s.T(), s.eventsProducer.events[0].UserID, int32(1))
+	assert.Check(s.T(), s.eventsProducer.events[0].User == nil)
+}
+
+func (s *ProfileEventsServiceSuite) TestDeleteUserErrorPublishesNothing() {
+	s.profileStorage.EXPECT().DeleteUser(s.ctx, int32(1)).Return(errors.New("delete error"))
+
+	err := s.profileService.DeleteUser(s.ctx, 1)
+	assert.ErrorContains(s.T(), err, "delete error")
+	assert.Equal(s.T(), len(s.eventsProducer.events), 0)
+}
+
+func (s *ProfileEventsServiceSuite) TestMealEventsCarryUserID() {
+	meal := testMeal(5, 1, "Плов", []int32{1})
+
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, int32(5)).Return(meal, nil)
+	s.profileStorage.EXPECT().DeleteMeal(s.ctx, int32(5)).Return(nil)
+
+	err := s.profileService.DeleteMeal(s.ctx, 5)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), s.eventsProducer.events[0].EventType, models.ProfileEventTypeMealDeleted)
+	assert.Equal(s.T(), s.eventsProducer.events[0].UserID, int32(1))
+	assert.Equal(s.T(), s.eventsProducer.events[0].Meal.Name, "Плов")
+}
+
+func (s *ProfileEventsServiceSuite) TestPublishErrorDoesNotFailChange() {
+	s.eventsProducer.err = errors.New("kafka publish error")
+	product := testProduct(0, 1, "Рис")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().CreateProduct(s.ctx, product).Return(nil)
+
+	err := s.profileService.CreateProduct(s.ctx, product)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), s.eventsProducer.events[0].EventType, models.ProfileEventTypeProductCreated)
+}
+
+func TestProfileEventsServiceSuite(t *testing.T) {
+	suite.Run(t, new(ProfileEventsServiceSuite))
+}
@@ -0,0 +1,90 @@
+package profile_service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+)
+
+const maxIdempotencyKeyLen = 255
+
+var (
+	// ErrIdempotencyKeyReused ключ уже использован запросом с другим телом
+	ErrIdempotencyKeyReused = errors.New("ключ идемпотентности уже использован с другим запросом")
+	// ErrIdempotencyKeyInProgress первый запрос с этим ключом ещё не завершился
+	ErrIdempotencyKeyInProgress = errors.New("запрос с этим ключом идемпотентности ещё выполняется")
+)
+
+// ExecuteIdempotent выполняет run один раз для ключа и сохраняет его ответ на idempotencyKeyTTL.
+// Повтор с тем же ключом и тем же requestHash получает сохранённый ответ без повторного выполнения.
+// Ключ принадлежит пользователю userID; для CreateUser userID равен нулю.
+// Пустой ключ или нулевой TTL отключают проверку.
+func (s *ProfileService) ExecuteIdempotent(ctx context.Context, userID int32, method, key string, requestHash []byte, run func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	if key == "" || s.idempotencyKeyTTL <= 0 {
+		return run(ctx)
+	}
+	if len(key) > maxIdempotencyKeyLen {
+		return nil, errors.New("ключ идемпотентности длиннее 255 символов")
+	}
+
+	idempotencyKey := &models.IdempotencyKey{
+		UserID:      userID,
+		Method:      method,
+		Key:         key,
+		RequestHash: requestHash,
+		TTL:         s.idempotencyKeyTTL,
+	}
+
+	// Вторая попытка нужна, если ключ освободился между вставкой и чтением:
+	// первый запрос завершился ошибкой или ключ истёк
+	for attempt := 0; attempt < 2; attempt++ {
+		created, err := s.profileStorage.CreateIdempotencyKey(ctx, idempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			return s.runIdempotent(ctx, idempotencyKey, run)
+		}
+
+		existing, err := s.profileStorage.GetIdempotencyKey(ctx, userID, method, key)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			continue
+		}
+		if !bytes.Equal(existing.RequestHash, requestHash) {
+			return nil, ErrIdempotencyKeyReused
+		}
+		if existing.Response == nil {
+			return nil, ErrIdempotencyKeyInProgress
+		}
+
+		slog.Info("idempotent request replayed", "method", method, "user_id", userID)
+		return existing.Response, nil
+	}
+
+	return nil, ErrIdempotencyKeyInProgress
+}
+
+func (s *ProfileService) runIdempotent(ctx context.Context, idempotencyKey *models.IdempotencyKey, run func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	response, err := run(ctx)
+	if err != nil {
+		// Ошибочный ответ не сохраняем: клиент может повторить запрос с тем же ключом
+		if deleteErr := s.profileStorage.DeleteIdempotencyKey(context.WithoutCancel(ctx), idempotencyKey.UserID, idempotencyKey.Method, idempotencyKey.Key); deleteErr != nil {
+			slog.Error("failed to release idempotency key", "method", idempotencyKey.Method, "user_id", idempotencyKey.UserID, "error", deleteErr)
+		}
+		return nil, err
+	}
+
+	idempotencyKey.Response = response
+	if err := s.profileStorage.CompleteIdempotencyKey(context.WithoutCancel(ctx), idempotencyKey); err != nil {
+		// Запись уже создана: ключ остаётся занятым до истечения, чтобы повтор не создал дубликат
+		slog.Error("failed to save idempotent response", "method", idempotencyKey.Method, "user_id", idempotencyKey.UserID, "error", err)
+	}
+
+	return response, nil
+}
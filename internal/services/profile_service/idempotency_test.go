@@ -0,0 +1,133 @@
+package profile_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+const (
+	testIdempotencyKey = "4f1c2d3e-retry"
+	testIdempotencyTTL = 24 * time.Hour
+)
+
+type IdempotencyServiceSuite struct {
+	suite.Suite
+	ctx            context.Context
+	profileStorage *mocks.ProfileStorage
+	profileService *ProfileService
+	runs           int
+}
+
+func (s *IdempotencyServiceSuite) SetupTest() {
+	s.profileStorage = mocks.NewProfileStorage(s.T())
+	s.ctx = context.Background()
+	s.runs = 0
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}}, Options{Settings: testSettings, IdempotencyKeyTTL: testIdempotencyTTL})
+}
+
+func (s *IdempotencyServiceSuite) run(response []byte, err error) func(ctx context.Context) ([]byte, error) {
+	return func(ctx context.Context) ([]byte, error) {
+		s.runs++
+		return response, err
+	}
+}
+
+func (s *IdempotencyServiceSuite) TestWithoutKeyRunsDirectly() {
+	got, err := s.profileService.ExecuteIdempotent(s.ctx, 1, "CreateProduct", "", []byte("hash"), s.run([]byte("response"), nil))
+	assert.NilError(s.T(), err)
+	assert.DeepEqual(s.T(), got, []byte("response"))
+	assert.Equal(s.T(), s.runs, 1)
+}
+
+func (s *IdempotencyServiceSuite) TestFirstRequestStoresResponse() {
+	s.profileStorage.EXPECT().CreateIdempotencyKey(s.ctx, &models.IdempotencyKey{
+		UserID:      1,
+		Method:      "CreateProduct",
+		Key:         testIdempotencyKey,
+		RequestHash: []byte("hash"),
+		TTL:         testIdempotencyTTL,
+	}).Return(true, nil)
+	s.profileStorage.EXPECT().CompleteIdempotencyKey(mock.Anything, mock.MatchedBy(func(key *models.IdempotencyKey) bool {
+		return string(key.Response) == "response"
+	})).Return(nil)
+
+	got, err := s.profileService.ExecuteIdempotent(s.ctx, 1, "CreateProduct", testIdempotencyKey, []byte("hash"), s.run([]byte("response"), nil))
+	assert.NilError(s.T(), err)
+	assert.DeepEqual(s.T(), got, []byte("response"))
+	assert.Equal(s.T(), s.runs, 1)
+}
+
+func (s *IdempotencyServiceSuite) TestRepeatReplaysStoredResponse() {
+	s.profileStorage.EXPECT().CreateIdempotencyKey(s.ctx, mock.Anything).Return(false, nil)
+	s.profileStorage.EXPECT().GetIdempotencyKey(s.ctx, int32(1), "CreateProduct", testIdempotencyKey).Return(&models.IdempotencyKey{
+		RequestHash: []byte("hash"),
+		Response:    []byte("stored"),
+	}, nil)
+
+	got, err := s.profileService.ExecuteIdempotent(s.ctx, 1, "CreateProduct", testIdempotencyKey, []byte("hash"), s.run([]byte("response"), nil))
+	assert.NilError(s.T(), err)
+	assert.DeepEqual(s.T(), got, []byte("stored"))
+	assert.Equal(s.T(), s.runs, 0)
+}
+
+func (s *IdempotencyServiceSuite) TestReusedKeyWithDifferentPayload() {
+	s.profileStorage.EXPECT().CreateIdempotencyKey(s.ctx, mock.Anything).Return(false, nil)
+	s.profileStorage.EXPECT().GetIdempotencyKey(s.ctx, int32(1), "CreateProduct", testIdempotencyKey).Return(&models.IdempotencyKey{
+		RequestHash: []byte("other"),
+		Response:    []byte("stored"),
+	}, nil)
+
+	_, err := s.profileService.ExecuteIdempotent(s.ctx, 1, "CreateProduct", testIdempotencyKey, []byte("hash"), s.run([]byte("response"), nil))
+	assert.ErrorIs(s.T(), err, ErrIdempotencyKeyReused)
+	assert.Equal(s.T(), s.runs, 0)
+}
+
+func (s *IdempotencyServiceSuite) TestRepeatWhileFirstRequestInProgress() {
+	s.profileStorage.EXPECT().CreateIdempotencyKey(s.ctx, mock.Anything).Return(false, nil)
+	s.profileStorage.EXPECT().GetIdempotencyKey(s.ctx, int32(1), "CreateProduct", testIdempotencyKey).Return(&models.IdempotencyKey{
+		RequestHash: []byte("hash"),
+	}, nil)
+
+	_, err := s.profileService.ExecuteIdempotent(s.ctx, 1, "CreateProduct", testIdempotencyKey, []byte("hash"), s.run([]byte("response"), nil))
+	assert.ErrorIs(s.T(), err, ErrIdempotencyKeyInProgress)
+}
+
+func (s *IdempotencyServiceSuite) TestFailedRequestReleasesKey() {
+	s.profileStorage.EXPECT().CreateIdempotencyKey(s.ctx, mock.Anything).Return(true, nil)
+	s.profileStorage.EXPECT().DeleteIdempotencyKey(mock.Anything, int32(0), "CreateUser", testIdempotencyKey).Return(nil)
+
+	_, err := s.profileService.ExecuteIdempotent(s.ctx, 0, "CreateUser", testIdempotencyKey, []byte("hash"), s.run(nil, errors.New("username taken")))
+	assert.ErrorContains(s.T(), err, "username taken")
+}
+
+func (s *IdempotencyServiceSuite) TestKeyReleasedConcurrentlyIsRetried() {
+	s.profileStorage.EXPECT().CreateIdempotencyKey(s.ctx, mock.Anything).Return(false, nil).Once()
+	s.profileStorage.EXPECT().GetIdempotencyKey(s.ctx, int32(1), "CreateMeal", testIdempotencyKey).Return(nil, nil).Once()
+	s.profileStorage.EXPECT().CreateIdempotencyKey(s.ctx, mock.Anything).Return(true, nil).Once()
+	s.profileStorage.EXPECT().CompleteIdempotencyKey(mock.Anything, mock.Anything).Return(nil)
+
+	got, err := s.profileService.ExecuteIdempotent(s.ctx, 1, "CreateMeal", testIdempotencyKey, []byte("hash"), s.run([]byte("response"), nil))
+	assert.NilError(s.T(), err)
+	assert.DeepEqual(s.T(), got, []byte("response"))
+	assert.Equal(s.T(), s.runs, 1)
+}
+
+func (s *IdempotencyServiceSuite) TestKeyTooLong() {
+	key := string(make([]byte, maxIdempotencyKeyLen+1))
+
+	_, err := s.profileService.ExecuteIdempotent(s.ctx, 1, "CreateProduct", key, []byte("hash"), s.run([]byte("response"), nil))
+	assert.ErrorContains(s.T(), err, "ключ идемпотентности длиннее")
+	assert.Equal(s.T(), s.runs, 0)
+}
+
+func TestIdempotencyServiceSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyServiceSuite))
+}
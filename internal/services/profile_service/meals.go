@@ -4,28 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/samber/lo"
 )
 
 func (s *ProfileService) CreateMeal(ctx context.Context, meal *models.Meal) error {
 	_, err := s.profileStorage.GetUserByID(ctx, meal.UserID)
 	if err != nil {
-		return errors.New("пользователь не найден")
+		return userLookupError(err, "пользователь не найден")
 	}
 
 	if err := s.validateMeal(meal); err != nil {
 		return err
 	}
 
-	for _, productID := range meal.ProductIDs {
-		_, err := s.profileStorage.GetProductByID(ctx, productID)
-		if err != nil {
-			return fmt.Errorf("продукт с id %d не найден", productID)
-		}
+	if err := s.checkMealProductsOwner(ctx, meal.UserID, meal.ProductIDs); err != nil {
+		return err
+	}
+
+	if err := s.profileStorage.CreateMeal(ctx, meal); err != nil {
+		return err
 	}
 
-	return s.profileStorage.CreateMeal(ctx, meal)
+	s.publishMealChange(ctx, models.ChangeEventTypeMealCreated, meal)
+	s.auditMeal(ctx, models.AuditActionCreate, nil, meal)
+	return nil
 }
 
 func (s *ProfileService) GetMealsByUserID(ctx context.Context, userID int32) ([]*models.Meal, error) {
@@ -37,30 +43,74 @@ func (s *ProfileService) GetMealByID(ctx context.Context, id int32) (*models.Mea
 }
 
 func (s *ProfileService) UpdateMeal(ctx context.Context, meal *models.Meal) error {
-	_, err := s.profileStorage.GetMealByID(ctx, meal.ID)
+	existingMeal, err := s.profileStorage.GetMealByID(ctx, meal.ID)
 	if err != nil {
 		return errors.New("блюдо не найдено")
 	}
 
 	_, err = s.profileStorage.GetUserByID(ctx, meal.UserID)
 	if err != nil {
-		return errors.New("пользователь не найден")
+		return userLookupError(err, "пользователь не найден")
 	}
 
 	if err := s.validateMeal(meal); err != nil {
 		return err
 	}
 
-	for _, productID := range meal.ProductIDs {
-		_, err := s.profileStorage.GetProductByID(ctx, productID)
-		if err != nil {
-			return fmt.Errorf("продукт с id %d не найден", productID)
-		}
+	if err := s.checkMealProductsOwner(ctx, existingMeal.UserID, meal.ProductIDs); err != nil {
+		return err
+	}
+
+	if err := s.profileStorage.UpdateMeal(ctx, meal); err != nil {
+		return err
 	}
 
-	return s.profileStorage.UpdateMeal(ctx, meal)
+	meal.UserID = existingMeal.UserID
+	s.publishMealChange(ctx, models.ChangeEventTypeMealUpdated, meal)
+	after := *meal
+	after.CreatedAt = existingMeal.CreatedAt
+	s.auditMeal(ctx, models.AuditActionUpdate, existingMeal, &after)
+	return nil
 }
 
 func (s *ProfileService) DeleteMeal(ctx context.Context, id int32) error {
-	return s.profileStorage.DeleteMeal(ctx, id)
+	meal, err := s.profileStorage.GetMealByID(ctx, id)
+	if err != nil {
+		return errors.New("блюдо не найдено")
+	}
+
+	if err := s.profileStorage.DeleteMeal(ctx, id); err != nil {
+		return err
+	}
+
+	s.publishMealChange(ctx, models.ChangeEventTypeMealDeleted, meal)
+	s.auditMeal(ctx, models.AuditActionDelete, meal, nil)
+	return nil
+}
+
+// checkMealProductsOwner одним запросом к шарду пользователя проверяет, что все продукты существуют и принадлежат ему
+func (s *ProfileService) checkMealProductsOwner(ctx context.Context, userID int32, productIDs []int32) error {
+	products, err := s.profileStorage.GetUserProductsByIDs(ctx, userID, productIDs)
+	if err != nil {
+		return err
+	}
+
+	owned := lo.Map(products, func(product *models.Product, _ int) int32 {
+		return product.ID
+	})
+	missing := lo.Uniq(lo.Without(productIDs, owned...))
+	switch len(missing) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("продукт с id %d не найден у пользователя", missing[0])
+	default:
+		return fmt.Errorf("продукты с id %s не найдены у пользователя", joinIDs(missing))
+	}
+}
+
+func joinIDs(ids []int32) string {
+	return strings.Join(lo.Map(ids, func(id int32, _ int) string {
+		return strconv.Itoa(int(id))
+	}), ", ")
 }
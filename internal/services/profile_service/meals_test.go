@@ -22,18 +22,15 @@ func (s *MealServiceSuite) SetupTest() {
 	s.profileStorage = mocks.NewProfileStorage(s.T())
 	s.ctx = context.Background()
 	mockProducer := &mockMenuGenerationProducer{}
-	s.profileService = NewProfileService(s.ctx, s.profileStorage, mockProducer, 3, 50, 6)
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: mockProducer}, Options{Settings: testSettings})
 }
 
 func (s *MealServiceSuite) TestCreateMealSuccess() {
 	meal := testMeal(0, 1, "Курица с рисом", []int32{1, 2})
 	user := testUser(1, "testuser")
-	product1 := testProduct(1, 1, "Куриная грудка")
-	product2 := testProduct(2, 1, "Рис")
 
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(user, nil)
-	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(1)).Return(product1, nil)
-	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(2)).Return(product2, nil)
+	s.profileStorage.EXPECT().GetUserProductsByIDs(s.ctx, meal.UserID, []int32{1, 2}).Return([]*models.Product{testProduct(1, 1, "Продукт 1"), testProduct(2, 1, "Продукт 2")}, nil)
 	s.profileStorage.EXPECT().CreateMeal(s.ctx, meal).Return(nil)
 
 	got := s.profileService.CreateMeal(s.ctx, meal)
@@ -42,7 +39,7 @@ func (s *MealServiceSuite) TestCreateMealSuccess() {
 
 func (s *MealServiceSuite) TestCreateMealUserNotFound() {
 	meal := testMeal(0, 1, "Курица с рисом", []int32{1, 2})
-	want := errors.New("user not found")
+	want := models.ErrNotFound
 
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(nil, want)
 
@@ -76,18 +73,70 @@ func (s *MealServiceSuite) TestCreateMealValidationError_EmptyProductIDs() {
 func (s *MealServiceSuite) TestCreateMealProductNotFound() {
 	meal := testMeal(0, 1, "Курица с рисом", []int32{1, 2})
 	user := testUser(1, "testuser")
-	product1 := testProduct(1, 1, "Куриная грудка")
-	want := errors.New("product not found")
 
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(user, nil)
-	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(1)).Return(product1, nil)
-	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(2)).Return(nil, want)
+	s.profileStorage.EXPECT().GetUserProductsByIDs(s.ctx, meal.UserID, []int32{1, 2}).Return([]*models.Product{testProduct(1, 1, "Продукт 1")}, nil)
 
 	got := s.profileService.CreateMeal(s.ctx, meal)
 	assert.Check(s.T(), got != nil)
 	assert.ErrorContains(s.T(), got, "продукт с id 2 не найден")
 }
 
+func (s *MealServiceSuite) TestCreateMealProductsOfOtherUser() {
+	meal := testMeal(0, 1, "Курица с рисом", []int32{1, 2, 3})
+	user := testUser(1, "testuser")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(user, nil)
+	s.profileStorage.EXPECT().GetUserProductsByIDs(s.ctx, meal.UserID, []int32{1, 2, 3}).Return([]*models.Product{testProduct(1, 1, "Продукт 1")}, nil)
+
+	got := s.profileService.CreateMeal(s.ctx, meal)
+	assert.ErrorContains(s.T(), got, "продукты с id 2, 3 не найдены у пользователя")
+}
+
+func (s *MealServiceSuite) TestCreateMealWithQuantities() {
+	meal := &models.Meal{
+		UserID: 1,
+		Name:   "Курица с рисом",
+		Products: []models.MealProduct{
+			{ProductID: 1, Quantity: int32Ptr(150)},
+			{ProductID: 2},
+		},
+	}
+	user := testUser(1, "testuser")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(user, nil)
+	s.profileStorage.EXPECT().GetUserProductsByIDs(s.ctx, meal.UserID, []int32{1, 2}).Return([]*models.Product{testProduct(1, 1, "Продукт 1"), testProduct(2, 1, "Продукт 2")}, nil)
+	s.profileStorage.EXPECT().CreateMeal(s.ctx, meal).Return(nil)
+
+	got := s.profileService.CreateMeal(s.ctx, meal)
+	assert.NilError(s.T(), got)
+	assert.DeepEqual(s.T(), meal.ProductIDs, []int32{1, 2})
+}
+
+func (s *MealServiceSuite) TestCreateMealValidationError_DuplicateProduct() {
+	meal := testMeal(0, 1, "Курица с рисом", []int32{1, 1})
+	user := testUser(1, "testuser")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(user, nil)
+
+	got := s.profileService.CreateMeal(s.ctx, meal)
+	assert.ErrorContains(s.T(), got, "продукт с id 1 указан в блюде несколько раз")
+}
+
+func (s *MealServiceSuite) TestCreateMealValidationError_NonPositiveQuantity() {
+	meal := &models.Meal{
+		UserID:   1,
+		Name:     "Курица с рисом",
+		Products: []models.MealProduct{{ProductID: 1, Quantity: int32Ptr(0)}},
+	}
+	user := testUser(1, "testuser")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(user, nil)
+
+	got := s.profileService.CreateMeal(s.ctx, meal)
+	assert.ErrorContains(s.T(), got, "количество продукта с id 1 должно быть положительным")
+}
+
 func (s *MealServiceSuite) TestGetMealsByUserIDSuccess() {
 	userID := int32(1)
 	want := []*models.Meal{
@@ -116,19 +165,32 @@ func (s *MealServiceSuite) TestUpdateMealSuccess() {
 	meal := testMeal(1, 1, "Обновленная курица с рисом", []int32{1, 2})
 	existingMeal := testMeal(1, 1, "Курица с рисом", []int32{1})
 	user := testUser(1, "testuser")
-	product1 := testProduct(1, 1, "Куриная грудка")
-	product2 := testProduct(2, 1, "Рис")
 
 	s.profileStorage.EXPECT().GetMealByID(s.ctx, meal.ID).Return(existingMeal, nil)
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(user, nil)
-	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(1)).Return(product1, nil)
-	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(2)).Return(product2, nil)
+	s.profileStorage.EXPECT().GetUserProductsByIDs(s.ctx, meal.UserID, []int32{1, 2}).Return([]*models.Product{testProduct(1, 1, "Продукт 1"), testProduct(2, 1, "Продукт 2")}, nil)
 	s.profileStorage.EXPECT().UpdateMeal(s.ctx, meal).Return(nil)
 
 	got := s.profileService.UpdateMeal(s.ctx, meal)
 	assert.NilError(s.T(), got)
 }
 
+func (s *MealServiceSuite) TestUpdateMealVersionMismatch() {
+	meal := testMeal(1, 1, "Обновленная курица с рисом", []int32{1, 2})
+	meal.Version = 1
+	existingMeal := testMeal(1, 1, "Курица с рисом", []int32{1})
+	existingMeal.Version = 2
+	user := testUser(1, "testuser")
+
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, meal.ID).Return(existingMeal, nil)
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(user, nil)
+	s.profileStorage.EXPECT().GetUserProductsByIDs(s.ctx, meal.UserID, []int32{1, 2}).Return([]*models.Product{testProduct(1, 1, "Продукт 1"), testProduct(2, 1, "Продукт 2")}, nil)
+	s.profileStorage.EXPECT().UpdateMeal(s.ctx, meal).Return(models.ErrVersionMismatch)
+
+	got := s.profileService.UpdateMeal(s.ctx, meal)
+	assert.ErrorIs(s.T(), got, models.ErrVersionMismatch)
+}
+
 func (s *MealServiceSuite) TestUpdateMealNotFound() {
 	meal := testMeal(1, 1, "Обновленная курица с рисом", []int32{1})
 	want := errors.New("meal not found")
@@ -143,7 +205,7 @@ func (s *MealServiceSuite) TestUpdateMealNotFound() {
 func (s *MealServiceSuite) TestUpdateMealUserNotFound() {
 	meal := testMeal(1, 1, "Обновленная курица с рисом", []int32{1})
 	existingMeal := testMeal(1, 1, "Курица с рисом", []int32{1})
-	want := errors.New("user not found")
+	want := models.ErrNotFound
 
 	s.profileStorage.EXPECT().GetMealByID(s.ctx, meal.ID).Return(existingMeal, nil)
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(nil, want)
@@ -183,13 +245,10 @@ func (s *MealServiceSuite) TestUpdateMealProductNotFound() {
 	meal := testMeal(1, 1, "Обновленная курица с рисом", []int32{1, 2})
 	existingMeal := testMeal(1, 1, "Курица с рисом", []int32{1})
 	user := testUser(1, "testuser")
-	product1 := testProduct(1, 1, "Куриная грудка")
-	want := errors.New("product not found")
 
 	s.profileStorage.EXPECT().GetMealByID(s.ctx, meal.ID).Return(existingMeal, nil)
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, meal.UserID).Return(user, nil)
-	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(1)).Return(product1, nil)
-	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(2)).Return(nil, want)
+	s.profileStorage.EXPECT().GetUserProductsByIDs(s.ctx, meal.UserID, []int32{1, 2}).Return([]*models.Product{testProduct(1, 1, "Продукт 1")}, nil)
 
 	got := s.profileService.UpdateMeal(s.ctx, meal)
 	assert.Check(s.T(), got != nil)
@@ -199,6 +258,7 @@ func (s *MealServiceSuite) TestUpdateMealProductNotFound() {
 func (s *MealServiceSuite) TestDeleteMealSuccess() {
 	mealID := int32(1)
 
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, mealID).Return(testMeal(mealID, 1, "Курица с рисом", []int32{1}), nil)
 	s.profileStorage.EXPECT().DeleteMeal(s.ctx, mealID).Return(nil)
 
 	got := s.profileService.DeleteMeal(s.ctx, mealID)
@@ -209,12 +269,22 @@ func (s *MealServiceSuite) TestDeleteMealError() {
 	mealID := int32(1)
 	want := errors.New("delete error")
 
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, mealID).Return(testMeal(mealID, 1, "Курица с рисом", []int32{1}), nil)
 	s.profileStorage.EXPECT().DeleteMeal(s.ctx, mealID).Return(want)
 
 	got := s.profileService.DeleteMeal(s.ctx, mealID)
 	assert.ErrorIs(s.T(), got, want)
 }
 
+func (s *MealServiceSuite) TestDeleteMealNotFound() {
+	mealID := int32(1)
+
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, mealID).Return(nil, errors.New("meal not found"))
+
+	got := s.profileService.DeleteMeal(s.ctx, mealID)
+	assert.ErrorContains(s.T(), got, "блюдо не найдено")
+}
+
 func TestMealServiceSuite(t *testing.T) {
 	suite.Run(t, new(MealServiceSuite))
 }
@@ -0,0 +1,187 @@
+package profile_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/kafka_retry_writer"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultGeneratedMenusLimit = 10
+	maxGeneratedMenusLimit     = 100
+	maxMenuGenerationDays      = 31
+	maxMealsPerDay             = 10
+)
+
+// RequestMenuGeneration явно запрашивает генерацию меню с переопределениями options и возвращает request_id.
+// Отложенный автоматический запрос для пользователя отменяется: явный запрос уже учитывает текущий профиль.
+func (s *ProfileService) RequestMenuGeneration(ctx context.Context, userID int32, options *models.MenuGenerationOptions) (string, error) {
+	user, err := s.profileStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", userLookupError(err, "пользователь не найден")
+	}
+
+	if err := validateMenuGenerationOptions(options); err != nil {
+		return "", err
+	}
+
+	s.menuGenerationDebouncer.Cancel(userID)
+
+	return s.requestMenuGeneration(ctx, user, options)
+}
+
+// scheduleMenuGeneration запускает автоматическую генерацию меню после изменения профиля.
+// Изменения одного пользователя в пределах окна debounce схлопываются в один запрос по последнему состоянию профиля.
+func (s *ProfileService) scheduleMenuGeneration(ctx context.Context, user *models.User) {
+	if !s.shouldPublishMenuGenerationEvent(user) {
+		return
+	}
+
+	if s.menuGenerationDebouncer.window <= 0 {
+		// Ошибка не возвращается, т.к. профиль уже сохранён; статус запроса сохранён как failed
+		_, _ = s.requestMenuGeneration(ctx, user, nil)
+		return
+	}
+
+	userID := user.ID
+	s.menuGenerationDebouncer.Trigger(userID, func() {
+		ctx := context.Background()
+		user, err := s.profileStorage.GetUserByID(ctx, userID)
+		if err != nil || !s.shouldPublishMenuGenerationEvent(user) {
+			return
+		}
+		if _, err := s.requestMenuGeneration(ctx, user, nil); err != nil {
+			slog.Error("debounced menu generation request failed", "user_id", userID, "error", err)
+		}
+	})
+}
+
+// requestMenuGeneration сохраняет запрос генерации меню в статусе pending и публикует его в Kafka.
+// Запись создаётся до публикации, чтобы быстрый ответ генератора нашёл её по request_id.
+func (s *ProfileService) requestMenuGeneration(ctx context.Context, user *models.User, options *models.MenuGenerationOptions) (string, error) {
+	products, err := s.profileStorage.GetProductsByUserID(ctx, user.ID)
+	if err != nil {
+		// Продолжаем публикацию без продуктов
+		slog.Warn("failed to load products for menu generation", "user_id", user.ID, "error", err)
+	}
+	meals, err := s.profileStorage.GetMealsByUserID(ctx, user.ID)
+	if err != nil {
+		// Продолжаем публикацию без блюд
+		slog.Warn("failed to load meals for menu generation", "user_id", user.ID, "error", err)
+	}
+
+	generation := &models.MenuGeneration{
+		RequestID: uuid.New().String(),
+		UserID:    user.ID,
+		Status:    models.MenuGenerationStatusPending,
+	}
+	if err := s.profileStorage.CreateMenuGeneration(ctx, generation); err != nil {
+		return "", err
+	}
+	s.audit(ctx, user.ID, models.AuditActionRequest, models.AuditEntityMenuGeneration, generation.RequestID,
+		nil, map[string]any{"status": generation.Status, "options": options})
+
+	request := &models.MenuGenerationRequest{
+		RequestID: generation.RequestID,
+		User:      user,
+		Budget:    user.Budget,
+		Products:  products,
+		Meals:     meals,
+		Options:   options,
+	}
+	if options != nil && options.Budget != nil {
+		request.Budget = options.Budget
+	}
+
+	err = s.menuGenerationProducer.PublishMenuGenerationRequest(ctx, request)
+	if errors.Is(err, kafka_retry_writer.ErrDeadLettered) {
+		// Запрос уйдёт генератору после повторной отправки из dead-letter, поэтому остаётся в статусе pending
+		slog.Warn("menu generation request saved to dead letters", "request_id", generation.RequestID, "error", err)
+		return generation.RequestID, nil
+	}
+	if err != nil {
+		generation.Status = models.MenuGenerationStatusFailed
+		generation.Error = "не удалось отправить запрос генерации меню"
+		if _, completeErr := s.profileStorage.CompleteMenuGeneration(ctx, generation); completeErr != nil {
+			slog.Error("failed to mark menu generation failed", "request_id", generation.RequestID, "error", completeErr)
+		}
+		return "", err
+	}
+
+	return generation.RequestID, nil
+}
+
+// HandleMenuGenerationResult сохраняет результат генерации, пришедший из menu-generation-results.
+// Неизвестные и повторно доставленные результаты пропускаются без ошибки.
+func (s *ProfileService) HandleMenuGenerationResult(ctx context.Context, result *models.MenuGenerationResultEvent) error {
+	if result.RequestID == "" {
+		return errors.New("в результате генерации меню не указан request_id")
+	}
+
+	generation := &models.MenuGeneration{
+		RequestID: result.RequestID,
+		UserID:    result.UserID,
+		Status:    result.Status,
+		Error:     result.Error,
+	}
+
+	switch result.Status {
+	case models.MenuGenerationStatusCompleted:
+		if len(result.Menu) == 0 || !json.Valid(result.Menu) {
+			generation.Status = models.MenuGenerationStatusFailed
+			generation.Error = "генератор вернул некорректное меню"
+		} else {
+			generation.Menu = string(result.Menu)
+		}
+	case models.MenuGenerationStatusFailed:
+		if generation.Error == "" {
+			generation.Error = "генерация меню завершилась ошибкой"
+		}
+	default:
+		return fmt.Errorf("неизвестный статус генерации меню %q", result.Status)
+	}
+
+	updated, err := s.profileStorage.CompleteMenuGeneration(ctx, generation)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		slog.Info("menu generation result skipped: unknown or already completed request", "request_id", result.RequestID)
+		return nil
+	}
+
+	// Меню целиком не пишем в журнал, оно доступно через GetMenuGenerationStatus
+	s.audit(ctx, generation.UserID, models.AuditActionComplete, models.AuditEntityMenuGeneration, generation.RequestID,
+		map[string]any{"status": models.MenuGenerationStatusPending},
+		map[string]any{"status": generation.Status, "error": generation.Error})
+	return nil
+}
+
+func (s *ProfileService) GetMenuGenerationStatus(ctx context.Context, requestID string) (*models.MenuGeneration, error) {
+	generation, err := s.profileStorage.GetMenuGeneration(ctx, requestID)
+	if err != nil {
+		return nil, errors.New("запрос генерации меню не найден")
+	}
+	return generation, nil
+}
+
+// GetGeneratedMenus возвращает последние сгенерированные меню пользователя, новые первыми
+func (s *ProfileService) GetGeneratedMenus(ctx context.Context, userID int32, limit int32) ([]*models.MenuGeneration, error) {
+	_, err := s.profileStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, userLookupError(err, "пользователь не найден")
+	}
+
+	if limit <= 0 {
+		limit = defaultGeneratedMenusLimit
+	}
+	limit = min(limit, maxGeneratedMenusLimit)
+
+	return s.profileStorage.GetGeneratedMenus(ctx, userID, uint64(limit))
+}
@@ -0,0 +1,55 @@
+package profile_service
+
+import (
+	"sync"
+	"time"
+)
+
+// menuGenerationDebouncer откладывает действие по пользователю до окончания окна без новых вызовов
+type menuGenerationDebouncer struct {
+	mu     sync.Mutex
+	window time.Duration
+	timers map[int32]*time.Timer
+}
+
+func newMenuGenerationDebouncer(window time.Duration) *menuGenerationDebouncer {
+	return &menuGenerationDebouncer{
+		window: window,
+		timers: make(map[int32]*time.Timer),
+	}
+}
+
+// Trigger (пере)запускает таймер пользователя; fn выполнится через window после последнего вызова
+func (d *menuGenerationDebouncer) Trigger(userID int32, fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if timer, ok := d.timers[userID]; ok {
+		timer.Stop()
+	}
+
+	var timer *time.Timer
+	timer = time.AfterFunc(d.window, func() {
+		d.mu.Lock()
+		if d.timers[userID] != timer {
+			d.mu.Unlock()
+			return
+		}
+		delete(d.timers, userID)
+		d.mu.Unlock()
+
+		fn()
+	})
+	d.timers[userID] = timer
+}
+
+// Cancel отменяет отложенное действие пользователя
+func (d *menuGenerationDebouncer) Cancel(userID int32) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if timer, ok := d.timers[userID]; ok {
+		timer.Stop()
+		delete(d.timers, userID)
+	}
+}
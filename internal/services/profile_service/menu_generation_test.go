@@ -0,0 +1,223 @@
+package profile_service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+type MenuGenerationServiceSuite struct {
+	suite.Suite
+	ctx            context.Context
+	profileStorage *mocks.ProfileStorage
+	profileService *ProfileService
+}
+
+func (s *MenuGenerationServiceSuite) SetupTest() {
+	s.profileStorage = mocks.NewProfileStorage(s.T())
+	s.ctx = context.Background()
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}}, Options{Settings: testSettings})
+}
+
+func (s *MenuGenerationServiceSuite) TestHandleResultCompleted() {
+	result := &models.MenuGenerationResultEvent{
+		RequestID: "req-1",
+		UserID:    1,
+		Status:    models.MenuGenerationStatusCompleted,
+		Menu:      json.RawMessage(`{"days": [{"meals": ["Плов"]}]}`),
+	}
+
+	s.profileStorage.EXPECT().CompleteMenuGeneration(s.ctx, &models.MenuGeneration{
+		RequestID: "req-1",
+		UserID:    1,
+		Status:    models.MenuGenerationStatusCompleted,
+		Menu:      `{"days": [{"meals": ["Плов"]}]}`,
+	}).Return(true, nil)
+
+	err := s.profileService.HandleMenuGenerationResult(s.ctx, result)
+	assert.NilError(s.T(), err)
+}
+
+func (s *MenuGenerationServiceSuite) TestHandleResultInvalidMenuMarkedFailed() {
+	result := &models.MenuGenerationResultEvent{
+		RequestID: "req-1",
+		Status:    models.MenuGenerationStatusCompleted,
+	}
+
+	s.profileStorage.EXPECT().CompleteMenuGeneration(s.ctx, mock.MatchedBy(func(generation *models.MenuGeneration) bool {
+		return generation.Status == models.MenuGenerationStatusFailed && generation.Error == "генератор вернул некорректное меню"
+	})).Return(true, nil)
+
+	err := s.profileService.HandleMenuGenerationResult(s.ctx, result)
+	assert.NilError(s.T(), err)
+}
+
+func (s *MenuGenerationServiceSuite) TestHandleResultDuplicateIgnored() {
+	result := &models.MenuGenerationResultEvent{
+		RequestID: "req-1",
+		Status:    models.MenuGenerationStatusFailed,
+	}
+
+	s.profileStorage.EXPECT().CompleteMenuGeneration(s.ctx, mock.Anything).Return(false, nil)
+
+	err := s.profileService.HandleMenuGenerationResult(s.ctx, result)
+	assert.NilError(s.T(), err)
+}
+
+func (s *MenuGenerationServiceSuite) TestHandleResultUnknownStatus() {
+	result := &models.MenuGenerationResultEvent{RequestID: "req-1", Status: "done"}
+
+	err := s.profileService.HandleMenuGenerationResult(s.ctx, result)
+	assert.ErrorContains(s.T(), err, `неизвестный статус генерации меню "done"`)
+}
+
+func (s *MenuGenerationServiceSuite) TestHandleResultWithoutRequestID() {
+	err := s.profileService.HandleMenuGenerationResult(s.ctx, &models.MenuGenerationResultEvent{Status: models.MenuGenerationStatusCompleted})
+	assert.ErrorContains(s.T(), err, "не указан request_id")
+}
+
+func (s *MenuGenerationServiceSuite) TestGetMenuGenerationStatusNotFound() {
+	s.profileStorage.EXPECT().GetMenuGeneration(s.ctx, "req-1").Return(nil, errors.New("menu generation not found"))
+
+	_, err := s.profileService.GetMenuGenerationStatus(s.ctx, "req-1")
+	assert.ErrorContains(s.T(), err, "запрос генерации меню не найден")
+}
+
+func (s *MenuGenerationServiceSuite) TestGetGeneratedMenusDefaultLimit() {
+	want := []*models.MenuGeneration{{RequestID: "req-1", UserID: 1, Status: models.MenuGenerationStatusCompleted, Menu: "{}"}}
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().GetGeneratedMenus(s.ctx, int32(1), uint64(defaultGeneratedMenusLimit)).Return(want, nil)
+
+	got, err := s.profileService.GetGeneratedMenus(s.ctx, 1, 0)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), len(got), 1)
+}
+
+func (s *MenuGenerationServiceSuite) TestGetGeneratedMenusLimitCapped() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().GetGeneratedMenus(s.ctx, int32(1), uint64(maxGeneratedMenusLimit)).Return(nil, nil)
+
+	_, err := s.profileService.GetGeneratedMenus(s.ctx, 1, 1000)
+	assert.NilError(s.T(), err)
+}
+
+func (s *MenuGenerationServiceSuite) TestRequestMenuGenerationWithOverrides() {
+	producer := newRecordingMenuGenerationProducer()
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: producer}, Options{Settings: testSettings})
+
+	user := testUserWithParams(1, "testuser", nil, nil, int32Ptr(5000), testBJU(100, 70, 250))
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(user, nil)
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return([]*models.Product{{ID: 1, Name: "Рис"}}, nil)
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, int32(1)).Return([]*models.Meal{{ID: 2, Name: "Рис отварной", ProductIDs: []int32{1}}}, nil)
+	s.profileStorage.EXPECT().CreateMenuGeneration(s.ctx, mock.Anything).Return(nil)
+
+	options := &models.MenuGenerationOptions{DateFrom: "2026-10-19", DateTo: "2026-10-25", MealsPerDay: 3, Budget: int32Ptr(3000)}
+	requestID, err := s.profileService.RequestMenuGeneration(s.ctx, 1, options)
+	assert.NilError(s.T(), err)
+	assert.Assert(s.T(), requestID != "")
+
+	requests := producer.Requests()
+	assert.Equal(s.T(), len(requests), 1)
+	assert.Equal(s.T(), requests[0].RequestID, requestID)
+	assert.Equal(s.T(), *requests[0].Budget, int32(3000))
+	assert.Equal(s.T(), requests[0].User, user)
+	assert.Equal(s.T(), len(requests[0].Products), 1)
+	assert.Equal(s.T(), len(requests[0].Meals), 1)
+	assert.Equal(s.T(), requests[0].Options, options)
+}
+
+func (s *MenuGenerationServiceSuite) TestRequestMenuGenerationDeadLetteredStaysPending() {
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducerDeadLettered{}}, Options{Settings: testSettings})
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return(nil, nil)
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, int32(1)).Return(nil, nil)
+	s.profileStorage.EXPECT().CreateMenuGeneration(s.ctx, mock.Anything).Return(nil)
+
+	requestID, err := s.profileService.RequestMenuGeneration(s.ctx, 1, nil)
+	assert.NilError(s.T(), err)
+	assert.Assert(s.T(), requestID != "")
+	s.profileStorage.AssertNotCalled(s.T(), "CompleteMenuGeneration")
+}
+
+func (s *MenuGenerationServiceSuite) TestRequestMenuGenerationInvalidOptions() {
+	cases := []struct {
+		options *models.MenuGenerationOptions
+		wantErr string
+	}{
+		{&models.MenuGenerationOptions{DateFrom: "2026-10-19"}, "период меню задаётся обеими датами"},
+		{&models.MenuGenerationOptions{DateFrom: "19.10.2026", DateTo: "2026-10-25"}, "date_from должна быть в формате YYYY-MM-DD"},
+		{&models.MenuGenerationOptions{DateFrom: "2026-10-25", DateTo: "2026-10-19"}, "date_to не может быть раньше date_from"},
+		{&models.MenuGenerationOptions{DateFrom: "2026-10-01", DateTo: "2026-11-01"}, "период меню не может превышать 31 дней"},
+		{&models.MenuGenerationOptions{MealsPerDay: 11}, "количество приёмов пищи должно быть от 1 до 10"},
+		{&models.MenuGenerationOptions{Budget: int32Ptr(-1)}, "бюджет должен быть положительным"},
+	}
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil).Times(len(cases))
+
+	for _, tc := range cases {
+		_, err := s.profileService.RequestMenuGeneration(s.ctx, 1, tc.options)
+		assert.ErrorContains(s.T(), err, tc.wantErr)
+	}
+}
+
+func (s *MenuGenerationServiceSuite) TestRequestMenuGenerationUserNotFound() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(nil, models.ErrNotFound)
+
+	_, err := s.profileService.RequestMenuGeneration(s.ctx, 1, nil)
+	assert.ErrorContains(s.T(), err, "пользователь не найден")
+}
+
+func (s *MenuGenerationServiceSuite) TestAutomaticTriggersDebounced() {
+	producer := newRecordingMenuGenerationProducer()
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: producer}, Options{Settings: testSettings, MenuGenerationDebounceWindow: 20 * time.Millisecond})
+
+	user := testUserWithParams(1, "testuser", nil, nil, int32Ptr(5000), nil)
+	s.profileStorage.EXPECT().GetUserByID(mock.Anything, int32(1)).Return(user, nil).Once()
+	s.profileStorage.EXPECT().GetProductsByUserID(mock.Anything, int32(1)).Return(nil, nil).Once()
+	s.profileStorage.EXPECT().GetMealsByUserID(mock.Anything, int32(1)).Return(nil, nil).Once()
+	s.profileStorage.EXPECT().CreateMenuGeneration(mock.Anything, mock.Anything).Return(nil).Once()
+
+	for i := 0; i < 3; i++ {
+		s.profileService.scheduleMenuGeneration(s.ctx, user)
+	}
+
+	select {
+	case <-producer.published:
+	case <-time.After(time.Second):
+		s.T().Fatal("debounced menu generation request was not published")
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(s.T(), len(producer.Requests()), 1)
+}
+
+func (s *MenuGenerationServiceSuite) TestExplicitRequestCancelsPendingTrigger() {
+	producer := newRecordingMenuGenerationProducer()
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: producer}, Options{Settings: testSettings, MenuGenerationDebounceWindow: 20 * time.Millisecond})
+
+	user := testUserWithParams(1, "testuser", nil, nil, int32Ptr(5000), nil)
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(user, nil).Once()
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return(nil, nil).Once()
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, int32(1)).Return(nil, nil).Once()
+	s.profileStorage.EXPECT().CreateMenuGeneration(s.ctx, mock.Anything).Return(nil).Once()
+
+	s.profileService.scheduleMenuGeneration(s.ctx, user)
+	_, err := s.profileService.RequestMenuGeneration(s.ctx, 1, nil)
+	assert.NilError(s.T(), err)
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(s.T(), len(producer.Requests()), 1)
+}
+
+func TestMenuGenerationServiceSuite(t *testing.T) {
+	suite.Run(t, new(MenuGenerationServiceSuite))
+}
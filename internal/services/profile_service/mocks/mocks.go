@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
 	mock "github.com/stretchr/testify/mock"
@@ -38,44 +39,44 @@ func (_m *ProfileStorage) EXPECT() *ProfileStorage_Expecter {
 	return &ProfileStorage_Expecter{mock: &_m.Mock}
 }
 
-// CreateMeal provides a mock function for the type ProfileStorage
-func (_mock *ProfileStorage) CreateMeal(ctx context.Context, meal *models.Meal) error {
-	ret := _mock.Called(ctx, meal)
+// CompleteIdempotencyKey provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) CompleteIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) error {
+	ret := _mock.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateMeal")
+		panic("no return value specified for CompleteIdempotencyKey")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Meal) error); ok {
-		r0 = returnFunc(ctx, meal)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey) error); ok {
+		r0 = returnFunc(ctx, key)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// ProfileStorage_CreateMeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMeal'
-type ProfileStorage_CreateMeal_Call struct {
+// ProfileStorage_CompleteIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteIdempotencyKey'
+type ProfileStorage_CompleteIdempotencyKey_Call struct {
 	*mock.Call
 }
 
-// CreateMeal is a helper method to define mock.On call
+// CompleteIdempotencyKey is a helper method to define mock.On call
 //   - ctx context.Context
-//   - meal *models.Meal
-func (_e *ProfileStorage_Expecter) CreateMeal(ctx interface{}, meal interface{}) *ProfileStorage_CreateMeal_Call {
-	return &ProfileStorage_CreateMeal_Call{Call: _e.mock.On("CreateMeal", ctx, meal)}
+//   - key *models.IdempotencyKey
+func (_e *ProfileStorage_Expecter) CompleteIdempotencyKey(ctx interface{}, key interface{}) *ProfileStorage_CompleteIdempotencyKey_Call {
+	return &ProfileStorage_CompleteIdempotencyKey_Call{Call: _e.mock.On("CompleteIdempotencyKey", ctx, key)}
 }
 
-func (_c *ProfileStorage_CreateMeal_Call) Run(run func(ctx context.Context, meal *models.Meal)) *ProfileStorage_CreateMeal_Call {
+func (_c *ProfileStorage_CompleteIdempotencyKey_Call) Run(run func(ctx context.Context, key *models.IdempotencyKey)) *ProfileStorage_CompleteIdempotencyKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Meal
+		var arg1 *models.IdempotencyKey
 		if args[1] != nil {
-			arg1 = args[1].(*models.Meal)
+			arg1 = args[1].(*models.IdempotencyKey)
 		}
 		run(
 			arg0,
@@ -85,54 +86,63 @@ func (_c *ProfileStorage_CreateMeal_Call) Run(run func(ctx context.Context, meal
 	return _c
 }
 
-func (_c *ProfileStorage_CreateMeal_Call) Return(err error) *ProfileStorage_CreateMeal_Call {
+func (_c *ProfileStorage_CompleteIdempotencyKey_Call) Return(err error) *ProfileStorage_CompleteIdempotencyKey_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ProfileStorage_CreateMeal_Call) RunAndReturn(run func(ctx context.Context, meal *models.Meal) error) *ProfileStorage_CreateMeal_Call {
+func (_c *ProfileStorage_CompleteIdempotencyKey_Call) RunAndReturn(run func(ctx context.Context, key *models.IdempotencyKey) error) *ProfileStorage_CompleteIdempotencyKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateProduct provides a mock function for the type ProfileStorage
-func (_mock *ProfileStorage) CreateProduct(ctx context.Context, product *models.Product) error {
-	ret := _mock.Called(ctx, product)
+// CompleteMenuGeneration provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) CompleteMenuGeneration(ctx context.Context, generation *models.MenuGeneration) (bool, error) {
+	ret := _mock.Called(ctx, generation)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateProduct")
+		panic("no return value specified for CompleteMenuGeneration")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Product) error); ok {
-		r0 = returnFunc(ctx, product)
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MenuGeneration) (bool, error)); ok {
+		return returnFunc(ctx, generation)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MenuGeneration) bool); ok {
+		r0 = returnFunc(ctx, generation)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.MenuGeneration) error); ok {
+		r1 = returnFunc(ctx, generation)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// ProfileStorage_CreateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProduct'
-type ProfileStorage_CreateProduct_Call struct {
+// ProfileStorage_CompleteMenuGeneration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteMenuGeneration'
+type ProfileStorage_CompleteMenuGeneration_Call struct {
 	*mock.Call
 }
 
-// CreateProduct is a helper method to define mock.On call
+// CompleteMenuGeneration is a helper method to define mock.On call
 //   - ctx context.Context
-//   - product *models.Product
-func (_e *ProfileStorage_Expecter) CreateProduct(ctx interface{}, product interface{}) *ProfileStorage_CreateProduct_Call {
-	return &ProfileStorage_CreateProduct_Call{Call: _e.mock.On("CreateProduct", ctx, product)}
+//   - generation *models.MenuGeneration
+func (_e *ProfileStorage_Expecter) CompleteMenuGeneration(ctx interface{}, generation interface{}) *ProfileStorage_CompleteMenuGeneration_Call {
+	return &ProfileStorage_CompleteMenuGeneration_Call{Call: _e.mock.On("CompleteMenuGeneration", ctx, generation)}
 }
 
-func (_c *ProfileStorage_CreateProduct_Call) Run(run func(ctx context.Context, product *models.Product)) *ProfileStorage_CreateProduct_Call {
+func (_c *ProfileStorage_CompleteMenuGeneration_Call) Run(run func(ctx context.Context, generation *models.MenuGeneration)) *ProfileStorage_CompleteMenuGeneration_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.Product
+		var arg1 *models.MenuGeneration
 		if args[1] != nil {
-			arg1 = args[1].(*models.Product)
+			arg1 = args[1].(*models.MenuGeneration)
 		}
 		run(
 			arg0,
@@ -142,54 +152,63 @@ func (_c *ProfileStorage_CreateProduct_Call) Run(run func(ctx context.Context, p
 	return _c
 }
 
-func (_c *ProfileStorage_CreateProduct_Call) Return(err error) *ProfileStorage_CreateProduct_Call {
-	_c.Call.Return(err)
+func (_c *ProfileStorage_CompleteMenuGeneration_Call) Return(b bool, err error) *ProfileStorage_CompleteMenuGeneration_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *ProfileStorage_CreateProduct_Call) RunAndReturn(run func(ctx context.Context, product *models.Product) error) *ProfileStorage_CreateProduct_Call {
+func (_c *ProfileStorage_CompleteMenuGeneration_Call) RunAndReturn(run func(ctx context.Context, generation *models.MenuGeneration) (bool, error)) *ProfileStorage_CompleteMenuGeneration_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateUser provides a mock function for the type ProfileStorage
-func (_mock *ProfileStorage) CreateUser(ctx context.Context, user *models.User) error {
-	ret := _mock.Called(ctx, user)
+// CreateIdempotencyKey provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) CreateIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) (bool, error) {
+	ret := _mock.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateUser")
+		panic("no return value specified for CreateIdempotencyKey")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
-		r0 = returnFunc(ctx, user)
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey) (bool, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey) bool); ok {
+		r0 = returnFunc(ctx, key)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.IdempotencyKey) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// ProfileStorage_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
-type ProfileStorage_CreateUser_Call struct {
+// ProfileStorage_CreateIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateIdempotencyKey'
+type ProfileStorage_CreateIdempotencyKey_Call struct {
 	*mock.Call
 }
 
-// CreateUser is a helper method to define mock.On call
+// CreateIdempotencyKey is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *models.User
-func (_e *ProfileStorage_Expecter) CreateUser(ctx interface{}, user interface{}) *ProfileStorage_CreateUser_Call {
-	return &ProfileStorage_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+//   - key *models.IdempotencyKey
+func (_e *ProfileStorage_Expecter) CreateIdempotencyKey(ctx interface{}, key interface{}) *ProfileStorage_CreateIdempotencyKey_Call {
+	return &ProfileStorage_CreateIdempotencyKey_Call{Call: _e.mock.On("CreateIdempotencyKey", ctx, key)}
 }
 
-func (_c *ProfileStorage_CreateUser_Call) Run(run func(ctx context.Context, user *models.User)) *ProfileStorage_CreateUser_Call {
+func (_c *ProfileStorage_CreateIdempotencyKey_Call) Run(run func(ctx context.Context, key *models.IdempotencyKey)) *ProfileStorage_CreateIdempotencyKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.User
+		var arg1 *models.IdempotencyKey
 		if args[1] != nil {
-			arg1 = args[1].(*models.User)
+			arg1 = args[1].(*models.IdempotencyKey)
 		}
 		run(
 			arg0,
@@ -199,54 +218,54 @@ func (_c *ProfileStorage_CreateUser_Call) Run(run func(ctx context.Context, user
 	return _c
 }
 
-func (_c *ProfileStorage_CreateUser_Call) Return(err error) *ProfileStorage_CreateUser_Call {
-	_c.Call.Return(err)
+func (_c *ProfileStorage_CreateIdempotencyKey_Call) Return(b bool, err error) *ProfileStorage_CreateIdempotencyKey_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *ProfileStorage_CreateUser_Call) RunAndReturn(run func(ctx context.Context, user *models.User) error) *ProfileStorage_CreateUser_Call {
+func (_c *ProfileStorage_CreateIdempotencyKey_Call) RunAndReturn(run func(ctx context.Context, key *models.IdempotencyKey) (bool, error)) *ProfileStorage_CreateIdempotencyKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteMeal provides a mock function for the type ProfileStorage
-func (_mock *ProfileStorage) DeleteMeal(ctx context.Context, id int32) error {
-	ret := _mock.Called(ctx, id)
+// CreateMeal provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) CreateMeal(ctx context.Context, meal *models.Meal) error {
+	ret := _mock.Called(ctx, meal)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteMeal")
+		panic("no return value specified for CreateMeal")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Meal) error); ok {
+		r0 = returnFunc(ctx, meal)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// ProfileStorage_DeleteMeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMeal'
-type ProfileStorage_DeleteMeal_Call struct {
+// ProfileStorage_CreateMeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMeal'
+type ProfileStorage_CreateMeal_Call struct {
 	*mock.Call
 }
 
-// DeleteMeal is a helper method to define mock.On call
+// CreateMeal is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int32
-func (_e *ProfileStorage_Expecter) DeleteMeal(ctx interface{}, id interface{}) *ProfileStorage_DeleteMeal_Call {
-	return &ProfileStorage_DeleteMeal_Call{Call: _e.mock.On("DeleteMeal", ctx, id)}
+//   - meal *models.Meal
+func (_e *ProfileStorage_Expecter) CreateMeal(ctx interface{}, meal interface{}) *ProfileStorage_CreateMeal_Call {
+	return &ProfileStorage_CreateMeal_Call{Call: _e.mock.On("CreateMeal", ctx, meal)}
 }
 
-func (_c *ProfileStorage_DeleteMeal_Call) Run(run func(ctx context.Context, id int32)) *ProfileStorage_DeleteMeal_Call {
+func (_c *ProfileStorage_CreateMeal_Call) Run(run func(ctx context.Context, meal *models.Meal)) *ProfileStorage_CreateMeal_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int32
+		var arg1 *models.Meal
 		if args[1] != nil {
-			arg1 = args[1].(int32)
+			arg1 = args[1].(*models.Meal)
 		}
 		run(
 			arg0,
@@ -256,46 +275,47 @@ func (_c *ProfileStorage_DeleteMeal_Call) Run(run func(ctx context.Context, id i
 	return _c
 }
 
-func (_c *ProfileStorage_DeleteMeal_Call) Return(err error) *ProfileStorage_DeleteMeal_Call {
+func (_c *ProfileStorage_CreateMeal_Call) Return(err error) *ProfileStorage_CreateMeal_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ProfileStorage_DeleteMeal_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *ProfileStorage_DeleteMeal_Call {
+func (_c *ProfileStorage_CreateMeal_Call) RunAndReturn(run func(ctx context.Context, meal *models.Meal) error) *ProfileStorage_CreateMeal_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteProduct provides a mock function for the type ProfileStorage
-func (_mock *ProfileStorage) DeleteProduct(ctx context.Context, id int32) error {
-	ret := _mock.Called(ctx, id)
+// CreateMeals provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) CreateMeals(ctx context.Context, userID int32, meals []*models.Meal) error {
+	ret := _mock.Called(ctx, userID, meals)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteProduct")
+		panic("no return value specified for CreateMeals")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, []*models.Meal) error); ok {
+		r0 = returnFunc(ctx, userID, meals)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// ProfileStorage_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
-type ProfileStorage_DeleteProduct_Call struct {
+// ProfileStorage_CreateMeals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMeals'
+type ProfileStorage_CreateMeals_Call struct {
 	*mock.Call
 }
 
-// DeleteProduct is a helper method to define mock.On call
+// CreateMeals is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int32
-func (_e *ProfileStorage_Expecter) DeleteProduct(ctx interface{}, id interface{}) *ProfileStorage_DeleteProduct_Call {
-	return &ProfileStorage_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id)}
+//   - userID int32
+//   - meals []*models.Meal
+func (_e *ProfileStorage_Expecter) CreateMeals(ctx interface{}, userID interface{}, meals interface{}) *ProfileStorage_CreateMeals_Call {
+	return &ProfileStorage_CreateMeals_Call{Call: _e.mock.On("CreateMeals", ctx, userID, meals)}
 }
 
-func (_c *ProfileStorage_DeleteProduct_Call) Run(run func(ctx context.Context, id int32)) *ProfileStorage_DeleteProduct_Call {
+func (_c *ProfileStorage_CreateMeals_Call) Run(run func(ctx context.Context, userID int32, meals []*models.Meal)) *ProfileStorage_CreateMeals_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -305,62 +325,67 @@ func (_c *ProfileStorage_DeleteProduct_Call) Run(run func(ctx context.Context, i
 		if args[1] != nil {
 			arg1 = args[1].(int32)
 		}
+		var arg2 []*models.Meal
+		if args[2] != nil {
+			arg2 = args[2].([]*models.Meal)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *ProfileStorage_DeleteProduct_Call) Return(err error) *ProfileStorage_DeleteProduct_Call {
+func (_c *ProfileStorage_CreateMeals_Call) Return(err error) *ProfileStorage_CreateMeals_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ProfileStorage_DeleteProduct_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *ProfileStorage_DeleteProduct_Call {
+func (_c *ProfileStorage_CreateMeals_Call) RunAndReturn(run func(ctx context.Context, userID int32, meals []*models.Meal) error) *ProfileStorage_CreateMeals_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUser provides a mock function for the type ProfileStorage
-func (_mock *ProfileStorage) DeleteUser(ctx context.Context, id int32) error {
-	ret := _mock.Called(ctx, id)
+// CreateMenuGeneration provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) CreateMenuGeneration(ctx context.Context, generation *models.MenuGeneration) error {
+	ret := _mock.Called(ctx, generation)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteUser")
+		panic("no return value specified for CreateMenuGeneration")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MenuGeneration) error); ok {
+		r0 = returnFunc(ctx, generation)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// ProfileStorage_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
-type ProfileStorage_DeleteUser_Call struct {
+// ProfileStorage_CreateMenuGeneration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMenuGeneration'
+type ProfileStorage_CreateMenuGeneration_Call struct {
 	*mock.Call
 }
 
-// DeleteUser is a helper method to define mock.On call
+// CreateMenuGeneration is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int32
-func (_e *ProfileStorage_Expecter) DeleteUser(ctx interface{}, id interface{}) *ProfileStorage_DeleteUser_Call {
-	return &ProfileStorage_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+//   - generation *models.MenuGeneration
+func (_e *ProfileStorage_Expecter) CreateMenuGeneration(ctx interface{}, generation interface{}) *ProfileStorage_CreateMenuGeneration_Call {
+	return &ProfileStorage_CreateMenuGeneration_Call{Call: _e.mock.On("CreateMenuGeneration", ctx, generation)}
 }
 
-func (_c *ProfileStorage_DeleteUser_Call) Run(run func(ctx context.Context, id int32)) *ProfileStorage_DeleteUser_Call {
+func (_c *ProfileStorage_CreateMenuGeneration_Call) Run(run func(ctx context.Context, generation *models.MenuGeneration)) *ProfileStorage_CreateMenuGeneration_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int32
+		var arg1 *models.MenuGeneration
 		if args[1] != nil {
-			arg1 = args[1].(int32)
+			arg1 = args[1].(*models.MenuGeneration)
 		}
 		run(
 			arg0,
@@ -370,65 +395,54 @@ func (_c *ProfileStorage_DeleteUser_Call) Run(run func(ctx context.Context, id i
 	return _c
 }
 
-func (_c *ProfileStorage_DeleteUser_Call) Return(err error) *ProfileStorage_DeleteUser_Call {
+func (_c *ProfileStorage_CreateMenuGeneration_Call) Return(err error) *ProfileStorage_CreateMenuGeneration_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ProfileStorage_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *ProfileStorage_DeleteUser_Call {
+func (_c *ProfileStorage_CreateMenuGeneration_Call) RunAndReturn(run func(ctx context.Context, generation *models.MenuGeneration) error) *ProfileStorage_CreateMenuGeneration_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMealByID provides a mock function for the type ProfileStorage
-func (_mock *ProfileStorage) GetMealByID(ctx context.Context, id int32) (*models.Meal, error) {
-	ret := _mock.Called(ctx, id)
+// CreateProduct provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) CreateProduct(ctx context.Context, product *models.Product) error {
+	ret := _mock.Called(ctx, product)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMealByID")
+		panic("no return value specified for CreateProduct")
 	}
 
-	var r0 *models.Meal
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (*models.Meal, error)); ok {
-		return returnFunc(ctx, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) *models.Meal); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Meal)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
-		r1 = returnFunc(ctx, id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Product) error); ok {
+		r0 = returnFunc(ctx, product)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// ProfileStorage_GetMealByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMealByID'
-type ProfileStorage_GetMealByID_Call struct {
+// ProfileStorage_CreateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProduct'
+type ProfileStorage_CreateProduct_Call struct {
 	*mock.Call
 }
 
-// GetMealByID is a helper method to define mock.On call
+// CreateProduct is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int32
-func (_e *ProfileStorage_Expecter) GetMealByID(ctx interface{}, id interface{}) *ProfileStorage_GetMealByID_Call {
-	return &ProfileStorage_GetMealByID_Call{Call: _e.mock.On("GetMealByID", ctx, id)}
+//   - product *models.Product
+func (_e *ProfileStorage_Expecter) CreateProduct(ctx interface{}, product interface{}) *ProfileStorage_CreateProduct_Call {
+	return &ProfileStorage_CreateProduct_Call{Call: _e.mock.On("CreateProduct", ctx, product)}
 }
 
-func (_c *ProfileStorage_GetMealByID_Call) Run(run func(ctx context.Context, id int32)) *ProfileStorage_GetMealByID_Call {
+func (_c *ProfileStorage_CreateProduct_Call) Run(run func(ctx context.Context, product *models.Product)) *ProfileStorage_CreateProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int32
+		var arg1 *models.Product
 		if args[1] != nil {
-			arg1 = args[1].(int32)
+			arg1 = args[1].(*models.Product)
 		}
 		run(
 			arg0,
@@ -438,57 +452,47 @@ func (_c *ProfileStorage_GetMealByID_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *ProfileStorage_GetMealByID_Call) Return(meal *models.Meal, err error) *ProfileStorage_GetMealByID_Call {
-	_c.Call.Return(meal, err)
+func (_c *ProfileStorage_CreateProduct_Call) Return(err error) *ProfileStorage_CreateProduct_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ProfileStorage_GetMealByID_Call) RunAndReturn(run func(ctx context.Context, id int32) (*models.Meal, error)) *ProfileStorage_GetMealByID_Call {
+func (_c *ProfileStorage_CreateProduct_Call) RunAndReturn(run func(ctx context.Context, product *models.Product) error) *ProfileStorage_CreateProduct_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMealsByUserID provides a mock function for the type ProfileStorage
-func (_mock *ProfileStorage) GetMealsByUserID(ctx context.Context, userID int32) ([]*models.Meal, error) {
-	ret := _mock.Called(ctx, userID)
+// CreateProducts provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) CreateProducts(ctx context.Context, userID int32, products []*models.Product) error {
+	ret := _mock.Called(ctx, userID, products)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetMealsByUserID")
+		panic("no return value specified for CreateProducts")
 	}
 
-	var r0 []*models.Meal
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]*models.Meal, error)); ok {
-		return returnFunc(ctx, userID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []*models.Meal); ok {
-		r0 = returnFunc(ctx, userID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Meal)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
-		r1 = returnFunc(ctx, userID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, []*models.Product) error); ok {
+		r0 = returnFunc(ctx, userID, products)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// ProfileStorage_GetMealsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMealsByUserID'
-type ProfileStorage_GetMealsByUserID_Call struct {
+// ProfileStorage_CreateProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProducts'
+type ProfileStorage_CreateProducts_Call struct {
 	*mock.Call
 }
 
-// GetMealsByUserID is a helper method to define mock.On call
+// CreateProducts is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID int32
-func (_e *ProfileStorage_Expecter) GetMealsByUserID(ctx interface{}, userID interface{}) *ProfileStorage_GetMealsByUserID_Call {
-	return &ProfileStorage_GetMealsByUserID_Call{Call: _e.mock.On("GetMealsByUserID", ctx, userID)}
+//   - products []*models.Product
+func (_e *ProfileStorage_Expecter) CreateProducts(ctx interface{}, userID interface{}, products interface{}) *ProfileStorage_CreateProducts_Call {
+	return &ProfileStorage_CreateProducts_Call{Call: _e.mock.On("CreateProducts", ctx, userID, products)}
 }
 
-func (_c *ProfileStorage_GetMealsByUserID_Call) Run(run func(ctx context.Context, userID int32)) *ProfileStorage_GetMealsByUserID_Call {
+func (_c *ProfileStorage_CreateProducts_Call) Run(run func(ctx context.Context, userID int32, products []*models.Product)) *ProfileStorage_CreateProducts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -498,58 +502,1065 @@ func (_c *ProfileStorage_GetMealsByUserID_Call) Run(run func(ctx context.Context
 		if args[1] != nil {
 			arg1 = args[1].(int32)
 		}
+		var arg2 []*models.Product
+		if args[2] != nil {
+			arg2 = args[2].([]*models.Product)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *ProfileStorage_GetMealsByUserID_Call) Return(meals []*models.Meal, err error) *ProfileStorage_GetMealsByUserID_Call {
-	_c.Call.Return(meals, err)
+func (_c *ProfileStorage_CreateProducts_Call) Return(err error) *ProfileStorage_CreateProducts_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *ProfileStorage_GetMealsByUserID_Call) RunAndReturn(run func(ctx context.Context, userID int32) ([]*models.Meal, error)) *ProfileStorage_GetMealsByUserID_Call {
+func (_c *ProfileStorage_CreateProducts_Call) RunAndReturn(run func(ctx context.Context, userID int32, products []*models.Product) error) *ProfileStorage_CreateProducts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProductByID provides a mock function for the type ProfileStorage
-func (_mock *ProfileStorage) GetProductByID(ctx context.Context, id int32) (*models.Product, error) {
-	ret := _mock.Called(ctx, id)
+// CreateUser provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) CreateUser(ctx context.Context, user *models.User) error {
+	ret := _mock.Called(ctx, user)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProductByID")
+		panic("no return value specified for CreateUser")
 	}
 
-	var r0 *models.Product
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (*models.Product, error)); ok {
-		return returnFunc(ctx, id)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) *models.Product); ok {
-		r0 = returnFunc(ctx, id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Product)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
-		r1 = returnFunc(ctx, id)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.User) error); ok {
+		r0 = returnFunc(ctx, user)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// ProfileStorage_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
-type ProfileStorage_GetProductByID_Call struct {
+// ProfileStorage_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type ProfileStorage_CreateUser_Call struct {
 	*mock.Call
 }
 
-// GetProductByID is a helper method to define mock.On call
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user *models.User
+func (_e *ProfileStorage_Expecter) CreateUser(ctx interface{}, user interface{}) *ProfileStorage_CreateUser_Call {
+	return &ProfileStorage_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+}
+
+func (_c *ProfileStorage_CreateUser_Call) Run(run func(ctx context.Context, user *models.User)) *ProfileStorage_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.User
+		if args[1] != nil {
+			arg1 = args[1].(*models.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_CreateUser_Call) Return(err error) *ProfileStorage_CreateUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProfileStorage_CreateUser_Call) RunAndReturn(run func(ctx context.Context, user *models.User) error) *ProfileStorage_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDeadLetter provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) DeleteDeadLetter(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProfileStorage_DeleteDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDeadLetter'
+type ProfileStorage_DeleteDeadLetter_Call struct {
+	*mock.Call
+}
+
+// DeleteDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ProfileStorage_Expecter) DeleteDeadLetter(ctx interface{}, id interface{}) *ProfileStorage_DeleteDeadLetter_Call {
+	return &ProfileStorage_DeleteDeadLetter_Call{Call: _e.mock.On("DeleteDeadLetter", ctx, id)}
+}
+
+func (_c *ProfileStorage_DeleteDeadLetter_Call) Run(run func(ctx context.Context, id string)) *ProfileStorage_DeleteDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteDeadLetter_Call) Return(err error) *ProfileStorage_DeleteDeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteDeadLetter_Call) RunAndReturn(run func(ctx context.Context, id string) error) *ProfileStorage_DeleteDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIdempotencyKey provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) DeleteIdempotencyKey(ctx context.Context, userID int32, method string, key string) error {
+	ret := _mock.Called(ctx, userID, method, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKey")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, method, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProfileStorage_DeleteIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdempotencyKey'
+type ProfileStorage_DeleteIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// DeleteIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int32
+//   - method string
+//   - key string
+func (_e *ProfileStorage_Expecter) DeleteIdempotencyKey(ctx interface{}, userID interface{}, method interface{}, key interface{}) *ProfileStorage_DeleteIdempotencyKey_Call {
+	return &ProfileStorage_DeleteIdempotencyKey_Call{Call: _e.mock.On("DeleteIdempotencyKey", ctx, userID, method, key)}
+}
+
+func (_c *ProfileStorage_DeleteIdempotencyKey_Call) Run(run func(ctx context.Context, userID int32, method string, key string)) *ProfileStorage_DeleteIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteIdempotencyKey_Call) Return(err error) *ProfileStorage_DeleteIdempotencyKey_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteIdempotencyKey_Call) RunAndReturn(run func(ctx context.Context, userID int32, method string, key string) error) *ProfileStorage_DeleteIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMeal provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) DeleteMeal(ctx context.Context, id int32) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMeal")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProfileStorage_DeleteMeal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMeal'
+type ProfileStorage_DeleteMeal_Call struct {
+	*mock.Call
+}
+
+// DeleteMeal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *ProfileStorage_Expecter) DeleteMeal(ctx interface{}, id interface{}) *ProfileStorage_DeleteMeal_Call {
+	return &ProfileStorage_DeleteMeal_Call{Call: _e.mock.On("DeleteMeal", ctx, id)}
+}
+
+func (_c *ProfileStorage_DeleteMeal_Call) Run(run func(ctx context.Context, id int32)) *ProfileStorage_DeleteMeal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteMeal_Call) Return(err error) *ProfileStorage_DeleteMeal_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteMeal_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *ProfileStorage_DeleteMeal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProduct provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) DeleteProduct(ctx context.Context, id int32, detachFromMeals bool) error {
+	ret := _mock.Called(ctx, id, detachFromMeals)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProduct")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, bool) error); ok {
+		r0 = returnFunc(ctx, id, detachFromMeals)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProfileStorage_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
+type ProfileStorage_DeleteProduct_Call struct {
+	*mock.Call
+}
+
+// DeleteProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+//   - detachFromMeals bool
+func (_e *ProfileStorage_Expecter) DeleteProduct(ctx interface{}, id interface{}, detachFromMeals interface{}) *ProfileStorage_DeleteProduct_Call {
+	return &ProfileStorage_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id, detachFromMeals)}
+}
+
+func (_c *ProfileStorage_DeleteProduct_Call) Run(run func(ctx context.Context, id int32, detachFromMeals bool)) *ProfileStorage_DeleteProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteProduct_Call) Return(err error) *ProfileStorage_DeleteProduct_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteProduct_Call) RunAndReturn(run func(ctx context.Context, id int32, detachFromMeals bool) error) *ProfileStorage_DeleteProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) DeleteUser(ctx context.Context, id int32) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProfileStorage_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type ProfileStorage_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *ProfileStorage_Expecter) DeleteUser(ctx interface{}, id interface{}) *ProfileStorage_DeleteUser_Call {
+	return &ProfileStorage_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+}
+
+func (_c *ProfileStorage_DeleteUser_Call) Run(run func(ctx context.Context, id int32)) *ProfileStorage_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteUser_Call) Return(err error) *ProfileStorage_DeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProfileStorage_DeleteUser_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *ProfileStorage_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeadLetter provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetter")
+	}
+
+	var r0 *models.DeadLetter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.DeadLetter, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.DeadLetter); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeadLetter)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadLetter'
+type ProfileStorage_GetDeadLetter_Call struct {
+	*mock.Call
+}
+
+// GetDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *ProfileStorage_Expecter) GetDeadLetter(ctx interface{}, id interface{}) *ProfileStorage_GetDeadLetter_Call {
+	return &ProfileStorage_GetDeadLetter_Call{Call: _e.mock.On("GetDeadLetter", ctx, id)}
+}
+
+func (_c *ProfileStorage_GetDeadLetter_Call) Run(run func(ctx context.Context, id string)) *ProfileStorage_GetDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetDeadLetter_Call) Return(deadLetter *models.DeadLetter, err error) *ProfileStorage_GetDeadLetter_Call {
+	_c.Call.Return(deadLetter, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetDeadLetter_Call) RunAndReturn(run func(ctx context.Context, id string) (*models.DeadLetter, error)) *ProfileStorage_GetDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeadLetters provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetDeadLetters(ctx context.Context, topic string, limit uint64) ([]*models.DeadLetter, error) {
+	ret := _mock.Called(ctx, topic, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeadLetters")
+	}
+
+	var r0 []*models.DeadLetter
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64) ([]*models.DeadLetter, error)); ok {
+		return returnFunc(ctx, topic, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, uint64) []*models.DeadLetter); ok {
+		r0 = returnFunc(ctx, topic, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DeadLetter)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, uint64) error); ok {
+		r1 = returnFunc(ctx, topic, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeadLetters'
+type ProfileStorage_GetDeadLetters_Call struct {
+	*mock.Call
+}
+
+// GetDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - topic string
+//   - limit uint64
+func (_e *ProfileStorage_Expecter) GetDeadLetters(ctx interface{}, topic interface{}, limit interface{}) *ProfileStorage_GetDeadLetters_Call {
+	return &ProfileStorage_GetDeadLetters_Call{Call: _e.mock.On("GetDeadLetters", ctx, topic, limit)}
+}
+
+func (_c *ProfileStorage_GetDeadLetters_Call) Run(run func(ctx context.Context, topic string, limit uint64)) *ProfileStorage_GetDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetDeadLetters_Call) Return(deadLetters []*models.DeadLetter, err error) *ProfileStorage_GetDeadLetters_Call {
+	_c.Call.Return(deadLetters, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetDeadLetters_Call) RunAndReturn(run func(ctx context.Context, topic string, limit uint64) ([]*models.DeadLetter, error)) *ProfileStorage_GetDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGeneratedMenus provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetGeneratedMenus(ctx context.Context, userID int32, limit uint64) ([]*models.MenuGeneration, error) {
+	ret := _mock.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGeneratedMenus")
+	}
+
+	var r0 []*models.MenuGeneration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, uint64) ([]*models.MenuGeneration, error)); ok {
+		return returnFunc(ctx, userID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, uint64) []*models.MenuGeneration); ok {
+		r0 = returnFunc(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.MenuGeneration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, uint64) error); ok {
+		r1 = returnFunc(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetGeneratedMenus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGeneratedMenus'
+type ProfileStorage_GetGeneratedMenus_Call struct {
+	*mock.Call
+}
+
+// GetGeneratedMenus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int32
+//   - limit uint64
+func (_e *ProfileStorage_Expecter) GetGeneratedMenus(ctx interface{}, userID interface{}, limit interface{}) *ProfileStorage_GetGeneratedMenus_Call {
+	return &ProfileStorage_GetGeneratedMenus_Call{Call: _e.mock.On("GetGeneratedMenus", ctx, userID, limit)}
+}
+
+func (_c *ProfileStorage_GetGeneratedMenus_Call) Run(run func(ctx context.Context, userID int32, limit uint64)) *ProfileStorage_GetGeneratedMenus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetGeneratedMenus_Call) Return(menuGenerations []*models.MenuGeneration, err error) *ProfileStorage_GetGeneratedMenus_Call {
+	_c.Call.Return(menuGenerations, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetGeneratedMenus_Call) RunAndReturn(run func(ctx context.Context, userID int32, limit uint64) ([]*models.MenuGeneration, error)) *ProfileStorage_GetGeneratedMenus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIdempotencyKey provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetIdempotencyKey(ctx context.Context, userID int32, method string, key string) (*models.IdempotencyKey, error) {
+	ret := _mock.Called(ctx, userID, method, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyKey")
+	}
+
+	var r0 *models.IdempotencyKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, string, string) (*models.IdempotencyKey, error)); ok {
+		return returnFunc(ctx, userID, method, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, string, string) *models.IdempotencyKey); ok {
+		r0 = returnFunc(ctx, userID, method, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotencyKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, method, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdempotencyKey'
+type ProfileStorage_GetIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int32
+//   - method string
+//   - key string
+func (_e *ProfileStorage_Expecter) GetIdempotencyKey(ctx interface{}, userID interface{}, method interface{}, key interface{}) *ProfileStorage_GetIdempotencyKey_Call {
+	return &ProfileStorage_GetIdempotencyKey_Call{Call: _e.mock.On("GetIdempotencyKey", ctx, userID, method, key)}
+}
+
+func (_c *ProfileStorage_GetIdempotencyKey_Call) Run(run func(ctx context.Context, userID int32, method string, key string)) *ProfileStorage_GetIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetIdempotencyKey_Call) Return(idempotencyKey *models.IdempotencyKey, err error) *ProfileStorage_GetIdempotencyKey_Call {
+	_c.Call.Return(idempotencyKey, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetIdempotencyKey_Call) RunAndReturn(run func(ctx context.Context, userID int32, method string, key string) (*models.IdempotencyKey, error)) *ProfileStorage_GetIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMealByID provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetMealByID(ctx context.Context, id int32) (*models.Meal, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMealByID")
+	}
+
+	var r0 *models.Meal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (*models.Meal, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) *models.Meal); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Meal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetMealByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMealByID'
+type ProfileStorage_GetMealByID_Call struct {
+	*mock.Call
+}
+
+// GetMealByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *ProfileStorage_Expecter) GetMealByID(ctx interface{}, id interface{}) *ProfileStorage_GetMealByID_Call {
+	return &ProfileStorage_GetMealByID_Call{Call: _e.mock.On("GetMealByID", ctx, id)}
+}
+
+func (_c *ProfileStorage_GetMealByID_Call) Run(run func(ctx context.Context, id int32)) *ProfileStorage_GetMealByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetMealByID_Call) Return(meal *models.Meal, err error) *ProfileStorage_GetMealByID_Call {
+	_c.Call.Return(meal, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetMealByID_Call) RunAndReturn(run func(ctx context.Context, id int32) (*models.Meal, error)) *ProfileStorage_GetMealByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMealsByIDs provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetMealsByIDs(ctx context.Context, ids []int32) ([]*models.Meal, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMealsByIDs")
+	}
+
+	var r0 []*models.Meal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int32) ([]*models.Meal, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int32) []*models.Meal); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Meal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int32) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetMealsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMealsByIDs'
+type ProfileStorage_GetMealsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetMealsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int32
+func (_e *ProfileStorage_Expecter) GetMealsByIDs(ctx interface{}, ids interface{}) *ProfileStorage_GetMealsByIDs_Call {
+	return &ProfileStorage_GetMealsByIDs_Call{Call: _e.mock.On("GetMealsByIDs", ctx, ids)}
+}
+
+func (_c *ProfileStorage_GetMealsByIDs_Call) Run(run func(ctx context.Context, ids []int32)) *ProfileStorage_GetMealsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []int32
+		if args[1] != nil {
+			arg1 = args[1].([]int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetMealsByIDs_Call) Return(meals []*models.Meal, err error) *ProfileStorage_GetMealsByIDs_Call {
+	_c.Call.Return(meals, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetMealsByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []int32) ([]*models.Meal, error)) *ProfileStorage_GetMealsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMealsByProductID provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetMealsByProductID(ctx context.Context, userID int32, productID int32) ([]*models.Meal, error) {
+	ret := _mock.Called(ctx, userID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMealsByProductID")
+	}
+
+	var r0 []*models.Meal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) ([]*models.Meal, error)); ok {
+		return returnFunc(ctx, userID, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, int32) []*models.Meal); ok {
+		r0 = returnFunc(ctx, userID, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Meal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, int32) error); ok {
+		r1 = returnFunc(ctx, userID, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetMealsByProductID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMealsByProductID'
+type ProfileStorage_GetMealsByProductID_Call struct {
+	*mock.Call
+}
+
+// GetMealsByProductID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int32
+//   - productID int32
+func (_e *ProfileStorage_Expecter) GetMealsByProductID(ctx interface{}, userID interface{}, productID interface{}) *ProfileStorage_GetMealsByProductID_Call {
+	return &ProfileStorage_GetMealsByProductID_Call{Call: _e.mock.On("GetMealsByProductID", ctx, userID, productID)}
+}
+
+func (_c *ProfileStorage_GetMealsByProductID_Call) Run(run func(ctx context.Context, userID int32, productID int32)) *ProfileStorage_GetMealsByProductID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 int32
+		if args[2] != nil {
+			arg2 = args[2].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetMealsByProductID_Call) Return(meals []*models.Meal, err error) *ProfileStorage_GetMealsByProductID_Call {
+	_c.Call.Return(meals, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetMealsByProductID_Call) RunAndReturn(run func(ctx context.Context, userID int32, productID int32) ([]*models.Meal, error)) *ProfileStorage_GetMealsByProductID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMealsByUserID provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetMealsByUserID(ctx context.Context, userID int32) ([]*models.Meal, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMealsByUserID")
+	}
+
+	var r0 []*models.Meal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]*models.Meal, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []*models.Meal); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Meal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetMealsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMealsByUserID'
+type ProfileStorage_GetMealsByUserID_Call struct {
+	*mock.Call
+}
+
+// GetMealsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int32
+func (_e *ProfileStorage_Expecter) GetMealsByUserID(ctx interface{}, userID interface{}) *ProfileStorage_GetMealsByUserID_Call {
+	return &ProfileStorage_GetMealsByUserID_Call{Call: _e.mock.On("GetMealsByUserID", ctx, userID)}
+}
+
+func (_c *ProfileStorage_GetMealsByUserID_Call) Run(run func(ctx context.Context, userID int32)) *ProfileStorage_GetMealsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetMealsByUserID_Call) Return(meals []*models.Meal, err error) *ProfileStorage_GetMealsByUserID_Call {
+	_c.Call.Return(meals, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetMealsByUserID_Call) RunAndReturn(run func(ctx context.Context, userID int32) ([]*models.Meal, error)) *ProfileStorage_GetMealsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMenuGeneration provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetMenuGeneration(ctx context.Context, requestID string) (*models.MenuGeneration, error) {
+	ret := _mock.Called(ctx, requestID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMenuGeneration")
+	}
+
+	var r0 *models.MenuGeneration
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.MenuGeneration, error)); ok {
+		return returnFunc(ctx, requestID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.MenuGeneration); ok {
+		r0 = returnFunc(ctx, requestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MenuGeneration)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, requestID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetMenuGeneration_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMenuGeneration'
+type ProfileStorage_GetMenuGeneration_Call struct {
+	*mock.Call
+}
+
+// GetMenuGeneration is a helper method to define mock.On call
+//   - ctx context.Context
+//   - requestID string
+func (_e *ProfileStorage_Expecter) GetMenuGeneration(ctx interface{}, requestID interface{}) *ProfileStorage_GetMenuGeneration_Call {
+	return &ProfileStorage_GetMenuGeneration_Call{Call: _e.mock.On("GetMenuGeneration", ctx, requestID)}
+}
+
+func (_c *ProfileStorage_GetMenuGeneration_Call) Run(run func(ctx context.Context, requestID string)) *ProfileStorage_GetMenuGeneration_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetMenuGeneration_Call) Return(menuGeneration *models.MenuGeneration, err error) *ProfileStorage_GetMenuGeneration_Call {
+	_c.Call.Return(menuGeneration, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetMenuGeneration_Call) RunAndReturn(run func(ctx context.Context, requestID string) (*models.MenuGeneration, error)) *ProfileStorage_GetMenuGeneration_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductByID provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetProductByID(ctx context.Context, id int32) (*models.Product, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByID")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (*models.Product, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) *models.Product); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
+type ProfileStorage_GetProductByID_Call struct {
+	*mock.Call
+}
+
+// GetProductByID is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id int32
 func (_e *ProfileStorage_Expecter) GetProductByID(ctx interface{}, id interface{}) *ProfileStorage_GetProductByID_Call {
@@ -584,6 +1595,74 @@ func (_c *ProfileStorage_GetProductByID_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// GetProductsByIDs provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetProductsByIDs(ctx context.Context, ids []int32) ([]*models.Product, error) {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductsByIDs")
+	}
+
+	var r0 []*models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int32) ([]*models.Product, error)); ok {
+		return returnFunc(ctx, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int32) []*models.Product); ok {
+		r0 = returnFunc(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int32) error); ok {
+		r1 = returnFunc(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetProductsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductsByIDs'
+type ProfileStorage_GetProductsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetProductsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int32
+func (_e *ProfileStorage_Expecter) GetProductsByIDs(ctx interface{}, ids interface{}) *ProfileStorage_GetProductsByIDs_Call {
+	return &ProfileStorage_GetProductsByIDs_Call{Call: _e.mock.On("GetProductsByIDs", ctx, ids)}
+}
+
+func (_c *ProfileStorage_GetProductsByIDs_Call) Run(run func(ctx context.Context, ids []int32)) *ProfileStorage_GetProductsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []int32
+		if args[1] != nil {
+			arg1 = args[1].([]int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetProductsByIDs_Call) Return(products []*models.Product, err error) *ProfileStorage_GetProductsByIDs_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetProductsByIDs_Call) RunAndReturn(run func(ctx context.Context, ids []int32) ([]*models.Product, error)) *ProfileStorage_GetProductsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProductsByUserID provides a mock function for the type ProfileStorage
 func (_mock *ProfileStorage) GetProductsByUserID(ctx context.Context, userID int32) ([]*models.Product, error) {
 	ret := _mock.Called(ctx, userID)
@@ -720,6 +1799,200 @@ func (_c *ProfileStorage_GetUserByID_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// GetUserProductsByIDs provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) GetUserProductsByIDs(ctx context.Context, userID int32, ids []int32) ([]*models.Product, error) {
+	ret := _mock.Called(ctx, userID, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserProductsByIDs")
+	}
+
+	var r0 []*models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, []int32) ([]*models.Product, error)); ok {
+		return returnFunc(ctx, userID, ids)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, []int32) []*models.Product); ok {
+		r0 = returnFunc(ctx, userID, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32, []int32) error); ok {
+		r1 = returnFunc(ctx, userID, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProfileStorage_GetUserProductsByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserProductsByIDs'
+type ProfileStorage_GetUserProductsByIDs_Call struct {
+	*mock.Call
+}
+
+// GetUserProductsByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int32
+//   - ids []int32
+func (_e *ProfileStorage_Expecter) GetUserProductsByIDs(ctx interface{}, userID interface{}, ids interface{}) *ProfileStorage_GetUserProductsByIDs_Call {
+	return &ProfileStorage_GetUserProductsByIDs_Call{Call: _e.mock.On("GetUserProductsByIDs", ctx, userID, ids)}
+}
+
+func (_c *ProfileStorage_GetUserProductsByIDs_Call) Run(run func(ctx context.Context, userID int32, ids []int32)) *ProfileStorage_GetUserProductsByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 []int32
+		if args[2] != nil {
+			arg2 = args[2].([]int32)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_GetUserProductsByIDs_Call) Return(products []*models.Product, err error) *ProfileStorage_GetUserProductsByIDs_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *ProfileStorage_GetUserProductsByIDs_Call) RunAndReturn(run func(ctx context.Context, userID int32, ids []int32) ([]*models.Product, error)) *ProfileStorage_GetUserProductsByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreUser provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) RestoreUser(ctx context.Context, id int32, deletedAfter time.Time) error {
+	ret := _mock.Called(ctx, id, deletedAfter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32, time.Time) error); ok {
+		r0 = returnFunc(ctx, id, deletedAfter)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProfileStorage_RestoreUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreUser'
+type ProfileStorage_RestoreUser_Call struct {
+	*mock.Call
+}
+
+// RestoreUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+//   - deletedAfter time.Time
+func (_e *ProfileStorage_Expecter) RestoreUser(ctx interface{}, id interface{}, deletedAfter interface{}) *ProfileStorage_RestoreUser_Call {
+	return &ProfileStorage_RestoreUser_Call{Call: _e.mock.On("RestoreUser", ctx, id, deletedAfter)}
+}
+
+func (_c *ProfileStorage_RestoreUser_Call) Run(run func(ctx context.Context, id int32, deletedAfter time.Time)) *ProfileStorage_RestoreUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_RestoreUser_Call) Return(err error) *ProfileStorage_RestoreUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProfileStorage_RestoreUser_Call) RunAndReturn(run func(ctx context.Context, id int32, deletedAfter time.Time) error) *ProfileStorage_RestoreUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SoftDeleteUser provides a mock function for the type ProfileStorage
+func (_mock *ProfileStorage) SoftDeleteUser(ctx context.Context, id int32) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SoftDeleteUser")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProfileStorage_SoftDeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SoftDeleteUser'
+type ProfileStorage_SoftDeleteUser_Call struct {
+	*mock.Call
+}
+
+// SoftDeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *ProfileStorage_Expecter) SoftDeleteUser(ctx interface{}, id interface{}) *ProfileStorage_SoftDeleteUser_Call {
+	return &ProfileStorage_SoftDeleteUser_Call{Call: _e.mock.On("SoftDeleteUser", ctx, id)}
+}
+
+func (_c *ProfileStorage_SoftDeleteUser_Call) Run(run func(ctx context.Context, id int32)) *ProfileStorage_SoftDeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProfileStorage_SoftDeleteUser_Call) Return(err error) *ProfileStorage_SoftDeleteUser_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProfileStorage_SoftDeleteUser_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *ProfileStorage_SoftDeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateMeal provides a mock function for the type ProfileStorage
 func (_mock *ProfileStorage) UpdateMeal(ctx context.Context, meal *models.Meal) error {
 	ret := _mock.Called(ctx, meal)
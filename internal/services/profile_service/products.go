@@ -3,20 +3,30 @@ package profile_service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/samber/lo"
 )
 
 func (s *ProfileService) CreateProduct(ctx context.Context, product *models.Product) error {
 	_, err := s.profileStorage.GetUserByID(ctx, product.UserID)
 	if err != nil {
-		return errors.New("пользователь не найден")
+		return userLookupError(err, "пользователь не найден")
 	}
 
 	if err := s.validateProduct(product); err != nil {
 		return err
 	}
-	return s.profileStorage.CreateProduct(ctx, product)
+
+	if err := s.profileStorage.CreateProduct(ctx, product); err != nil {
+		return err
+	}
+
+	s.publishProductChange(ctx, models.ChangeEventTypeProductCreated, product)
+	s.auditProduct(ctx, models.AuditActionCreate, nil, product)
+	return nil
 }
 
 func (s *ProfileService) GetProductsByUserID(ctx context.Context, userID int32) ([]*models.Product, error) {
@@ -28,22 +38,76 @@ func (s *ProfileService) GetProductByID(ctx context.Context, id int32) (*models.
 }
 
 func (s *ProfileService) UpdateProduct(ctx context.Context, product *models.Product) error {
-	_, err := s.profileStorage.GetProductByID(ctx, product.ID)
+	existingProduct, err := s.profileStorage.GetProductByID(ctx, product.ID)
 	if err != nil {
 		return errors.New("продукт не найден")
 	}
 
 	_, err = s.profileStorage.GetUserByID(ctx, product.UserID)
 	if err != nil {
-		return errors.New("пользователь не найден")
+		return userLookupError(err, "пользователь не найден")
 	}
 
 	if err := s.validateProduct(product); err != nil {
 		return err
 	}
-	return s.profileStorage.UpdateProduct(ctx, product)
+
+	if err := s.profileStorage.UpdateProduct(ctx, product); err != nil {
+		return err
+	}
+
+	s.publishProductChange(ctx, models.ChangeEventTypeProductUpdated, product)
+	after := *product
+	after.CreatedAt = existingProduct.CreatedAt
+	s.auditProduct(ctx, models.AuditActionUpdate, existingProduct, &after)
+	return nil
 }
 
-func (s *ProfileService) DeleteProduct(ctx context.Context, id int32) error {
-	return s.profileStorage.DeleteProduct(ctx, id)
+// DeleteProduct удаляет продукт. Если продукт входит в блюда, удаление блокируется
+// со списком этих блюд, либо при detachFromMeals продукт убирается из их состава.
+// Блюдо не может остаться без продуктов, поэтому detach отклоняется, если продукт - единственный в блюде.
+func (s *ProfileService) DeleteProduct(ctx context.Context, id int32, detachFromMeals bool) error {
+	product, err := s.profileStorage.GetProductByID(ctx, id)
+	if err != nil {
+		return errors.New("продукт не найден")
+	}
+
+	meals, err := s.profileStorage.GetMealsByProductID(ctx, product.UserID, id)
+	if err != nil {
+		return err
+	}
+
+	if len(meals) > 0 && !detachFromMeals {
+		names := lo.Map(meals, func(meal *models.Meal, _ int) string {
+			return fmt.Sprintf("%q (id %d)", meal.Name, meal.ID)
+		})
+		return fmt.Errorf("продукт используется в блюдах: %s; уберите его из блюд или удалите с detach_from_meals", strings.Join(names, ", "))
+	}
+
+	if emptied := lo.Filter(meals, func(meal *models.Meal, _ int) bool {
+		return len(lo.Without(meal.ProductIDs, id)) == 0
+	}); len(emptied) > 0 {
+		names := lo.Map(emptied, func(meal *models.Meal, _ int) string {
+			return fmt.Sprintf("%q (id %d)", meal.Name, meal.ID)
+		})
+		return fmt.Errorf("продукт - единственный в блюдах: %s; добавьте в них другие продукты или удалите эти блюда", strings.Join(names, ", "))
+	}
+
+	if err := s.profileStorage.DeleteProduct(ctx, id, detachFromMeals); err != nil {
+		return err
+	}
+
+	for _, meal := range meals {
+		before := *meal
+		meal.Products = lo.Reject(meal.Products, func(mealProduct models.MealProduct, _ int) bool {
+			return mealProduct.ProductID == id
+		})
+		meal.ProductIDs = lo.Without(meal.ProductIDs, id)
+		s.publishMealChange(ctx, models.ChangeEventTypeMealUpdated, meal)
+		s.auditMeal(ctx, models.AuditActionUpdate, &before, meal)
+	}
+	s.publishProductChange(ctx, models.ChangeEventTypeProductDeleted, product)
+	s.auditProduct(ctx, models.AuditActionDelete, product, nil)
+
+	return nil
 }
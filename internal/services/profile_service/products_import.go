@@ -0,0 +1,206 @@
+package profile_service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+)
+
+// maxProductImportRows ограничивает размер одного импорта
+const maxProductImportRows = 1000
+
+type productImportRecord struct {
+	Name     string `json:"name"`
+	Calories *int32 `json:"calories"`
+	Protein  *int32 `json:"protein"`
+	Fat      *int32 `json:"fat"`
+	Carbs    *int32 `json:"carbs"`
+}
+
+// ImportProducts валидирует строки файла и сохраняет корректные продукты одной транзакцией.
+// В режиме dryRun продукты только проверяются.
+func (s *ProfileService) ImportProducts(ctx context.Context, userID int32, format models.ProductImportFormat, data []byte, dryRun bool) (*models.ProductImportResult, error) {
+	_, err := s.profileStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, userLookupError(err, "пользователь не найден")
+	}
+
+	var rows []*models.ProductImportRow
+	switch format {
+	case models.ProductImportFormatCSV:
+		rows, err = parseProductsCSV(data)
+	case models.ProductImportFormatJSON:
+		rows, err = parseProductsJSON(data)
+	default:
+		return nil, errors.New("неподдерживаемый формат импорта")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("файл импорта не содержит продуктов")
+	}
+	if len(rows) > maxProductImportRows {
+		return nil, fmt.Errorf("файл импорта не может содержать больше %d продуктов", maxProductImportRows)
+	}
+
+	result := &models.ProductImportResult{
+		Rows:   rows,
+		DryRun: dryRun,
+	}
+
+	products := make([]*models.Product, 0, len(rows))
+	for _, row := range rows {
+		if row.Error == "" {
+			row.Product.UserID = userID
+			if err := s.validateProduct(row.Product); err != nil {
+				row.Error = err.Error()
+			}
+		}
+
+		if row.Error != "" {
+			result.RejectedCount++
+			continue
+		}
+		products = append(products, row.Product)
+	}
+
+	if !dryRun && len(products) > 0 {
+		err = s.profileStorage.CreateProducts(ctx, userID, products)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range products {
+			s.publishProductChange(ctx, models.ChangeEventTypeProductCreated, product)
+			s.auditProduct(ctx, models.AuditActionCreate, nil, product)
+		}
+	}
+	result.ImportedCount = len(products)
+
+	return result, nil
+}
+
+func parseProductsCSV(data []byte) ([]*models.ProductImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("не удалось прочитать заголовок CSV")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, errors.New("в заголовке CSV отсутствует колонка name")
+	}
+
+	var rows []*models.ProductImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("некорректный CSV: %v", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := &models.ProductImportRow{Line: line}
+		rows = append(rows, row)
+
+		product := &models.Product{Name: csvField(record, columns, "name")}
+		numbers := map[string]**int32{
+			"calories": &product.Calories,
+			"protein":  &product.Protein,
+			"fat":      &product.Fat,
+			"carbs":    &product.Carbs,
+		}
+		for _, column := range []string{"calories", "protein", "fat", "carbs"} {
+			value := csvField(record, columns, column)
+			if value == "" {
+				continue
+			}
+			number, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				row.Error = fmt.Sprintf("некорректное значение поля %s", column)
+				break
+			}
+			parsed := int32(number)
+			*numbers[column] = &parsed
+		}
+		row.Product = product
+	}
+
+	return rows, nil
+}
+
+func csvField(record []string, columns map[string]int, name string) string {
+	i, ok := columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func parseProductsJSON(data []byte) ([]*models.ProductImportRow, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil || token != json.Delim('[') {
+		return nil, errors.New("JSON должен содержать массив продуктов")
+	}
+
+	var rows []*models.ProductImportRow
+	for decoder.More() {
+		line := jsonLine(data, decoder.InputOffset())
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("некорректный JSON: %v", err)
+		}
+
+		row := &models.ProductImportRow{Line: line}
+		rows = append(rows, row)
+
+		var record productImportRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			row.Error = "некорректная запись продукта"
+			continue
+		}
+		row.Product = &models.Product{
+			Name:     strings.TrimSpace(record.Name),
+			Calories: record.Calories,
+			Protein:  record.Protein,
+			Fat:      record.Fat,
+			Carbs:    record.Carbs,
+		}
+	}
+
+	return rows, nil
+}
+
+// jsonLine возвращает номер строки, с которой начинается следующий элемент массива
+func jsonLine(data []byte, offset int64) int {
+	for int(offset) < len(data) {
+		c := data[offset]
+		if c != ' ' && c != '\t' && c != '\r' && c != '\n' && c != ',' {
+			break
+		}
+		offset++
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
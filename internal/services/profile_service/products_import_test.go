@@ -0,0 +1,130 @@
+package profile_service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+type ProductImportServiceSuite struct {
+	suite.Suite
+	ctx            context.Context
+	profileStorage *mocks.ProfileStorage
+	profileService *ProfileService
+}
+
+func (s *ProductImportServiceSuite) SetupTest() {
+	s.profileStorage = mocks.NewProfileStorage(s.T())
+	s.ctx = context.Background()
+	mockProducer := &mockMenuGenerationProducer{}
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: mockProducer}, Options{Settings: testSettings})
+}
+
+func (s *ProductImportServiceSuite) TestImportProductsCSVSuccess() {
+	data := []byte("name,calories,protein,fat,carbs\nКуриная грудка,165,31,3,0\nРис,130,,,28\n")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().CreateProducts(s.ctx, int32(1), mock.Anything).
+		Run(func(ctx context.Context, userID int32, products []*models.Product) {
+			assert.Equal(s.T(), len(products), 2)
+			assert.Equal(s.T(), products[0].Name, "Куриная грудка")
+			assert.Equal(s.T(), *products[0].Calories, int32(165))
+			assert.Check(s.T(), products[1].Protein == nil)
+		}).
+		Return(nil)
+
+	got, err := s.profileService.ImportProducts(s.ctx, 1, models.ProductImportFormatCSV, data, false)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), got.ImportedCount, 2)
+	assert.Equal(s.T(), got.RejectedCount, 0)
+	assert.Equal(s.T(), got.Rows[0].Line, 2)
+	assert.Equal(s.T(), got.Rows[1].Line, 3)
+}
+
+func (s *ProductImportServiceSuite) TestImportProductsCSVRejectedRows() {
+	data := []byte("name,calories\nКуриная грудка,165\n,100\nРис,abc\nГречка,-5\n")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().CreateProducts(s.ctx, int32(1), mock.Anything).Return(nil)
+
+	got, err := s.profileService.ImportProducts(s.ctx, 1, models.ProductImportFormatCSV, data, false)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), got.ImportedCount, 1)
+	assert.Equal(s.T(), got.RejectedCount, 3)
+	assert.Equal(s.T(), got.Rows[1].Line, 3)
+	assert.ErrorContains(s.T(), errors.New(got.Rows[1].Error), "название продукта не может быть пустым")
+	assert.Equal(s.T(), got.Rows[2].Line, 4)
+	assert.ErrorContains(s.T(), errors.New(got.Rows[2].Error), "некорректное значение поля calories")
+	assert.Equal(s.T(), got.Rows[3].Line, 5)
+	assert.ErrorContains(s.T(), errors.New(got.Rows[3].Error), "калории не могут быть отрицательными")
+}
+
+func (s *ProductImportServiceSuite) TestImportProductsCSVMissingNameColumn() {
+	data := []byte("calories,protein\n165,31\n")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+
+	_, err := s.profileService.ImportProducts(s.ctx, 1, models.ProductImportFormatCSV, data, false)
+	assert.ErrorContains(s.T(), err, "в заголовке CSV отсутствует колонка name")
+}
+
+func (s *ProductImportServiceSuite) TestImportProductsJSONLineNumbers() {
+	data := []byte("[\n  {\"name\": \"Куриная грудка\", \"calories\": 165},\n  {\"name\": \"Рис\", \"calories\": \"много\"},\n  {\"name\": \"\"}\n]")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().CreateProducts(s.ctx, int32(1), mock.Anything).Return(nil)
+
+	got, err := s.profileService.ImportProducts(s.ctx, 1, models.ProductImportFormatJSON, data, false)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), got.ImportedCount, 1)
+	assert.Equal(s.T(), got.RejectedCount, 2)
+	assert.Equal(s.T(), got.Rows[0].Line, 2)
+	assert.Equal(s.T(), got.Rows[1].Line, 3)
+	assert.Equal(s.T(), got.Rows[1].Error, "некорректная запись продукта")
+	assert.Equal(s.T(), got.Rows[2].Line, 4)
+}
+
+func (s *ProductImportServiceSuite) TestImportProductsJSONNotArray() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+
+	_, err := s.profileService.ImportProducts(s.ctx, 1, models.ProductImportFormatJSON, []byte(`{"name": "Рис"}`), false)
+	assert.ErrorContains(s.T(), err, "JSON должен содержать массив продуктов")
+}
+
+func (s *ProductImportServiceSuite) TestImportProductsDryRun() {
+	data := []byte(`[{"name": "Рис"}, {"name": "Гречка"}]`)
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+
+	got, err := s.profileService.ImportProducts(s.ctx, 1, models.ProductImportFormatJSON, data, true)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), got.DryRun, true)
+	assert.Equal(s.T(), got.ImportedCount, 2)
+}
+
+func (s *ProductImportServiceSuite) TestImportProductsUserNotFound() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(nil, models.ErrNotFound)
+
+	_, err := s.profileService.ImportProducts(s.ctx, 1, models.ProductImportFormatCSV, []byte("name\nРис\n"), false)
+	assert.ErrorContains(s.T(), err, "пользователь не найден")
+}
+
+func (s *ProductImportServiceSuite) TestImportProductsStorageError() {
+	want := errors.New("storage error")
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().CreateProducts(s.ctx, int32(1), mock.Anything).Return(want)
+
+	_, err := s.profileService.ImportProducts(s.ctx, 1, models.ProductImportFormatCSV, []byte("name\nРис\n"), false)
+	assert.ErrorIs(s.T(), err, want)
+}
+
+func TestProductImportServiceSuite(t *testing.T) {
+	suite.Run(t, new(ProductImportServiceSuite))
+}
@@ -3,6 +3,7 @@ package profile_service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
@@ -22,7 +23,7 @@ func (s *ProductServiceSuite) SetupTest() {
 	s.profileStorage = mocks.NewProfileStorage(s.T())
 	s.ctx = context.Background()
 	mockProducer := &mockMenuGenerationProducer{}
-	s.profileService = NewProfileService(s.ctx, s.profileStorage, mockProducer, 3, 50, 6)
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: mockProducer}, Options{Settings: testSettings})
 }
 
 func (s *ProductServiceSuite) TestCreateProductSuccess() {
@@ -38,7 +39,7 @@ func (s *ProductServiceSuite) TestCreateProductSuccess() {
 
 func (s *ProductServiceSuite) TestCreateProductUserNotFound() {
 	product := testProduct(0, 1, "Куриная грудка")
-	want := errors.New("user not found")
+	want := models.ErrNotFound
 
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, product.UserID).Return(nil, want)
 
@@ -152,6 +153,21 @@ func (s *ProductServiceSuite) TestUpdateProductSuccess() {
 	assert.NilError(s.T(), got)
 }
 
+func (s *ProductServiceSuite) TestUpdateProductVersionMismatch() {
+	product := testProduct(1, 1, "Обновленная куриная грудка")
+	product.Version = 2
+	existingProduct := testProduct(1, 1, "Куриная грудка")
+	existingProduct.Version = 3
+	user := testUser(1, "testuser")
+
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, product.ID).Return(existingProduct, nil)
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, product.UserID).Return(user, nil)
+	s.profileStorage.EXPECT().UpdateProduct(s.ctx, product).Return(models.ErrVersionMismatch)
+
+	got := s.profileService.UpdateProduct(s.ctx, product)
+	assert.ErrorIs(s.T(), got, models.ErrVersionMismatch)
+}
+
 func (s *ProductServiceSuite) TestUpdateProductNotFound() {
 	product := testProduct(1, 1, "Обновленная куриная грудка")
 	want := errors.New("product not found")
@@ -166,7 +182,7 @@ func (s *ProductServiceSuite) TestUpdateProductNotFound() {
 func (s *ProductServiceSuite) TestUpdateProductUserNotFound() {
 	product := testProduct(1, 1, "Обновленная куриная грудка")
 	existingProduct := testProduct(1, 1, "Куриная грудка")
-	want := errors.New("user not found")
+	want := models.ErrNotFound
 
 	s.profileStorage.EXPECT().GetProductByID(s.ctx, product.ID).Return(existingProduct, nil)
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, product.UserID).Return(nil, want)
@@ -176,6 +192,17 @@ func (s *ProductServiceSuite) TestUpdateProductUserNotFound() {
 	assert.ErrorContains(s.T(), got, "пользователь не найден")
 }
 
+func (s *ProductServiceSuite) TestCreateProductShardUnavailable() {
+	product := testProduct(0, 1, "Куриная грудка")
+	want := fmt.Errorf("shard 1: %w", models.ErrShardUnavailable)
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, product.UserID).Return(nil, want)
+
+	// Недоступность шарда не превращается в «пользователь не найден»: клиент должен получить UNAVAILABLE
+	got := s.profileService.CreateProduct(s.ctx, product)
+	assert.ErrorIs(s.T(), got, models.ErrShardUnavailable)
+}
+
 func (s *ProductServiceSuite) TestUpdateProductValidationError_EmptyName() {
 	product := testProduct(1, 1, "")
 	existingProduct := testProduct(1, 1, "Куриная грудка")
@@ -205,19 +232,71 @@ func (s *ProductServiceSuite) TestUpdateProductValidationError_NegativeCalories(
 func (s *ProductServiceSuite) TestDeleteProductSuccess() {
 	productID := int32(1)
 
-	s.profileStorage.EXPECT().DeleteProduct(s.ctx, productID).Return(nil)
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, productID).Return(testProduct(productID, 1, "Рис"), nil)
+	s.profileStorage.EXPECT().GetMealsByProductID(s.ctx, int32(1), productID).Return(nil, nil)
+	s.profileStorage.EXPECT().DeleteProduct(s.ctx, productID, false).Return(nil)
+
+	got := s.profileService.DeleteProduct(s.ctx, productID, false)
+	assert.NilError(s.T(), got)
+}
+
+func (s *ProductServiceSuite) TestDeleteProductUsedInMeals() {
+	productID := int32(2)
+	meals := []*models.Meal{
+		testMeal(3, 1, "Плов", []int32{1, 2}),
+		testMeal(4, 1, "Рисовая каша", []int32{2}),
+	}
+
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, productID).Return(testProduct(productID, 1, "Рис"), nil)
+	s.profileStorage.EXPECT().GetMealsByProductID(s.ctx, int32(1), productID).Return(meals, nil)
+
+	got := s.profileService.DeleteProduct(s.ctx, productID, false)
+	assert.ErrorContains(s.T(), got, `продукт используется в блюдах: "Плов" (id 3), "Рисовая каша" (id 4)`)
+}
+
+func (s *ProductServiceSuite) TestDeleteProductDetachFromMeals() {
+	productID := int32(2)
+
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, productID).Return(testProduct(productID, 1, "Рис"), nil)
+	s.profileStorage.EXPECT().GetMealsByProductID(s.ctx, int32(1), productID).Return([]*models.Meal{testMeal(3, 1, "Плов", []int32{1, 2})}, nil)
+	s.profileStorage.EXPECT().DeleteProduct(s.ctx, productID, true).Return(nil)
 
-	got := s.profileService.DeleteProduct(s.ctx, productID)
+	got := s.profileService.DeleteProduct(s.ctx, productID, true)
 	assert.NilError(s.T(), got)
 }
 
+func (s *ProductServiceSuite) TestDeleteProductDetachWouldEmptyMeal() {
+	productID := int32(2)
+	meals := []*models.Meal{
+		testMeal(3, 1, "Плов", []int32{1, 2}),
+		testMeal(4, 1, "Рисовая каша", []int32{2}),
+	}
+
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, productID).Return(testProduct(productID, 1, "Рис"), nil)
+	s.profileStorage.EXPECT().GetMealsByProductID(s.ctx, int32(1), productID).Return(meals, nil)
+
+	got := s.profileService.DeleteProduct(s.ctx, productID, true)
+	assert.ErrorContains(s.T(), got, `продукт - единственный в блюдах: "Рисовая каша" (id 4)`)
+}
+
+func (s *ProductServiceSuite) TestDeleteProductNotFound() {
+	productID := int32(1)
+
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, productID).Return(nil, errors.New("product not found"))
+
+	got := s.profileService.DeleteProduct(s.ctx, productID, false)
+	assert.ErrorContains(s.T(), got, "продукт не найден")
+}
+
 func (s *ProductServiceSuite) TestDeleteProductError() {
 	productID := int32(1)
 	want := errors.New("delete error")
 
-	s.profileStorage.EXPECT().DeleteProduct(s.ctx, productID).Return(want)
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, productID).Return(testProduct(productID, 1, "Рис"), nil)
+	s.profileStorage.EXPECT().GetMealsByProductID(s.ctx, int32(1), productID).Return(nil, nil)
+	s.profileStorage.EXPECT().DeleteProduct(s.ctx, productID, false).Return(want)
 
-	got := s.profileService.DeleteProduct(s.ctx, productID)
+	got := s.profileService.DeleteProduct(s.ctx, productID, false)
 	assert.ErrorIs(s.T(), got, want)
 }
 
@@ -2,12 +2,36 @@ package profile_service
 
 import (
 	"context"
+	"sync/atomic"
+	"time"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
 )
 
 type MenuGenerationProducer interface {
-	PublishMenuGenerationRequest(ctx context.Context, userID int32, bju *models.BJU, budget *int32, preferences string, productNames []string) error
+	PublishMenuGenerationRequest(ctx context.Context, request *models.MenuGenerationRequest) error
+}
+
+// ProfileEventsProducer публикует доменные события об изменениях в Kafka
+type ProfileEventsProducer interface {
+	PublishProfileEvent(ctx context.Context, event *models.ProfileEvent) error
+}
+
+// DeadLetterReplayer повторно отправляет сохранённые в dead-letter сообщения Kafka
+type DeadLetterReplayer interface {
+	ReplayDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error
+}
+
+// AuditLog журнал аудита изменений профиля
+type AuditLog interface {
+	CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	GetAuditEvents(ctx context.Context, userID int32, entityType, entityID string, from, to *time.Time, limit uint64) ([]*models.AuditEvent, error)
+}
+
+// ChangeEventBus лента изменений пользователя для WatchUser
+type ChangeEventBus interface {
+	Publish(event *models.ChangeEvent)
+	Subscribe(userID int32, resumeToken string) (<-chan *models.ChangeEvent, func(), error)
 }
 
 type ProfileStorage interface {
@@ -15,32 +39,104 @@ type ProfileStorage interface {
 	GetUserByID(ctx context.Context, id int32) (*models.User, error)
 	UpdateUser(ctx context.Context, user *models.User) error
 	DeleteUser(ctx context.Context, id int32) error
+	SoftDeleteUser(ctx context.Context, id int32) error
+	RestoreUser(ctx context.Context, id int32, deletedAfter time.Time) error
 	CreateProduct(ctx context.Context, product *models.Product) error
+	CreateProducts(ctx context.Context, userID int32, products []*models.Product) error
 	GetProductsByUserID(ctx context.Context, userID int32) ([]*models.Product, error)
 	GetProductByID(ctx context.Context, id int32) (*models.Product, error)
+	GetProductsByIDs(ctx context.Context, ids []int32) ([]*models.Product, error)
+	GetUserProductsByIDs(ctx context.Context, userID int32, ids []int32) ([]*models.Product, error)
 	UpdateProduct(ctx context.Context, product *models.Product) error
-	DeleteProduct(ctx context.Context, id int32) error
+	DeleteProduct(ctx context.Context, id int32, detachFromMeals bool) error
 	CreateMeal(ctx context.Context, meal *models.Meal) error
+	CreateMeals(ctx context.Context, userID int32, meals []*models.Meal) error
 	GetMealsByUserID(ctx context.Context, userID int32) ([]*models.Meal, error)
 	GetMealByID(ctx context.Context, id int32) (*models.Meal, error)
+	GetMealsByIDs(ctx context.Context, ids []int32) ([]*models.Meal, error)
+	GetMealsByProductID(ctx context.Context, userID, productID int32) ([]*models.Meal, error)
 	UpdateMeal(ctx context.Context, meal *models.Meal) error
 	DeleteMeal(ctx context.Context, id int32) error
+	CreateMenuGeneration(ctx context.Context, generation *models.MenuGeneration) error
+	CompleteMenuGeneration(ctx context.Context, generation *models.MenuGeneration) (bool, error)
+	GetMenuGeneration(ctx context.Context, requestID string) (*models.MenuGeneration, error)
+	GetGeneratedMenus(ctx context.Context, userID int32, limit uint64) ([]*models.MenuGeneration, error)
+	GetDeadLetters(ctx context.Context, topic string, limit uint64) ([]*models.DeadLetter, error)
+	GetDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error)
+	DeleteDeadLetter(ctx context.Context, id string) error
+	CreateIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) (bool, error)
+	GetIdempotencyKey(ctx context.Context, userID int32, method, key string) (*models.IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, userID int32, method, key string) error
+}
+
+// Settings настройки проверки пользователей, которые можно менять на лету через UpdateSettings
+type Settings struct {
+	MinUsernameLen int
+	MaxUsernameLen int
+	MinPasswordLen int
 }
 
 type ProfileService struct {
 	profileStorage         ProfileStorage
 	menuGenerationProducer MenuGenerationProducer
-	minUsernameLen         int
-	maxUsernameLen         int
-	minPasswordLen         int
-}
-
-func NewProfileService(ctx context.Context, profileStorage ProfileStorage, menuGenerationProducer MenuGenerationProducer, minUsernameLen, maxUsernameLen, minPasswordLen int) *ProfileService {
-	return &ProfileService{
-		profileStorage:         profileStorage,
-		menuGenerationProducer: menuGenerationProducer,
-		minUsernameLen:         minUsernameLen,
-		maxUsernameLen:         maxUsernameLen,
-		minPasswordLen:         minPasswordLen,
+	changeEventBus         ChangeEventBus
+	profileEventsProducer  ProfileEventsProducer
+	deadLetterReplayer     DeadLetterReplayer
+	auditLog               AuditLog
+	// settings меняются при перезагрузке конфига без перезапуска
+	settings atomic.Pointer[Settings]
+	// userDeletionGracePeriod включает мягкое удаление пользователей, если больше нуля
+	userDeletionGracePeriod time.Duration
+	// menuGenerationDebouncer схлопывает автоматические запросы генерации меню при частых правках профиля
+	menuGenerationDebouncer *menuGenerationDebouncer
+	// idempotencyKeyTTL сколько хранится первый ответ на создающий запрос с Idempotency-Key
+	idempotencyKeyTTL time.Duration
+}
+
+// Dependencies хранилище и внешние зависимости сервиса; все, кроме Storage, могут быть nil
+type Dependencies struct {
+	Storage                ProfileStorage
+	MenuGenerationProducer MenuGenerationProducer
+	ChangeEventBus         ChangeEventBus
+	ProfileEventsProducer  ProfileEventsProducer
+	DeadLetterReplayer     DeadLetterReplayer
+	AuditLog               AuditLog
+}
+
+// Options настройки сервиса; Settings можно менять и после создания через UpdateSettings
+type Options struct {
+	Settings
+	// UserDeletionGracePeriod включает мягкое удаление пользователей, если больше нуля
+	UserDeletionGracePeriod time.Duration
+	// MenuGenerationDebounceWindow окно, в котором автоматические запросы генерации меню схлопываются в один
+	MenuGenerationDebounceWindow time.Duration
+	// IdempotencyKeyTTL сколько хранится первый ответ на создающий запрос с Idempotency-Key
+	IdempotencyKeyTTL time.Duration
+}
+
+func NewProfileService(ctx context.Context, deps Dependencies, options Options) *ProfileService {
+	s := &ProfileService{
+		profileStorage:          deps.Storage,
+		menuGenerationProducer:  deps.MenuGenerationProducer,
+		changeEventBus:          deps.ChangeEventBus,
+		profileEventsProducer:   deps.ProfileEventsProducer,
+		deadLetterReplayer:      deps.DeadLetterReplayer,
+		auditLog:                deps.AuditLog,
+		userDeletionGracePeriod: options.UserDeletionGracePeriod,
+		menuGenerationDebouncer: newMenuGenerationDebouncer(options.MenuGenerationDebounceWindow),
+		idempotencyKeyTTL:       options.IdempotencyKeyTTL,
 	}
+	s.UpdateSettings(options.Settings)
+	return s
+}
+
+// UpdateSettings атомарно заменяет настройки; запросы, которые уже идут, дорабатывают со старыми
+func (s *ProfileService) UpdateSettings(settings Settings) {
+	s.settings.Store(&settings)
+}
+
+// Settings текущие настройки сервиса
+func (s *ProfileService) Settings() Settings {
+	return *s.settings.Load()
 }
@@ -4,6 +4,9 @@ import (
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
 )
 
+// testSettings ограничения username и пароля, с которыми создаётся сервис в тестах
+var testSettings = Settings{MinUsernameLen: 3, MaxUsernameLen: 50, MinPasswordLen: 6}
+
 func int32Ptr(v int32) *int32 {
 	return &v
 }
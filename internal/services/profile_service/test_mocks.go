@@ -3,18 +3,92 @@ package profile_service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
+	"time"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/producer/kafka_retry_writer"
 )
 
 type mockMenuGenerationProducer struct{}
 
-func (m *mockMenuGenerationProducer) PublishMenuGenerationRequest(ctx context.Context, userID int32, bju *models.BJU, budget *int32, preferences string, productNames []string) error {
+func (m *mockMenuGenerationProducer) PublishMenuGenerationRequest(ctx context.Context, request *models.MenuGenerationRequest) error {
 	return nil
 }
 
 type mockMenuGenerationProducerWithError struct{}
 
-func (m *mockMenuGenerationProducerWithError) PublishMenuGenerationRequest(ctx context.Context, userID int32, bju *models.BJU, budget *int32, preferences string, productNames []string) error {
+func (m *mockMenuGenerationProducerWithError) PublishMenuGenerationRequest(ctx context.Context, request *models.MenuGenerationRequest) error {
 	return errors.New("kafka publish error")
 }
+
+// mockMenuGenerationProducerDeadLettered имитирует публикацию, исчерпавшую попытки и сохранённую в dead-letter
+type mockMenuGenerationProducerDeadLettered struct{}
+
+func (m *mockMenuGenerationProducerDeadLettered) PublishMenuGenerationRequest(ctx context.Context, request *models.MenuGenerationRequest) error {
+	return fmt.Errorf("failed to write message to kafka: %w", kafka_retry_writer.ErrDeadLettered)
+}
+
+type mockProfileEventsProducer struct {
+	events []*models.ProfileEvent
+	err    error
+}
+
+func (m *mockProfileEventsProducer) PublishProfileEvent(ctx context.Context, event *models.ProfileEvent) error {
+	m.events = append(m.events, event)
+	return m.err
+}
+
+// recordingMenuGenerationProducer сохраняет опубликованные запросы и сигнализирует о каждом в published
+type recordingMenuGenerationProducer struct {
+	mu        sync.Mutex
+	requests  []*models.MenuGenerationRequest
+	published chan struct{}
+}
+
+func newRecordingMenuGenerationProducer() *recordingMenuGenerationProducer {
+	return &recordingMenuGenerationProducer{published: make(chan struct{}, 16)}
+}
+
+func (m *recordingMenuGenerationProducer) PublishMenuGenerationRequest(ctx context.Context, request *models.MenuGenerationRequest) error {
+	m.mu.Lock()
+	m.requests = append(m.requests, request)
+	m.mu.Unlock()
+	m.published <- struct{}{}
+	return nil
+}
+
+func (m *recordingMenuGenerationProducer) Requests() []*models.MenuGenerationRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*models.MenuGenerationRequest(nil), m.requests...)
+}
+
+type mockDeadLetterReplayer struct {
+	replayed []*models.DeadLetter
+	err      error
+}
+
+func (m *mockDeadLetterReplayer) ReplayDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.replayed = append(m.replayed, deadLetter)
+	return nil
+}
+
+// mockAuditLog сохраняет записанные события журнала аудита
+type mockAuditLog struct {
+	events []*models.AuditEvent
+	err    error
+}
+
+func (m *mockAuditLog) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	m.events = append(m.events, event)
+	return m.err
+}
+
+func (m *mockAuditLog) GetAuditEvents(ctx context.Context, userID int32, entityType, entityID string, from, to *time.Time, limit uint64) ([]*models.AuditEvent, error) {
+	return m.events, m.err
+}
@@ -0,0 +1,409 @@
+package profile_service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/samber/lo"
+)
+
+// Файлы внутри zip-архива выгрузки
+const (
+	archiveManifestFile = "manifest.json"
+	archiveUserFile     = "user.json"
+	archiveProductsFile = "products.json"
+	archiveMealsFile    = "meals.json"
+	archiveProductsCSV  = "products.csv"
+	archiveMealsCSV     = "meals.csv"
+)
+
+type userDataArchiveManifest struct {
+	Version    int    `json:"version"`
+	ExportedAt string `json:"exported_at"`
+}
+
+// ExportUserData собирает профиль, продукты и блюда пользователя в версионированный архив
+func (s *ProfileService) ExportUserData(ctx context.Context, userID int32, format models.UserDataArchiveFormat) (*models.UserDataExport, error) {
+	user, err := s.profileStorage.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, userLookupError(err, "пользователь не найден")
+	}
+
+	products, err := s.profileStorage.GetProductsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	meals, err := s.profileStorage.GetMealsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err = s.addMealProducts(ctx, userID, products, meals)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := &models.UserDataArchive{
+		Version:    models.UserDataArchiveVersion,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		User:       mapUserToArchive(user),
+		Products:   products,
+		Meals:      meals,
+	}
+	if archive.Products == nil {
+		archive.Products = []*models.Product{}
+	}
+	if archive.Meals == nil {
+		archive.Meals = []*models.Meal{}
+	}
+
+	switch format {
+	case models.UserDataArchiveFormatUnknown, models.UserDataArchiveFormatJSON:
+		data, err := json.MarshalIndent(archive, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &models.UserDataExport{
+			Filename:    fmt.Sprintf("user_%d_export.json", userID),
+			ContentType: "application/json",
+			Data:        data,
+		}, nil
+	case models.UserDataArchiveFormatZIP:
+		data, err := encodeUserDataZIP(archive)
+		if err != nil {
+			return nil, err
+		}
+		return &models.UserDataExport{
+			Filename:    fmt.Sprintf("user_%d_export.zip", userID),
+			ContentType: "application/zip",
+			Data:        data,
+		}, nil
+	default:
+		return nil, errors.New("неподдерживаемый формат архива")
+	}
+}
+
+// addMealProducts дочитывает одним пакетным запросом продукты, на которые ссылаются блюда, но которых нет
+// в products: их могли создать между чтением продуктов и блюд. Без них архив не импортируется.
+func (s *ProfileService) addMealProducts(ctx context.Context, userID int32, products []*models.Product, meals []*models.Meal) ([]*models.Product, error) {
+	exported := lo.SliceToMap(products, func(product *models.Product) (int32, struct{}) {
+		return product.ID, struct{}{}
+	})
+	var missing []int32
+	for _, meal := range meals {
+		for _, product := range mealProducts(meal) {
+			if _, ok := exported[product.ProductID]; !ok {
+				missing = append(missing, product.ProductID)
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return products, nil
+	}
+
+	added, err := s.profileStorage.GetUserProductsByIDs(ctx, userID, lo.Uniq(missing))
+	if err != nil {
+		return nil, err
+	}
+	return append(products, added...), nil
+}
+
+// ImportUserData восстанавливает архив выгрузки в новый аккаунт.
+// Идентификаторы продуктов в блюдах переназначаются на созданные продукты.
+func (s *ProfileService) ImportUserData(ctx context.Context, format models.UserDataArchiveFormat, data []byte, username, passwordHash string) (*models.UserDataImportResult, error) {
+	archive, err := decodeUserDataArchive(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if archive.Version < 1 || archive.Version > models.UserDataArchiveVersion {
+		return nil, fmt.Errorf("неподдерживаемая версия архива %d", archive.Version)
+	}
+	if archive.User == nil {
+		return nil, errors.New("архив не содержит профиль пользователя")
+	}
+
+	user := &models.User{
+		Username:     archive.User.Username,
+		PasswordHash: passwordHash,
+		Height:       archive.User.Height,
+		Weight:       archive.User.Weight,
+		BJU:          archive.User.BJU,
+		Budget:       archive.User.Budget,
+		Preferences:  archive.User.Preferences,
+	}
+	if username != "" {
+		user.Username = username
+	}
+	if err := s.validateUser(user); err != nil {
+		return nil, err
+	}
+
+	products := make([]*models.Product, 0, len(archive.Products))
+	for _, archived := range archive.Products {
+		product := *archived
+		product.ID = 0
+		product.CreatedAt = ""
+		if err := s.validateProduct(&product); err != nil {
+			return nil, fmt.Errorf("продукт %d: %w", archived.ID, err)
+		}
+		products = append(products, &product)
+	}
+
+	err = s.profileStorage.CreateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	meals, err := s.importUserContent(ctx, user.ID, archive, products)
+	if err != nil {
+		// Откатываем созданный аккаунт, чтобы повторный импорт не упирался в занятый username
+		_ = s.profileStorage.DeleteUser(ctx, user.ID)
+		return nil, err
+	}
+
+	s.publishUserChange(ctx, models.ChangeEventTypeUserCreated, user)
+	s.auditUser(ctx, models.AuditActionCreate, user.ID, nil, user)
+	for _, product := range products {
+		s.publishProductChange(ctx, models.ChangeEventTypeProductCreated, product)
+		s.auditProduct(ctx, models.AuditActionCreate, nil, product)
+	}
+	for _, meal := range meals {
+		s.publishMealChange(ctx, models.ChangeEventTypeMealCreated, meal)
+		s.auditMeal(ctx, models.AuditActionCreate, nil, meal)
+	}
+
+	return &models.UserDataImportResult{
+		User:             user,
+		ImportedProducts: len(products),
+		ImportedMeals:    len(meals),
+	}, nil
+}
+
+func (s *ProfileService) importUserContent(ctx context.Context, userID int32, archive *models.UserDataArchive, products []*models.Product) ([]*models.Meal, error) {
+	if len(products) > 0 {
+		err := s.profileStorage.CreateProducts(ctx, userID, products)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	productIDs := make(map[int32]int32, len(products))
+	for i, archived := range archive.Products {
+		productIDs[archived.ID] = products[i].ID
+	}
+
+	meals := make([]*models.Meal, 0, len(archive.Meals))
+	for _, archived := range archive.Meals {
+		archivedProducts := mealProducts(archived)
+		meal := &models.Meal{
+			UserID:   userID,
+			Name:     archived.Name,
+			Products: make([]models.MealProduct, 0, len(archivedProducts)),
+		}
+		for _, product := range archivedProducts {
+			newID, ok := productIDs[product.ProductID]
+			if !ok {
+				return nil, fmt.Errorf("блюдо %d ссылается на продукт %d, которого нет в архиве", archived.ID, product.ProductID)
+			}
+			meal.Products = append(meal.Products, models.MealProduct{ProductID: newID, Quantity: product.Quantity})
+		}
+		if err := s.validateMeal(meal); err != nil {
+			return nil, fmt.Errorf("блюдо %d: %w", archived.ID, err)
+		}
+		meals = append(meals, meal)
+	}
+
+	if len(meals) > 0 {
+		err := s.profileStorage.CreateMeals(ctx, userID, meals)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return meals, nil
+}
+
+func mapUserToArchive(user *models.User) *models.UserDataArchiveUser {
+	return &models.UserDataArchiveUser{
+		ID:          user.ID,
+		Username:    user.Username,
+		Height:      user.Height,
+		Weight:      user.Weight,
+		BJU:         user.BJU,
+		Budget:      user.Budget,
+		Preferences: user.Preferences,
+		CreatedAt:   user.CreatedAt,
+	}
+}
+
+func encodeUserDataZIP(archive *models.UserDataArchive) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+
+	files := []struct {
+		name  string
+		value interface{}
+	}{
+		{archiveManifestFile, userDataArchiveManifest{Version: archive.Version, ExportedAt: archive.ExportedAt}},
+		{archiveUserFile, archive.User},
+		{archiveProductsFile, archive.Products},
+		{archiveMealsFile, archive.Meals},
+	}
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if err := writeZIPFile(writer, file.name, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeZIPFile(writer, archiveProductsCSV, productsToCSV(archive.Products)); err != nil {
+		return nil, err
+	}
+	if err := writeZIPFile(writer, archiveMealsCSV, mealsToCSV(archive.Meals)); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeZIPFile(writer *zip.Writer, name string, data []byte) error {
+	file, err := writer.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	return err
+}
+
+func productsToCSV(products []*models.Product) []byte {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write([]string{"id", "name", "calories", "protein", "fat", "carbs", "created_at"})
+	for _, product := range products {
+		_ = writer.Write([]string{
+			strconv.Itoa(int(product.ID)),
+			product.Name,
+			optionalInt32(product.Calories),
+			optionalInt32(product.Protein),
+			optionalInt32(product.Fat),
+			optionalInt32(product.Carbs),
+			product.CreatedAt,
+		})
+	}
+	writer.Flush()
+	return buf.Bytes()
+}
+
+func mealsToCSV(meals []*models.Meal) []byte {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write([]string{"id", "name", "product_ids", "created_at"})
+	for _, meal := range meals {
+		// Состав в виде "id" или "id:граммы" через ";"
+		productIDs := make([]string, 0, len(meal.Products))
+		for _, product := range mealProducts(meal) {
+			item := strconv.Itoa(int(product.ProductID))
+			if product.Quantity != nil {
+				item += ":" + strconv.Itoa(int(*product.Quantity))
+			}
+			productIDs = append(productIDs, item)
+		}
+		_ = writer.Write([]string{
+			strconv.Itoa(int(meal.ID)),
+			meal.Name,
+			strings.Join(productIDs, ";"),
+			meal.CreatedAt,
+		})
+	}
+	writer.Flush()
+	return buf.Bytes()
+}
+
+func optionalInt32(v *int32) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*v))
+}
+
+func decodeUserDataArchive(format models.UserDataArchiveFormat, data []byte) (*models.UserDataArchive, error) {
+	if format == models.UserDataArchiveFormatUnknown {
+		format = models.UserDataArchiveFormatJSON
+		if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+			format = models.UserDataArchiveFormatZIP
+		}
+	}
+
+	switch format {
+	case models.UserDataArchiveFormatJSON:
+		var archive models.UserDataArchive
+		if err := json.Unmarshal(data, &archive); err != nil {
+			return nil, errors.New("некорректный JSON архива")
+		}
+		return &archive, nil
+	case models.UserDataArchiveFormatZIP:
+		return decodeUserDataZIP(data)
+	default:
+		return nil, errors.New("неподдерживаемый формат архива")
+	}
+}
+
+func decodeUserDataZIP(data []byte) (*models.UserDataArchive, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("некорректный zip-архив")
+	}
+
+	var manifest userDataArchiveManifest
+	archive := &models.UserDataArchive{}
+	files := map[string]interface{}{
+		archiveManifestFile: &manifest,
+		archiveUserFile:     &archive.User,
+		archiveProductsFile: &archive.Products,
+		archiveMealsFile:    &archive.Meals,
+	}
+	for name, target := range files {
+		if err := readZIPJSON(reader, name, target); err != nil {
+			return nil, err
+		}
+	}
+
+	archive.Version = manifest.Version
+	archive.ExportedAt = manifest.ExportedAt
+	return archive, nil
+}
+
+func readZIPJSON(reader *zip.Reader, name string, target interface{}) error {
+	file, err := reader.Open(name)
+	if err != nil {
+		return fmt.Errorf("в архиве отсутствует файл %s", name)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения файла %s: %v", name, err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("некорректный JSON в файле %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package profile_service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+type UserDataServiceSuite struct {
+	suite.Suite
+	ctx            context.Context
+	profileStorage *mocks.ProfileStorage
+	profileService *ProfileService
+}
+
+func (s *UserDataServiceSuite) SetupTest() {
+	s.profileStorage = mocks.NewProfileStorage(s.T())
+	s.ctx = context.Background()
+	mockProducer := &mockMenuGenerationProducer{}
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: mockProducer}, Options{Settings: testSettings})
+}
+
+func (s *UserDataServiceSuite) expectExport(userID int32) {
+	user := testUserWithParams(userID, "testuser", int32Ptr(180), int32Ptr(75), int32Ptr(3000), testBJU(100, 70, 250))
+	user.PasswordHash = "secret-hash"
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, userID).Return(user, nil)
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, userID).Return([]*models.Product{
+		testProductWithParams(10, userID, "Рис", int32Ptr(130), nil, nil, int32Ptr(28)),
+		testProduct(11, userID, "Курица"),
+	}, nil)
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, userID).Return([]*models.Meal{
+		testMeal(20, userID, "Плов", []int32{10, 11}),
+	}, nil)
+}
+
+func (s *UserDataServiceSuite) TestExportUserDataJSON() {
+	s.expectExport(1)
+
+	got, err := s.profileService.ExportUserData(s.ctx, 1, models.UserDataArchiveFormatJSON)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), got.ContentType, "application/json")
+	assert.Equal(s.T(), got.Filename, "user_1_export.json")
+
+	var archive models.UserDataArchive
+	assert.NilError(s.T(), json.Unmarshal(got.Data, &archive))
+	assert.Equal(s.T(), archive.Version, models.UserDataArchiveVersion)
+	assert.Equal(s.T(), archive.User.Username, "testuser")
+	assert.Equal(s.T(), len(archive.Products), 2)
+	assert.Equal(s.T(), len(archive.Meals), 1)
+	assert.Check(s.T(), !strings.Contains(string(got.Data), "secret-hash"))
+}
+
+func (s *UserDataServiceSuite) TestExportUserDataAddsMealProductsCreatedAfterProductsRead() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(testUser(1, "testuser"), nil)
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return([]*models.Product{testProduct(10, 1, "Рис")}, nil)
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, int32(1)).Return([]*models.Meal{
+		testMeal(20, 1, "Плов", []int32{10, 11}),
+		testMeal(21, 1, "Суп", []int32{11, 12}),
+	}, nil)
+	// Продукт 12 у пользователя уже удалён: шард владельца его не возвращает
+	s.profileStorage.EXPECT().GetUserProductsByIDs(s.ctx, int32(1), []int32{11, 12}).Return([]*models.Product{
+		testProduct(11, 1, "Курица"),
+	}, nil)
+
+	got, err := s.profileService.ExportUserData(s.ctx, 1, models.UserDataArchiveFormatJSON)
+	assert.NilError(s.T(), err)
+
+	var archive models.UserDataArchive
+	assert.NilError(s.T(), json.Unmarshal(got.Data, &archive))
+	assert.DeepEqual(s.T(), lo.Map(archive.Products, func(product *models.Product, _ int) int32 {
+		return product.ID
+	}), []int32{10, 11})
+}
+
+func (s *UserDataServiceSuite) TestExportUserDataUserNotFound() {
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(nil, models.ErrNotFound)
+
+	_, err := s.profileService.ExportUserData(s.ctx, 1, models.UserDataArchiveFormatJSON)
+	assert.ErrorContains(s.T(), err, "пользователь не найден")
+}
+
+func (s *UserDataServiceSuite) TestExportImportZIPRoundTrip() {
+	s.expectExport(1)
+
+	export, err := s.profileService.ExportUserData(s.ctx, 1, models.UserDataArchiveFormatZIP)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), export.ContentType, "application/zip")
+
+	s.profileStorage.EXPECT().CreateUser(s.ctx, mock.Anything).
+		Run(func(ctx context.Context, user *models.User) {
+			assert.Equal(s.T(), user.Username, "restored")
+			assert.Equal(s.T(), user.PasswordHash, "new-hash")
+			assert.Equal(s.T(), *user.Budget, int32(3000))
+			user.ID = 2
+		}).
+		Return(nil)
+	s.profileStorage.EXPECT().CreateProducts(s.ctx, int32(2), mock.Anything).
+		Run(func(ctx context.Context, userID int32, products []*models.Product) {
+			assert.Equal(s.T(), len(products), 2)
+			products[0].ID = 100
+			products[1].ID = 101
+		}).
+		Return(nil)
+	s.profileStorage.EXPECT().CreateMeals(s.ctx, int32(2), mock.Anything).
+		Run(func(ctx context.Context, userID int32, meals []*models.Meal) {
+			assert.Equal(s.T(), len(meals), 1)
+			assert.DeepEqual(s.T(), meals[0].ProductIDs, []int32{100, 101})
+		}).
+		Return(nil)
+
+	got, err := s.profileService.ImportUserData(s.ctx, models.UserDataArchiveFormatUnknown, export.Data, "restored", "new-hash")
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), got.User.ID, int32(2))
+	assert.Equal(s.T(), got.ImportedProducts, 2)
+	assert.Equal(s.T(), got.ImportedMeals, 1)
+}
+
+func (s *UserDataServiceSuite) TestImportUserDataUnsupportedVersion() {
+	data := []byte(`{"version": 99, "user": {"username": "testuser"}}`)
+
+	_, err := s.profileService.ImportUserData(s.ctx, models.UserDataArchiveFormatJSON, data, "", "hash")
+	assert.ErrorContains(s.T(), err, "неподдерживаемая версия архива 99")
+}
+
+func (s *UserDataServiceSuite) TestImportUserDataUnknownProductRollsBack() {
+	data := []byte(`{"version": 1, "user": {"username": "testuser"}, "products": [], "meals": [{"id": 1, "name": "Плов", "product_ids": [5]}]}`)
+
+	s.profileStorage.EXPECT().CreateUser(s.ctx, mock.Anything).
+		Run(func(ctx context.Context, user *models.User) { user.ID = 3 }).
+		Return(nil)
+	s.profileStorage.EXPECT().DeleteUser(s.ctx, int32(3)).Return(nil)
+
+	_, err := s.profileService.ImportUserData(s.ctx, models.UserDataArchiveFormatJSON, data, "", "hash")
+	assert.ErrorContains(s.T(), err, "блюдо 1 ссылается на продукт 5, которого нет в архиве")
+}
+
+func (s *UserDataServiceSuite) TestImportUserDataInvalidUsername() {
+	data := []byte(`{"version": 1, "user": {"username": "ab"}}`)
+
+	_, err := s.profileService.ImportUserData(s.ctx, models.UserDataArchiveFormatJSON, data, "", "hash")
+	assert.ErrorContains(s.T(), err, "username должен быть от 3 до 50 символов")
+}
+
+func TestUserDataServiceSuite(t *testing.T) {
+	suite.Run(t, new(UserDataServiceSuite))
+}
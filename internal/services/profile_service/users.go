@@ -3,6 +3,7 @@ package profile_service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
 )
@@ -17,23 +18,10 @@ func (s *ProfileService) CreateUser(ctx context.Context, user *models.User) erro
 		return err
 	}
 
-	if s.shouldPublishMenuGenerationEvent(user) {
-		products, err := s.profileStorage.GetProductsByUserID(ctx, user.ID)
-		if err != nil {
-			// Логируем ошибку, но продолжаем публикацию без продуктов
-		}
-		productNames := make([]string, 0)
-		if products != nil {
-			productNames = make([]string, 0, len(products))
-			for _, product := range products {
-				productNames = append(productNames, product.Name)
-			}
-		}
-
-		if err := s.menuGenerationProducer.PublishMenuGenerationRequest(ctx, user.ID, user.BJU, user.Budget, user.Preferences, productNames); err != nil {
-			// Логируем ошибку, но не возвращаем её, т.к. пользователь уже создан
-		}
-	}
+	s.publishUserChange(ctx, models.ChangeEventTypeUserCreated, user)
+	s.auditUser(ctx, models.AuditActionCreate, user.ID, nil, user)
+
+	s.scheduleMenuGeneration(ctx, user)
 
 	return nil
 }
@@ -43,9 +31,9 @@ func (s *ProfileService) GetUserByID(ctx context.Context, id int32) (*models.Use
 }
 
 func (s *ProfileService) UpdateUser(ctx context.Context, user *models.User) error {
-	_, err := s.profileStorage.GetUserByID(ctx, user.ID)
+	existingUser, err := s.profileStorage.GetUserByID(ctx, user.ID)
 	if err != nil {
-		return errors.New("пользователь не найден")
+		return userLookupError(err, "пользователь не найден")
 	}
 
 	if err := s.validateUser(user); err != nil {
@@ -57,23 +45,10 @@ func (s *ProfileService) UpdateUser(ctx context.Context, user *models.User) erro
 		return err
 	}
 
-	if s.shouldPublishMenuGenerationEvent(user) {
-		products, err := s.profileStorage.GetProductsByUserID(ctx, user.ID)
-		if err != nil {
-			// Логируем ошибку, но продолжаем публикацию без продуктов
-		}
-		productNames := make([]string, 0)
-		if products != nil {
-			productNames = make([]string, 0, len(products))
-			for _, product := range products {
-				productNames = append(productNames, product.Name)
-			}
-		}
-
-		if err := s.menuGenerationProducer.PublishMenuGenerationRequest(ctx, user.ID, user.BJU, user.Budget, user.Preferences, productNames); err != nil {
-			// Логируем ошибку, но не возвращаем её, т.к. пользователь уже обновлен
-		}
-	}
+	s.publishUserChange(ctx, models.ChangeEventTypeUserUpdated, user)
+	s.auditUser(ctx, models.AuditActionUpdate, user.ID, existingUser, userAfterUpdate(existingUser, user))
+
+	s.scheduleMenuGeneration(ctx, user)
 
 	return nil
 }
@@ -82,6 +57,52 @@ func (s *ProfileService) shouldPublishMenuGenerationEvent(user *models.User) boo
 	return user.BJU != nil || user.Budget != nil || user.Preferences != ""
 }
 
+// DeleteUser удаляет пользователя с продуктами и блюдами.
+// При включённом сроке восстановления удаление мягкое, строки удаляет фоновая очистка.
 func (s *ProfileService) DeleteUser(ctx context.Context, id int32) error {
-	return s.profileStorage.DeleteUser(ctx, id)
+	var err error
+	if s.userDeletionGracePeriod > 0 {
+		err = s.profileStorage.SoftDeleteUser(ctx, id)
+	} else {
+		err = s.profileStorage.DeleteUser(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.publishChange(ctx, &models.ChangeEvent{Type: models.ChangeEventTypeUserDeleted, UserID: id})
+	s.auditUser(ctx, models.AuditActionDelete, id, nil, nil)
+	return nil
+}
+
+func (s *ProfileService) RestoreUser(ctx context.Context, id int32) (*models.User, error) {
+	if s.userDeletionGracePeriod <= 0 {
+		return nil, errors.New("восстановление пользователей отключено")
+	}
+
+	// deleted_at хранится в UTC без часового пояса, поэтому и границу считаем в UTC
+	err := s.profileStorage.RestoreUser(ctx, id, time.Now().UTC().Add(-s.userDeletionGracePeriod))
+	if err != nil {
+		return nil, userLookupError(err, "пользователь не найден или срок восстановления истёк")
+	}
+	s.auditUser(ctx, models.AuditActionRestore, id, nil, nil)
+
+	return s.profileStorage.GetUserByID(ctx, id)
+}
+
+// userLookupError переводит отсутствие пользователя в ошибку message для клиента. Остальные ошибки
+// хранилища, например недоступность шарда, возвращаются как есть, чтобы клиент получил UNAVAILABLE.
+func userLookupError(err error, message string) error {
+	if errors.Is(err, models.ErrNotFound) {
+		return errors.New(message)
+	}
+	return err
+}
+
+// userAfterUpdate состояние пользователя после UpdateUser: пароль и дата создания не меняются
+func userAfterUpdate(existing, update *models.User) *models.User {
+	after := *update
+	after.PasswordHash = existing.PasswordHash
+	after.CreatedAt = existing.CreatedAt
+	return &after
 }
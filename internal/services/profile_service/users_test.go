@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
@@ -23,7 +24,7 @@ func (s *UserServiceSuite) SetupTest() {
 	s.profileStorage = mocks.NewProfileStorage(s.T())
 	s.ctx = context.Background()
 	mockProducer := &mockMenuGenerationProducer{}
-	s.profileService = NewProfileService(s.ctx, s.profileStorage, mockProducer, 3, 50, 6)
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: mockProducer}, Options{Settings: testSettings})
 }
 
 func (s *UserServiceSuite) TestCreateUserSuccess() {
@@ -31,6 +32,8 @@ func (s *UserServiceSuite) TestCreateUserSuccess() {
 
 	s.profileStorage.EXPECT().CreateUser(s.ctx, user).Return(nil)
 	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, mock.Anything).Return([]*models.Product{}, nil)
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, mock.Anything).Return(nil, nil)
+	s.profileStorage.EXPECT().CreateMenuGeneration(s.ctx, mock.Anything).Return(nil)
 
 	got := s.profileService.CreateUser(s.ctx, user)
 	assert.NilError(s.T(), got)
@@ -156,9 +159,14 @@ func (s *UserServiceSuite) TestCreateUserKafkaPublishError() {
 
 	s.profileStorage.EXPECT().CreateUser(s.ctx, user).Return(nil)
 	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, user.ID).Return([]*models.Product{}, nil)
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, user.ID).Return(nil, nil)
+	s.profileStorage.EXPECT().CreateMenuGeneration(s.ctx, mock.Anything).Return(nil)
+	s.profileStorage.EXPECT().CompleteMenuGeneration(s.ctx, mock.MatchedBy(func(generation *models.MenuGeneration) bool {
+		return generation.Status == models.MenuGenerationStatusFailed
+	})).Return(true, nil)
 
 	mockProducer := &mockMenuGenerationProducerWithError{}
-	s.profileService = NewProfileService(s.ctx, s.profileStorage, mockProducer, 3, 50, 6)
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: mockProducer}, Options{Settings: testSettings})
 
 	got := s.profileService.CreateUser(s.ctx, user)
 	assert.NilError(s.T(), got)
@@ -178,7 +186,7 @@ func (s *UserServiceSuite) TestGetUserByIDSuccess() {
 
 func (s *UserServiceSuite) TestGetUserByIDError() {
 	userID := int32(1)
-	want := errors.New("user not found")
+	want := models.ErrNotFound
 
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, userID).Return(nil, want)
 
@@ -199,9 +207,22 @@ func (s *UserServiceSuite) TestUpdateUserSuccess() {
 	assert.NilError(s.T(), got)
 }
 
+func (s *UserServiceSuite) TestUpdateUserVersionMismatch() {
+	user := testUser(1, "updateduser")
+	user.Version = 4
+	existingUser := testUser(1, "olduser")
+	existingUser.Version = 5
+
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, user.ID).Return(existingUser, nil)
+	s.profileStorage.EXPECT().UpdateUser(s.ctx, user).Return(models.ErrVersionMismatch)
+
+	got := s.profileService.UpdateUser(s.ctx, user)
+	assert.ErrorIs(s.T(), got, models.ErrVersionMismatch)
+}
+
 func (s *UserServiceSuite) TestUpdateUserNotFound() {
 	user := testUser(1, "updateduser")
-	want := errors.New("user not found")
+	want := models.ErrNotFound
 
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, user.ID).Return(nil, want)
 
@@ -262,6 +283,48 @@ func (s *UserServiceSuite) TestDeleteUserError() {
 	assert.ErrorIs(s.T(), got, want)
 }
 
+func (s *UserServiceSuite) TestDeleteUserSoftWithGracePeriod() {
+	userID := int32(1)
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}}, Options{Settings: testSettings, UserDeletionGracePeriod: 24 * time.Hour})
+
+	s.profileStorage.EXPECT().SoftDeleteUser(s.ctx, userID).Return(nil)
+
+	got := s.profileService.DeleteUser(s.ctx, userID)
+	assert.NilError(s.T(), got)
+}
+
+func (s *UserServiceSuite) TestRestoreUserSuccess() {
+	userID := int32(1)
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}}, Options{Settings: testSettings, UserDeletionGracePeriod: 24 * time.Hour})
+
+	s.profileStorage.EXPECT().RestoreUser(s.ctx, userID, mock.Anything).
+		Run(func(ctx context.Context, id int32, deletedAfter time.Time) {
+			assert.Check(s.T(), time.Since(deletedAfter) >= 24*time.Hour)
+			assert.Equal(s.T(), deletedAfter.Location(), time.UTC)
+		}).
+		Return(nil)
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, userID).Return(testUser(userID, "testuser"), nil)
+
+	got, err := s.profileService.RestoreUser(s.ctx, userID)
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), got.ID, userID)
+}
+
+func (s *UserServiceSuite) TestRestoreUserExpired() {
+	userID := int32(1)
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: &mockMenuGenerationProducer{}}, Options{Settings: testSettings, UserDeletionGracePeriod: 24 * time.Hour})
+
+	s.profileStorage.EXPECT().RestoreUser(s.ctx, userID, mock.Anything).Return(models.ErrNotFound)
+
+	_, err := s.profileService.RestoreUser(s.ctx, userID)
+	assert.ErrorContains(s.T(), err, "пользователь не найден или срок восстановления истёк")
+}
+
+func (s *UserServiceSuite) TestRestoreUserDisabled() {
+	_, err := s.profileService.RestoreUser(s.ctx, 1)
+	assert.ErrorContains(s.T(), err, "восстановление пользователей отключено")
+}
+
 func (s *UserServiceSuite) TestUpdateUserWithoutBJUAndBudget() {
 	user := testUser(1, "updateduser")
 	existingUser := testUser(1, "olduser")
@@ -280,9 +343,14 @@ func (s *UserServiceSuite) TestUpdateUserKafkaPublishError() {
 	s.profileStorage.EXPECT().GetUserByID(s.ctx, user.ID).Return(existingUser, nil)
 	s.profileStorage.EXPECT().UpdateUser(s.ctx, user).Return(nil)
 	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, user.ID).Return([]*models.Product{}, nil)
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, user.ID).Return(nil, nil)
+	s.profileStorage.EXPECT().CreateMenuGeneration(s.ctx, mock.Anything).Return(nil)
+	s.profileStorage.EXPECT().CompleteMenuGeneration(s.ctx, mock.MatchedBy(func(generation *models.MenuGeneration) bool {
+		return generation.Status == models.MenuGenerationStatusFailed
+	})).Return(true, nil)
 
 	mockProducer := &mockMenuGenerationProducerWithError{}
-	s.profileService = NewProfileService(s.ctx, s.profileStorage, mockProducer, 3, 50, 6)
+	s.profileService = NewProfileService(s.ctx, Dependencies{Storage: s.profileStorage, MenuGenerationProducer: mockProducer}, Options{Settings: testSettings})
 
 	got := s.profileService.UpdateUser(s.ctx, user)
 	assert.NilError(s.T(), got)
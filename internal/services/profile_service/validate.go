@@ -3,13 +3,15 @@ package profile_service
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
 )
 
 func (s *ProfileService) validateUser(user *models.User) error {
-	if len(user.Username) < s.minUsernameLen || len(user.Username) > s.maxUsernameLen {
-		return fmt.Errorf("username должен быть от %d до %d символов", s.minUsernameLen, s.maxUsernameLen)
+	settings := s.Settings()
+	if len(user.Username) < settings.MinUsernameLen || len(user.Username) > settings.MaxUsernameLen {
+		return fmt.Errorf("username должен быть от %d до %d символов", settings.MinUsernameLen, settings.MaxUsernameLen)
 	}
 
 	if user.Height != nil && *user.Height <= 0 {
@@ -68,9 +70,74 @@ func (s *ProfileService) validateMeal(meal *models.Meal) error {
 		return errors.New("название блюда не может быть пустым")
 	}
 
-	if len(meal.ProductIDs) == 0 {
+	meal.Products = mealProducts(meal)
+	if len(meal.Products) == 0 {
 		return errors.New("блюдо должно содержать хотя бы один продукт")
 	}
 
+	seen := make(map[int32]struct{}, len(meal.Products))
+	meal.ProductIDs = make([]int32, 0, len(meal.Products))
+	for _, product := range meal.Products {
+		if _, ok := seen[product.ProductID]; ok {
+			return fmt.Errorf("продукт с id %d указан в блюде несколько раз", product.ProductID)
+		}
+		seen[product.ProductID] = struct{}{}
+
+		if product.Quantity != nil && *product.Quantity <= 0 {
+			return fmt.Errorf("количество продукта с id %d должно быть положительным", product.ProductID)
+		}
+
+		meal.ProductIDs = append(meal.ProductIDs, product.ProductID)
+	}
+
+	return nil
+}
+
+// mealProducts возвращает состав блюда; если задан только список ProductIDs, количество не указывается
+func mealProducts(meal *models.Meal) []models.MealProduct {
+	if len(meal.Products) > 0 {
+		return meal.Products
+	}
+
+	products := make([]models.MealProduct, 0, len(meal.ProductIDs))
+	for _, productID := range meal.ProductIDs {
+		products = append(products, models.MealProduct{ProductID: productID})
+	}
+	return products
+}
+
+func validateMenuGenerationOptions(options *models.MenuGenerationOptions) error {
+	if options == nil {
+		return nil
+	}
+
+	if (options.DateFrom == "") != (options.DateTo == "") {
+		return errors.New("период меню задаётся обеими датами date_from и date_to")
+	}
+	if options.DateFrom != "" {
+		dateFrom, err := time.Parse(time.DateOnly, options.DateFrom)
+		if err != nil {
+			return errors.New("date_from должна быть в формате YYYY-MM-DD")
+		}
+		dateTo, err := time.Parse(time.DateOnly, options.DateTo)
+		if err != nil {
+			return errors.New("date_to должна быть в формате YYYY-MM-DD")
+		}
+		if dateTo.Before(dateFrom) {
+			return errors.New("date_to не может быть раньше date_from")
+		}
+		if days := int(dateTo.Sub(dateFrom).Hours()/24) + 1; days > maxMenuGenerationDays {
+			return fmt.Errorf("период меню не может превышать %d дней", maxMenuGenerationDays)
+		}
+	}
+
+	if options.MealsPerDay < 0 || options.MealsPerDay > maxMealsPerDay {
+		return fmt.Errorf("количество приёмов пищи должно быть от 1 до %d", maxMealsPerDay)
+	}
+
+	if options.Budget != nil && *options.Budget <= 0 {
+		return errors.New("бюджет должен быть положительным")
+	}
+
 	return nil
 }
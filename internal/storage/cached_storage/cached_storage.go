@@ -0,0 +1,147 @@
+package cached_storage
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service"
+	"github.com/samber/lo"
+)
+
+// Счётчики по видам записей (product, meal, products, meals), доступны в /debug/vars
+var (
+	cacheHits   = expvar.NewMap("storage_cache_hits")
+	cacheMisses = expvar.NewMap("storage_cache_misses")
+	cacheErrors = expvar.NewMap("storage_cache_errors")
+)
+
+// Cache хранит сериализованные записи с ограниченным сроком жизни
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// CachedStorage кеширует чтение продуктов и блюд поверх ProfileStorage. Пользователи не кешируются:
+// в записи есть хеш пароля, которому не место во внешнем кеше. Каждое изменение через CachedStorage
+// сбрасывает затронутые записи; после изменений в обход него нужно вызвать InvalidateUsers.
+type CachedStorage struct {
+	profile_service.ProfileStorage
+	cache Cache
+	ttl   time.Duration
+}
+
+func NewCachedStorage(storage profile_service.ProfileStorage, cache Cache, ttl time.Duration) *CachedStorage {
+	return &CachedStorage{
+		ProfileStorage: storage,
+		cache:          cache,
+		ttl:            ttl,
+	}
+}
+
+func userProductsKey(userID int32) string {
+	return fmt.Sprintf("profile_cache:user:%d:products", userID)
+}
+
+func userMealsKey(userID int32) string {
+	return fmt.Sprintf("profile_cache:user:%d:meals", userID)
+}
+
+func productKey(id int32) string {
+	return fmt.Sprintf("profile_cache:product:%d", id)
+}
+
+func mealKey(id int32) string {
+	return fmt.Sprintf("profile_cache:meal:%d", id)
+}
+
+// readThrough возвращает запись из кеша, а при промахе читает её через load и кладёт в кеш.
+// Ошибки кеша не возвращаются: запрос обслуживается хранилищем.
+// Ошибки load не кешируются, поэтому отсутствующая запись каждый раз читается из хранилища.
+func readThrough[T any](ctx context.Context, s *CachedStorage, kind, key string, load func() (T, error)) (T, error) {
+	if value, ok := cacheGet[T](ctx, s, kind, key); ok {
+		return value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	cacheSet(ctx, s, kind, key, value)
+	return value, nil
+}
+
+// readManyThrough пакетный readThrough: записи из ids, которых нет в кеше, читаются одним вызовом load
+// и кладутся в кеш по одной. Результат, как у хранилища, упорядочен по id без повторов.
+func readManyThrough[T any](ctx context.Context, s *CachedStorage, kind string, ids []int32, key func(id int32) string, id func(T) int32, load func(ids []int32) ([]T, error)) ([]T, error) {
+	var values []T
+	var missing []int32
+	for _, valueID := range lo.Uniq(ids) {
+		if value, ok := cacheGet[T](ctx, s, kind, key(valueID)); ok {
+			values = append(values, value)
+		} else {
+			missing = append(missing, valueID)
+		}
+	}
+
+	if len(missing) > 0 {
+		loaded, err := load(missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, value := range loaded {
+			cacheSet(ctx, s, kind, key(id(value)), value)
+		}
+		values = append(values, loaded...)
+	}
+
+	slices.SortFunc(values, func(a, b T) int {
+		return cmp.Compare(id(a), id(b))
+	})
+	return values, nil
+}
+
+func cacheGet[T any](ctx context.Context, s *CachedStorage, kind, key string) (T, bool) {
+	var value T
+	data, ok, err := s.cache.Get(ctx, key)
+	if err != nil {
+		cacheErrors.Add(kind, 1)
+		slog.Warn("storage cache get failed", "key", key, "error", err)
+	}
+	if ok {
+		if err := json.Unmarshal(data, &value); err == nil {
+			cacheHits.Add(kind, 1)
+			return value, true
+		}
+		slog.Warn("storage cache entry is corrupted", "key", key)
+	}
+	cacheMisses.Add(kind, 1)
+	return value, false
+}
+
+func cacheSet[T any](ctx context.Context, s *CachedStorage, kind, key string, value T) {
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = s.cache.Set(ctx, key, data, s.ttl)
+	}
+	if err != nil {
+		cacheErrors.Add(kind, 1)
+		slog.Warn("storage cache set failed", "key", key, "error", err)
+	}
+}
+
+// invalidate сбрасывает записи после изменения. Ошибка только логируется: изменение уже сохранено,
+// а устаревшая запись истечёт через ttl.
+func (s *CachedStorage) invalidate(ctx context.Context, keys ...string) {
+	if err := s.cache.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+		cacheErrors.Add("invalidate", 1)
+		slog.Error("storage cache invalidation failed", "keys", keys, "error", err)
+	}
+}
@@ -0,0 +1,171 @@
+package cached_storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/cache/lru_cache"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/services/profile_service/mocks"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+	"gotest.tools/v3/assert"
+)
+
+type CachedStorageSuite struct {
+	suite.Suite
+	ctx            context.Context
+	profileStorage *mocks.ProfileStorage
+	cachedStorage  *CachedStorage
+}
+
+func (s *CachedStorageSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.profileStorage = mocks.NewProfileStorage(s.T())
+	s.cachedStorage = NewCachedStorage(s.profileStorage, lru_cache.NewLRUCache(100), time.Minute)
+}
+
+func (s *CachedStorageSuite) TestGetUserByIDIsNotCached() {
+	// В записи пользователя есть хеш пароля, поэтому она всегда читается из хранилища
+	user := &models.User{ID: 1, Username: "testuser", PasswordHash: "$2a$10$hash"}
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(user, nil).Twice()
+
+	for range 2 {
+		got, err := s.cachedStorage.GetUserByID(s.ctx, 1)
+		assert.NilError(s.T(), err)
+		assert.Equal(s.T(), got.PasswordHash, user.PasswordHash)
+	}
+}
+
+func (s *CachedStorageSuite) TestCreateProductInvalidatesUserProducts() {
+	product := &models.Product{ID: 5, UserID: 1, Name: "Овсянка"}
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return(nil, nil).Once()
+	s.profileStorage.EXPECT().CreateProduct(s.ctx, product).Return(nil)
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return([]*models.Product{product}, nil).Once()
+
+	products, _ := s.cachedStorage.GetProductsByUserID(s.ctx, 1)
+	assert.Equal(s.T(), len(products), 0)
+	assert.NilError(s.T(), s.cachedStorage.CreateProduct(s.ctx, product))
+	products, _ = s.cachedStorage.GetProductsByUserID(s.ctx, 1)
+	assert.Equal(s.T(), len(products), 1)
+}
+
+func (s *CachedStorageSuite) TestUpdateProductInvalidatesOwnerProducts() {
+	stored := &models.Product{ID: 5, UserID: 1, Name: "Овсянка", Version: 1}
+	update := &models.Product{ID: 5, UserID: 2, Name: "Гречка"}
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(5)).Return(stored, nil).Once()
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return([]*models.Product{stored}, nil).Twice()
+	s.profileStorage.EXPECT().UpdateProduct(s.ctx, update).Return(nil)
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(5)).Return(&models.Product{ID: 5, UserID: 1, Name: "Гречка", Version: 2}, nil).Once()
+
+	_, _ = s.cachedStorage.GetProductsByUserID(s.ctx, 1)
+	assert.NilError(s.T(), s.cachedStorage.UpdateProduct(s.ctx, update))
+	_, _ = s.cachedStorage.GetProductsByUserID(s.ctx, 1)
+
+	got, _ := s.cachedStorage.GetProductByID(s.ctx, 5)
+	assert.Equal(s.T(), got.Name, "Гречка")
+}
+
+func (s *CachedStorageSuite) TestGetProductsByIDsLoadsOnlyMisses() {
+	cached := &models.Product{ID: 3, UserID: 1, Name: "Овсянка"}
+	loaded := &models.Product{ID: 1, UserID: 1, Name: "Гречка"}
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(3)).Return(cached, nil).Once()
+	// Продукта 9 нет ни на одном шарде: в ответе его нет
+	s.profileStorage.EXPECT().GetProductsByIDs(s.ctx, []int32{1, 9}).Return([]*models.Product{loaded}, nil).Once()
+
+	_, _ = s.cachedStorage.GetProductByID(s.ctx, 3)
+	products, err := s.cachedStorage.GetProductsByIDs(s.ctx, []int32{3, 1, 3, 9})
+	assert.NilError(s.T(), err)
+	assert.DeepEqual(s.T(), lo.Map(products, func(p *models.Product, _ int) int32 { return p.ID }), []int32{1, 3})
+
+	// Повторный запрос целиком обслуживается кешем, кроме ненайденного id
+	s.profileStorage.EXPECT().GetProductsByIDs(s.ctx, []int32{9}).Return(nil, nil).Once()
+	products, err = s.cachedStorage.GetProductsByIDs(s.ctx, []int32{1, 3, 9})
+	assert.NilError(s.T(), err)
+	assert.Equal(s.T(), len(products), 2)
+}
+
+func (s *CachedStorageSuite) TestUpdateMealVersionMismatchInvalidates() {
+	meal := &models.Meal{ID: 7, UserID: 1, Name: "Завтрак", Version: 1}
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, int32(7)).Return(meal, nil).Twice()
+	s.profileStorage.EXPECT().UpdateMeal(s.ctx, meal).Return(models.ErrVersionMismatch)
+
+	_, _ = s.cachedStorage.GetMealByID(s.ctx, 7)
+	err := s.cachedStorage.UpdateMeal(s.ctx, meal)
+	assert.ErrorIs(s.T(), err, models.ErrVersionMismatch)
+	_, _ = s.cachedStorage.GetMealByID(s.ctx, 7)
+}
+
+func (s *CachedStorageSuite) TestDeleteProductDetachInvalidatesMeals() {
+	product := &models.Product{ID: 5, UserID: 1, Name: "Овсянка"}
+	meal := &models.Meal{ID: 7, UserID: 1, Name: "Завтрак", ProductIDs: []int32{5}}
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, int32(7)).Return(meal, nil).Once()
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(5)).Return(product, nil).Once()
+	s.profileStorage.EXPECT().GetMealsByProductID(s.ctx, int32(1), int32(5)).Return([]*models.Meal{meal}, nil)
+	s.profileStorage.EXPECT().DeleteProduct(s.ctx, int32(5), true).Return(nil)
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, int32(7)).Return(&models.Meal{ID: 7, UserID: 1, Name: "Завтрак"}, nil).Once()
+
+	_, _ = s.cachedStorage.GetMealByID(s.ctx, 7)
+	assert.NilError(s.T(), s.cachedStorage.DeleteProduct(s.ctx, 5, true))
+
+	got, _ := s.cachedStorage.GetMealByID(s.ctx, 7)
+	assert.Equal(s.T(), len(got.ProductIDs), 0)
+}
+
+func (s *CachedStorageSuite) TestDeleteUserInvalidatesContent() {
+	product := &models.Product{ID: 5, UserID: 1, Name: "Овсянка"}
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(&models.User{ID: 1}, nil).Once()
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(5)).Return(product, nil).Once()
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return([]*models.Product{product}, nil).Once()
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, int32(1)).Return(nil, nil).Once()
+	s.profileStorage.EXPECT().DeleteUser(s.ctx, int32(1)).Return(nil)
+	s.profileStorage.EXPECT().GetUserByID(s.ctx, int32(1)).Return(nil, errors.New("user not found")).Once()
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(5)).Return(nil, errors.New("product not found")).Once()
+
+	_, _ = s.cachedStorage.GetUserByID(s.ctx, 1)
+	_, _ = s.cachedStorage.GetProductByID(s.ctx, 5)
+	assert.NilError(s.T(), s.cachedStorage.DeleteUser(s.ctx, 1))
+
+	_, err := s.cachedStorage.GetUserByID(s.ctx, 1)
+	assert.ErrorContains(s.T(), err, "user not found")
+	_, err = s.cachedStorage.GetProductByID(s.ctx, 5)
+	assert.ErrorContains(s.T(), err, "product not found")
+}
+
+func (s *CachedStorageSuite) TestSoftDeleteUserErrorInvalidatesContent() {
+	product := &models.Product{ID: 5, UserID: 1, Name: "Овсянка"}
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(5)).Return(product, nil).Twice()
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return([]*models.Product{product}, nil).Once()
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, int32(1)).Return(nil, nil).Once()
+	s.profileStorage.EXPECT().SoftDeleteUser(s.ctx, int32(1)).Return(errors.New("shard unavailable"))
+
+	_, _ = s.cachedStorage.GetProductByID(s.ctx, 5)
+	assert.ErrorContains(s.T(), s.cachedStorage.SoftDeleteUser(s.ctx, 1), "shard unavailable")
+	_, _ = s.cachedStorage.GetProductByID(s.ctx, 5)
+}
+
+func (s *CachedStorageSuite) TestInvalidateUsersDropsContent() {
+	product := &models.Product{ID: 5, UserID: 1, Name: "Овсянка"}
+	meal := &models.Meal{ID: 7, UserID: 1, Name: "Завтрак", ProductIDs: []int32{5}}
+	s.profileStorage.EXPECT().GetProductByID(s.ctx, int32(5)).Return(product, nil).Twice()
+	s.profileStorage.EXPECT().GetMealByID(s.ctx, int32(7)).Return(meal, nil).Twice()
+	s.profileStorage.EXPECT().GetProductsByUserID(s.ctx, int32(1)).Return([]*models.Product{product}, nil).Once()
+	s.profileStorage.EXPECT().GetMealsByUserID(s.ctx, int32(1)).Return([]*models.Meal{meal}, nil).Once()
+
+	_, _ = s.cachedStorage.GetProductByID(s.ctx, 5)
+	_, _ = s.cachedStorage.GetMealByID(s.ctx, 7)
+	s.cachedStorage.InvalidateUsers(s.ctx, 1)
+	_, _ = s.cachedStorage.GetProductByID(s.ctx, 5)
+	_, _ = s.cachedStorage.GetMealByID(s.ctx, 7)
+}
+
+func TestInvalidateUsersWithoutCache(t *testing.T) {
+	var cachedStorage *CachedStorage
+	cachedStorage.InvalidateUsers(context.Background(), 1, 2)
+}
+
+func TestCachedStorageSuite(t *testing.T) {
+	suite.Run(t, new(CachedStorageSuite))
+}
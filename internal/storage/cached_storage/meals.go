@@ -0,0 +1,69 @@
+package cached_storage
+
+import (
+	"context"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+)
+
+func (s *CachedStorage) GetMealsByUserID(ctx context.Context, userID int32) ([]*models.Meal, error) {
+	return readThrough(ctx, s, "meals", userMealsKey(userID), func() ([]*models.Meal, error) {
+		return s.ProfileStorage.GetMealsByUserID(ctx, userID)
+	})
+}
+
+func (s *CachedStorage) GetMealByID(ctx context.Context, id int32) (*models.Meal, error) {
+	return readThrough(ctx, s, "meal", mealKey(id), func() (*models.Meal, error) {
+		return s.ProfileStorage.GetMealByID(ctx, id)
+	})
+}
+
+func (s *CachedStorage) GetMealsByIDs(ctx context.Context, ids []int32) ([]*models.Meal, error) {
+	return readManyThrough(ctx, s, "meal", ids, mealKey, func(meal *models.Meal) int32 {
+		return meal.ID
+	}, func(ids []int32) ([]*models.Meal, error) {
+		return s.ProfileStorage.GetMealsByIDs(ctx, ids)
+	})
+}
+
+func (s *CachedStorage) CreateMeal(ctx context.Context, meal *models.Meal) error {
+	if err := s.ProfileStorage.CreateMeal(ctx, meal); err != nil {
+		return err
+	}
+	s.invalidate(ctx, userMealsKey(meal.UserID), mealKey(meal.ID))
+	return nil
+}
+
+func (s *CachedStorage) CreateMeals(ctx context.Context, userID int32, meals []*models.Meal) error {
+	if err := s.ProfileStorage.CreateMeals(ctx, userID, meals); err != nil {
+		return err
+	}
+	s.invalidate(ctx, userMealsKey(userID))
+	return nil
+}
+
+func (s *CachedStorage) UpdateMeal(ctx context.Context, meal *models.Meal) error {
+	keys := []string{mealKey(meal.ID)}
+	// Владелец берётся из сохранённого блюда: в запросе может быть указан другой пользователь
+	if existing, err := s.GetMealByID(ctx, meal.ID); err == nil {
+		keys = append(keys, userMealsKey(existing.UserID))
+	}
+
+	err := s.ProfileStorage.UpdateMeal(ctx, meal)
+	// Сбрасываем и при ошибке: при несовпадении версии в кеше могла остаться устаревшая запись
+	s.invalidate(ctx, keys...)
+	return err
+}
+
+func (s *CachedStorage) DeleteMeal(ctx context.Context, id int32) error {
+	keys := []string{mealKey(id)}
+	if existing, err := s.GetMealByID(ctx, id); err == nil {
+		keys = append(keys, userMealsKey(existing.UserID))
+	}
+
+	if err := s.ProfileStorage.DeleteMeal(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate(ctx, keys...)
+	return nil
+}
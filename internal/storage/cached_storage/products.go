@@ -0,0 +1,78 @@
+package cached_storage
+
+import (
+	"context"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+)
+
+func (s *CachedStorage) GetProductsByUserID(ctx context.Context, userID int32) ([]*models.Product, error) {
+	return readThrough(ctx, s, "products", userProductsKey(userID), func() ([]*models.Product, error) {
+		return s.ProfileStorage.GetProductsByUserID(ctx, userID)
+	})
+}
+
+func (s *CachedStorage) GetProductByID(ctx context.Context, id int32) (*models.Product, error) {
+	return readThrough(ctx, s, "product", productKey(id), func() (*models.Product, error) {
+		return s.ProfileStorage.GetProductByID(ctx, id)
+	})
+}
+
+func (s *CachedStorage) GetProductsByIDs(ctx context.Context, ids []int32) ([]*models.Product, error) {
+	return readManyThrough(ctx, s, "product", ids, productKey, func(product *models.Product) int32 {
+		return product.ID
+	}, func(ids []int32) ([]*models.Product, error) {
+		return s.ProfileStorage.GetProductsByIDs(ctx, ids)
+	})
+}
+
+func (s *CachedStorage) CreateProduct(ctx context.Context, product *models.Product) error {
+	if err := s.ProfileStorage.CreateProduct(ctx, product); err != nil {
+		return err
+	}
+	s.invalidate(ctx, userProductsKey(product.UserID), productKey(product.ID))
+	return nil
+}
+
+func (s *CachedStorage) CreateProducts(ctx context.Context, userID int32, products []*models.Product) error {
+	if err := s.ProfileStorage.CreateProducts(ctx, userID, products); err != nil {
+		return err
+	}
+	s.invalidate(ctx, userProductsKey(userID))
+	return nil
+}
+
+func (s *CachedStorage) UpdateProduct(ctx context.Context, product *models.Product) error {
+	keys := []string{productKey(product.ID)}
+	// Владелец берётся из сохранённого продукта: в запросе может быть указан другой пользователь
+	if existing, err := s.GetProductByID(ctx, product.ID); err == nil {
+		keys = append(keys, userProductsKey(existing.UserID))
+	}
+
+	err := s.ProfileStorage.UpdateProduct(ctx, product)
+	// Сбрасываем и при ошибке: при несовпадении версии в кеше могла остаться устаревшая запись
+	s.invalidate(ctx, keys...)
+	return err
+}
+
+func (s *CachedStorage) DeleteProduct(ctx context.Context, id int32, detachFromMeals bool) error {
+	keys := []string{productKey(id)}
+	if existing, err := s.GetProductByID(ctx, id); err == nil {
+		keys = append(keys, userProductsKey(existing.UserID))
+		if detachFromMeals {
+			// Продукт убирается из состава блюд, поэтому сбрасываем и их
+			keys = append(keys, userMealsKey(existing.UserID))
+			if meals, err := s.ProfileStorage.GetMealsByProductID(ctx, existing.UserID, id); err == nil {
+				for _, meal := range meals {
+					keys = append(keys, mealKey(meal.ID))
+				}
+			}
+		}
+	}
+
+	if err := s.ProfileStorage.DeleteProduct(ctx, id, detachFromMeals); err != nil {
+		return err
+	}
+	s.invalidate(ctx, keys...)
+	return nil
+}
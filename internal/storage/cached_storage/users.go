@@ -0,0 +1,63 @@
+package cached_storage
+
+import (
+	"context"
+	"time"
+)
+
+func (s *CachedStorage) DeleteUser(ctx context.Context, id int32) error {
+	keys := s.userContentKeys(ctx, id)
+	if err := s.ProfileStorage.DeleteUser(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate(ctx, keys...)
+	return nil
+}
+
+func (s *CachedStorage) SoftDeleteUser(ctx context.Context, id int32) error {
+	keys := s.userContentKeys(ctx, id)
+	err := s.ProfileStorage.SoftDeleteUser(ctx, id)
+	// Сбрасываем и при ошибке: прерванное удаление могло уже пометить данные удалёнными
+	s.invalidate(ctx, keys...)
+	return err
+}
+
+func (s *CachedStorage) RestoreUser(ctx context.Context, id int32, deletedAfter time.Time) error {
+	if err := s.ProfileStorage.RestoreUser(ctx, id, deletedAfter); err != nil {
+		return err
+	}
+	s.invalidate(ctx, userProductsKey(id), userMealsKey(id))
+	return nil
+}
+
+// InvalidateUsers сбрасывает записи пользователей, чьи данные изменены в обход CachedStorage:
+// окончательное удаление, перенос между шардами. На nil ничего не делает, так что вызывать можно и без кеша.
+func (s *CachedStorage) InvalidateUsers(ctx context.Context, ids ...int32) {
+	if s == nil {
+		return
+	}
+	for _, id := range ids {
+		s.invalidate(ctx, s.userContentKeys(ctx, id)...)
+	}
+}
+
+// userContentKeys ключи списков пользователя, его продуктов и блюд. Списки читаются до удаления,
+// потому что после него id продуктов и блюд уже не узнать.
+func (s *CachedStorage) userContentKeys(ctx context.Context, id int32) []string {
+	keys := []string{userProductsKey(id), userMealsKey(id)}
+
+	products, err := s.GetProductsByUserID(ctx, id)
+	if err == nil {
+		for _, product := range products {
+			keys = append(keys, productKey(product.ID))
+		}
+	}
+	meals, err := s.GetMealsByUserID(ctx, id)
+	if err == nil {
+		for _, meal := range meals {
+			keys = append(keys, mealKey(meal.ID))
+		}
+	}
+
+	return keys
+}
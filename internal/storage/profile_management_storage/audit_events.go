@@ -0,0 +1,149 @@
+package profile_management_storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// CreateAuditEvent дописывает событие в журнал аудита на шарде пользователя
+func (s *ProfileManagementStorage) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return errors.Wrap(err, "marshal changes error")
+	}
+
+	query := squirrel.Insert(auditEventsTableName).
+		Columns(auditEventsIDColumn, auditEventsUserIDColumn, auditEventsActorColumn, auditEventsActorVerifiedColumn,
+			auditEventsActionColumn, auditEventsEntityTypeColumn, auditEventsEntityIDColumn, auditEventsChangesColumn,
+			auditEventsRequestIDColumn).
+		Values(event.ID, event.UserID, event.Actor, event.ActorVerified, string(event.Action),
+			string(event.EntityType), event.EntityID, string(changes), event.RequestID).
+		Suffix("RETURNING " + auditEventsCreatedAtColumn).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "generate query error")
+	}
+
+	var createdAt sql.NullTime
+	shard := s.getShard(event.UserID)
+	err = s.onShard(shard, func() error {
+		return shard.QueryRow(ctx, queryText, args...).Scan(&createdAt)
+	})
+	if err != nil {
+		return errors.Wrap(err, "exec query error")
+	}
+
+	if createdAt.Valid {
+		event.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return nil
+}
+
+// GetAuditEvents возвращает события журнала аудита, новые первыми.
+// С userID читается только шард пользователя, иначе все шарды параллельно; ошибка любого шарда прерывает чтение.
+// Пустые entityType, entityID и nil-границы не ограничивают выборку.
+func (s *ProfileManagementStorage) GetAuditEvents(ctx context.Context, userID int32, entityType, entityID string, from, to *time.Time, limit uint64) ([]*models.AuditEvent, error) {
+	query := squirrel.Select(auditEventsIDColumn+"::text", auditEventsUserIDColumn, auditEventsActorColumn,
+		auditEventsActorVerifiedColumn, auditEventsActionColumn, auditEventsEntityTypeColumn, auditEventsEntityIDColumn,
+		auditEventsChangesColumn+"::text", auditEventsRequestIDColumn, auditEventsCreatedAtColumn).
+		From(auditEventsTableName).
+		OrderBy(auditEventsCreatedAtColumn + " DESC").
+		Limit(limit).
+		PlaceholderFormat(squirrel.Dollar)
+	if userID != 0 {
+		query = query.Where(squirrel.Eq{auditEventsUserIDColumn: userID})
+	}
+	if entityType != "" {
+		query = query.Where(squirrel.Eq{auditEventsEntityTypeColumn: entityType})
+	}
+	if entityID != "" {
+		query = query.Where(squirrel.Eq{auditEventsEntityIDColumn: entityID})
+	}
+	// created_at хранится без часового пояса в UTC
+	if from != nil {
+		query = query.Where(squirrel.GtOrEq{auditEventsCreatedAtColumn: from.UTC()})
+	}
+	if to != nil {
+		query = query.Where(squirrel.Lt{auditEventsCreatedAtColumn: to.UTC()})
+	}
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	selectEvents := func(ctx context.Context, shard *pgxpool.Pool) ([]*models.AuditEvent, error) {
+		rows, err := shard.Query(ctx, queryText, args...)
+		if err != nil {
+			return nil, errors.Wrap(err, "query error")
+		}
+		events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.AuditEvent, error) {
+			return scanAuditEvent(row)
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "scan row error")
+		}
+		return events, nil
+	}
+
+	var events []*models.AuditEvent
+	if userID != 0 {
+		events, err = queryShard(ctx, s, s.readShard(userID), selectEvents)
+	} else {
+		events, err = queryAllShards(ctx, s.readShards(), guard(s, selectEvents))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Каждый шард отдал до limit строк, оставляем limit самых новых среди всех шардов
+	sort.SliceStable(events, func(i, j int) bool {
+		createdI, _ := time.Parse(time.RFC3339, events[i].CreatedAt)
+		createdJ, _ := time.Parse(time.RFC3339, events[j].CreatedAt)
+		return createdI.After(createdJ)
+	})
+	if uint64(len(events)) > limit {
+		events = events[:limit]
+	}
+
+	return events, nil
+}
+
+func scanAuditEvent(row pgx.Row) (*models.AuditEvent, error) {
+	var event models.AuditEvent
+	var action, entityType string
+	var changes, requestID sql.NullString
+	var createdAt sql.NullTime
+
+	err := row.Scan(&event.ID, &event.UserID, &event.Actor, &event.ActorVerified, &action, &entityType, &event.EntityID,
+		&changes, &requestID, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	event.Action = models.AuditAction(action)
+	event.EntityType = models.AuditEntityType(entityType)
+	event.RequestID = requestID.String
+	if changes.Valid {
+		if err := json.Unmarshal([]byte(changes.String), &event.Changes); err != nil {
+			return nil, errors.Wrap(err, "unmarshal changes error")
+		}
+	}
+	if createdAt.Valid {
+		event.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return &event, nil
+}
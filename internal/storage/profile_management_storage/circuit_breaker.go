@@ -0,0 +1,275 @@
+package profile_management_storage
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitOpenTimeout      = 30 * time.Second
+)
+
+// ErrCircuitOpen запрос к пулу не отправлялся: его автомат отключения разомкнут
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Состояние автомата каждого пула (shard_0_primary, shard_0_replica_1), отказы из-за разомкнутого
+// автомата по пулам и переходы по состояниям, доступны в /debug/vars
+var (
+	circuitStates      = expvar.NewMap("storage_circuit_states")
+	circuitRejected    = expvar.NewMap("storage_circuit_rejected")
+	circuitTransitions = expvar.NewMap("storage_circuit_transitions")
+)
+
+// CircuitBreakerSettings настройки автоматов отключения пулов
+type CircuitBreakerSettings struct {
+	// FailureThreshold сколько ошибок подряд размыкают автомат
+	FailureThreshold int
+	// OpenTimeout через сколько разомкнутый автомат пропускает пробный запрос
+	OpenTimeout time.Duration
+}
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker автомат отключения одного пула. После FailureThreshold ошибок подряд пул не опрашивается
+// OpenTimeout, затем пропускается один пробный запрос: успех замыкает автомат, ошибка снова размыкает.
+type circuitBreaker struct {
+	name     string
+	shard    int
+	settings CircuitBreakerSettings
+	state    *expvar.String
+
+	mu       sync.Mutex
+	current  CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newCircuitBreaker(name string, shard int, settings CircuitBreakerSettings) *circuitBreaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = defaultCircuitFailureThreshold
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = defaultCircuitOpenTimeout
+	}
+
+	b := &circuitBreaker{
+		name:     name,
+		shard:    shard,
+		settings: settings,
+		state:    new(expvar.String),
+	}
+	b.state.Set(CircuitClosed.String())
+	circuitStates.Set(name, b.state)
+	return b
+}
+
+// allow разрешает запрос или возвращает ErrCircuitOpen. nil-автомат пропускает всё.
+func (b *circuitBreaker) allow(now time.Time) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.current {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < b.settings.OpenTimeout {
+			circuitRejected.Add(b.name, 1)
+			return ErrCircuitOpen
+		}
+		b.transition(CircuitHalfOpen)
+		b.probing = true
+	case CircuitHalfOpen:
+		// Пока пробный запрос не вернулся, остальные не пропускаем
+		if b.probing {
+			circuitRejected.Add(b.name, 1)
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// available пропустит ли автомат запрос, не занимая пробный слот
+func (b *circuitBreaker) available(now time.Time) bool {
+	if b == nil {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.current {
+	case CircuitOpen:
+		return now.Sub(b.openedAt) >= b.settings.OpenTimeout
+	case CircuitHalfOpen:
+		return !b.probing
+	}
+	return true
+}
+
+// record учитывает результат запроса. Отмена запроса вызывающим не говорит о здоровье пула.
+func (b *circuitBreaker) record(err error, now time.Time) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case err != nil && errors.Is(err, context.Canceled):
+		b.probing = false
+	case isShardFailure(err):
+		b.probing = false
+		b.failures++
+		if b.current == CircuitHalfOpen || b.failures >= b.settings.FailureThreshold {
+			b.openedAt = now
+			if b.current != CircuitOpen {
+				slog.Warn("storage circuit breaker opened", "pool", b.name, "failures", b.failures, "error", err)
+				b.transition(CircuitOpen)
+			}
+		}
+	default:
+		b.probing = false
+		b.failures = 0
+		if b.current != CircuitClosed {
+			slog.Info("storage circuit breaker closed", "pool", b.name)
+			b.transition(CircuitClosed)
+		}
+	}
+}
+
+func (b *circuitBreaker) transition(state CircuitState) {
+	b.current = state
+	b.state.Set(state.String())
+	circuitTransitions.Add(state.String(), 1)
+}
+
+func (b *circuitBreaker) snapshot() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.current
+}
+
+// isShardFailure ошибка говорит о недоступности пула: не удалось подключиться, соединение оборвалось
+// или шард не ответил вовремя. Ошибки, которые вернул сам PostgreSQL, к ним не относятся.
+func isShardFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrCircuitOpen) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return false
+	}
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) || errors.As(err, &netErr)
+}
+
+func circuitName(shard, replica int) string {
+	if replica < 0 {
+		return fmt.Sprintf("shard_%d_primary", shard)
+	}
+	return fmt.Sprintf("shard_%d_replica_%d", shard, replica)
+}
+
+// guard пропускает lookup через автомат пула: пул с разомкнутым автоматом не опрашивается,
+// и lookup сразу возвращает ErrCircuitOpen
+func guard[T any](s *ProfileManagementStorage, lookup func(ctx context.Context, shard *pgxpool.Pool) (T, error)) func(ctx context.Context, shard *pgxpool.Pool) (T, error) {
+	return func(ctx context.Context, shard *pgxpool.Pool) (T, error) {
+		breaker := s.breakers[shard]
+		if err := breaker.allow(time.Now()); err != nil {
+			var zero T
+			return zero, err
+		}
+
+		value, err := lookup(ctx, shard)
+		breaker.record(err, time.Now())
+		return value, err
+	}
+}
+
+// onShard выполняет fn на пуле через его автомат. Недоступность пула возвращается как *ShardError.
+func (s *ProfileManagementStorage) onShard(shard *pgxpool.Pool, fn func() error) error {
+	breaker := s.breakers[shard]
+	if err := breaker.allow(time.Now()); err != nil {
+		return &ShardError{Shard: breaker.shard, Err: err}
+	}
+
+	err := fn()
+	breaker.record(err, time.Now())
+	if breaker != nil && isShardFailure(err) {
+		return &ShardError{Shard: breaker.shard, Err: err}
+	}
+	return err
+}
+
+// queryShard выполняет query на пуле через его автомат отключения
+func queryShard[T any](ctx context.Context, s *ProfileManagementStorage, shard *pgxpool.Pool, query func(ctx context.Context, shard *pgxpool.Pool) (T, error)) (T, error) {
+	var value T
+	err := s.onShard(shard, func() error {
+		var err error
+		value, err = query(ctx, shard)
+		return err
+	})
+	return value, err
+}
+
+// PoolHealth состояние автомата одного пула; Replica равен -1 у primary
+type PoolHealth struct {
+	Shard   int
+	Replica int
+	State   CircuitState
+}
+
+// Health состояние автоматов всех пулов: сначала primary шарда, затем его реплики
+func (s *ProfileManagementStorage) Health() []PoolHealth {
+	var health []PoolHealth
+	for i, shard := range s.shards {
+		health = append(health, PoolHealth{Shard: i, Replica: -1, State: s.breakers[shard].snapshot()})
+		for j, r := range s.replicas[i].replicas {
+			health = append(health, PoolHealth{Shard: i, Replica: j, State: s.breakers[r.pool].snapshot()})
+		}
+	}
+	return health
+}
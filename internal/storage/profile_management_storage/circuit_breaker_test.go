@@ -0,0 +1,135 @@
+package profile_management_storage
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gotest.tools/v3/assert"
+)
+
+var errConnRefused = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	b := newCircuitBreaker("test_opens", 0, CircuitBreakerSettings{FailureThreshold: 2, OpenTimeout: time.Minute})
+	now := time.Now()
+
+	assert.NilError(t, b.allow(now))
+	b.record(errConnRefused, now)
+	// Успех между ошибками сбрасывает счётчик
+	b.record(nil, now)
+	b.record(errConnRefused, now)
+	assert.Equal(t, b.snapshot(), CircuitClosed)
+
+	b.record(errConnRefused, now)
+	assert.Equal(t, b.snapshot(), CircuitOpen)
+	assert.ErrorIs(t, b.allow(now.Add(time.Second)), ErrCircuitOpen)
+}
+
+func TestCircuitBreakerHalfOpensOnProbe(t *testing.T) {
+	b := newCircuitBreaker("test_probe", 0, CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	now := time.Now()
+	b.record(errConnRefused, now)
+
+	// После OpenTimeout проходит один пробный запрос, остальные ждут его результата
+	later := now.Add(time.Minute)
+	assert.NilError(t, b.allow(later))
+	assert.Equal(t, b.snapshot(), CircuitHalfOpen)
+	assert.ErrorIs(t, b.allow(later), ErrCircuitOpen)
+
+	// Неудачная проба снова размыкает автомат
+	b.record(errConnRefused, later)
+	assert.Equal(t, b.snapshot(), CircuitOpen)
+	assert.ErrorIs(t, b.allow(later.Add(time.Second)), ErrCircuitOpen)
+
+	probe := later.Add(time.Minute)
+	assert.NilError(t, b.allow(probe))
+	b.record(nil, probe)
+	assert.Equal(t, b.snapshot(), CircuitClosed)
+	assert.NilError(t, b.allow(probe))
+}
+
+func TestCircuitBreakerIgnoresCancelledProbe(t *testing.T) {
+	b := newCircuitBreaker("test_cancel", 0, CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	now := time.Now().Add(-time.Minute)
+	b.record(errConnRefused, now)
+
+	assert.NilError(t, b.allow(time.Now()))
+	b.record(context.Canceled, time.Now())
+	assert.Equal(t, b.snapshot(), CircuitHalfOpen)
+	assert.NilError(t, b.allow(time.Now()))
+}
+
+func TestIsShardFailure(t *testing.T) {
+	assert.Assert(t, isShardFailure(errConnRefused))
+	assert.Assert(t, isShardFailure(context.DeadlineExceeded))
+	assert.Assert(t, isShardFailure(ErrCircuitOpen))
+	assert.Assert(t, !isShardFailure(pgx.ErrNoRows))
+	assert.Assert(t, !isShardFailure(&pgconn.PgError{Code: foreignKeyViolationCode}))
+	assert.Assert(t, !isShardFailure(models.ErrVersionMismatch))
+}
+
+func TestScatterGatherSkipsOpenCircuitShard(t *testing.T) {
+	shards := testShards(2)
+	s := &ProfileManagementStorage{
+		shards:   shards,
+		breakers: map[*pgxpool.Pool]*circuitBreaker{},
+	}
+	breaker := newCircuitBreaker("test_skip", 0, CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	breaker.record(errConnRefused, time.Now())
+	s.breakers[shards[0]] = breaker
+
+	queried := make(chan int, 2)
+	_, _, err := gatherLowest(context.Background(), shards, []int{0, 1}, guard(s, func(ctx context.Context, shard *pgxpool.Pool) (int, error) {
+		queried <- shardIndex(shards, shard)
+		return 0, pgx.ErrNoRows
+	}))
+	close(queried)
+
+	// Шард с разомкнутым автоматом не опрашивается, а ошибка называет его и считается недоступностью
+	for index := range queried {
+		assert.Equal(t, index, 1)
+	}
+	var shardsErr *ShardsError
+	assert.Assert(t, errors.As(err, &shardsErr))
+	assert.Equal(t, len(shardsErr.Errors), 1)
+	assert.Equal(t, shardsErr.Errors[0].Shard, 0)
+	assert.ErrorIs(t, err, models.ErrShardUnavailable)
+	assert.ErrorContains(t, err, "shard 0: circuit breaker is open")
+}
+
+func TestShardErrorIsUnavailableOnlyForFailures(t *testing.T) {
+	assert.ErrorIs(t, &ShardError{Shard: 1, Err: errConnRefused}, models.ErrShardUnavailable)
+	assert.Assert(t, !errors.Is(&ShardError{Shard: 1, Err: errors.New("syntax error")}, models.ErrShardUnavailable))
+}
+
+func TestAuditEventsRespectOpenCircuit(t *testing.T) {
+	shards := testShards(1)
+	s := &ProfileManagementStorage{
+		shards:        shards,
+		replicas:      []*replicaSet{{}},
+		pins:          newWritePins(0),
+		bucketCount:   1,
+		bucketToShard: []int{0},
+		breakers:      map[*pgxpool.Pool]*circuitBreaker{},
+	}
+	breaker := newCircuitBreaker("test_audit", 0, CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	breaker.record(errConnRefused, time.Now())
+	s.breakers[shards[0]] = breaker
+
+	// Запросы к шарду с разомкнутым автоматом не уходят в пул и возвращают недоступность шарда
+	err := s.CreateAuditEvent(context.Background(), &models.AuditEvent{UserID: 1})
+	assert.ErrorIs(t, err, models.ErrShardUnavailable)
+
+	_, err = s.GetAuditEvents(context.Background(), 1, "", "", nil, nil, 10)
+	assert.ErrorIs(t, err, models.ErrShardUnavailable)
+
+	_, err = s.GetAuditEvents(context.Background(), 0, "", "", nil, nil, 10)
+	assert.ErrorIs(t, err, models.ErrShardUnavailable)
+}
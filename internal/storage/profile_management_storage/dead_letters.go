@@ -0,0 +1,175 @@
+package profile_management_storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+// CreateDeadLetter сохраняет неопубликованное сообщение на шарде пользователя
+func (s *ProfileManagementStorage) CreateDeadLetter(ctx context.Context, deadLetter *models.DeadLetter) error {
+	headers, err := json.Marshal(deadLetter.Headers)
+	if err != nil {
+		return errors.Wrap(err, "marshal headers error")
+	}
+
+	query := squirrel.Insert(deadLettersTableName).
+		Columns(deadLettersIDColumn, deadLettersUserIDColumn, deadLettersTopicColumn, deadLettersKeyColumn,
+			deadLettersValueColumn, deadLettersHeadersColumn, deadLettersErrorColumn, deadLettersAttemptsColumn).
+		Values(deadLetter.ID, deadLetter.UserID, deadLetter.Topic, deadLetter.Key,
+			deadLetter.Value, string(headers), deadLetter.Error, deadLetter.Attempts).
+		Suffix("RETURNING " + deadLettersCreatedAtColumn).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "generate query error")
+	}
+
+	var createdAt sql.NullTime
+	err = s.getShard(deadLetter.UserID).QueryRow(ctx, queryText, args...).Scan(&createdAt)
+	if err != nil {
+		return errors.Wrap(err, "exec query error")
+	}
+
+	if createdAt.Valid {
+		deadLetter.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return nil
+}
+
+// GetDeadLetters возвращает последние неопубликованные сообщения со всех шардов, новые первыми.
+// Пустой topic означает все топики.
+func (s *ProfileManagementStorage) GetDeadLetters(ctx context.Context, topic string, limit uint64) ([]*models.DeadLetter, error) {
+	query := selectDeadLetters().
+		OrderBy(deadLettersCreatedAtColumn + " DESC").
+		Limit(limit)
+	if topic != "" {
+		query = query.Where(squirrel.Eq{deadLettersTopicColumn: topic})
+	}
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	var deadLetters []*models.DeadLetter
+	for _, shard := range s.shards {
+		rows, err := shard.Query(ctx, queryText, args...)
+		if err != nil {
+			return nil, errors.Wrap(err, "query error")
+		}
+
+		for rows.Next() {
+			deadLetter, err := scanDeadLetter(rows)
+			if err != nil {
+				rows.Close()
+				return nil, errors.Wrap(err, "scan row error")
+			}
+			deadLetters = append(deadLetters, deadLetter)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, errors.Wrap(err, "rows error")
+		}
+	}
+
+	// Каждый шард отдал до limit строк, оставляем limit самых новых среди всех шардов
+	sort.SliceStable(deadLetters, func(i, j int) bool {
+		createdI, _ := time.Parse(time.RFC3339, deadLetters[i].CreatedAt)
+		createdJ, _ := time.Parse(time.RFC3339, deadLetters[j].CreatedAt)
+		return createdI.After(createdJ)
+	})
+	if uint64(len(deadLetters)) > limit {
+		deadLetters = deadLetters[:limit]
+	}
+
+	return deadLetters, nil
+}
+
+// GetDeadLetter ищет неопубликованное сообщение на всех шардах
+func (s *ProfileManagementStorage) GetDeadLetter(ctx context.Context, id string) (*models.DeadLetter, error) {
+	query := selectDeadLetters().
+		Where(squirrel.Eq{deadLettersIDColumn: id})
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	for _, shard := range s.shards {
+		deadLetter, err := scanDeadLetter(shard.QueryRow(ctx, queryText, args...))
+		if err == nil {
+			return deadLetter, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.Wrap(err, "scan row error")
+		}
+	}
+
+	return nil, errors.New("dead letter not found")
+}
+
+// DeleteDeadLetter удаляет неопубликованное сообщение после повторной отправки или по решению администратора
+func (s *ProfileManagementStorage) DeleteDeadLetter(ctx context.Context, id string) error {
+	query := squirrel.Delete(deadLettersTableName).
+		Where(squirrel.Eq{deadLettersIDColumn: id}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "generate query error")
+	}
+
+	for _, shard := range s.shards {
+		result, err := shard.Exec(ctx, queryText, args...)
+		if err != nil {
+			return errors.Wrap(err, "exec query error")
+		}
+		if result.RowsAffected() > 0 {
+			return nil
+		}
+	}
+
+	return errors.New("dead letter not found")
+}
+
+func selectDeadLetters() squirrel.SelectBuilder {
+	return squirrel.Select(deadLettersIDColumn+"::text", deadLettersUserIDColumn, deadLettersTopicColumn,
+		deadLettersKeyColumn, deadLettersValueColumn, deadLettersHeadersColumn+"::text", deadLettersErrorColumn,
+		deadLettersAttemptsColumn, deadLettersCreatedAtColumn).
+		From(deadLettersTableName).
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func scanDeadLetter(row pgx.Row) (*models.DeadLetter, error) {
+	var deadLetter models.DeadLetter
+	var headers, errorText sql.NullString
+	var createdAt sql.NullTime
+
+	err := row.Scan(&deadLetter.ID, &deadLetter.UserID, &deadLetter.Topic, &deadLetter.Key, &deadLetter.Value,
+		&headers, &errorText, &deadLetter.Attempts, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if headers.Valid {
+		if err := json.Unmarshal([]byte(headers.String), &deadLetter.Headers); err != nil {
+			return nil, errors.Wrap(err, "unmarshal headers error")
+		}
+	}
+	deadLetter.Error = errorText.String
+	if createdAt.Valid {
+		deadLetter.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return &deadLetter, nil
+}
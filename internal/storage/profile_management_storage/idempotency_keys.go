@@ -0,0 +1,157 @@
+package profile_management_storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// CreateIdempotencyKey резервирует ключ под выполняющийся запрос.
+// Возвращает false, если действующий ключ уже занят; истёкший ключ перезаписывается.
+func (s *ProfileManagementStorage) CreateIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) (bool, error) {
+	query := squirrel.Insert(idempotencyKeysTableName).
+		Columns(idempotencyKeysUserIDColumn, idempotencyKeysMethodColumn, idempotencyKeysKeyColumn,
+			idempotencyKeysRequestHashColumn, idempotencyKeysExpiresAtColumn).
+		// Срок считаем в базе, чтобы сравнение с NOW() не зависело от часового пояса сервиса
+		Values(key.UserID, key.Method, key.Key, key.RequestHash,
+			squirrel.Expr("NOW() + make_interval(secs => ?)", key.TTL.Seconds())).
+		// Занятый ключ перезаписываем, только если он истёк
+		Suffix(fmt.Sprintf("ON CONFLICT (%[1]s, %[2]s, %[3]s) DO UPDATE SET %[4]s = EXCLUDED.%[4]s, %[5]s = NULL, %[6]s = NOW(), %[7]s = EXCLUDED.%[7]s WHERE %[8]s.%[7]s <= NOW() RETURNING %[3]s",
+			idempotencyKeysUserIDColumn, idempotencyKeysMethodColumn, idempotencyKeysKeyColumn,
+			idempotencyKeysRequestHashColumn, idempotencyKeysResponseColumn, idempotencyKeysCreatedAtColumn,
+			idempotencyKeysExpiresAtColumn, idempotencyKeysTableName)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "generate query error")
+	}
+
+	var created string
+	err = s.getIdempotencyKeyShard(key.UserID, key.Key).QueryRow(ctx, queryText, args...).Scan(&created)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "exec query error")
+	}
+
+	return true, nil
+}
+
+// GetIdempotencyKey возвращает действующий ключ или nil, если ключа нет или он истёк
+func (s *ProfileManagementStorage) GetIdempotencyKey(ctx context.Context, userID int32, method, key string) (*models.IdempotencyKey, error) {
+	query := squirrel.Select(idempotencyKeysRequestHashColumn, idempotencyKeysResponseColumn).
+		From(idempotencyKeysTableName).
+		Where(squirrel.Eq{
+			idempotencyKeysUserIDColumn: userID,
+			idempotencyKeysMethodColumn: method,
+			idempotencyKeysKeyColumn:    key,
+		}).
+		Where(squirrel.Expr(idempotencyKeysExpiresAtColumn + " > NOW()")).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	result := models.IdempotencyKey{
+		UserID: userID,
+		Method: method,
+		Key:    key,
+	}
+	err = s.getIdempotencyKeyShard(userID, key).QueryRow(ctx, queryText, args...).
+		Scan(&result.RequestHash, &result.Response)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "scan row error")
+	}
+
+	return &result, nil
+}
+
+// CompleteIdempotencyKey сохраняет первый ответ для повторов с тем же ключом
+func (s *ProfileManagementStorage) CompleteIdempotencyKey(ctx context.Context, key *models.IdempotencyKey) error {
+	query := squirrel.Update(idempotencyKeysTableName).
+		Set(idempotencyKeysResponseColumn, key.Response).
+		Where(squirrel.Eq{
+			idempotencyKeysUserIDColumn: key.UserID,
+			idempotencyKeysMethodColumn: key.Method,
+			idempotencyKeysKeyColumn:    key.Key,
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "generate query error")
+	}
+
+	_, err = s.getIdempotencyKeyShard(key.UserID, key.Key).Exec(ctx, queryText, args...)
+	if err != nil {
+		return errors.Wrap(err, "exec query error")
+	}
+
+	return nil
+}
+
+// DeleteIdempotencyKey освобождает ключ, если запрос завершился ошибкой и его можно повторить
+func (s *ProfileManagementStorage) DeleteIdempotencyKey(ctx context.Context, userID int32, method, key string) error {
+	query := squirrel.Delete(idempotencyKeysTableName).
+		Where(squirrel.Eq{
+			idempotencyKeysUserIDColumn: userID,
+			idempotencyKeysMethodColumn: method,
+			idempotencyKeysKeyColumn:    key,
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "generate query error")
+	}
+
+	_, err = s.getIdempotencyKeyShard(userID, key).Exec(ctx, queryText, args...)
+	if err != nil {
+		return errors.Wrap(err, "exec query error")
+	}
+
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys удаляет истёкшие ключи на всех шардах
+func (s *ProfileManagementStorage) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	queryText, args, err := squirrel.Delete(idempotencyKeysTableName).
+		Where(squirrel.Expr(idempotencyKeysExpiresAtColumn + " <= NOW()")).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "generate query error")
+	}
+
+	var deleted int64
+	for i, shard := range s.shards {
+		result, err := shard.Exec(ctx, queryText, args...)
+		if err != nil {
+			return deleted, errors.Wrapf(err, "delete expired idempotency keys on shard %d", i)
+		}
+		deleted += result.RowsAffected()
+	}
+
+	return deleted, nil
+}
+
+// getIdempotencyKeyShard возвращает шард пользователя. Ключи CreateUser (userID == 0)
+// распределяются по шардам по самому ключу, чтобы не собираться на одном шарде.
+func (s *ProfileManagementStorage) getIdempotencyKeyShard(userID int32, key string) *pgxpool.Pool {
+	if userID == 0 {
+		return s.getShardByUsername(key)
+	}
+	return s.getShard(userID)
+}
@@ -7,14 +7,37 @@ import (
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 )
 
+// CreateMeal вставляет блюдо и его состав одной транзакцией
 func (s *ProfileManagementStorage) CreateMeal(ctx context.Context, meal *models.Meal) error {
+	s.pinUser(meal.UserID)
+	return s.inTx(ctx, s.getShard(meal.UserID), func(tx pgx.Tx) error {
+		return insertMeal(ctx, tx, meal)
+	})
+}
+
+// CreateMeals вставляет блюда пользователя одной транзакцией на его шарде
+func (s *ProfileManagementStorage) CreateMeals(ctx context.Context, userID int32, meals []*models.Meal) error {
+	s.pinUser(userID)
+	return s.inTx(ctx, s.getShard(userID), func(tx pgx.Tx) error {
+		for _, meal := range meals {
+			meal.UserID = userID
+			if err := insertMeal(ctx, tx, meal); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func insertMeal(ctx context.Context, tx pgx.Tx, meal *models.Meal) error {
 	query := squirrel.Insert(mealsTableName).
-		Columns(mealsUserIDColumn, mealsNameColumn, mealsProductIDsColumn).
-		Values(meal.UserID, meal.Name, meal.ProductIDs).
-		Suffix("RETURNING " + mealsIDColumn + ", " + mealsCreatedAtColumn).
+		Columns(mealsUserIDColumn, mealsNameColumn).
+		Values(meal.UserID, meal.Name).
+		Suffix("RETURNING " + mealsIDColumn + ", " + mealsCreatedAtColumn + ", " + mealsVersionColumn).
 		PlaceholderFormat(squirrel.Dollar)
 
 	queryText, args, err := query.ToSql()
@@ -22,9 +45,8 @@ func (s *ProfileManagementStorage) CreateMeal(ctx context.Context, meal *models.
 		return errors.Wrap(err, "generate query error")
 	}
 
-	shard := s.getShard(meal.UserID)
 	var createdAt sql.NullTime
-	err = shard.QueryRow(ctx, queryText, args...).Scan(&meal.ID, &createdAt)
+	err = tx.QueryRow(ctx, queryText, args...).Scan(&meal.ID, &createdAt, &meal.Version)
 	if err != nil {
 		return errors.Wrap(err, "exec query error")
 	}
@@ -33,22 +55,60 @@ func (s *ProfileManagementStorage) CreateMeal(ctx context.Context, meal *models.
 		meal.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
 	}
 
-	return nil
+	return insertMealProducts(ctx, tx, meal)
+}
+
+func insertMealProducts(ctx context.Context, tx pgx.Tx, meal *models.Meal) error {
+	if len(meal.Products) == 0 {
+		return nil
+	}
+
+	query := squirrel.Insert(mealProductsTableName).
+		Columns(mealProductsMealIDColumn, mealProductsProductIDColumn, mealProductsQuantityColumn).
+		PlaceholderFormat(squirrel.Dollar)
+	for _, product := range meal.Products {
+		query = query.Values(meal.ID, product.ProductID, product.Quantity)
+	}
+
+	_, err := execTx(ctx, tx, query)
+	return err
 }
 
 func (s *ProfileManagementStorage) GetMealsByUserID(ctx context.Context, userID int32) ([]*models.Meal, error) {
-	query := squirrel.Select(mealsIDColumn, mealsUserIDColumn, mealsNameColumn,
-		mealsProductIDsColumn, mealsCreatedAtColumn).
+	query := squirrel.Select(mealsIDColumn, mealsUserIDColumn, mealsNameColumn, mealsCreatedAtColumn, mealsVersionColumn).
 		From(mealsTableName).
-		Where(squirrel.Eq{mealsUserIDColumn: userID}).
+		Where(squirrel.Eq{mealsUserIDColumn: userID, mealsDeletedAtColumn: nil}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	return queryShard(ctx, s, s.readShard(userID), func(ctx context.Context, shard *pgxpool.Pool) ([]*models.Meal, error) {
+		return s.selectMeals(ctx, shard, query)
+	})
+}
+
+// GetMealsByProductID возвращает блюда пользователя, в состав которых входит продукт
+func (s *ProfileManagementStorage) GetMealsByProductID(ctx context.Context, userID, productID int32) ([]*models.Meal, error) {
+	query := squirrel.Select("m."+mealsIDColumn, "m."+mealsUserIDColumn, "m."+mealsNameColumn, "m."+mealsCreatedAtColumn, "m."+mealsVersionColumn).
+		From(mealsTableName + " m").
+		Join(mealProductsTableName + " mp ON mp." + mealProductsMealIDColumn + " = m." + mealsIDColumn).
+		Where(squirrel.Eq{
+			"m." + mealsUserIDColumn:            userID,
+			"m." + mealsDeletedAtColumn:         nil,
+			"mp." + mealProductsProductIDColumn: productID,
+		}).
+		OrderBy("m." + mealsIDColumn).
 		PlaceholderFormat(squirrel.Dollar)
 
+	return queryShard(ctx, s, s.readShard(userID), func(ctx context.Context, shard *pgxpool.Pool) ([]*models.Meal, error) {
+		return s.selectMeals(ctx, shard, query)
+	})
+}
+
+func (s *ProfileManagementStorage) selectMeals(ctx context.Context, shard querier, query squirrel.SelectBuilder) ([]*models.Meal, error) {
 	queryText, args, err := query.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "generate query error")
 	}
 
-	shard := s.getShard(userID)
 	rows, err := shard.Query(ctx, queryText, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "query error")
@@ -58,98 +118,180 @@ func (s *ProfileManagementStorage) GetMealsByUserID(ctx context.Context, userID
 	var meals []*models.Meal
 	for rows.Next() {
 		var meal models.Meal
-		var productIDs []int32
 		var createdAt sql.NullTime
 
-		err := rows.Scan(
-			&meal.ID, &meal.UserID, &meal.Name,
-			&productIDs, &createdAt,
-		)
+		err := rows.Scan(&meal.ID, &meal.UserID, &meal.Name, &createdAt, &meal.Version)
 		if err != nil {
 			return nil, errors.Wrap(err, "scan row error")
 		}
 
-		meal.ProductIDs = productIDs
-
 		if createdAt.Valid {
 			meal.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
 		}
 
 		meals = append(meals, &meal)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "query error")
+	}
+
+	if err := loadMealProducts(ctx, shard, meals); err != nil {
+		return nil, err
+	}
 
 	return meals, nil
 }
 
-func (s *ProfileManagementStorage) GetMealByID(ctx context.Context, id int32) (*models.Meal, error) {
-	query := squirrel.Select(mealsIDColumn, mealsUserIDColumn, mealsNameColumn,
-		mealsProductIDsColumn, mealsCreatedAtColumn).
-		From(mealsTableName).
-		Where(squirrel.Eq{mealsIDColumn: id}).
+// loadMealProducts заполняет состав блюд одним запросом к шарду
+func loadMealProducts(ctx context.Context, shard querier, meals []*models.Meal) error {
+	if len(meals) == 0 {
+		return nil
+	}
+
+	byID := make(map[int32]*models.Meal, len(meals))
+	ids := make([]int32, 0, len(meals))
+	for _, meal := range meals {
+		byID[meal.ID] = meal
+		ids = append(ids, meal.ID)
+		meal.Products = []models.MealProduct{}
+		meal.ProductIDs = []int32{}
+	}
+
+	query := squirrel.Select(mealProductsMealIDColumn, mealProductsProductIDColumn, mealProductsQuantityColumn).
+		From(mealProductsTableName).
+		Where(squirrel.Eq{mealProductsMealIDColumn: ids}).
+		OrderBy(mealProductsMealIDColumn, mealProductsProductIDColumn).
 		PlaceholderFormat(squirrel.Dollar)
 
 	queryText, args, err := query.ToSql()
 	if err != nil {
-		return nil, errors.Wrap(err, "generate query error")
+		return errors.Wrap(err, "generate query error")
 	}
 
-	var meal models.Meal
-	var productIDs []int32
-	var createdAt sql.NullTime
+	rows, err := shard.Query(ctx, queryText, args...)
+	if err != nil {
+		return errors.Wrap(err, "query error")
+	}
+	defer rows.Close()
 
-	var found bool
-	for _, shard := range s.shards {
-		err = shard.QueryRow(ctx, queryText, args...).Scan(
-			&meal.ID, &meal.UserID, &meal.Name,
-			&productIDs, &createdAt,
-		)
-		if err == nil {
-			found = true
-			break
-		}
-		if !errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.Wrap(err, "scan row error")
+	for rows.Next() {
+		var mealID int32
+		var product models.MealProduct
+		if err := rows.Scan(&mealID, &product.ProductID, &product.Quantity); err != nil {
+			return errors.Wrap(err, "scan row error")
 		}
+
+		meal := byID[mealID]
+		meal.Products = append(meal.Products, product)
+		meal.ProductIDs = append(meal.ProductIDs, product.ProductID)
 	}
 
-	if !found {
-		return nil, errors.New("meal not found")
+	return errors.Wrap(rows.Err(), "query error")
+}
+
+// GetMealByID ищет блюдо параллельно на всех шардах и возвращает его вместе с составом. id уникальны
+// только в пределах шарда: при совпадении возвращается блюдо младшего шарда.
+func (s *ProfileManagementStorage) GetMealByID(ctx context.Context, id int32) (*models.Meal, error) {
+	meal, err := readByID(ctx, s, s.mealByIDLookup(id), func(meal *models.Meal) int32 {
+		return meal.UserID
+	})
+	if err != nil {
+		return nil, notFound(err, "meal not found")
 	}
 
-	meal.ProductIDs = productIDs
+	return meal, nil
+}
 
-	if createdAt.Valid {
-		meal.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
+// getPrimaryMealByID ищет блюдо только на primary: изменениям нужна актуальная запись.
+// Как и GetMealByID, при совпадении id выбирает блюдо младшего шарда.
+func (s *ProfileManagementStorage) getPrimaryMealByID(ctx context.Context, id int32) (*models.Meal, error) {
+	meal, _, err := gatherLowest(ctx, s.shards, s.shardIndexes(), guard(s, s.mealByIDLookup(id)))
+	if err != nil {
+		return nil, notFound(err, "meal not found")
+	}
+
+	return meal, nil
+}
+
+func (s *ProfileManagementStorage) mealByIDLookup(id int32) func(ctx context.Context, shard *pgxpool.Pool) (*models.Meal, error) {
+	query := squirrel.Select(mealsIDColumn, mealsUserIDColumn, mealsNameColumn, mealsCreatedAtColumn, mealsVersionColumn).
+		From(mealsTableName).
+		Where(squirrel.Eq{mealsIDColumn: id, mealsDeletedAtColumn: nil}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	return func(ctx context.Context, shard *pgxpool.Pool) (*models.Meal, error) {
+		meals, err := s.selectMeals(ctx, shard, query)
+		if err != nil {
+			return nil, err
+		}
+		if len(meals) == 0 {
+			return nil, pgx.ErrNoRows
+		}
+		return meals[0], nil
 	}
+}
 
-	return &meal, nil
+// GetMealsByIDs возвращает найденные блюда из ids вместе с составом, упорядоченные по id; отсутствующие пропускаются.
+// Владелец блюд неизвестен, поэтому все шарды опрашиваются параллельно.
+// id уникальны только в пределах шарда: при совпадении возвращается блюдо младшего шарда, а остальные
+// отбрасываются, поэтому для блюд известного владельца метод не подходит.
+func (s *ProfileManagementStorage) GetMealsByIDs(ctx context.Context, ids []int32) ([]*models.Meal, error) {
+	return readAllByIDs(ctx, s, ids, func(ctx context.Context, shard *pgxpool.Pool, ids []int32) ([]*models.Meal, error) {
+		return s.selectMeals(ctx, shard, squirrel.Select(mealsIDColumn, mealsUserIDColumn, mealsNameColumn, mealsCreatedAtColumn, mealsVersionColumn).
+			From(mealsTableName).
+			Where(squirrel.Expr(mealsIDColumn+" = ANY(?)", ids)).
+			Where(squirrel.Eq{mealsDeletedAtColumn: nil}).
+			PlaceholderFormat(squirrel.Dollar))
+	}, func(meal *models.Meal) (int32, int32) {
+		return meal.ID, meal.UserID
+	})
 }
 
+// UpdateMeal обновляет название и полностью заменяет состав блюда
 func (s *ProfileManagementStorage) UpdateMeal(ctx context.Context, meal *models.Meal) error {
+	tempMeal, err := s.getPrimaryMealByID(ctx, meal.ID)
+	if err != nil {
+		return err
+	}
+	s.pinUser(tempMeal.UserID)
+
 	query := squirrel.Update(mealsTableName).
 		Set(mealsNameColumn, meal.Name).
-		Set(mealsProductIDsColumn, meal.ProductIDs).
+		Set(mealsVersionColumn, squirrel.Expr(mealsVersionColumn+" + 1")).
 		Where(squirrel.Eq{mealsIDColumn: meal.ID}).
+		Suffix("RETURNING " + mealsVersionColumn).
 		PlaceholderFormat(squirrel.Dollar)
+	if meal.Version > 0 {
+		query = query.Where(squirrel.Eq{mealsVersionColumn: meal.Version})
+	}
 
 	queryText, args, err := query.ToSql()
 	if err != nil {
 		return errors.Wrap(err, "generate query error")
 	}
 
-	tempMeal, err := s.GetMealByID(ctx, meal.ID)
-	if err != nil {
-		return err
-	}
-	shard := s.getShard(tempMeal.UserID)
-	_, err = shard.Exec(ctx, queryText, args...)
-	if err != nil {
-		return errors.Wrap(err, "exec query error")
-	}
+	return s.inTx(ctx, s.getShard(tempMeal.UserID), func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, queryText, args...).Scan(&meal.Version)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Блюдо найдено выше, значит не совпала версия
+			return models.ErrVersionMismatch
+		}
+		if err != nil {
+			return errors.Wrap(err, "exec query error")
+		}
 
-	return nil
+		_, err = execTx(ctx, tx, squirrel.Delete(mealProductsTableName).
+			Where(squirrel.Eq{mealProductsMealIDColumn: meal.ID}).
+			PlaceholderFormat(squirrel.Dollar))
+		if err != nil {
+			return err
+		}
+
+		return insertMealProducts(ctx, tx, meal)
+	})
 }
 
+// DeleteMeal удаляет блюдо, состав удаляется каскадно
 func (s *ProfileManagementStorage) DeleteMeal(ctx context.Context, id int32) error {
 	query := squirrel.Delete(mealsTableName).
 		Where(squirrel.Eq{mealsIDColumn: id}).
@@ -160,12 +302,16 @@ func (s *ProfileManagementStorage) DeleteMeal(ctx context.Context, id int32) err
 		return errors.Wrap(err, "generate query error")
 	}
 
-	tempMeal, err := s.GetMealByID(ctx, id)
+	tempMeal, err := s.getPrimaryMealByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	s.pinUser(tempMeal.UserID)
 	shard := s.getShard(tempMeal.UserID)
-	_, err = shard.Exec(ctx, queryText, args...)
+	err = s.onShard(shard, func() error {
+		_, err := shard.Exec(ctx, queryText, args...)
+		return err
+	})
 	if err != nil {
 		return errors.Wrap(err, "exec query error")
 	}
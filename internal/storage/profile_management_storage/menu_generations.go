@@ -0,0 +1,186 @@
+package profile_management_storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// CreateMenuGeneration сохраняет запрос генерации меню в статусе из generation.Status
+func (s *ProfileManagementStorage) CreateMenuGeneration(ctx context.Context, generation *models.MenuGeneration) error {
+	query := squirrel.Insert(menuGenerationsTableName).
+		Columns(menuGenerationsRequestIDColumn, menuGenerationsUserIDColumn, menuGenerationsStatusColumn).
+		Values(generation.RequestID, generation.UserID, string(generation.Status)).
+		Suffix("RETURNING " + menuGenerationsCreatedAtColumn).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "generate query error")
+	}
+
+	s.pinUser(generation.UserID)
+	var createdAt sql.NullTime
+	shard := s.getShard(generation.UserID)
+	err = s.onShard(shard, func() error {
+		return shard.QueryRow(ctx, queryText, args...).Scan(&createdAt)
+	})
+	if err != nil {
+		return errors.Wrap(err, "exec query error")
+	}
+
+	if createdAt.Valid {
+		generation.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return nil
+}
+
+// CompleteMenuGeneration переводит ожидающий запрос в итоговый статус.
+// Возвращает false, если запроса нет или он уже завершён (повторная доставка результата).
+func (s *ProfileManagementStorage) CompleteMenuGeneration(ctx context.Context, generation *models.MenuGeneration) (bool, error) {
+	var menu any
+	if generation.Menu != "" {
+		menu = generation.Menu
+	}
+
+	query := squirrel.Update(menuGenerationsTableName).
+		Set(menuGenerationsStatusColumn, string(generation.Status)).
+		Set(menuGenerationsErrorColumn, generation.Error).
+		Set(menuGenerationsMenuColumn, menu).
+		Set(menuGenerationsCompletedAtColumn, time.Now().UTC()).
+		Where(squirrel.Eq{
+			menuGenerationsRequestIDColumn: generation.RequestID,
+			menuGenerationsStatusColumn:    string(models.MenuGenerationStatusPending),
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "generate query error")
+	}
+
+	if generation.UserID != 0 {
+		s.pinUser(generation.UserID)
+	}
+	for _, shard := range s.menuGenerationShards(generation.UserID) {
+		result, err := shard.Exec(ctx, queryText, args...)
+		if err != nil {
+			return false, errors.Wrap(err, "exec query error")
+		}
+		if result.RowsAffected() > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetMenuGeneration ищет запрос генерации меню параллельно на всех шардах
+func (s *ProfileManagementStorage) GetMenuGeneration(ctx context.Context, requestID string) (*models.MenuGeneration, error) {
+	query := selectMenuGenerations().
+		Where(squirrel.Eq{menuGenerationsRequestIDColumn: requestID})
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	generation, err := readByID(ctx, s, func(ctx context.Context, shard *pgxpool.Pool) (*models.MenuGeneration, error) {
+		return scanMenuGeneration(shard.QueryRow(ctx, queryText, args...))
+	}, func(generation *models.MenuGeneration) int32 {
+		return generation.UserID
+	})
+	if err != nil {
+		return nil, notFound(err, "menu generation not found")
+	}
+
+	return generation, nil
+}
+
+// GetGeneratedMenus возвращает последние успешно сгенерированные меню пользователя
+func (s *ProfileManagementStorage) GetGeneratedMenus(ctx context.Context, userID int32, limit uint64) ([]*models.MenuGeneration, error) {
+	query := selectMenuGenerations().
+		Where(squirrel.Eq{
+			menuGenerationsUserIDColumn: userID,
+			menuGenerationsStatusColumn: string(models.MenuGenerationStatusCompleted),
+		}).
+		OrderBy(menuGenerationsCreatedAtColumn + " DESC").
+		Limit(limit)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	rows, err := queryShard(ctx, s, s.readShard(userID), func(ctx context.Context, shard *pgxpool.Pool) (pgx.Rows, error) {
+		return shard.Query(ctx, queryText, args...)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "query error")
+	}
+	defer rows.Close()
+
+	var generations []*models.MenuGeneration
+	for rows.Next() {
+		generation, err := scanMenuGeneration(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan row error")
+		}
+		generations = append(generations, generation)
+	}
+
+	return generations, nil
+}
+
+// menuGenerationShards возвращает шард пользователя, а если пользователь неизвестен - все шарды
+func (s *ProfileManagementStorage) menuGenerationShards(userID int32) []querier {
+	if userID != 0 {
+		return []querier{s.getShard(userID)}
+	}
+
+	shards := make([]querier, 0, len(s.shards))
+	for _, shard := range s.shards {
+		shards = append(shards, shard)
+	}
+	return shards
+}
+
+func selectMenuGenerations() squirrel.SelectBuilder {
+	return squirrel.Select(menuGenerationsRequestIDColumn+"::text", menuGenerationsUserIDColumn,
+		menuGenerationsStatusColumn, menuGenerationsErrorColumn, menuGenerationsMenuColumn+"::text",
+		menuGenerationsCreatedAtColumn, menuGenerationsCompletedAtColumn).
+		From(menuGenerationsTableName).
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func scanMenuGeneration(row pgx.Row) (*models.MenuGeneration, error) {
+	var generation models.MenuGeneration
+	var status string
+	var errorText, menu sql.NullString
+	var createdAt, completedAt sql.NullTime
+
+	err := row.Scan(&generation.RequestID, &generation.UserID, &status,
+		&errorText, &menu, &createdAt, &completedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	generation.Status = models.MenuGenerationStatus(status)
+	generation.Error = errorText.String
+	generation.Menu = menu.String
+	if createdAt.Valid {
+		generation.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	if completedAt.Valid {
+		generation.CompletedAt = completedAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return &generation, nil
+}
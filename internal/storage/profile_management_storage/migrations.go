@@ -0,0 +1,394 @@
+package profile_management_storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// undefinedTableCode код ошибки PostgreSQL при обращении к несуществующей таблице
+const undefinedTableCode = "42P01"
+
+// migrationsAdvisoryLockKey ключ advisory-блокировки, под которой шард мигрирует один экземпляр
+const migrationsAdvisoryLockKey int64 = 7342019
+
+// ErrIrreversibleMigration у последней применённой миграции нет отката
+var ErrIrreversibleMigration = errors.New("migration cannot be rolled back")
+
+// migration изменение схемы шарда; up и down выполняются в одной транзакции. Миграция без down необратима.
+type migration struct {
+	version int
+	name    string
+	up      []string
+	down    []string
+}
+
+// migrations применяются по возрастанию версии. Новую миграцию добавляют в конец, применённые не меняют.
+var migrations = []migration{
+	{
+		version: 1,
+		name:    "initial_schema",
+		up:      initialSchemaUp(),
+		// Схема создаётся и поверх существующих баз с данными, поэтому откат, удаляющий таблицы, не допускается
+		down: nil,
+	},
+	{
+		version: 2,
+		name:    "audit_events_actor_verified",
+		up: []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s BOOLEAN NOT NULL DEFAULT FALSE",
+			auditEventsTableName, auditEventsActorVerifiedColumn)},
+		down: []string{fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s",
+			auditEventsTableName, auditEventsActorVerifiedColumn)},
+	},
+}
+
+// MigrationStatus состояние миграции на шарде
+type MigrationStatus struct {
+	Shard   int
+	Version int
+	Name    string
+	// AppliedAt nil, если миграция ещё не применена
+	AppliedAt *time.Time
+}
+
+// MigrateUp применяет на каждом шарде миграции, которых там ещё нет. Экземпляры, стартующие
+// одновременно, ждут друг друга на advisory-блокировке.
+func (s *ProfileManagementStorage) MigrateUp(ctx context.Context) error {
+	for i, shard := range s.shards {
+		err := s.inTx(ctx, shard, func(tx pgx.Tx) error {
+			applied, err := lockMigrations(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			for _, m := range migrations {
+				if _, ok := applied[m.version]; ok {
+					continue
+				}
+				if err := execMigration(ctx, tx, m.up); err != nil {
+					return errors.Wrapf(err, "migration %d %s", m.version, m.name)
+				}
+				_, err := tx.Exec(ctx, fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES ($1, $2)",
+					schemaMigrationsTableName, schemaMigrationsVersionColumn, schemaMigrationsNameColumn), m.version, m.name)
+				if err != nil {
+					return errors.Wrapf(err, "record migration %d", m.version)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "migrate shard %d", i)
+		}
+	}
+	return nil
+}
+
+// MigrateDown откатывает на каждом шарде последнюю применённую миграцию и возвращает откаченные.
+// Необратимая миграция не откатывается: возвращается ErrIrreversibleMigration.
+func (s *ProfileManagementStorage) MigrateDown(ctx context.Context) ([]MigrationStatus, error) {
+	var rolledBack []MigrationStatus
+	for i, shard := range s.shards {
+		err := s.inTx(ctx, shard, func(tx pgx.Tx) error {
+			applied, err := lockMigrations(ctx, tx)
+			if err != nil {
+				return err
+			}
+
+			for j := len(migrations) - 1; j >= 0; j-- {
+				m := migrations[j]
+				appliedAt, ok := applied[m.version]
+				if !ok {
+					continue
+				}
+				if m.down == nil {
+					return errors.Wrapf(ErrIrreversibleMigration, "migration %d %s", m.version, m.name)
+				}
+				if err := execMigration(ctx, tx, m.down); err != nil {
+					return errors.Wrapf(err, "rollback migration %d %s", m.version, m.name)
+				}
+				_, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s = $1",
+					schemaMigrationsTableName, schemaMigrationsVersionColumn), m.version)
+				if err != nil {
+					return errors.Wrapf(err, "forget migration %d", m.version)
+				}
+				rolledBack = append(rolledBack, MigrationStatus{Shard: i, Version: m.version, Name: m.name, AppliedAt: &appliedAt})
+				return nil
+			}
+			return nil
+		})
+		if err != nil {
+			return rolledBack, errors.Wrapf(err, "rollback shard %d", i)
+		}
+	}
+	return rolledBack, nil
+}
+
+// MigrationStatus состояние всех миграций на каждом шарде; схему не меняет
+func (s *ProfileManagementStorage) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	for i, shard := range s.shards {
+		applied, err := queryShard(ctx, s, shard, func(ctx context.Context, shard *pgxpool.Pool) (map[int]time.Time, error) {
+			return appliedMigrations(ctx, shard)
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "shard %d", i)
+		}
+
+		for _, m := range migrations {
+			status := MigrationStatus{Shard: i, Version: m.version, Name: m.name}
+			if appliedAt, ok := applied[m.version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+// lockMigrations берёт блокировку до конца транзакции, создаёт таблицу версий и читает применённые миграции
+func lockMigrations(ctx context.Context, tx pgx.Tx) (map[int]time.Time, error) {
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationsAdvisoryLockKey); err != nil {
+		return nil, errors.Wrap(err, "lock migrations")
+	}
+
+	_, err := tx.Exec(ctx, fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s INT PRIMARY KEY,
+			%s VARCHAR(255) NOT NULL,
+			%s TIMESTAMP NOT NULL DEFAULT NOW()
+		)`, schemaMigrationsTableName, schemaMigrationsVersionColumn, schemaMigrationsNameColumn,
+		schemaMigrationsAppliedAtColumn))
+	if err != nil {
+		return nil, errors.Wrap(err, "init schema_migrations table")
+	}
+
+	return appliedMigrations(ctx, tx)
+}
+
+// appliedMigrations время применения миграций по версиям; без таблицы версий ни одна не применена
+func appliedMigrations(ctx context.Context, shard querier) (map[int]time.Time, error) {
+	rows, err := shard.Query(ctx, fmt.Sprintf("SELECT %s, %s FROM %s",
+		schemaMigrationsVersionColumn, schemaMigrationsAppliedAtColumn, schemaMigrationsTableName))
+	if isUndefinedTable(err) {
+		return map[int]time.Time{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "select query error")
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, errors.Wrap(err, "scan row error")
+		}
+		applied[version] = appliedAt
+	}
+
+	if err := rows.Err(); isUndefinedTable(err) {
+		return map[int]time.Time{}, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "rows error")
+	}
+	return applied, nil
+}
+
+func isUndefinedTable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == undefinedTableCode
+}
+
+func execMigration(ctx context.Context, tx pgx.Tx, statements []string) error {
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initialSchemaUp схема на момент появления миграций. Все запросы идемпотентны, поэтому миграция
+// применяется и к базам, созданным до неё.
+func initialSchemaUp() []string {
+	usersSQL := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s SERIAL PRIMARY KEY,
+			%s VARCHAR(50) UNIQUE NOT NULL,
+			%s VARCHAR(255) NOT NULL,
+			%s INT,
+			%s INT,
+			%s JSONB,
+			%s INT,
+			%s JSONB,
+			%s TIMESTAMP DEFAULT NOW(),
+			%s TIMESTAMP,
+			%s INT NOT NULL DEFAULT 1
+		)`, usersTableName, usersIDColumn, usersUsernameColumn, usersPasswordHashColumn,
+		usersHeightColumn, usersWeightColumn, usersBJUColumn, usersBudgetColumn,
+		usersPreferencesColumn, usersCreatedAtColumn, usersDeletedAtColumn, usersVersionColumn)
+
+	productsSQL := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s SERIAL PRIMARY KEY,
+			%s INT NOT NULL,
+			%s VARCHAR(100) NOT NULL,
+			%s INT,
+			%s INT,
+			%s INT,
+			%s INT,
+			%s TIMESTAMP DEFAULT NOW(),
+			%s TIMESTAMP,
+			%s INT NOT NULL DEFAULT 1
+		)`, productsTableName, productsIDColumn, productsUserIDColumn,
+		productsNameColumn, productsCaloriesColumn, productsProteinColumn,
+		productsFatColumn, productsCarbsColumn, productsCreatedAtColumn, productsDeletedAtColumn,
+		productsVersionColumn)
+
+	mealsSQL := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s SERIAL PRIMARY KEY,
+			%s INT NOT NULL,
+			%s VARCHAR(100) NOT NULL,
+			%s INT[],
+			%s TIMESTAMP DEFAULT NOW(),
+			%s TIMESTAMP,
+			%s INT NOT NULL DEFAULT 1
+		)`, mealsTableName, mealsIDColumn, mealsUserIDColumn,
+		mealsNameColumn, mealsProductIDsColumn, mealsCreatedAtColumn, mealsDeletedAtColumn, mealsVersionColumn)
+
+	// Состав блюд; продукты и блюда пользователя лежат на одном шарде, поэтому внешние ключи работают
+	mealProductsSQL := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s INT NOT NULL REFERENCES %s (%s) ON DELETE CASCADE,
+			%s INT NOT NULL REFERENCES %s (%s) ON DELETE RESTRICT,
+			%s INT CHECK (%s > 0),
+			PRIMARY KEY (%s, %s)
+		)`, mealProductsTableName,
+		mealProductsMealIDColumn, mealsTableName, mealsIDColumn,
+		mealProductsProductIDColumn, productsTableName, productsIDColumn,
+		mealProductsQuantityColumn, mealProductsQuantityColumn,
+		mealProductsMealIDColumn, mealProductsProductIDColumn)
+
+	mealProductsIndexSQL := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_%s_idx ON %s (%s)",
+		mealProductsTableName, mealProductsProductIDColumn, mealProductsTableName, mealProductsProductIDColumn)
+
+	// Перенос старого meals.product_ids в meal_products; ссылки на несуществующие продукты отбрасываются.
+	// Миграция выполняется один раз, поэтому старая колонка не трогается и остаётся как резервная копия.
+	mealProductsBackfillSQL := []string{
+		fmt.Sprintf(`
+		INSERT INTO %[1]s (%[2]s, %[3]s)
+		SELECT m.%[4]s, p.%[5]s
+		FROM %[6]s m
+		CROSS JOIN LATERAL unnest(m.%[7]s) AS legacy(product_id)
+		JOIN %[8]s p ON p.%[5]s = legacy.product_id AND p.%[9]s = m.%[10]s
+		ON CONFLICT DO NOTHING`,
+			mealProductsTableName, mealProductsMealIDColumn, mealProductsProductIDColumn,
+			mealsIDColumn, productsIDColumn, mealsTableName, mealsProductIDsColumn,
+			productsTableName, productsUserIDColumn, mealsUserIDColumn),
+	}
+
+	// Запросы генерации меню хранятся на шарде данных пользователя
+	menuGenerationsSQL := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s UUID PRIMARY KEY,
+			%s INT NOT NULL,
+			%s VARCHAR(16) NOT NULL,
+			%s TEXT,
+			%s JSONB,
+			%s TIMESTAMP DEFAULT NOW(),
+			%s TIMESTAMP
+		)`, menuGenerationsTableName, menuGenerationsRequestIDColumn, menuGenerationsUserIDColumn,
+		menuGenerationsStatusColumn, menuGenerationsErrorColumn, menuGenerationsMenuColumn,
+		menuGenerationsCreatedAtColumn, menuGenerationsCompletedAtColumn)
+
+	menuGenerationsIndexSQL := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_%s_idx ON %s (%s, %s DESC)",
+		menuGenerationsTableName, menuGenerationsUserIDColumn, menuGenerationsTableName,
+		menuGenerationsUserIDColumn, menuGenerationsCreatedAtColumn)
+
+	// Сообщения Kafka, не опубликованные после всех попыток; хранятся на шарде пользователя события
+	deadLettersSQL := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s UUID PRIMARY KEY,
+			%s INT NOT NULL,
+			%s VARCHAR(255) NOT NULL,
+			%s BYTEA,
+			%s BYTEA NOT NULL,
+			%s JSONB,
+			%s TEXT,
+			%s INT NOT NULL,
+			%s TIMESTAMP DEFAULT NOW()
+		)`, deadLettersTableName, deadLettersIDColumn, deadLettersUserIDColumn, deadLettersTopicColumn,
+		deadLettersKeyColumn, deadLettersValueColumn, deadLettersHeadersColumn, deadLettersErrorColumn,
+		deadLettersAttemptsColumn, deadLettersCreatedAtColumn)
+
+	deadLettersIndexSQL := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_%s_idx ON %s (%s DESC)",
+		deadLettersTableName, deadLettersCreatedAtColumn, deadLettersTableName, deadLettersCreatedAtColumn)
+
+	// Первые ответы на создающие запросы с Idempotency-Key; хранятся на шарде пользователя
+	idempotencyKeysSQL := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s INT NOT NULL,
+			%s VARCHAR(64) NOT NULL,
+			%s VARCHAR(255) NOT NULL,
+			%s BYTEA NOT NULL,
+			%s BYTEA,
+			%s TIMESTAMP DEFAULT NOW(),
+			%s TIMESTAMP NOT NULL,
+			PRIMARY KEY (%s, %s, %s)
+		)`, idempotencyKeysTableName, idempotencyKeysUserIDColumn, idempotencyKeysMethodColumn,
+		idempotencyKeysKeyColumn, idempotencyKeysRequestHashColumn, idempotencyKeysResponseColumn,
+		idempotencyKeysCreatedAtColumn, idempotencyKeysExpiresAtColumn,
+		idempotencyKeysUserIDColumn, idempotencyKeysMethodColumn, idempotencyKeysKeyColumn)
+
+	idempotencyKeysIndexSQL := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_%s_idx ON %s (%s)",
+		idempotencyKeysTableName, idempotencyKeysExpiresAtColumn, idempotencyKeysTableName, idempotencyKeysExpiresAtColumn)
+
+	// Журнал аудита изменений профиля; только дополняется, хранится на шарде пользователя
+	auditEventsSQL := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			%s UUID PRIMARY KEY,
+			%s INT NOT NULL,
+			%s VARCHAR(255) NOT NULL,
+			%s VARCHAR(32) NOT NULL,
+			%s VARCHAR(32) NOT NULL,
+			%s VARCHAR(64) NOT NULL,
+			%s JSONB,
+			%s VARCHAR(64),
+			%s TIMESTAMP DEFAULT NOW()
+		)`, auditEventsTableName, auditEventsIDColumn, auditEventsUserIDColumn, auditEventsActorColumn,
+		auditEventsActionColumn, auditEventsEntityTypeColumn, auditEventsEntityIDColumn,
+		auditEventsChangesColumn, auditEventsRequestIDColumn, auditEventsCreatedAtColumn)
+
+	auditEventsIndexSQL := []string{
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_%s_idx ON %s (%s, %s DESC)",
+			auditEventsTableName, auditEventsUserIDColumn, auditEventsTableName,
+			auditEventsUserIDColumn, auditEventsCreatedAtColumn),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s_%s_idx ON %s (%s DESC)",
+			auditEventsTableName, auditEventsCreatedAtColumn, auditEventsTableName, auditEventsCreatedAtColumn),
+	}
+
+	// Колонки, добавленные после создания таблиц, для существующих баз
+	alterSQL := []string{
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s TIMESTAMP", usersTableName, usersDeletedAtColumn),
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s TIMESTAMP", productsTableName, productsDeletedAtColumn),
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s TIMESTAMP", mealsTableName, mealsDeletedAtColumn),
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s INT NOT NULL DEFAULT 1", usersTableName, usersVersionColumn),
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s INT NOT NULL DEFAULT 1", productsTableName, productsVersionColumn),
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s INT NOT NULL DEFAULT 1", mealsTableName, mealsVersionColumn),
+	}
+
+	up := []string{usersSQL, productsSQL, mealsSQL}
+	up = append(up, alterSQL...)
+	up = append(up, mealProductsSQL, mealProductsIndexSQL)
+	up = append(up, mealProductsBackfillSQL...)
+	up = append(up, menuGenerationsSQL, menuGenerationsIndexSQL, deadLettersSQL, deadLettersIndexSQL,
+		idempotencyKeysSQL, idempotencyKeysIndexSQL, auditEventsSQL)
+	return append(up, auditEventsIndexSQL...)
+}
@@ -0,0 +1,15 @@
+package profile_management_storage
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMigrationsOrderedAndInitialIrreversible(t *testing.T) {
+	for i, m := range migrations {
+		assert.Equal(t, m.version, i+1, "migration %s", m.name)
+	}
+	// Откат начальной схемы удалил бы все таблицы вместе с данными
+	assert.Assert(t, migrations[0].down == nil)
+}
@@ -12,6 +12,8 @@ const (
 	usersBudgetColumn       = "budget"
 	usersPreferencesColumn  = "preferences"
 	usersCreatedAtColumn    = "created_at"
+	usersDeletedAtColumn    = "deleted_at"
+	usersVersionColumn      = "version"
 )
 
 // Products table constants
@@ -25,6 +27,8 @@ const (
 	productsFatColumn       = "fat"
 	productsCarbsColumn     = "carbs"
 	productsCreatedAtColumn = "created_at"
+	productsDeletedAtColumn = "deleted_at"
+	productsVersionColumn   = "version"
 )
 
 // Meals table constants
@@ -33,6 +37,77 @@ const (
 	mealsIDColumn         = "id"
 	mealsUserIDColumn     = "user_id"
 	mealsNameColumn       = "name"
-	mealsProductIDsColumn = "product_ids"
+	mealsProductIDsColumn = "product_ids" // устарела, состав блюд хранится в meal_products
 	mealsCreatedAtColumn  = "created_at"
+	mealsDeletedAtColumn  = "deleted_at"
+	mealsVersionColumn    = "version"
+)
+
+// Meal products table constants
+const (
+	mealProductsTableName       = "meal_products"
+	mealProductsMealIDColumn    = "meal_id"
+	mealProductsProductIDColumn = "product_id"
+	mealProductsQuantityColumn  = "quantity"
+)
+
+// Menu generations table constants
+const (
+	menuGenerationsTableName         = "menu_generations"
+	menuGenerationsRequestIDColumn   = "request_id"
+	menuGenerationsUserIDColumn      = "user_id"
+	menuGenerationsStatusColumn      = "status"
+	menuGenerationsErrorColumn       = "error"
+	menuGenerationsMenuColumn        = "menu"
+	menuGenerationsCreatedAtColumn   = "created_at"
+	menuGenerationsCompletedAtColumn = "completed_at"
+)
+
+// Kafka dead letters table constants
+const (
+	deadLettersTableName       = "kafka_dead_letters"
+	deadLettersIDColumn        = "id"
+	deadLettersUserIDColumn    = "user_id"
+	deadLettersTopicColumn     = "topic"
+	deadLettersKeyColumn       = "message_key"
+	deadLettersValueColumn     = "message_value"
+	deadLettersHeadersColumn   = "headers"
+	deadLettersErrorColumn     = "error"
+	deadLettersAttemptsColumn  = "attempts"
+	deadLettersCreatedAtColumn = "created_at"
+)
+
+// Idempotency keys table constants
+const (
+	idempotencyKeysTableName         = "idempotency_keys"
+	idempotencyKeysUserIDColumn      = "user_id"
+	idempotencyKeysMethodColumn      = "method"
+	idempotencyKeysKeyColumn         = "idempotency_key"
+	idempotencyKeysRequestHashColumn = "request_hash"
+	idempotencyKeysResponseColumn    = "response"
+	idempotencyKeysCreatedAtColumn   = "created_at"
+	idempotencyKeysExpiresAtColumn   = "expires_at"
+)
+
+// Audit events table constants
+const (
+	auditEventsTableName           = "audit_events"
+	auditEventsIDColumn            = "id"
+	auditEventsUserIDColumn        = "user_id"
+	auditEventsActorColumn         = "actor"
+	auditEventsActorVerifiedColumn = "actor_verified"
+	auditEventsActionColumn        = "action"
+	auditEventsEntityTypeColumn    = "entity_type"
+	auditEventsEntityIDColumn      = "entity_id"
+	auditEventsChangesColumn       = "changes"
+	auditEventsRequestIDColumn     = "request_id"
+	auditEventsCreatedAtColumn     = "created_at"
+)
+
+// Schema migrations table constants
+const (
+	schemaMigrationsTableName       = "schema_migrations"
+	schemaMigrationsVersionColumn   = "version"
+	schemaMigrationsNameColumn      = "name"
+	schemaMigrationsAppliedAtColumn = "applied_at"
 )
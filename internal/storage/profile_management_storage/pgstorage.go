@@ -4,38 +4,72 @@ import (
 	"context"
 	"fmt"
 	"hash/fnv"
+	"slices"
+	"time"
 
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 )
 
 type ProfileManagementStorage struct {
 	shards        []*pgxpool.Pool
 	bucketCount   int
 	bucketToShard []int
+	// replicas реплики шардов в порядке shards; чтения уходят на них, записи - на primary
+	replicas []*replicaSet
+	pins     *writePins
+	// breakers автоматы отключения primary и реплик
+	breakers map[*pgxpool.Pool]*circuitBreaker
 }
 
-func NewProfileManagementStorage(connStrings []string, bucketCount int) (*ProfileManagementStorage, error) {
-	if len(connStrings) == 0 {
+// NewProfileManagementStorage подключается к шардам и применяет недостающие миграции
+func NewProfileManagementStorage(connections []ShardConnection, bucketCount int, readYourWritesWindow time.Duration, breakerSettings CircuitBreakerSettings) (*ProfileManagementStorage, error) {
+	storage, err := ConnectProfileManagementStorage(connections, bucketCount, readYourWritesWindow, breakerSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := storage.MigrateUp(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return storage, nil
+}
+
+// ConnectProfileManagementStorage подключается к шардам и их репликам, не меняя схему. readYourWritesWindow -
+// сколько после записи чтения пользователя идут на primary; 0 отключает закрепление.
+func ConnectProfileManagementStorage(connections []ShardConnection, bucketCount int, readYourWritesWindow time.Duration, breakerSettings CircuitBreakerSettings) (*ProfileManagementStorage, error) {
+	if len(connections) == 0 {
 		return nil, errors.New("необходимо указать хотя бы один шард")
 	}
 
-	if bucketCount < len(connStrings) {
+	if bucketCount < len(connections) {
 		return nil, errors.New("количество бакетов должно быть >= количества шардов")
 	}
 
-	shards := make([]*pgxpool.Pool, 0, len(connStrings))
-	for i, connString := range connStrings {
-		config, err := pgxpool.ParseConfig(connString)
+	shards := make([]*pgxpool.Pool, 0, len(connections))
+	replicas := make([]*replicaSet, 0, len(connections))
+	breakers := make(map[*pgxpool.Pool]*circuitBreaker)
+	for i, connection := range connections {
+		db, err := newPool(connection.Primary, connection.Pool)
 		if err != nil {
-			return nil, errors.Wrapf(err, "ошибка парсинга конфига для шарда %d", i)
+			return nil, errors.Wrapf(err, "шард %d", i)
 		}
+		shards = append(shards, db)
+		breakers[db] = newCircuitBreaker(circuitName(i, -1), i, breakerSettings)
 
-		db, err := pgxpool.NewWithConfig(context.Background(), config)
+		set, err := newReplicaSet(i, connection.Replicas, connection.Pool, breakerSettings)
 		if err != nil {
-			return nil, errors.Wrapf(err, "ошибка подключения к шарду %d", i)
+			return nil, err
+		}
+		replicas = append(replicas, set)
+		for _, r := range set.replicas {
+			breakers[r.pool] = r.breaker
 		}
-		shards = append(shards, db)
 	}
 
 	bucketToShard := make([]int, bucketCount)
@@ -43,18 +77,14 @@ func NewProfileManagementStorage(connStrings []string, bucketCount int) (*Profil
 		bucketToShard[i] = i % len(shards)
 	}
 
-	storage := &ProfileManagementStorage{
+	return &ProfileManagementStorage{
 		shards:        shards,
 		bucketCount:   bucketCount,
 		bucketToShard: bucketToShard,
-	}
-
-	err := storage.initTables()
-	if err != nil {
-		return nil, err
-	}
-
-	return storage, nil
+		replicas:      replicas,
+		pins:          newWritePins(readYourWritesWindow),
+		breakers:      breakers,
+	}, nil
 }
 
 // getBucket вычисляет номер бакета для user_id используя хеш-функцию
@@ -86,73 +116,113 @@ func (s *ProfileManagementStorage) getBucketByUsername(username string) int {
 }
 
 func (s *ProfileManagementStorage) getShard(userID int32) *pgxpool.Pool {
-	bucket := s.getBucket(userID)
-	shardIndex := s.bucketToShard[bucket]
-	return s.shards[shardIndex]
+	return s.shards[s.getShardIndex(userID)]
+}
+
+func (s *ProfileManagementStorage) getShardIndex(userID int32) int {
+	return s.bucketToShard[s.getBucket(userID)]
 }
 
 func (s *ProfileManagementStorage) getShardByUsername(username string) *pgxpool.Pool {
-	bucket := s.getBucketByUsername(username)
-	shardIndex := s.bucketToShard[bucket]
-	return s.shards[shardIndex]
+	return s.shards[s.getShardIndexByUsername(username)]
 }
 
-func (s *ProfileManagementStorage) initTables() error {
-	usersSQL := fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s (
-			%s SERIAL PRIMARY KEY,
-			%s VARCHAR(50) UNIQUE NOT NULL,
-			%s VARCHAR(255) NOT NULL,
-			%s INT,
-			%s INT,
-			%s JSONB,
-			%s INT,
-			%s JSONB,
-			%s TIMESTAMP DEFAULT NOW()
-		)`, usersTableName, usersIDColumn, usersUsernameColumn, usersPasswordHashColumn,
-		usersHeightColumn, usersWeightColumn, usersBJUColumn, usersBudgetColumn,
-		usersPreferencesColumn, usersCreatedAtColumn)
-
-	productsSQL := fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s (
-			%s SERIAL PRIMARY KEY,
-			%s INT NOT NULL,
-			%s VARCHAR(100) NOT NULL,
-			%s INT,
-			%s INT,
-			%s INT,
-			%s INT,
-			%s TIMESTAMP DEFAULT NOW()
-		)`, productsTableName, productsIDColumn, productsUserIDColumn,
-		productsNameColumn, productsCaloriesColumn, productsProteinColumn,
-		productsFatColumn, productsCarbsColumn, productsCreatedAtColumn)
-
-	mealsSQL := fmt.Sprintf(`
-		CREATE TABLE IF NOT EXISTS %s (
-			%s SERIAL PRIMARY KEY,
-			%s INT NOT NULL,
-			%s VARCHAR(100) NOT NULL,
-			%s INT[],
-			%s TIMESTAMP DEFAULT NOW()
-		)`, mealsTableName, mealsIDColumn, mealsUserIDColumn,
-		mealsNameColumn, mealsProductIDsColumn, mealsCreatedAtColumn)
-
-	for i, shard := range s.shards {
-		_, err := shard.Exec(context.Background(), usersSQL)
-		if err != nil {
-			return errors.Wrapf(err, "init users table on shard %d", i)
-		}
+func (s *ProfileManagementStorage) getShardIndexByUsername(username string) int {
+	return s.bucketToShard[s.getBucketByUsername(username)]
+}
 
-		_, err = shard.Exec(context.Background(), productsSQL)
-		if err != nil {
-			return errors.Wrapf(err, "init products table on shard %d", i)
-		}
+// findUserShard возвращает шард, на котором хранится строка пользователя.
+// Сначала проверяется шард по id, затем параллельно остальные шарды.
+func (s *ProfileManagementStorage) findUserShard(ctx context.Context, id int32, where squirrel.Sqlizer) (*pgxpool.Pool, error) {
+	query := squirrel.Select("1").
+		From(usersTableName).
+		Where(squirrel.Eq{usersIDColumn: id}).
+		Where(where).
+		PlaceholderFormat(squirrel.Dollar)
 
-		_, err = shard.Exec(context.Background(), mealsSQL)
-		if err != nil {
-			return errors.Wrapf(err, "init meals table on shard %d", i)
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	_, index, err := lookupUser(ctx, s, s.shards, id, func(ctx context.Context, shard *pgxpool.Pool) (int, error) {
+		var exists int
+		err := shard.QueryRow(ctx, queryText, args...).Scan(&exists)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return 0, errors.Wrap(err, "scan row error")
 		}
+		return exists, err
+	})
+	if err != nil {
+		return nil, notFound(err, "user not found")
+	}
+
+	return s.shards[index], nil
+}
+
+// querier общий интерфейс пула шарда и транзакции
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// queryAllShards выполняет query на всех шардах параллельно и склеивает результаты в порядке шардов.
+// Ошибка любого шарда отменяет остальные запросы.
+func queryAllShards[T any](ctx context.Context, shards []*pgxpool.Pool, query func(ctx context.Context, shard *pgxpool.Pool) ([]T, error)) ([]T, error) {
+	results := make([][]T, len(shards))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, shard := range shards {
+		group.Go(func() error {
+			result, err := query(groupCtx, shard)
+			if err != nil {
+				return &ShardError{Shard: i, Err: err}
+			}
+			results[i] = result
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(results...), nil
+}
+
+// inTx выполняет fn в транзакции на указанном шарде через его автомат отключения
+func (s *ProfileManagementStorage) inTx(ctx context.Context, shard *pgxpool.Pool, fn func(tx pgx.Tx) error) error {
+	return s.onShard(shard, func() error {
+		return runTx(ctx, shard, fn)
+	})
+}
+
+func runTx(ctx context.Context, shard *pgxpool.Pool, fn func(tx pgx.Tx) error) error {
+	tx, err := shard.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "begin tx error")
 	}
+	defer tx.Rollback(ctx)
 
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "commit tx error")
+	}
 	return nil
 }
+
+// execTx собирает запрос и выполняет его в транзакции
+func execTx(ctx context.Context, tx pgx.Tx, query squirrel.Sqlizer) (int64, error) {
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "generate query error")
+	}
+
+	result, err := tx.Exec(ctx, queryText, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "exec query error")
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,61 @@
+package profile_management_storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// PoolSettings настройки пула подключений шарда; нулевые значения оставляют значения pgxpool по умолчанию
+type PoolSettings struct {
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+}
+
+// newPool создаёт пул по строке подключения и применяет к нему settings
+func newPool(connString string, settings PoolSettings) (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, errors.Wrap(err, "ошибка парсинга конфига")
+	}
+	if err := settings.apply(config); err != nil {
+		return nil, err
+	}
+
+	db, err := pgxpool.NewWithConfig(context.Background(), config)
+	if err != nil {
+		return nil, errors.Wrap(err, "ошибка подключения")
+	}
+	return db, nil
+}
+
+func (p PoolSettings) apply(config *pgxpool.Config) error {
+	if p.MaxConns < 0 || p.MinConns < 0 {
+		return errors.New("размер пула не может быть отрицательным")
+	}
+	if p.MaxConns > 0 {
+		config.MaxConns = p.MaxConns
+	}
+	if p.MinConns > 0 {
+		config.MinConns = p.MinConns
+	}
+	if config.MinConns > config.MaxConns {
+		return errors.Errorf("min_conns (%d) больше max_conns (%d)", config.MinConns, config.MaxConns)
+	}
+
+	if p.MaxConnLifetime > 0 {
+		config.MaxConnLifetime = p.MaxConnLifetime
+	}
+	if p.MaxConnIdleTime > 0 {
+		config.MaxConnIdleTime = p.MaxConnIdleTime
+	}
+	if p.HealthCheckPeriod > 0 {
+		config.HealthCheckPeriod = p.HealthCheckPeriod
+	}
+	return nil
+}
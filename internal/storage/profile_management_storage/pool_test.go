@@ -0,0 +1,37 @@
+package profile_management_storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gotest.tools/v3/assert"
+)
+
+func TestPoolSettingsApply(t *testing.T) {
+	config, err := pgxpool.ParseConfig("postgres://postgres@localhost:5432/postgres")
+	assert.NilError(t, err)
+	defaultIdle := config.MaxConnIdleTime
+
+	err = PoolSettings{
+		MaxConns:          20,
+		MinConns:          2,
+		MaxConnLifetime:   time.Hour,
+		HealthCheckPeriod: 15 * time.Second,
+	}.apply(config)
+	assert.NilError(t, err)
+	assert.Equal(t, config.MaxConns, int32(20))
+	assert.Equal(t, config.MinConns, int32(2))
+	assert.Equal(t, config.MaxConnLifetime, time.Hour)
+	assert.Equal(t, config.HealthCheckPeriod, 15*time.Second)
+	// Незаданные настройки остаются значениями pgxpool по умолчанию
+	assert.Equal(t, config.MaxConnIdleTime, defaultIdle)
+}
+
+func TestPoolSettingsRejectMinAboveMax(t *testing.T) {
+	config, err := pgxpool.ParseConfig("postgres://postgres@localhost:5432/postgres")
+	assert.NilError(t, err)
+
+	err = PoolSettings{MaxConns: 2, MinConns: 5}.apply(config)
+	assert.ErrorContains(t, err, "min_conns (5) больше max_conns (2)")
+}
@@ -3,30 +3,32 @@ package profile_management_storage
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
 )
 
-func (s *ProfileManagementStorage) CreateProduct(ctx context.Context, product *models.Product) error {
-	query := squirrel.Insert(productsTableName).
-		Columns(productsUserIDColumn, productsNameColumn, productsCaloriesColumn,
-			productsProteinColumn, productsFatColumn, productsCarbsColumn).
-		Values(product.UserID, product.Name, product.Calories,
-			product.Protein, product.Fat, product.Carbs).
-		Suffix("RETURNING " + productsIDColumn + ", " + productsCreatedAtColumn).
-		PlaceholderFormat(squirrel.Dollar)
+// ErrMealWouldBeEmpty удаление продукта оставило бы блюдо без продуктов
+var ErrMealWouldBeEmpty = errors.New("product is the only product of a meal")
 
-	queryText, args, err := query.ToSql()
+func (s *ProfileManagementStorage) CreateProduct(ctx context.Context, product *models.Product) error {
+	queryText, args, err := createProductQuery(product)
 	if err != nil {
-		return errors.Wrap(err, "generate query error")
+		return err
 	}
 
+	s.pinUser(product.UserID)
 	shard := s.getShard(product.UserID)
 	var createdAt sql.NullTime
-	err = shard.QueryRow(ctx, queryText, args...).Scan(&product.ID, &createdAt)
+	err = s.onShard(shard, func() error {
+		return shard.QueryRow(ctx, queryText, args...).Scan(&product.ID, &createdAt, &product.Version)
+	})
 	if err != nil {
 		return errors.Wrap(err, "exec query error")
 	}
@@ -38,99 +40,193 @@ func (s *ProfileManagementStorage) CreateProduct(ctx context.Context, product *m
 	return nil
 }
 
+// CreateProducts вставляет продукты пользователя одной транзакцией на его шарде
+func (s *ProfileManagementStorage) CreateProducts(ctx context.Context, userID int32, products []*models.Product) error {
+	s.pinUser(userID)
+	return s.inTx(ctx, s.getShard(userID), func(tx pgx.Tx) error {
+		for _, product := range products {
+			product.UserID = userID
+			queryText, args, err := createProductQuery(product)
+			if err != nil {
+				return err
+			}
+
+			var createdAt sql.NullTime
+			err = tx.QueryRow(ctx, queryText, args...).Scan(&product.ID, &createdAt, &product.Version)
+			if err != nil {
+				return errors.Wrap(err, "exec query error")
+			}
+
+			if createdAt.Valid {
+				product.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
+			}
+		}
+		return nil
+	})
+}
+
+func createProductQuery(product *models.Product) (string, []interface{}, error) {
+	query := squirrel.Insert(productsTableName).
+		Columns(productsUserIDColumn, productsNameColumn, productsCaloriesColumn,
+			productsProteinColumn, productsFatColumn, productsCarbsColumn).
+		Values(product.UserID, product.Name, product.Calories,
+			product.Protein, product.Fat, product.Carbs).
+		Suffix("RETURNING " + productsIDColumn + ", " + productsCreatedAtColumn + ", " + productsVersionColumn).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "generate query error")
+	}
+
+	return queryText, args, nil
+}
+
+// productColumns колонки продукта в порядке сканирования scanProduct
+var productColumns = []string{productsIDColumn, productsUserIDColumn, productsNameColumn,
+	productsCaloriesColumn, productsProteinColumn, productsFatColumn,
+	productsCarbsColumn, productsCreatedAtColumn, productsVersionColumn}
+
 func (s *ProfileManagementStorage) GetProductsByUserID(ctx context.Context, userID int32) ([]*models.Product, error) {
-	query := squirrel.Select(productsIDColumn, productsUserIDColumn, productsNameColumn,
-		productsCaloriesColumn, productsProteinColumn, productsFatColumn,
-		productsCarbsColumn, productsCreatedAtColumn).
+	query := squirrel.Select(productColumns...).
 		From(productsTableName).
-		Where(squirrel.Eq{productsUserIDColumn: userID}).
+		Where(squirrel.Eq{productsUserIDColumn: userID, productsDeletedAtColumn: nil}).
 		PlaceholderFormat(squirrel.Dollar)
 
-	queryText, args, err := query.ToSql()
+	return queryShard(ctx, s, s.readShard(userID), func(ctx context.Context, shard *pgxpool.Pool) ([]*models.Product, error) {
+		return selectProducts(ctx, shard, query)
+	})
+}
+
+// GetProductByID ищет продукт параллельно на всех шардах. id уникальны только в пределах шарда:
+// при совпадении возвращается продукт младшего шарда.
+func (s *ProfileManagementStorage) GetProductByID(ctx context.Context, id int32) (*models.Product, error) {
+	lookup, err := productByIDLookup(id)
 	if err != nil {
-		return nil, errors.Wrap(err, "generate query error")
+		return nil, err
 	}
 
-	shard := s.getShard(userID)
-	rows, err := shard.Query(ctx, queryText, args...)
+	product, err := readByID(ctx, s, lookup, func(product *models.Product) int32 {
+		return product.UserID
+	})
 	if err != nil {
-		return nil, errors.Wrap(err, "query error")
+		return nil, notFound(err, "product not found")
 	}
-	defer rows.Close()
 
-	var products []*models.Product
-	for rows.Next() {
-		var product models.Product
-		var calories, protein, fat, carbs sql.NullInt32
-		var createdAt sql.NullTime
-
-		err := rows.Scan(
-			&product.ID, &product.UserID, &product.Name,
-			&calories, &protein, &fat,
-			&carbs, &createdAt,
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "scan row error")
-		}
+	return product, nil
+}
 
-		if calories.Valid {
-			product.Calories = &calories.Int32
-		}
-		if protein.Valid {
-			product.Protein = &protein.Int32
-		}
-		if fat.Valid {
-			product.Fat = &fat.Int32
-		}
-		if carbs.Valid {
-			product.Carbs = &carbs.Int32
-		}
+// getPrimaryProductByID ищет продукт только на primary: изменениям нужна актуальная запись.
+// Как и GetProductByID, при совпадении id выбирает продукт младшего шарда.
+func (s *ProfileManagementStorage) getPrimaryProductByID(ctx context.Context, id int32) (*models.Product, error) {
+	lookup, err := productByIDLookup(id)
+	if err != nil {
+		return nil, err
+	}
 
-		if createdAt.Valid {
-			product.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
-		}
+	product, _, err := gatherLowest(ctx, s.shards, s.shardIndexes(), guard(s, lookup))
+	if err != nil {
+		return nil, notFound(err, "product not found")
+	}
+
+	return product, nil
+}
 
-		products = append(products, &product)
+func productByIDLookup(id int32) (func(ctx context.Context, shard *pgxpool.Pool) (*models.Product, error), error) {
+	queryText, args, err := squirrel.Select(productColumns...).
+		From(productsTableName).
+		Where(squirrel.Eq{productsIDColumn: id, productsDeletedAtColumn: nil}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
 	}
 
-	return products, nil
+	return func(ctx context.Context, shard *pgxpool.Pool) (*models.Product, error) {
+		return scanProduct(shard.QueryRow(ctx, queryText, args...))
+	}, nil
 }
 
-func (s *ProfileManagementStorage) GetProductByID(ctx context.Context, id int32) (*models.Product, error) {
-	query := squirrel.Select(productsIDColumn, productsUserIDColumn, productsNameColumn,
-		productsCaloriesColumn, productsProteinColumn, productsFatColumn,
-		productsCarbsColumn, productsCreatedAtColumn).
+// GetProductsByIDs возвращает найденные продукты из ids, упорядоченные по id; отсутствующие пропускаются.
+// Владелец продуктов неизвестен, поэтому все шарды опрашиваются параллельно одним запросом каждый.
+// id уникальны только в пределах шарда: при совпадении возвращается продукт младшего шарда, а остальные
+// отбрасываются. Продукты известного владельца читайте через GetUserProductsByIDs.
+func (s *ProfileManagementStorage) GetProductsByIDs(ctx context.Context, ids []int32) ([]*models.Product, error) {
+	return readAllByIDs(ctx, s, ids, func(ctx context.Context, shard *pgxpool.Pool, ids []int32) ([]*models.Product, error) {
+		return selectProducts(ctx, shard, squirrel.Select(productColumns...).
+			From(productsTableName).
+			Where(squirrel.Expr(productsIDColumn+" = ANY(?)", ids)).
+			Where(squirrel.Eq{productsDeletedAtColumn: nil}).
+			PlaceholderFormat(squirrel.Dollar))
+	}, func(product *models.Product) (int32, int32) {
+		return product.ID, product.UserID
+	})
+}
+
+// GetUserProductsByIDs возвращает продукты пользователя из ids, упорядоченные по id; чужие и отсутствующие
+// пропускаются. id уникальны только в пределах шарда, поэтому запрос идёт только на primary шарда
+// данных владельца: там лежат все его продукты, и совпадения id на других шардах не мешают.
+func (s *ProfileManagementStorage) GetUserProductsByIDs(ctx context.Context, userID int32, ids []int32) ([]*models.Product, error) {
+	ids = lo.Uniq(ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := squirrel.Select(productColumns...).
 		From(productsTableName).
-		Where(squirrel.Eq{productsIDColumn: id}).
+		Where(squirrel.Expr(productsIDColumn+" = ANY(?)", ids)).
+		Where(squirrel.Eq{productsUserIDColumn: userID, productsDeletedAtColumn: nil}).
+		OrderBy(productsIDColumn).
 		PlaceholderFormat(squirrel.Dollar)
 
+	return queryShard(ctx, s, s.getShard(userID), func(ctx context.Context, shard *pgxpool.Pool) ([]*models.Product, error) {
+		return selectProducts(ctx, shard, query)
+	})
+}
+
+func selectProducts(ctx context.Context, shard querier, query squirrel.SelectBuilder) ([]*models.Product, error) {
 	queryText, args, err := query.ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "generate query error")
 	}
 
+	rows, err := shard.Query(ctx, queryText, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "query error")
+	}
+	defer rows.Close()
+
+	var products []*models.Product
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "query error")
+	}
+
+	return products, nil
+}
+
+// scanProduct читает строку продукта; отсутствие строки возвращается как pgx.ErrNoRows
+func scanProduct(row pgx.Row) (*models.Product, error) {
 	var product models.Product
 	var calories, protein, fat, carbs sql.NullInt32
 	var createdAt sql.NullTime
-	var found bool
 
-	for _, shard := range s.shards {
-		err = shard.QueryRow(ctx, queryText, args...).Scan(
-			&product.ID, &product.UserID, &product.Name,
-			&calories, &protein, &fat,
-			&carbs, &createdAt,
-		)
-		if err == nil {
-			found = true
-			break
-		}
-		if !errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.Wrap(err, "scan row error")
-		}
+	err := row.Scan(
+		&product.ID, &product.UserID, &product.Name,
+		&calories, &protein, &fat,
+		&carbs, &createdAt, &product.Version,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
 	}
-
-	if !found {
-		return nil, errors.New("product not found")
+	if err != nil {
+		return nil, errors.Wrap(err, "scan row error")
 	}
 
 	if calories.Valid {
@@ -160,20 +256,32 @@ func (s *ProfileManagementStorage) UpdateProduct(ctx context.Context, product *m
 		Set(productsProteinColumn, product.Protein).
 		Set(productsFatColumn, product.Fat).
 		Set(productsCarbsColumn, product.Carbs).
+		Set(productsVersionColumn, squirrel.Expr(productsVersionColumn+" + 1")).
 		Where(squirrel.Eq{productsIDColumn: product.ID}).
+		Suffix("RETURNING " + productsVersionColumn).
 		PlaceholderFormat(squirrel.Dollar)
+	if product.Version > 0 {
+		query = query.Where(squirrel.Eq{productsVersionColumn: product.Version})
+	}
 
 	queryText, args, err := query.ToSql()
 	if err != nil {
 		return errors.Wrap(err, "generate query error")
 	}
 
-	tempProduct, err := s.GetProductByID(ctx, product.ID)
+	tempProduct, err := s.getPrimaryProductByID(ctx, product.ID)
 	if err != nil {
 		return err
 	}
+	s.pinUser(tempProduct.UserID)
 	shard := s.getShard(tempProduct.UserID)
-	_, err = shard.Exec(ctx, queryText, args...)
+	err = s.onShard(shard, func() error {
+		return shard.QueryRow(ctx, queryText, args...).Scan(&product.Version)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Продукт найден выше, значит не совпала версия
+		return models.ErrVersionMismatch
+	}
 	if err != nil {
 		return errors.Wrap(err, "exec query error")
 	}
@@ -181,25 +289,65 @@ func (s *ProfileManagementStorage) UpdateProduct(ctx context.Context, product *m
 	return nil
 }
 
-func (s *ProfileManagementStorage) DeleteProduct(ctx context.Context, id int32) error {
-	query := squirrel.Delete(productsTableName).
-		Where(squirrel.Eq{productsIDColumn: id}).
-		PlaceholderFormat(squirrel.Dollar)
+// foreignKeyViolationCode код ошибки PostgreSQL при нарушении внешнего ключа
+const foreignKeyViolationCode = "23503"
 
-	queryText, args, err := query.ToSql()
+// DeleteProduct удаляет продукт. Если detachFromMeals, продукт сначала убирается из состава блюд,
+// иначе удаление блокируется внешним ключом meal_products. Detach отклоняется с ErrMealWouldBeEmpty,
+// если продукт единственный хотя бы в одном блюде.
+func (s *ProfileManagementStorage) DeleteProduct(ctx context.Context, id int32, detachFromMeals bool) error {
+	tempProduct, err := s.getPrimaryProductByID(ctx, id)
 	if err != nil {
-		return errors.Wrap(err, "generate query error")
+		return err
 	}
 
-	tempProduct, err := s.GetProductByID(ctx, id)
-	if err != nil {
+	s.pinUser(tempProduct.UserID)
+	shard := s.getShard(tempProduct.UserID)
+	return s.inTx(ctx, shard, func(tx pgx.Tx) error {
+		if detachFromMeals {
+			if err := checkMealsKeepProducts(ctx, tx, id); err != nil {
+				return err
+			}
+			_, err := execTx(ctx, tx, squirrel.Delete(mealProductsTableName).
+				Where(squirrel.Eq{mealProductsProductIDColumn: id}).
+				PlaceholderFormat(squirrel.Dollar))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := execTx(ctx, tx, squirrel.Delete(productsTableName).
+			Where(squirrel.Eq{productsIDColumn: id}).
+			PlaceholderFormat(squirrel.Dollar))
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+			return errors.New("product is used in meals")
+		}
 		return err
+	})
+}
+
+// checkMealsKeepProducts возвращает ErrMealWouldBeEmpty, если без продукта productID какое-то блюдо останется пустым.
+// Строки состава этих блюд блокируются до конца транзакции, чтобы их не изменили параллельно.
+func checkMealsKeepProducts(ctx context.Context, tx pgx.Tx, productID int32) error {
+	queryText := fmt.Sprintf(`
+		SELECT mp.%[1]s FROM %[3]s mp
+		WHERE mp.%[2]s = $1 AND NOT EXISTS (
+			SELECT 1 FROM %[3]s other WHERE other.%[1]s = mp.%[1]s AND other.%[2]s <> $1
+		)
+		ORDER BY mp.%[1]s
+		FOR UPDATE`, mealProductsMealIDColumn, mealProductsProductIDColumn, mealProductsTableName)
+
+	rows, err := tx.Query(ctx, queryText, productID)
+	if err != nil {
+		return errors.Wrap(err, "select query error")
 	}
-	shard := s.getShard(tempProduct.UserID)
-	_, err = shard.Exec(ctx, queryText, args...)
+	mealIDs, err := pgx.CollectRows(rows, pgx.RowTo[int32])
 	if err != nil {
-		return errors.Wrap(err, "exec query error")
+		return errors.Wrap(err, "scan row error")
+	}
+	if len(mealIDs) > 0 {
+		return errors.Wrapf(ErrMealWouldBeEmpty, "meals %v", mealIDs)
 	}
-
 	return nil
 }
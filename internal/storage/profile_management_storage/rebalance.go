@@ -0,0 +1,267 @@
+package profile_management_storage
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// rowSet строки одной таблицы, принадлежащие пользователю; where получает id пользователя в $1
+type rowSet struct {
+	table string
+	where string
+}
+
+// userProfileRows строка пользователя, она лежит на шарде его username
+var userProfileRows = []rowSet{
+	{table: usersTableName, where: usersIDColumn + " = $1"},
+}
+
+// userDataRows данные пользователя, они лежат на шарде его id. Таблицы идут в порядке копирования:
+// сначала те, на которые ссылаются внешние ключи; удаляются в обратном порядке.
+var userDataRows = []rowSet{
+	{table: productsTableName, where: productsUserIDColumn + " = $1"},
+	{table: mealsTableName, where: mealsUserIDColumn + " = $1"},
+	{table: mealProductsTableName, where: fmt.Sprintf("%s IN (SELECT %s FROM %s WHERE %s = $1)",
+		mealProductsMealIDColumn, mealsIDColumn, mealsTableName, mealsUserIDColumn)},
+	{table: menuGenerationsTableName, where: menuGenerationsUserIDColumn + " = $1"},
+	{table: deadLettersTableName, where: deadLettersUserIDColumn + " = $1"},
+	// Ключи анонимных запросов лежат на шарде самого ключа и не переносятся
+	{table: idempotencyKeysTableName, where: idempotencyKeysUserIDColumn + " = $1 AND $1 <> 0"},
+	{table: auditEventsTableName, where: auditEventsUserIDColumn + " = $1"},
+}
+
+// ErrMoveIDConflict id переносимых строк уже заняты на шарде-получателе
+var ErrMoveIDConflict = errors.New("row ids already exist on target shard")
+
+// serialTables таблицы с SERIAL id: после копирования строк с явными id последовательность
+// шарда-получателя сдвигается за максимальный id
+var serialTables = []string{usersTableName, productsTableName, mealsTableName}
+
+// RebalanceMove перенос строк пользователя с шарда From на шард To
+type RebalanceMove struct {
+	UserID int32
+	// Profile перенос строки пользователя (её шард выбирается по username), иначе его данных (шард по id)
+	Profile bool
+	From    int
+	To      int
+	// Rows сколько строк скопировано
+	Rows int64
+	// Err ошибка переноса; строки остаются на From, если копирование не удалось
+	Err error
+}
+
+// Rebalance переносит строки, лежащие не на том шарде, который им отдаёт текущая карта бакетов, например
+// после добавления шарда или изменения bucket_count. При dryRun только возвращает план. Перенос не атомарен
+// между шардами и не блокирует записи: запускать его нужно, остановив сервис. Ошибка одного переноса
+// записывается в его Err и не останавливает остальные.
+func (s *ProfileManagementStorage) Rebalance(ctx context.Context, dryRun bool) ([]RebalanceMove, error) {
+	moves, err := s.planRebalance(ctx)
+	if err != nil || dryRun {
+		return moves, err
+	}
+
+	for i := range moves {
+		moves[i].Err = s.move(ctx, &moves[i])
+	}
+	return moves, nil
+}
+
+func (s *ProfileManagementStorage) planRebalance(ctx context.Context) ([]RebalanceMove, error) {
+	usersQuery := fmt.Sprintf("SELECT %s, %s FROM %s ORDER BY %s",
+		usersIDColumn, usersUsernameColumn, usersTableName, usersIDColumn)
+	ownersQuery := fmt.Sprintf(`
+		SELECT %s FROM %s UNION SELECT %s FROM %s UNION SELECT %s FROM %s
+		UNION SELECT %s FROM %s UNION SELECT %s FROM %s
+		UNION SELECT %s FROM %s WHERE %s <> 0
+		ORDER BY 1`,
+		productsUserIDColumn, productsTableName, mealsUserIDColumn, mealsTableName,
+		menuGenerationsUserIDColumn, menuGenerationsTableName, deadLettersUserIDColumn, deadLettersTableName,
+		auditEventsUserIDColumn, auditEventsTableName,
+		idempotencyKeysUserIDColumn, idempotencyKeysTableName, idempotencyKeysUserIDColumn)
+
+	var moves []RebalanceMove
+	for i, shard := range s.shards {
+		usernames, err := queryShard(ctx, s, shard, func(ctx context.Context, shard *pgxpool.Pool) (map[int32]string, error) {
+			rows, err := shard.Query(ctx, usersQuery)
+			if err != nil {
+				return nil, errors.Wrap(err, "select query error")
+			}
+			usernames := make(map[int32]string)
+			var id int32
+			var username string
+			_, err = pgx.ForEachRow(rows, []any{&id, &username}, func() error {
+				usernames[id] = username
+				return nil
+			})
+			return usernames, err
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "plan shard %d users", i)
+		}
+
+		owners, err := queryShard(ctx, s, shard, func(ctx context.Context, shard *pgxpool.Pool) ([]int32, error) {
+			rows, err := shard.Query(ctx, ownersQuery)
+			if err != nil {
+				return nil, errors.Wrap(err, "select query error")
+			}
+			return pgx.CollectRows(rows, pgx.RowTo[int32])
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "plan shard %d data", i)
+		}
+
+		moves = append(moves, s.misplacedRows(i, usernames, owners)...)
+	}
+	return moves, nil
+}
+
+// misplacedRows переносы для строк шарда from: пользователей, чей username ведёт на другой шард,
+// и данных пользователей, чей id ведёт на другой шард
+func (s *ProfileManagementStorage) misplacedRows(from int, usernames map[int32]string, owners []int32) []RebalanceMove {
+	var moves []RebalanceMove
+	for _, id := range slices.Sorted(maps.Keys(usernames)) {
+		if to := s.getShardIndexByUsername(usernames[id]); to != from {
+			moves = append(moves, RebalanceMove{UserID: id, Profile: true, From: from, To: to})
+		}
+	}
+	for _, id := range owners {
+		if to := s.getShardIndex(id); to != from {
+			moves = append(moves, RebalanceMove{UserID: id, From: from, To: to})
+		}
+	}
+	return moves
+}
+
+// move копирует строки пользователя на шард To в одной транзакции и затем удаляет их с шарда From.
+// Если id строк уже заняты на шарде To, ничего не копируется и возвращается ErrMoveIDConflict.
+func (s *ProfileManagementStorage) move(ctx context.Context, m *RebalanceMove) error {
+	sets := userDataRows
+	if m.Profile {
+		sets = userProfileRows
+	}
+	source, target := s.shards[m.From], s.shards[m.To]
+
+	err := s.inTx(ctx, target, func(tx pgx.Tx) error {
+		m.Rows = 0
+		for _, set := range sets {
+			if err := checkIDConflicts(ctx, source, tx, set, m.UserID); err != nil {
+				return err
+			}
+		}
+		for _, set := range sets {
+			copied, err := copyRows(ctx, source, tx, set, m.UserID)
+			if err != nil {
+				return errors.Wrapf(err, "copy %s", set.table)
+			}
+			m.Rows += copied
+		}
+		for _, table := range serialTables {
+			if err := advanceSequence(ctx, tx, table); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		m.Rows = 0
+		return errors.Wrapf(err, "copy to shard %d", m.To)
+	}
+
+	err = s.inTx(ctx, source, func(tx pgx.Tx) error {
+		for _, set := range slices.Backward(sets) {
+			_, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE %s", set.table, set.where), m.UserID)
+			if err != nil {
+				return errors.Wrapf(err, "delete %s", set.table)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// Строки уже есть на обоих шардах; повторный перенос упрётся в первичные ключи получателя
+		return errors.Wrapf(err, "rows copied to shard %d but not deleted from shard %d", m.To, m.From)
+	}
+	return nil
+}
+
+// checkIDConflicts проверяет, что id строк set пользователя свободны на шарде-получателе. SERIAL id
+// выдаются каждым шардом независимо, и без проверки копирование упёрлось бы в первичный ключ.
+// Переназначать id нельзя: на них ссылаются клиенты, составы блюд и сохранённые ответы запросов.
+func checkIDConflicts(ctx context.Context, source *pgxpool.Pool, target pgx.Tx, set rowSet, userID int32) error {
+	if !slices.Contains(serialTables, set.table) {
+		return nil
+	}
+
+	rows, err := source.Query(ctx, fmt.Sprintf("SELECT id FROM %s WHERE %s", set.table, set.where), userID)
+	if err != nil {
+		return errors.Wrap(err, "select query error")
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int32])
+	if err != nil {
+		return errors.Wrap(err, "scan row error")
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err = target.Query(ctx, fmt.Sprintf("SELECT id FROM %s WHERE id = ANY($1) ORDER BY id", set.table), ids)
+	if err != nil {
+		return errors.Wrap(err, "select query error")
+	}
+	taken, err := pgx.CollectRows(rows, pgx.RowTo[int32])
+	if err != nil {
+		return errors.Wrap(err, "scan row error")
+	}
+	if len(taken) > 0 {
+		return errors.Wrapf(ErrMoveIDConflict, "%s ids %v", set.table, taken)
+	}
+	return nil
+}
+
+// copyRows копирует строки set пользователя с пула source в транзакцию шарда-получателя как есть, со всеми колонками
+func copyRows(ctx context.Context, source *pgxpool.Pool, target pgx.Tx, set rowSet, userID int32) (int64, error) {
+	rows, err := source.Query(ctx, fmt.Sprintf("SELECT * FROM %s WHERE %s", set.table, set.where), userID)
+	if err != nil {
+		return 0, errors.Wrap(err, "select query error")
+	}
+	defer rows.Close()
+
+	columns := make([]string, 0, len(rows.FieldDescriptions()))
+	for _, field := range rows.FieldDescriptions() {
+		columns = append(columns, field.Name)
+	}
+
+	var values [][]any
+	for rows.Next() {
+		row, err := rows.Values()
+		if err != nil {
+			return 0, errors.Wrap(err, "scan row error")
+		}
+		values = append(values, row)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(err, "rows error")
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	return target.CopyFrom(ctx, pgx.Identifier{set.table}, columns, pgx.CopyFromRows(values))
+}
+
+// advanceSequence сдвигает последовательность id таблицы за максимальный id, чтобы новые строки
+// не столкнулись со скопированными
+func advanceSequence(ctx context.Context, tx pgx.Tx, table string) error {
+	_, err := tx.Exec(ctx, fmt.Sprintf(`
+		SELECT setval(seq, GREATEST(COALESCE(pg_sequence_last_value(seq), 1), (SELECT COALESCE(MAX(id), 1) FROM %[1]s)))
+		FROM (SELECT pg_get_serial_sequence('%[1]s', 'id')::regclass AS seq) AS sequence`, table))
+	if err != nil {
+		return errors.Wrapf(err, "advance %s id sequence", table)
+	}
+	return nil
+}
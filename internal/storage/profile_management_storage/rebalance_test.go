@@ -0,0 +1,46 @@
+package profile_management_storage
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestMisplacedRowsFollowBucketMap(t *testing.T) {
+	s := &ProfileManagementStorage{
+		shards:        testShards(2),
+		bucketCount:   4,
+		bucketToShard: []int{0, 1, 0, 1},
+	}
+
+	var homeUser, awayUser int32
+	for id := int32(1); homeUser == 0 || awayUser == 0; id++ {
+		if s.getShardIndex(id) == 0 {
+			homeUser = id
+		} else {
+			awayUser = id
+		}
+	}
+	var homeName, awayName string
+	for _, name := range []string{"alice", "bob", "carol", "dave", "erin", "frank"} {
+		if s.getShardIndexByUsername(name) == 0 {
+			homeName = name
+		} else {
+			awayName = name
+		}
+	}
+	assert.Assert(t, homeName != "" && awayName != "")
+
+	moves := s.misplacedRows(0, map[int32]string{1: homeName, 2: awayName}, []int32{homeUser, awayUser})
+	assert.DeepEqual(t, moves, []RebalanceMove{
+		{UserID: 2, Profile: true, From: 0, To: 1},
+		{UserID: awayUser, From: 0, To: 1},
+	})
+}
+
+func TestBucketMapIsCopy(t *testing.T) {
+	s := &ProfileManagementStorage{bucketToShard: []int{0, 1}}
+	buckets := s.BucketMap()
+	buckets[0] = 1
+	assert.DeepEqual(t, s.BucketMap(), []int{0, 1})
+}
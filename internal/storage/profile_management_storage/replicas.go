@@ -0,0 +1,287 @@
+package profile_management_storage
+
+import (
+	"cmp"
+	"context"
+	"expvar"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// replicaPingTimeout сколько ждём ответа реплики при проверке здоровья
+const replicaPingTimeout = 2 * time.Second
+
+// Чтения по типу пула (replica, primary) и переходы реплик между состояниями (healthy, unhealthy),
+// доступны в /debug/vars
+var (
+	storageReads       = expvar.NewMap("storage_reads")
+	replicaTransitions = expvar.NewMap("storage_replica_transitions")
+)
+
+// ShardConnection строки подключения к primary шарда и его репликам; Pool применяется ко всем их пулам
+type ShardConnection struct {
+	Primary  string
+	Replicas []string
+	Pool     PoolSettings
+}
+
+type replica struct {
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+	breaker *circuitBreaker
+}
+
+// replicaSet реплики одного шарда. Чтения раздаются по кругу между здоровыми репликами.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint32
+}
+
+// pick возвращает следующую здоровую реплику с замкнутым автоматом или nil, если таких нет
+func (r *replicaSet) pick() *pgxpool.Pool {
+	n := uint32(len(r.replicas))
+	if n == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	start := r.next.Add(1)
+	for i := uint32(0); i < n; i++ {
+		candidate := r.replicas[(start+i)%n]
+		if candidate.healthy.Load() && candidate.breaker.available(now) {
+			return candidate.pool
+		}
+	}
+	return nil
+}
+
+// writePins пользователи, недавно писавшие в хранилище. Пока окно не истекло, их чтения идут
+// на primary, чтобы они видели свои изменения несмотря на отставание реплик.
+type writePins struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	until     map[int32]time.Time
+	lastSweep time.Time
+}
+
+func newWritePins(window time.Duration) *writePins {
+	return &writePins{
+		window: window,
+		until:  make(map[int32]time.Time),
+	}
+}
+
+func (p *writePins) pin(userID int32, now time.Time) {
+	if p.window <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.until[userID] = now.Add(p.window)
+
+	// Истёкшие записи убираем не чаще раза в окно, чтобы карта не росла
+	if now.Sub(p.lastSweep) >= p.window {
+		for id, until := range p.until {
+			if !now.Before(until) {
+				delete(p.until, id)
+			}
+		}
+		p.lastSweep = now
+	}
+}
+
+func (p *writePins) pinned(userID int32, now time.Time) bool {
+	if p.window <= 0 {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	until, ok := p.until[userID]
+	return ok && now.Before(until)
+}
+
+func newReplicaSet(shard int, connStrings []string, poolSettings PoolSettings, breakerSettings CircuitBreakerSettings) (*replicaSet, error) {
+	set := &replicaSet{replicas: make([]*replica, 0, len(connStrings))}
+	for i, connString := range connStrings {
+		db, err := newPool(connString, poolSettings)
+		if err != nil {
+			return nil, errors.Wrapf(err, "реплика %d шарда %d", i, shard)
+		}
+
+		// До первой проверки реплика считается здоровой: пул подключается лениво
+		r := &replica{pool: db, breaker: newCircuitBreaker(circuitName(shard, i), shard, breakerSettings)}
+		r.healthy.Store(true)
+		set.replicas = append(set.replicas, r)
+	}
+	return set, nil
+}
+
+// hasReplicas есть ли у хранилища хотя бы одна реплика
+func (s *ProfileManagementStorage) hasReplicas() bool {
+	for _, set := range s.replicas {
+		if len(set.replicas) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// pinUser направляет чтения пользователя на primary на время окна read-your-writes
+func (s *ProfileManagementStorage) pinUser(userID int32) {
+	if s.hasReplicas() {
+		s.pins.pin(userID, time.Now())
+	}
+}
+
+func (s *ProfileManagementStorage) isPinned(userID int32) bool {
+	return s.pins.pinned(userID, time.Now())
+}
+
+// readPool пул для чтения с шарда: здоровая реплика, а если её нет - primary
+func (s *ProfileManagementStorage) readPool(shard int) *pgxpool.Pool {
+	if pool := s.replicas[shard].pick(); pool != nil {
+		storageReads.Add("replica", 1)
+		return pool
+	}
+	storageReads.Add("primary", 1)
+	return s.shards[shard]
+}
+
+// readShard пул для чтения данных пользователя: primary, если пользователь недавно писал, иначе реплика
+func (s *ProfileManagementStorage) readShard(userID int32) *pgxpool.Pool {
+	index := s.getShardIndex(userID)
+	if s.isPinned(userID) {
+		storageReads.Add("primary", 1)
+		return s.shards[index]
+	}
+	return s.readPool(index)
+}
+
+// readShards по одному пулу для чтения на каждый шард
+func (s *ProfileManagementStorage) readShards() []*pgxpool.Pool {
+	pools := make([]*pgxpool.Pool, len(s.shards))
+	for i := range pools {
+		pools[i] = s.readPool(i)
+	}
+	return pools
+}
+
+// readByID ищет запись с неизвестным владельцем сначала на репликах. id уникальны только в пределах
+// шарда, поэтому при совпадении возвращается запись младшего шарда (см. gatherLowest). Если владелец
+// недавно писал, на репликах записи нет или они не ответили, поиск повторяется на primary: реплика
+// могла ещё не получить новую запись.
+func readByID[T any](ctx context.Context, s *ProfileManagementStorage, lookup func(ctx context.Context, shard *pgxpool.Pool) (T, error), owner func(T) int32) (T, error) {
+	lookup = guard(s, lookup)
+	if s.hasReplicas() {
+		pools := s.readShards()
+		value, index, err := gatherLowest(ctx, pools, s.shardIndexes(), lookup)
+		if err == nil && (pools[index] == s.shards[index] || !s.isPinned(owner(value))) {
+			return value, nil
+		}
+	}
+
+	value, _, err := gatherLowest(ctx, s.shards, s.shardIndexes(), lookup)
+	return value, err
+}
+
+// readAllByIDs выполняет пакетное чтение по id на репликах всех шардов; query получает ids без повторов.
+// Если какой-то id не найден или владелец одной из записей недавно писал, чтение повторяется на primary.
+// Ошибка любого шарда прерывает чтение: по её отсутствию в ответе нельзя судить, что записи нет.
+// Результат упорядочен по id; при совпадении id на разных шардах остаётся запись младшего шарда,
+// а записи остальных шардов с тем же id молча отбрасываются (см. mergeByID). Поэтому readAllByIDs
+// годится только для id с неизвестным владельцем: если владелец известен, читать нужно с его шарда
+// данных, как GetUserProductsByIDs, иначе его записи могут потеряться за чужими с теми же id.
+func readAllByIDs[T any](ctx context.Context, s *ProfileManagementStorage, ids []int32, query func(ctx context.Context, shard *pgxpool.Pool, ids []int32) ([]T, error), key func(T) (id, owner int32)) ([]T, error) {
+	ids = lo.Uniq(ids)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	queryIDs := guard(s, func(ctx context.Context, shard *pgxpool.Pool) ([]T, error) {
+		return query(ctx, shard, ids)
+	})
+
+	pools := s.shards
+	if s.hasReplicas() {
+		pools = s.readShards()
+	}
+	values, err := queryAllShards(ctx, pools, queryIDs)
+	if s.hasReplicas() && (err != nil || readAllStale(s, ids, values, key)) {
+		values, err = queryAllShards(ctx, s.shards, queryIDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeByID(values, key), nil
+}
+
+// mergeByID упорядочивает ответы шардов, собранные по возрастанию номера шарда, по id и оставляет
+// для каждого id первую запись, то есть запись младшего шарда. Записи старших шардов с тем же id
+// отбрасываются, даже если принадлежат другим пользователям.
+func mergeByID[T any](values []T, key func(T) (id, owner int32)) []T {
+	slices.SortStableFunc(values, func(a, b T) int {
+		idA, _ := key(a)
+		idB, _ := key(b)
+		return cmp.Compare(idA, idB)
+	})
+	return lo.UniqBy(values, func(value T) int32 {
+		id, _ := key(value)
+		return id
+	})
+}
+
+func readAllStale[T any](s *ProfileManagementStorage, ids []int32, values []T, key func(T) (id, owner int32)) bool {
+	found := make(map[int32]struct{}, len(values))
+	for _, value := range values {
+		id, owner := key(value)
+		if s.isPinned(owner) {
+			return true
+		}
+		found[id] = struct{}{}
+	}
+
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckReplicas пингует все реплики и обновляет их состояние. Нездоровые реплики не получают
+// чтений, пока очередная проверка не покажет, что они снова отвечают.
+func (s *ProfileManagementStorage) CheckReplicas(ctx context.Context) {
+	for shard, set := range s.replicas {
+		for i, r := range set.replicas {
+			pingCtx, cancel := context.WithTimeout(ctx, replicaPingTimeout)
+			err := r.pool.Ping(pingCtx)
+			cancel()
+
+			healthy := err == nil
+			if r.healthy.Swap(healthy) == healthy {
+				continue
+			}
+
+			if healthy {
+				replicaTransitions.Add("healthy", 1)
+				slog.Info("replica is healthy again", "shard", shard, "replica", i)
+			} else {
+				replicaTransitions.Add("unhealthy", 1)
+				slog.Warn("replica is unhealthy", "shard", shard, "replica", i, "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package profile_management_storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gotest.tools/v3/assert"
+)
+
+func testReplicaSet(pools []*pgxpool.Pool, healthy ...bool) *replicaSet {
+	set := &replicaSet{}
+	for i, pool := range pools {
+		r := &replica{pool: pool}
+		r.healthy.Store(healthy[i])
+		set.replicas = append(set.replicas, r)
+	}
+	return set
+}
+
+// testReplicatedStorage хранилище-заглушка: у каждого шарда одна здоровая реплика
+func testReplicatedStorage(shardCount int, window time.Duration) (*ProfileManagementStorage, []*pgxpool.Pool) {
+	primaries := testShards(shardCount)
+	replicaPools := testShards(shardCount)
+
+	s := &ProfileManagementStorage{
+		shards:        primaries,
+		bucketCount:   shardCount,
+		bucketToShard: make([]int, shardCount),
+		pins:          newWritePins(window),
+	}
+	for i := range primaries {
+		s.bucketToShard[i] = i
+		s.replicas = append(s.replicas, testReplicaSet(replicaPools[i:i+1], true))
+	}
+	return s, replicaPools
+}
+
+func TestReplicaSetSkipsUnhealthyReplicas(t *testing.T) {
+	pools := testShards(3)
+	set := testReplicaSet(pools, true, false, true)
+
+	for range 6 {
+		assert.Assert(t, set.pick() != pools[1])
+	}
+
+	set.replicas[0].healthy.Store(false)
+	set.replicas[2].healthy.Store(false)
+	assert.Assert(t, set.pick() == nil)
+}
+
+func TestReplicaSetRoundRobin(t *testing.T) {
+	pools := testShards(2)
+	set := testReplicaSet(pools, true, true)
+
+	first, second := set.pick(), set.pick()
+	assert.Assert(t, first != second)
+	assert.Assert(t, set.pick() == first)
+}
+
+func TestWritePinsExpireAfterWindow(t *testing.T) {
+	pins := newWritePins(time.Second)
+	now := time.Now()
+
+	pins.pin(1, now)
+	assert.Assert(t, pins.pinned(1, now.Add(500*time.Millisecond)))
+	assert.Assert(t, !pins.pinned(2, now))
+	assert.Assert(t, !pins.pinned(1, now.Add(time.Second)))
+
+	// Следующая запись после окна вычищает истёкшие закрепления
+	pins.pin(2, now.Add(2*time.Second))
+	assert.Equal(t, len(pins.until), 1)
+}
+
+func TestWritePinsDisabledWithoutWindow(t *testing.T) {
+	pins := newWritePins(0)
+	now := time.Now()
+
+	pins.pin(1, now)
+	assert.Assert(t, !pins.pinned(1, now))
+}
+
+func TestReadShardPinsRecentWriterToPrimary(t *testing.T) {
+	s, replicaPools := testReplicatedStorage(2, time.Minute)
+	home := s.getShardIndex(7)
+
+	assert.Assert(t, s.readShard(7) == replicaPools[home])
+
+	s.pinUser(7)
+	assert.Assert(t, s.readShard(7) == s.shards[home])
+
+	// Без здоровых реплик чтения шарда уходят на primary
+	s.replicas[home].replicas[0].healthy.Store(false)
+	assert.Assert(t, s.readPool(home) == s.shards[home])
+}
+
+func TestReadByIDFallsBackToPrimaryOnReplicaMiss(t *testing.T) {
+	s, replicaPools := testReplicatedStorage(2, time.Minute)
+
+	// Запись есть только на primary шарда 1: реплика ещё не догнала
+	value, err := readByID(context.Background(), s, func(ctx context.Context, shard *pgxpool.Pool) (int32, error) {
+		if shard == s.shards[1] {
+			return 7, nil
+		}
+		return 0, pgx.ErrNoRows
+	}, func(owner int32) int32 { return owner })
+	assert.NilError(t, err)
+	assert.Equal(t, value, int32(7))
+
+	// Недавно писавший владелец получает запись с primary, а не устаревшую с реплики
+	s.pinUser(7)
+	value, err = readByID(context.Background(), s, func(ctx context.Context, shard *pgxpool.Pool) (int32, error) {
+		switch shard {
+		case replicaPools[0]:
+			return 7, nil
+		case s.shards[0]:
+			return 8, nil
+		}
+		return 0, pgx.ErrNoRows
+	}, func(value int32) int32 { return 7 })
+	assert.NilError(t, err)
+	assert.Equal(t, value, int32(8))
+}
+
+// testRow запись шарда для тестов readAllByIDs: id и владелец
+type testRow struct {
+	ID, Owner int32
+}
+
+func testRowKey(row testRow) (int32, int32) { return row.ID, row.Owner }
+
+func TestReadAllByIDsDedupsAndMergesByLowestShard(t *testing.T) {
+	s := &ProfileManagementStorage{shards: testShards(3)}
+
+	// id 5 есть на шардах 0 и 2, id 9 не найден ни на одном шарде
+	rows := map[int][]testRow{
+		0: {{ID: 5, Owner: 1}},
+		1: {{ID: 3, Owner: 2}},
+		2: {{ID: 5, Owner: 3}, {ID: 1, Owner: 3}},
+	}
+	values, err := readAllByIDs(context.Background(), s, []int32{5, 3, 5, 1, 9}, func(ctx context.Context, shard *pgxpool.Pool, ids []int32) ([]testRow, error) {
+		assert.DeepEqual(t, ids, []int32{5, 3, 1, 9})
+		return rows[shardIndex(s.shards, shard)], nil
+	}, testRowKey)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, values, []testRow{{ID: 1, Owner: 3}, {ID: 3, Owner: 2}, {ID: 5, Owner: 1}})
+}
+
+func TestReadAllByIDsEmpty(t *testing.T) {
+	s := &ProfileManagementStorage{shards: testShards(2)}
+
+	values, err := readAllByIDs(context.Background(), s, nil, func(ctx context.Context, shard *pgxpool.Pool, ids []int32) ([]testRow, error) {
+		t.Fatal("пустой список id не должен доходить до шардов")
+		return nil, nil
+	}, testRowKey)
+	assert.NilError(t, err)
+	assert.Equal(t, len(values), 0)
+}
+
+func TestReadAllByIDsFailsOnShardError(t *testing.T) {
+	s := &ProfileManagementStorage{shards: testShards(3)}
+
+	_, err := readAllByIDs(context.Background(), s, []int32{1}, func(ctx context.Context, shard *pgxpool.Pool, ids []int32) ([]testRow, error) {
+		if shardIndex(s.shards, shard) == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return []testRow{{ID: 1, Owner: 1}}, nil
+	}, testRowKey)
+
+	var shardErr *ShardError
+	assert.Assert(t, errors.As(err, &shardErr))
+	assert.Equal(t, shardErr.Shard, 1)
+}
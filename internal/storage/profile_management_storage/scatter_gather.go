@@ -0,0 +1,183 @@
+package profile_management_storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// shardLookupTimeout сколько ждём ответа одного шарда при поиске записи по всем шардам
+const shardLookupTimeout = 2 * time.Second
+
+// ShardError ошибка запроса к одному шарду
+type ShardError struct {
+	Shard int
+	Err   error
+}
+
+func (e *ShardError) Error() string {
+	return fmt.Sprintf("shard %d: %v", e.Shard, e.Err)
+}
+
+func (e *ShardError) Unwrap() error {
+	return e.Err
+}
+
+// Is ошибка шарда считается models.ErrShardUnavailable, если шард не ответил или его автомат разомкнут
+func (e *ShardError) Is(target error) bool {
+	return target == models.ErrShardUnavailable && isShardFailure(e.Err)
+}
+
+// ShardsError запись не найдена на ответивших шардах, а часть шардов ответила ошибкой,
+// поэтому запись могла лежать на одном из них
+type ShardsError struct {
+	Errors []*ShardError
+}
+
+func (e *ShardsError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return "shards failed: " + strings.Join(messages, "; ")
+}
+
+func (e *ShardsError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// gatherLowest ищет запись по id на шардах indexes параллельно, каждый шард не дольше shardLookupTimeout.
+// id уникальны только в пределах шарда, поэтому возвращается запись шарда с наименьшим номером: ответ
+// старшего шарда принимается, только когда все младшие ответили, что записи нет. Если младший шард
+// ответил ошибкой, запись могла лежать на нём, и возвращается *ShardsError.
+func gatherLowest[T any](ctx context.Context, shards []*pgxpool.Pool, indexes []int, lookup func(ctx context.Context, shard *pgxpool.Pool) (T, error)) (T, int, error) {
+	type shardResult struct {
+		value T
+		err   error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indexes = slices.Sorted(slices.Values(indexes))
+	results := make([]chan shardResult, len(indexes))
+	for i, index := range indexes {
+		results[i] = make(chan shardResult, 1)
+		go func() {
+			shardCtx, shardCancel := context.WithTimeout(ctx, shardLookupTimeout)
+			defer shardCancel()
+
+			value, err := lookup(shardCtx, shards[index])
+			results[i] <- shardResult{value: value, err: err}
+		}()
+	}
+
+	var failed []*ShardError
+	for i, index := range indexes {
+		result := <-results[i]
+		if result.err == nil {
+			if len(failed) > 0 {
+				break
+			}
+			return result.value, index, nil
+		}
+		if !errors.Is(result.err, pgx.ErrNoRows) {
+			failed = append(failed, &ShardError{Shard: index, Err: result.err})
+		}
+	}
+
+	var zero T
+	if len(failed) > 0 {
+		return zero, -1, &ShardsError{Errors: failed}
+	}
+	return zero, -1, pgx.ErrNoRows
+}
+
+// lookupUser ищет запись пользователя сначала на его шарде, а если её там нет или шард не ответил,
+// параллельно на остальных шардах; при совпадении id на них побеждает младший шард (см. gatherLowest).
+// shards - пулы шардов по номерам: primary или реплики.
+func lookupUser[T any](ctx context.Context, s *ProfileManagementStorage, shards []*pgxpool.Pool, userID int32, lookup func(ctx context.Context, shard *pgxpool.Pool) (T, error)) (T, int, error) {
+	return lookupFromHome(ctx, s, shards, s.getShardIndex(userID), lookup)
+}
+
+// lookupFromHome ищет запись сначала на шарде home, затем параллельно на остальных, выбирая младший шард
+func lookupFromHome[T any](ctx context.Context, s *ProfileManagementStorage, shards []*pgxpool.Pool, home int, lookup func(ctx context.Context, shard *pgxpool.Pool) (T, error)) (T, int, error) {
+	lookup = guard(s, lookup)
+
+	homeCtx, cancel := context.WithTimeout(ctx, shardLookupTimeout)
+	value, err := lookup(homeCtx, shards[home])
+	cancel()
+	if err == nil {
+		return value, home, nil
+	}
+
+	var homeErr *ShardError
+	if !errors.Is(err, pgx.ErrNoRows) {
+		homeErr = &ShardError{Shard: home, Err: err}
+	}
+
+	others := make([]int, 0, len(s.shards)-1)
+	for i := range s.shards {
+		if i != home {
+			others = append(others, i)
+		}
+	}
+
+	value, index, err := gatherLowest(ctx, shards, others, lookup)
+	if err == nil || homeErr == nil {
+		return value, index, err
+	}
+
+	shardsErr := &ShardsError{Errors: []*ShardError{homeErr}}
+	var othersErr *ShardsError
+	if errors.As(err, &othersErr) {
+		shardsErr.Errors = append(shardsErr.Errors, othersErr.Errors...)
+	}
+	return value, -1, shardsErr
+}
+
+// shardIndexes номера всех шардов
+func (s *ProfileManagementStorage) shardIndexes() []int {
+	indexes := make([]int, len(s.shards))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+// notFoundError запись не найдена; errors.Is считает её models.ErrNotFound
+type notFoundError struct {
+	message string
+}
+
+func (e *notFoundError) Error() string {
+	return e.message
+}
+
+func (e *notFoundError) Is(target error) bool {
+	return target == models.ErrNotFound
+}
+
+// notFound переводит отсутствие записи на всех шардах в ошибку notFoundErr, а ошибки шардов оставляет как есть
+func notFound(err error, notFoundErr string) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &notFoundError{message: notFoundErr}
+	}
+	return err
+}
+
+func isShardsError(err error) bool {
+	var shardsErr *ShardsError
+	return errors.As(err, &shardsErr)
+}
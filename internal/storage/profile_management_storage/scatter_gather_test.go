@@ -0,0 +1,135 @@
+package profile_management_storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gotest.tools/v3/assert"
+)
+
+// testShards шарды-заглушки: lookup различает их по указателю и не обращается к базе
+func testShards(n int) []*pgxpool.Pool {
+	shards := make([]*pgxpool.Pool, n)
+	for i := range shards {
+		shards[i] = new(pgxpool.Pool)
+	}
+	return shards
+}
+
+func shardIndex(shards []*pgxpool.Pool, shard *pgxpool.Pool) int {
+	for i, candidate := range shards {
+		if candidate == shard {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestGatherLowestCancelsHigherShardsAfterHit(t *testing.T) {
+	shards := testShards(2)
+	cancelled := make(chan struct{})
+
+	start := time.Now()
+	_, index, err := gatherLowest(context.Background(), shards, []int{0, 1}, func(ctx context.Context, shard *pgxpool.Pool) (int, error) {
+		if shardIndex(shards, shard) == 0 {
+			return 0, nil
+		}
+		<-ctx.Done()
+		close(cancelled)
+		return 0, ctx.Err()
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, index, 0)
+	assert.Assert(t, time.Since(start) < shardLookupTimeout)
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("slow shard was not cancelled")
+	}
+}
+
+func TestGatherLowestAggregatesShardErrors(t *testing.T) {
+	shards := testShards(3)
+
+	_, _, err := gatherLowest(context.Background(), shards, []int{0, 1, 2}, func(ctx context.Context, shard *pgxpool.Pool) (int, error) {
+		if shardIndex(shards, shard) == 1 {
+			return 0, pgx.ErrNoRows
+		}
+		return 0, errors.New("connection refused")
+	})
+
+	var shardsErr *ShardsError
+	assert.Assert(t, errors.As(err, &shardsErr))
+	assert.Equal(t, len(shardsErr.Errors), 2)
+	assert.ErrorContains(t, err, "shard 0: connection refused")
+	assert.ErrorContains(t, err, "shard 2: connection refused")
+	// Ошибка шардов не превращается в «не найдено»
+	assert.Equal(t, notFound(err, "user not found"), err)
+}
+
+func TestGatherLowestWaitsForLowerShards(t *testing.T) {
+	shards := testShards(3)
+
+	// Шард 2 отвечает сразу, шард 1 позже: id совпадают, побеждает младший шард
+	value, index, err := gatherLowest(context.Background(), shards, []int{2, 1, 0}, func(ctx context.Context, shard *pgxpool.Pool) (int, error) {
+		switch i := shardIndex(shards, shard); i {
+		case 0:
+			return 0, pgx.ErrNoRows
+		case 1:
+			time.Sleep(50 * time.Millisecond)
+			return i, nil
+		default:
+			return i, nil
+		}
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, index, 1)
+	assert.Equal(t, value, 1)
+}
+
+func TestGatherLowestIgnoresHigherShardErrors(t *testing.T) {
+	shards := testShards(3)
+
+	value, index, err := gatherLowest(context.Background(), shards, []int{0, 1, 2}, func(ctx context.Context, shard *pgxpool.Pool) (int, error) {
+		if i := shardIndex(shards, shard); i < 2 {
+			return i, nil
+		}
+		return 0, errors.New("connection refused")
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, index, 0)
+	assert.Equal(t, value, 0)
+}
+
+func TestGatherLowestFailsOnLowerShardError(t *testing.T) {
+	shards := testShards(3)
+
+	// Шард 0 не ответил: запись с тем же id могла лежать на нём, поэтому ответ шарда 1 не принимается
+	_, _, err := gatherLowest(context.Background(), shards, []int{0, 1, 2}, func(ctx context.Context, shard *pgxpool.Pool) (int, error) {
+		if i := shardIndex(shards, shard); i > 0 {
+			return i, nil
+		}
+		return 0, errors.New("connection refused")
+	})
+
+	var shardsErr *ShardsError
+	assert.Assert(t, errors.As(err, &shardsErr))
+	assert.Equal(t, len(shardsErr.Errors), 1)
+	assert.Equal(t, shardsErr.Errors[0].Shard, 0)
+}
+
+func TestGatherLowestNotFound(t *testing.T) {
+	shards := testShards(2)
+
+	_, index, err := gatherLowest(context.Background(), shards, []int{0, 1}, func(ctx context.Context, shard *pgxpool.Pool) (int, error) {
+		return 0, pgx.ErrNoRows
+	})
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	assert.Equal(t, index, -1)
+	assert.Error(t, notFound(err, "user not found"), "user not found")
+}
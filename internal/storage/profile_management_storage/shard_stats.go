@@ -0,0 +1,80 @@
+package profile_management_storage
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+// shardTables таблицы шарда в порядке вывода статистики
+var shardTables = []string{
+	usersTableName,
+	productsTableName,
+	mealsTableName,
+	mealProductsTableName,
+	menuGenerationsTableName,
+	deadLettersTableName,
+	idempotencyKeysTableName,
+	auditEventsTableName,
+}
+
+// TableRows число строк таблицы на шарде
+type TableRows struct {
+	Table string
+	Rows  int64
+}
+
+// ShardStats бакеты шарда и число строк в его таблицах. Err заполнен, если шард не ответил:
+// статистика остальных шардов при этом возвращается.
+type ShardStats struct {
+	Shard   int
+	Buckets []int
+	Tables  []TableRows
+	Err     error
+}
+
+// BucketMap номер шарда каждого бакета
+func (s *ProfileManagementStorage) BucketMap() []int {
+	return slices.Clone(s.bucketToShard)
+}
+
+// ShardStats считает строки таблиц на primary каждого шарда
+func (s *ProfileManagementStorage) ShardStats(ctx context.Context) []ShardStats {
+	stats := make([]ShardStats, len(s.shards))
+	for i := range stats {
+		stats[i].Shard = i
+	}
+	for bucket, shard := range s.bucketToShard {
+		stats[shard].Buckets = append(stats[shard].Buckets, bucket)
+	}
+
+	counts := make([]string, 0, len(shardTables))
+	for _, table := range shardTables {
+		counts = append(counts, fmt.Sprintf("(SELECT COUNT(*) FROM %s)", table))
+	}
+	queryText := "SELECT " + strings.Join(counts, ", ")
+
+	for i, shard := range s.shards {
+		stats[i].Tables, stats[i].Err = queryShard(ctx, s, shard, func(ctx context.Context, shard *pgxpool.Pool) ([]TableRows, error) {
+			rows := make([]int64, len(shardTables))
+			dest := make([]any, len(rows))
+			for j := range rows {
+				dest[j] = &rows[j]
+			}
+			if err := shard.QueryRow(ctx, queryText).Scan(dest...); err != nil {
+				return nil, errors.Wrap(err, "count rows error")
+			}
+
+			tables := make([]TableRows, len(shardTables))
+			for j, table := range shardTables {
+				tables[j] = TableRows{Table: table, Rows: rows[j]}
+			}
+			return tables, nil
+		})
+	}
+	return stats
+}
@@ -0,0 +1,185 @@
+package profile_management_storage
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrShardOutOfRange номер шарда за пределами настроенных шардов
+	ErrShardOutOfRange = errors.New("shard index out of range")
+	// ErrMoveOffRoute перенос на шард, который не совпадает с шардом по карте бакетов
+	ErrMoveOffRoute = errors.New("target shard does not match bucket map")
+	// ErrForceDataMove перенос данных в обход карты бакетов: данные читаются только с шарда по карте
+	ErrForceDataMove = errors.New("force is not allowed for data moves")
+)
+
+// likeEscaper экранирует спецсимволы LIKE, чтобы префикс искался как есть
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// UserPlacement шард, на котором лежит строка пользователя, и шарды, которые ему отдаёт карта бакетов.
+// У User заполнены только ID, Username и CreatedAt.
+type UserPlacement struct {
+	User *models.User
+	// Shard шард, на котором найдена строка пользователя
+	Shard int
+	// UsernameBucket и UsernameShard бакет и шард username, туда направляется строка пользователя
+	UsernameBucket int
+	UsernameShard  int
+	// IDBucket и DataShard бакет и шард id, туда направляются данные пользователя
+	IDBucket  int
+	DataShard int
+}
+
+func (s *ProfileManagementStorage) placement(user *models.User, shard int) *UserPlacement {
+	return &UserPlacement{
+		User:           user,
+		Shard:          shard,
+		UsernameBucket: s.getBucketByUsername(user.Username),
+		UsernameShard:  s.getShardIndexByUsername(user.Username),
+		IDBucket:       s.getBucket(user.ID),
+		DataShard:      s.getShardIndex(user.ID),
+	}
+}
+
+func placementQuery(where squirrel.Sqlizer) squirrel.SelectBuilder {
+	return squirrel.Select(usersIDColumn, usersUsernameColumn, usersCreatedAtColumn).
+		From(usersTableName).
+		Where(where).
+		Where(squirrel.Eq{usersDeletedAtColumn: nil}).
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func scanPlacementUser(row pgx.Row) (*models.User, error) {
+	var user models.User
+	var createdAt sql.NullTime
+	if err := row.Scan(&user.ID, &user.Username, &createdAt); err != nil {
+		return nil, err
+	}
+	if createdAt.Valid {
+		user.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return &user, nil
+}
+
+// SearchUsers ищет на всех шардах пользователей, чей username начинается с prefix, и возвращает
+// не больше limit из них в порядке username. Недоступный шард прерывает поиск.
+func (s *ProfileManagementStorage) SearchUsers(ctx context.Context, prefix string, limit uint64) ([]*UserPlacement, error) {
+	query := placementQuery(squirrel.Like{usersUsernameColumn: likeEscaper.Replace(prefix) + "%"}).
+		OrderBy(usersUsernameColumn).
+		Limit(limit)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	placements, err := queryAllShards(ctx, s.shards, guard(s, func(ctx context.Context, shard *pgxpool.Pool) ([]*UserPlacement, error) {
+		rows, err := shard.Query(ctx, queryText, args...)
+		if err != nil {
+			return nil, errors.Wrap(err, "select query error")
+		}
+		users, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*models.User, error) {
+			return scanPlacementUser(row)
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "scan row error")
+		}
+
+		index := slices.Index(s.shards, shard)
+		placements := make([]*UserPlacement, 0, len(users))
+		for _, user := range users {
+			placements = append(placements, s.placement(user, index))
+		}
+		return placements, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(placements, func(a, b *UserPlacement) int {
+		return cmp.Or(strings.Compare(a.User.Username, b.User.Username), cmp.Compare(a.Shard, b.Shard))
+	})
+	if uint64(len(placements)) > limit {
+		placements = placements[:limit]
+	}
+	return placements, nil
+}
+
+// LocateUserByID ищет строку пользователя на primary шардов, начиная с шарда его id
+func (s *ProfileManagementStorage) LocateUserByID(ctx context.Context, id int32) (*UserPlacement, error) {
+	return s.locateUser(ctx, squirrel.Eq{usersIDColumn: id}, s.getShardIndex(id))
+}
+
+// LocateUserByUsername ищет строку пользователя на primary шардов, начиная с шарда его username
+func (s *ProfileManagementStorage) LocateUserByUsername(ctx context.Context, username string) (*UserPlacement, error) {
+	return s.locateUser(ctx, squirrel.Eq{usersUsernameColumn: username}, s.getShardIndexByUsername(username))
+}
+
+func (s *ProfileManagementStorage) locateUser(ctx context.Context, where squirrel.Sqlizer, home int) (*UserPlacement, error) {
+	queryText, args, err := placementQuery(where).ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	user, index, err := lookupFromHome(ctx, s, s.shards, home, func(ctx context.Context, shard *pgxpool.Pool) (*models.User, error) {
+		user, err := scanPlacementUser(shard.QueryRow(ctx, queryText, args...))
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.Wrap(err, "scan row error")
+		}
+		return user, err
+	})
+	if err != nil {
+		return nil, notFound(err, "user not found")
+	}
+
+	return s.placement(user, index), nil
+}
+
+// MoveUser переносит строку пользователя (profile) или его данные с шарда from на шард to и возвращает
+// число перенесённых строк. Без force шард to должен совпадать с шардом по карте бакетов, иначе
+// возвращается ErrMoveOffRoute. force допустим только для profile: строку пользователя ищут на всех шардах,
+// а данные читаются только с шарда по карте бакетов и на другом шарде стали бы недоступны, поэтому
+// перенос данных с force возвращает ErrForceDataMove. Как и Rebalance, перенос не атомарен между шардами и не блокирует записи.
+func (s *ProfileManagementStorage) MoveUser(ctx context.Context, userID int32, profile bool, from, to int, force bool) (int64, error) {
+	for _, shard := range []int{from, to} {
+		if shard < 0 || shard >= len(s.shards) {
+			return 0, errors.Wrapf(ErrShardOutOfRange, "shard %d, shards: %d", shard, len(s.shards))
+		}
+	}
+	if from == to {
+		return 0, errors.Errorf("user %d is already on shard %d", userID, to)
+	}
+	if force && !profile {
+		return 0, errors.Wrapf(ErrForceDataMove, "user %d", userID)
+	}
+
+	if !force {
+		routed := s.getShardIndex(userID)
+		if profile {
+			placement, err := s.LocateUserByID(ctx, userID)
+			if err != nil {
+				return 0, err
+			}
+			routed = placement.UsernameShard
+		}
+		if routed != to {
+			return 0, errors.Wrapf(ErrMoveOffRoute, "bucket map routes user %d to shard %d", userID, routed)
+		}
+	}
+
+	move := &RebalanceMove{UserID: userID, Profile: profile, From: from, To: to}
+	if err := s.move(ctx, move); err != nil {
+		return 0, err
+	}
+	return move.Rows, nil
+}
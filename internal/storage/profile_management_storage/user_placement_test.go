@@ -0,0 +1,49 @@
+package profile_management_storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
+	"gotest.tools/v3/assert"
+)
+
+func TestPlacementFollowsBucketMap(t *testing.T) {
+	s := &ProfileManagementStorage{
+		shards:        testShards(2),
+		bucketCount:   4,
+		bucketToShard: []int{1, 0, 0, 1},
+	}
+
+	placement := s.placement(&models.User{ID: 7, Username: "alice"}, 1)
+	assert.Equal(t, placement.Shard, 1)
+	assert.Equal(t, placement.UsernameBucket, s.getBucketByUsername("alice"))
+	assert.Equal(t, placement.UsernameShard, s.bucketToShard[placement.UsernameBucket])
+	assert.Equal(t, placement.IDBucket, s.getBucket(7))
+	assert.Equal(t, placement.DataShard, s.bucketToShard[placement.IDBucket])
+}
+
+func TestLikeEscaperKeepsPrefixLiteral(t *testing.T) {
+	assert.Equal(t, likeEscaper.Replace(`a_b%c\d`), `a\_b\%c\\d`)
+}
+
+func TestMoveUserValidatesShards(t *testing.T) {
+	s := &ProfileManagementStorage{
+		shards:        testShards(2),
+		bucketCount:   4,
+		bucketToShard: []int{0, 1, 0, 1},
+	}
+
+	_, err := s.MoveUser(context.Background(), 1, false, 0, 2, true)
+	assert.ErrorIs(t, err, ErrShardOutOfRange)
+
+	_, err = s.MoveUser(context.Background(), 1, false, 1, 1, true)
+	assert.ErrorContains(t, err, "already on shard 1")
+
+	routed := s.getShardIndex(1)
+	_, err = s.MoveUser(context.Background(), 1, false, 1-routed, routed, true)
+	assert.ErrorIs(t, err, ErrForceDataMove)
+
+	_, err = s.MoveUser(context.Background(), 1, false, routed, 1-routed, false)
+	assert.ErrorIs(t, err, ErrMoveOffRoute)
+}
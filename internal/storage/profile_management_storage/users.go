@@ -8,6 +8,7 @@ import (
 	"github.com/Android12349/food_recomendation/profile_managment_service/internal/models"
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pkg/errors"
 )
 
@@ -26,7 +27,7 @@ func (s *ProfileManagementStorage) CreateUser(ctx context.Context, user *models.
 			usersWeightColumn, usersBJUColumn, usersBudgetColumn, usersPreferencesColumn).
 		Values(user.Username, user.PasswordHash, user.Height, user.Weight,
 			bjuJSON, user.Budget, user.Preferences).
-		Suffix("RETURNING " + usersIDColumn + ", " + usersCreatedAtColumn).
+		Suffix("RETURNING " + usersIDColumn + ", " + usersCreatedAtColumn + ", " + usersVersionColumn).
 		PlaceholderFormat(squirrel.Dollar)
 
 	queryText, args, err := query.ToSql()
@@ -36,10 +37,13 @@ func (s *ProfileManagementStorage) CreateUser(ctx context.Context, user *models.
 
 	shard := s.getShardByUsername(user.Username)
 	var createdAt sql.NullTime
-	err = shard.QueryRow(ctx, queryText, args...).Scan(&user.ID, &createdAt)
+	err = s.onShard(shard, func() error {
+		return shard.QueryRow(ctx, queryText, args...).Scan(&user.ID, &createdAt, &user.Version)
+	})
 	if err != nil {
 		return errors.Wrap(err, "exec query error")
 	}
+	s.pinUser(user.ID)
 
 	if createdAt.Valid {
 		user.CreatedAt = createdAt.Time.Format("2006-01-02T15:04:05Z07:00")
@@ -51,9 +55,9 @@ func (s *ProfileManagementStorage) CreateUser(ctx context.Context, user *models.
 func (s *ProfileManagementStorage) GetUserByID(ctx context.Context, id int32) (*models.User, error) {
 	query := squirrel.Select(usersIDColumn, usersUsernameColumn, usersPasswordHashColumn,
 		usersHeightColumn, usersWeightColumn, usersBJUColumn, usersBudgetColumn,
-		usersPreferencesColumn, usersCreatedAtColumn).
+		usersPreferencesColumn, usersCreatedAtColumn, usersVersionColumn).
 		From(usersTableName).
-		Where(squirrel.Eq{usersIDColumn: id}).
+		Where(squirrel.Eq{usersIDColumn: id, usersDeletedAtColumn: nil}).
 		PlaceholderFormat(squirrel.Dollar)
 
 	queryText, args, err := query.ToSql()
@@ -61,43 +65,66 @@ func (s *ProfileManagementStorage) GetUserByID(ctx context.Context, id int32) (*
 		return nil, errors.Wrap(err, "generate query error")
 	}
 
-	shard := s.getShard(id)
+	lookup := func(ctx context.Context, shard *pgxpool.Pool) (*models.User, error) {
+		return scanUser(shard.QueryRow(ctx, queryText, args...))
+	}
+
+	var user *models.User
+	if s.hasReplicas() && !s.isPinned(id) {
+		user, _, err = lookupUser(ctx, s, s.readShards(), id, lookup)
+	}
+	// Без реплик, для недавно писавшего пользователя и если реплики не нашли запись читаем с primary
+	if user == nil {
+		user, _, err = lookupUser(ctx, s, s.shards, id, lookup)
+	}
+	if err != nil {
+		return nil, notFound(err, "user not found")
+	}
+
+	return user, nil
+}
+
+// GetUserByUsername ищет пользователя сначала на шарде его username, затем на остальных
+func (s *ProfileManagementStorage) GetUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	query := squirrel.Select(usersIDColumn, usersUsernameColumn, usersPasswordHashColumn,
+		usersHeightColumn, usersWeightColumn, usersBJUColumn, usersBudgetColumn,
+		usersPreferencesColumn, usersCreatedAtColumn, usersVersionColumn).
+		From(usersTableName).
+		Where(squirrel.Eq{usersUsernameColumn: username, usersDeletedAtColumn: nil}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	queryText, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "generate query error")
+	}
+
+	user, _, err := lookupFromHome(ctx, s, s.shards, s.getShardIndexByUsername(username), func(ctx context.Context, shard *pgxpool.Pool) (*models.User, error) {
+		return scanUser(shard.QueryRow(ctx, queryText, args...))
+	})
+	if err != nil {
+		return nil, notFound(err, "user not found")
+	}
+
+	return user, nil
+}
+
+// scanUser читает строку пользователя; отсутствие строки возвращается как pgx.ErrNoRows
+func scanUser(row pgx.Row) (*models.User, error) {
 	var user models.User
 	var bjuJSON []byte
 	var height, weight, budget sql.NullInt32
 	var createdAt sql.NullTime
-	var found bool
 
-	err = shard.QueryRow(ctx, queryText, args...).Scan(
+	err := row.Scan(
 		&user.ID, &user.Username, &user.PasswordHash,
 		&height, &weight, &bjuJSON, &budget,
-		&user.Preferences, &createdAt,
+		&user.Preferences, &createdAt, &user.Version,
 	)
-	if err == nil {
-		found = true
-	} else if !errors.Is(err, pgx.ErrNoRows) {
-		return nil, errors.Wrap(err, "scan row error")
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
 	}
-
-	if !found {
-		for _, shard := range s.shards {
-			err = shard.QueryRow(ctx, queryText, args...).Scan(
-				&user.ID, &user.Username, &user.PasswordHash,
-				&height, &weight, &bjuJSON, &budget,
-				&user.Preferences, &createdAt,
-			)
-			if err == nil {
-				found = true
-				break
-			}
-			if !errors.Is(err, pgx.ErrNoRows) {
-				return nil, errors.Wrap(err, "scan row error")
-			}
-		}
-	}
-
-	if !found {
-		return nil, errors.New("user not found")
+	if err != nil {
+		return nil, errors.Wrap(err, "scan row error")
 	}
 
 	if height.Valid {
@@ -143,67 +170,93 @@ func (s *ProfileManagementStorage) UpdateUser(ctx context.Context, user *models.
 		Set(usersBJUColumn, bjuJSON).
 		Set(usersBudgetColumn, user.Budget).
 		Set(usersPreferencesColumn, user.Preferences).
-		Where(squirrel.Eq{usersIDColumn: user.ID}).
+		Set(usersVersionColumn, squirrel.Expr(usersVersionColumn+" + 1")).
+		Where(squirrel.Eq{usersIDColumn: user.ID, usersDeletedAtColumn: nil}).
+		Suffix("RETURNING " + usersVersionColumn).
 		PlaceholderFormat(squirrel.Dollar)
+	if user.Version > 0 {
+		query = query.Where(squirrel.Eq{usersVersionColumn: user.Version})
+	}
 
 	queryText, args, err := query.ToSql()
 	if err != nil {
 		return errors.Wrap(err, "generate query error")
 	}
 
-	shard := s.getShard(user.ID)
-	result, err := shard.Exec(ctx, queryText, args...)
+	s.pinUser(user.ID)
+
+	// Обычно пользователь лежит на своём шарде, и хватает одного запроса
+	home := s.getShardIndex(user.ID)
+	err = s.onShard(s.shards[home], func() error {
+		return s.shards[home].QueryRow(ctx, queryText, args...).Scan(&user.Version)
+	})
+	if err == nil {
+		return nil
+	}
+
+	// Иначе ищем шард пользователя параллельно и обновляем строку только там
+	shard, err := s.findUserShard(ctx, user.ID, squirrel.Eq{usersDeletedAtColumn: nil})
 	if err != nil {
-		return errors.Wrap(err, "exec query error")
+		if user.Version > 0 && !isShardsError(err) {
+			// Существование пользователя проверяет сервис, поэтому при заданной версии это конфликт
+			return models.ErrVersionMismatch
+		}
+		return err
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		for _, shard := range s.shards {
-			result, err = shard.Exec(ctx, queryText, args...)
-			if err != nil {
-				return errors.Wrap(err, "exec query error")
-			}
-			if result.RowsAffected() > 0 {
-				return nil
-			}
+	err = s.onShard(shard, func() error {
+		return shard.QueryRow(ctx, queryText, args...).Scan(&user.Version)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		if user.Version > 0 {
+			return models.ErrVersionMismatch
 		}
-		return errors.New("user not found")
+		return &notFoundError{message: "user not found"}
+	}
+	if err != nil {
+		return errors.Wrap(err, "exec query error")
 	}
 
 	return nil
 }
 
+// DeleteUser удаляет пользователя вместе с его продуктами и блюдами.
+// Если строка пользователя и его данные лежат на одном шарде, удаление выполняется одной транзакцией.
 func (s *ProfileManagementStorage) DeleteUser(ctx context.Context, id int32) error {
-	query := squirrel.Delete(usersTableName).
-		Where(squirrel.Eq{usersIDColumn: id}).
-		PlaceholderFormat(squirrel.Dollar)
-
-	queryText, args, err := query.ToSql()
+	s.pinUser(id)
+	userShard, err := s.findUserShard(ctx, id, squirrel.Expr("TRUE"))
 	if err != nil {
-		return errors.Wrap(err, "generate query error")
+		return err
 	}
 
-	shard := s.getShard(id)
-	result, err := shard.Exec(ctx, queryText, args...)
+	// Данные удаляются раньше строки пользователя: прерванное удаление оставит пользователя,
+	// и повторный DeleteUser его завершит
+	return s.updateUserAndContent(ctx, userShard, id,
+		func(tx pgx.Tx) error {
+			return deleteUserContent(ctx, tx, id)
+		},
+		squirrel.Delete(usersTableName).
+			Where(squirrel.Eq{usersIDColumn: id}).
+			PlaceholderFormat(squirrel.Dollar))
+}
+
+func deleteUserContent(ctx context.Context, tx pgx.Tx, userID int32) error {
+	_, err := execTx(ctx, tx, squirrel.Delete(mealsTableName).
+		Where(squirrel.Eq{mealsUserIDColumn: userID}).
+		PlaceholderFormat(squirrel.Dollar))
 	if err != nil {
-		return errors.Wrap(err, "exec query error")
+		return err
 	}
 
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		// Пропускаем первый шард, так как он уже проверен
-		for i := 1; i < len(s.shards); i++ {
-			result, err = s.shards[i].Exec(ctx, queryText, args...)
-			if err != nil {
-				return errors.Wrap(err, "exec query error")
-			}
-			if result.RowsAffected() > 0 {
-				return nil
-			}
-		}
-		return errors.New("user not found")
+	_, err = execTx(ctx, tx, squirrel.Delete(productsTableName).
+		Where(squirrel.Eq{productsUserIDColumn: userID}).
+		PlaceholderFormat(squirrel.Dollar))
+	if err != nil {
+		return err
 	}
 
-	return nil
+	_, err = execTx(ctx, tx, squirrel.Delete(menuGenerationsTableName).
+		Where(squirrel.Eq{menuGenerationsUserIDColumn: userID}).
+		PlaceholderFormat(squirrel.Dollar))
+	return err
 }
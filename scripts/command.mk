@@ -23,4 +23,4 @@ mock:
 
 .PHONY: run
 run:
-	@set configPath=./config.yaml && set swaggerPath=./internal/pb/swagger/profile_management_api/profile_management.swagger.json && go run cmd/app/main.go
+	@set configPath=./config.yaml && set swaggerPath=./internal/pb/swagger/profile_management_api/profile_management.swagger.json && go run ./cmd/app serve
@@ -10,7 +10,13 @@ protoc -I ./api \
   ./api/profile_management_api/profile_management.proto \
   ./api/models/user_model.proto \
   ./api/models/product_model.proto \
-  ./api/models/meal_model.proto
+  ./api/models/meal_model.proto \
+  ./api/profile_admin_api/profile_admin.proto
+
+# Генерация событий Kafka
+protoc -I ./api \
+  --go_out=./internal/pb --go_opt=paths=source_relative \
+  ./api/events/menu_generation_event.proto
 
 # Генерация gRPC-Gateway
 protoc -I ./api \